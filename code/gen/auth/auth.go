@@ -0,0 +1,50 @@
+// Package auth reads the user the gateway authenticated a request as from the metadata it forwards to the services.
+package auth
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// metadata keys of the id and the comma separated site-wide roles of the user the gateway authenticated a
+	// request as
+	UserIdMetadataKey    = "user-id"
+	UserRolesMetadataKey = "user-roles"
+	// metadata key the gateway sets on every request it forwards, other services call without it
+	GatewayMetadataKey = "via-gateway"
+)
+
+// RequesterId returns the id of the user the gateway authenticated a request as, empty for anonymous requests
+func RequesterId(ctx context.Context) string {
+	values := metadata.ValueFromIncomingContext(ctx, UserIdMetadataKey)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// RequesterHasRole reports whether the gateway authenticated a request as a user with any of the roles
+func RequesterHasRole(ctx context.Context, roles ...string) bool {
+	for _, values := range metadata.ValueFromIncomingContext(ctx, UserRolesMetadataKey) {
+		for _, role := range strings.Split(values, ",") {
+			if slices.Contains(roles, strings.TrimSpace(role)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RequesterIsModerator reports whether the gateway authenticated a request as a moderator or an admin
+func RequesterIsModerator(ctx context.Context) bool {
+	return RequesterHasRole(ctx, "moderator", "admin")
+}
+
+// RequesterIsInternal reports whether a request comes from another service rather than through the gateway
+func RequesterIsInternal(ctx context.Context) bool {
+	return len(metadata.ValueFromIncomingContext(ctx, GatewayMetadataKey)) == 0
+}
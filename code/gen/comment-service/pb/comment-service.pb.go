@@ -30,6 +30,7 @@ type ListCommentsRequest struct {
 	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
 	SortBy        *string                `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	PageToken     *string                `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"` // next_page_token of the previous page, the offset is ignored with it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -92,9 +93,17 @@ func (x *ListCommentsRequest) GetSortBy() string {
 	return ""
 }
 
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type ListCommentsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Comments      []*pb.Comment          `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -136,11 +145,21 @@ func (x *ListCommentsResponse) GetComments() []*pb.Comment {
 	return nil
 }
 
+func (x *ListCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type CreateCommentRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	ParentType    pb.CommentParentType   `protobuf:"varint,3,opt,name=parent_type,json=parentType,proto3,enum=models.CommentParentType" json:"parent_type,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Content    string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	ParentId   string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ParentType pb.CommentParentType   `protobuf:"varint,3,opt,name=parent_type,json=parentType,proto3,enum=models.CommentParentType" json:"parent_type,omitempty"`
+	// Deprecated: Marked as deprecated in comment-service.proto.
+	AuthorId      string   `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // ignored, comments are authored by the user the request is authenticated as
+	AttachmentIds []string `protobuf:"bytes,5,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -196,6 +215,21 @@ func (x *CreateCommentRequest) GetParentType() pb.CommentParentType {
 	return pb.CommentParentType(0)
 }
 
+// Deprecated: Marked as deprecated in comment-service.proto.
+func (x *CreateCommentRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
 type CreateCommentResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -396,33 +430,267 @@ func (x *DeleteCommentRequest) GetId() string {
 	return ""
 }
 
+type RemoveCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCommentRequest) Reset() {
+	*x = RemoveCommentRequest{}
+	mi := &file_comment_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCommentRequest) ProtoMessage() {}
+
+func (x *RemoveCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCommentRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCommentRequest) Descriptor() ([]byte, []int) {
+	return file_comment_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoveCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveCommentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RestoreCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCommentRequest) Reset() {
+	*x = RestoreCommentRequest{}
+	mi := &file_comment_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCommentRequest) ProtoMessage() {}
+
+func (x *RestoreCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCommentRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCommentRequest) Descriptor() ([]byte, []int) {
+	return file_comment_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PurgeCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeCommentRequest) Reset() {
+	*x = PurgeCommentRequest{}
+	mi := &file_comment_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeCommentRequest) ProtoMessage() {}
+
+func (x *PurgeCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeCommentRequest.ProtoReflect.Descriptor instead.
+func (*PurgeCommentRequest) Descriptor() ([]byte, []int) {
+	return file_comment_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PurgeCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsRequest) Reset() {
+	*x = ListRevisionsRequest{}
+	mi := &file_comment_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsRequest) ProtoMessage() {}
+
+func (x *ListRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_comment_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListRevisionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*pb.Revision         `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsResponse) Reset() {
+	*x = ListRevisionsResponse{}
+	mi := &file_comment_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsResponse) ProtoMessage() {}
+
+func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_comment_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_comment_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListRevisionsResponse) GetRevisions() []*pb.Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
 var File_comment_service_proto protoreflect.FileDescriptor
 
 const file_comment_service_proto_rawDesc = "" +
 	"\n" +
-	"\x15comment-service.proto\x12\acomment\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"\xbc\x01\n" +
+	"\x15comment-service.proto\x12\acomment\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"\xef\x01\n" +
 	"\x13ListCommentsRequest\x12 \n" +
 	"\tthread_id\x18\x01 \x01(\tH\x00R\bthreadId\x88\x01\x01\x12\x1b\n" +
 	"\x06offset\x18\x02 \x01(\x05H\x01R\x06offset\x88\x01\x01\x12\x19\n" +
 	"\x05limit\x18\x03 \x01(\x05H\x02R\x05limit\x88\x01\x01\x12\x1c\n" +
-	"\asort_by\x18\x04 \x01(\tH\x03R\x06sortBy\x88\x01\x01B\f\n" +
+	"\asort_by\x18\x04 \x01(\tH\x03R\x06sortBy\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tH\x04R\tpageToken\x88\x01\x01B\f\n" +
 	"\n" +
 	"_thread_idB\t\n" +
 	"\a_offsetB\b\n" +
 	"\x06_limitB\n" +
 	"\n" +
-	"\b_sort_by\"C\n" +
+	"\b_sort_byB\r\n" +
+	"\v_page_token\"k\n" +
 	"\x14ListCommentsResponse\x12+\n" +
-	"\bcomments\x18\x01 \x03(\v2\x0f.models.CommentR\bcomments\"\x89\x01\n" +
+	"\bcomments\x18\x01 \x03(\v2\x0f.models.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xd1\x01\n" +
 	"\x14CreateCommentRequest\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x1b\n" +
 	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12:\n" +
 	"\vparent_type\x18\x03 \x01(\x0e2\x19.models.CommentParentTypeR\n" +
-	"parentType\"'\n" +
+	"parentType\x12\x1f\n" +
+	"\tauthor_id\x18\x04 \x01(\tB\x02\x18\x01R\bauthorId\x12%\n" +
+	"\x0eattachment_ids\x18\x05 \x03(\tR\rattachmentIds\"'\n" +
 	"\x15CreateCommentResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
 	"\x11GetCommentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xd4\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xe5\x01\n" +
 	"\x14UpdateCommentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\acontent\x18\x02 \x01(\tH\x00R\acontent\x88\x01\x01\x12$\n" +
@@ -432,9 +700,20 @@ const file_comment_service_proto_rawDesc = "" +
 	"\n" +
 	"\b_contentB\x0e\n" +
 	"\f_vote_offsetB\x16\n" +
-	"\x14_num_comments_offset\"&\n" +
+	"\x14_num_comments_offsetJ\x04\b\x05\x10\x06R\teditor_id\"&\n" +
 	"\x14DeleteCommentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xab\x04\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\">\n" +
+	"\x14RemoveCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"'\n" +
+	"\x15RestoreCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x13PurgeCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x14ListRevisionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x15ListRevisionsResponse\x12.\n" +
+	"\trevisions\x18\x01 \x03(\v2\x10.models.RevisionR\trevisions2\xba\a\n" +
 	"\x0eCommentService\x12=\n" +
 	"\vCheckHealth\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12^\n" +
 	"\fListComments\x12\x1c.comment.ListCommentsRequest\x1a\x1d.comment.ListCommentsResponse\"\x11\x82\xd3\xe4\x93\x02\v\x12\t/comments\x12d\n" +
@@ -442,7 +721,11 @@ const file_comment_service_proto_rawDesc = "" +
 	"\n" +
 	"GetComment\x12\x1a.comment.GetCommentRequest\x1a\x0f.models.Comment\"\x16\x82\xd3\xe4\x93\x02\x10\x12\x0e/comments/{id}\x12a\n" +
 	"\rUpdateComment\x12\x1d.comment.UpdateCommentRequest\x1a\x16.google.protobuf.Empty\"\x19\x82\xd3\xe4\x93\x02\x13:\x01*2\x0e/comments/{id}\x12^\n" +
-	"\rDeleteComment\x12\x1d.comment.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/comments/{id}B\x1bZ\x19gen/comment-service/pb;pbb\x06proto3"
+	"\rDeleteComment\x12\x1d.comment.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\"\x16\x82\xd3\xe4\x93\x02\x10*\x0e/comments/{id}\x12h\n" +
+	"\rRemoveComment\x12\x1d.comment.RemoveCommentRequest\x1a\x16.google.protobuf.Empty\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/comments/{id}/remove\x12k\n" +
+	"\x0eRestoreComment\x12\x1e.comment.RestoreCommentRequest\x1a\x16.google.protobuf.Empty\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/comments/{id}/restore\x12p\n" +
+	"\rListRevisions\x12\x1d.comment.ListRevisionsRequest\x1a\x1e.comment.ListRevisionsResponse\" \x82\xd3\xe4\x93\x02\x1a\x12\x18/comments/{id}/revisions\x12D\n" +
+	"\fPurgeComment\x12\x1c.comment.PurgeCommentRequest\x1a\x16.google.protobuf.EmptyB\x1bZ\x19gen/comment-service/pb;pbb\x06proto3"
 
 var (
 	file_comment_service_proto_rawDescOnce sync.Once
@@ -456,7 +739,7 @@ func file_comment_service_proto_rawDescGZIP() []byte {
 	return file_comment_service_proto_rawDescData
 }
 
-var file_comment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_comment_service_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_comment_service_proto_goTypes = []any{
 	(*ListCommentsRequest)(nil),   // 0: comment.ListCommentsRequest
 	(*ListCommentsResponse)(nil),  // 1: comment.ListCommentsResponse
@@ -465,30 +748,45 @@ var file_comment_service_proto_goTypes = []any{
 	(*GetCommentRequest)(nil),     // 4: comment.GetCommentRequest
 	(*UpdateCommentRequest)(nil),  // 5: comment.UpdateCommentRequest
 	(*DeleteCommentRequest)(nil),  // 6: comment.DeleteCommentRequest
-	(*pb.Comment)(nil),            // 7: models.Comment
-	(pb.CommentParentType)(0),     // 8: models.CommentParentType
-	(*emptypb.Empty)(nil),         // 9: google.protobuf.Empty
+	(*RemoveCommentRequest)(nil),  // 7: comment.RemoveCommentRequest
+	(*RestoreCommentRequest)(nil), // 8: comment.RestoreCommentRequest
+	(*PurgeCommentRequest)(nil),   // 9: comment.PurgeCommentRequest
+	(*ListRevisionsRequest)(nil),  // 10: comment.ListRevisionsRequest
+	(*ListRevisionsResponse)(nil), // 11: comment.ListRevisionsResponse
+	(*pb.Comment)(nil),            // 12: models.Comment
+	(pb.CommentParentType)(0),     // 13: models.CommentParentType
+	(*pb.Revision)(nil),           // 14: models.Revision
+	(*emptypb.Empty)(nil),         // 15: google.protobuf.Empty
 }
 var file_comment_service_proto_depIdxs = []int32{
-	7, // 0: comment.ListCommentsResponse.comments:type_name -> models.Comment
-	8, // 1: comment.CreateCommentRequest.parent_type:type_name -> models.CommentParentType
-	9, // 2: comment.CommentService.CheckHealth:input_type -> google.protobuf.Empty
-	0, // 3: comment.CommentService.ListComments:input_type -> comment.ListCommentsRequest
-	2, // 4: comment.CommentService.CreateComment:input_type -> comment.CreateCommentRequest
-	4, // 5: comment.CommentService.GetComment:input_type -> comment.GetCommentRequest
-	5, // 6: comment.CommentService.UpdateComment:input_type -> comment.UpdateCommentRequest
-	6, // 7: comment.CommentService.DeleteComment:input_type -> comment.DeleteCommentRequest
-	9, // 8: comment.CommentService.CheckHealth:output_type -> google.protobuf.Empty
-	1, // 9: comment.CommentService.ListComments:output_type -> comment.ListCommentsResponse
-	3, // 10: comment.CommentService.CreateComment:output_type -> comment.CreateCommentResponse
-	7, // 11: comment.CommentService.GetComment:output_type -> models.Comment
-	9, // 12: comment.CommentService.UpdateComment:output_type -> google.protobuf.Empty
-	9, // 13: comment.CommentService.DeleteComment:output_type -> google.protobuf.Empty
-	8, // [8:14] is the sub-list for method output_type
-	2, // [2:8] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	12, // 0: comment.ListCommentsResponse.comments:type_name -> models.Comment
+	13, // 1: comment.CreateCommentRequest.parent_type:type_name -> models.CommentParentType
+	14, // 2: comment.ListRevisionsResponse.revisions:type_name -> models.Revision
+	15, // 3: comment.CommentService.CheckHealth:input_type -> google.protobuf.Empty
+	0,  // 4: comment.CommentService.ListComments:input_type -> comment.ListCommentsRequest
+	2,  // 5: comment.CommentService.CreateComment:input_type -> comment.CreateCommentRequest
+	4,  // 6: comment.CommentService.GetComment:input_type -> comment.GetCommentRequest
+	5,  // 7: comment.CommentService.UpdateComment:input_type -> comment.UpdateCommentRequest
+	6,  // 8: comment.CommentService.DeleteComment:input_type -> comment.DeleteCommentRequest
+	7,  // 9: comment.CommentService.RemoveComment:input_type -> comment.RemoveCommentRequest
+	8,  // 10: comment.CommentService.RestoreComment:input_type -> comment.RestoreCommentRequest
+	10, // 11: comment.CommentService.ListRevisions:input_type -> comment.ListRevisionsRequest
+	9,  // 12: comment.CommentService.PurgeComment:input_type -> comment.PurgeCommentRequest
+	15, // 13: comment.CommentService.CheckHealth:output_type -> google.protobuf.Empty
+	1,  // 14: comment.CommentService.ListComments:output_type -> comment.ListCommentsResponse
+	3,  // 15: comment.CommentService.CreateComment:output_type -> comment.CreateCommentResponse
+	12, // 16: comment.CommentService.GetComment:output_type -> models.Comment
+	15, // 17: comment.CommentService.UpdateComment:output_type -> google.protobuf.Empty
+	15, // 18: comment.CommentService.DeleteComment:output_type -> google.protobuf.Empty
+	15, // 19: comment.CommentService.RemoveComment:output_type -> google.protobuf.Empty
+	15, // 20: comment.CommentService.RestoreComment:output_type -> google.protobuf.Empty
+	11, // 21: comment.CommentService.ListRevisions:output_type -> comment.ListRevisionsResponse
+	15, // 22: comment.CommentService.PurgeComment:output_type -> google.protobuf.Empty
+	13, // [13:23] is the sub-list for method output_type
+	3,  // [3:13] is the sub-list for method input_type
+	3,  // [3:3] is the sub-list for extension type_name
+	3,  // [3:3] is the sub-list for extension extendee
+	0,  // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_comment_service_proto_init() }
@@ -504,7 +802,7 @@ func file_comment_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_comment_service_proto_rawDesc), len(file_comment_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -29,6 +29,7 @@ type ListCommunitiesRequest struct {
 	Name          *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
 	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	PageToken     *string                `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"` // next_page_token of the previous page, the offset is ignored with it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -84,9 +85,17 @@ func (x *ListCommunitiesRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListCommunitiesRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type ListCommunitiesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Communities   []*pb.Community        `protobuf:"bytes,1,rep,name=communities,proto3" json:"communities,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -128,6 +137,13 @@ func (x *ListCommunitiesResponse) GetCommunities() []*pb.Community {
 	return nil
 }
 
+func (x *ListCommunitiesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type CreateCommunityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
@@ -265,6 +281,7 @@ type UpdateCommunityRequest struct {
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name             *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
 	NumThreadsOffset *int32                 `protobuf:"varint,3,opt,name=num_threads_offset,json=numThreadsOffset,proto3,oneof" json:"num_threads_offset,omitempty"`
+	PostingRules     *pb.PostingRules       `protobuf:"bytes,4,opt,name=posting_rules,json=postingRules,proto3" json:"posting_rules,omitempty"` // replaces the overrides of the community
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -320,6 +337,57 @@ func (x *UpdateCommunityRequest) GetNumThreadsOffset() int32 {
 	return 0
 }
 
+func (x *UpdateCommunityRequest) GetPostingRules() *pb.PostingRules {
+	if x != nil {
+		return x.PostingRules
+	}
+	return nil
+}
+
+type GetCommunityPostingRulesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommunityPostingRulesRequest) Reset() {
+	*x = GetCommunityPostingRulesRequest{}
+	mi := &file_community_service_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommunityPostingRulesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommunityPostingRulesRequest) ProtoMessage() {}
+
+func (x *GetCommunityPostingRulesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_community_service_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommunityPostingRulesRequest.ProtoReflect.Descriptor instead.
+func (*GetCommunityPostingRulesRequest) Descriptor() ([]byte, []int) {
+	return file_community_service_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCommunityPostingRulesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type DeleteCommunityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -329,7 +397,7 @@ type DeleteCommunityRequest struct {
 
 func (x *DeleteCommunityRequest) Reset() {
 	*x = DeleteCommunityRequest{}
-	mi := &file_community_service_proto_msgTypes[6]
+	mi := &file_community_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -341,7 +409,7 @@ func (x *DeleteCommunityRequest) String() string {
 func (*DeleteCommunityRequest) ProtoMessage() {}
 
 func (x *DeleteCommunityRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_community_service_proto_msgTypes[6]
+	mi := &file_community_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -354,7 +422,7 @@ func (x *DeleteCommunityRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCommunityRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCommunityRequest) Descriptor() ([]byte, []int) {
-	return file_community_service_proto_rawDescGZIP(), []int{6}
+	return file_community_service_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DeleteCommunityRequest) GetId() string {
@@ -364,41 +432,310 @@ func (x *DeleteCommunityRequest) GetId() string {
 	return ""
 }
 
+type DeleteCommunityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommunityResponse) Reset() {
+	*x = DeleteCommunityResponse{}
+	mi := &file_community_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommunityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommunityResponse) ProtoMessage() {}
+
+func (x *DeleteCommunityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_community_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommunityResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommunityResponse) Descriptor() ([]byte, []int) {
+	return file_community_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteCommunityResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetJobStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobStatusRequest) Reset() {
+	*x = GetJobStatusRequest{}
+	mi := &file_community_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatusRequest) ProtoMessage() {}
+
+func (x *GetJobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_community_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetJobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_community_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetJobStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CreateFlairTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFlairTemplateRequest) Reset() {
+	*x = CreateFlairTemplateRequest{}
+	mi := &file_community_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFlairTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFlairTemplateRequest) ProtoMessage() {}
+
+func (x *CreateFlairTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_community_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFlairTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateFlairTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_community_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateFlairTemplateRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *CreateFlairTemplateRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *CreateFlairTemplateRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type CreateFlairTemplateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateFlairTemplateResponse) Reset() {
+	*x = CreateFlairTemplateResponse{}
+	mi := &file_community_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateFlairTemplateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateFlairTemplateResponse) ProtoMessage() {}
+
+func (x *CreateFlairTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_community_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateFlairTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateFlairTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_community_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CreateFlairTemplateResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteFlairTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteFlairTemplateRequest) Reset() {
+	*x = DeleteFlairTemplateRequest{}
+	mi := &file_community_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteFlairTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteFlairTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteFlairTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_community_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteFlairTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteFlairTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_community_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteFlairTemplateRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *DeleteFlairTemplateRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 var File_community_service_proto protoreflect.FileDescriptor
 
 const file_community_service_proto_rawDesc = "" +
 	"\n" +
-	"\x17community-service.proto\x12\tcommunity\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"\x87\x01\n" +
+	"\x17community-service.proto\x12\tcommunity\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"\xba\x01\n" +
 	"\x16ListCommunitiesRequest\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1b\n" +
 	"\x06offset\x18\x02 \x01(\x05H\x01R\x06offset\x88\x01\x01\x12\x19\n" +
-	"\x05limit\x18\x03 \x01(\x05H\x02R\x05limit\x88\x01\x01B\a\n" +
+	"\x05limit\x18\x03 \x01(\x05H\x02R\x05limit\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tH\x03R\tpageToken\x88\x01\x01B\a\n" +
 	"\x05_nameB\t\n" +
 	"\a_offsetB\b\n" +
-	"\x06_limit\"N\n" +
+	"\x06_limitB\r\n" +
+	"\v_page_token\"v\n" +
 	"\x17ListCommunitiesResponse\x123\n" +
-	"\vcommunities\x18\x01 \x03(\v2\x11.models.CommunityR\vcommunities\",\n" +
+	"\vcommunities\x18\x01 \x03(\v2\x11.models.CommunityR\vcommunities\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\",\n" +
 	"\x16CreateCommunityRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\")\n" +
 	"\x17CreateCommunityResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
 	"\x13GetCommunityRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x94\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xcf\x01\n" +
 	"\x16UpdateCommunityRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x121\n" +
-	"\x12num_threads_offset\x18\x03 \x01(\x05H\x01R\x10numThreadsOffset\x88\x01\x01B\a\n" +
+	"\x12num_threads_offset\x18\x03 \x01(\x05H\x01R\x10numThreadsOffset\x88\x01\x01\x129\n" +
+	"\rposting_rules\x18\x04 \x01(\v2\x14.models.PostingRulesR\fpostingRulesB\a\n" +
 	"\x05_nameB\x15\n" +
-	"\x13_num_threads_offset\"(\n" +
+	"\x13_num_threads_offset\"1\n" +
+	"\x1fGetCommunityPostingRulesRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"(\n" +
 	"\x16DeleteCommunityRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xe7\x04\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x17DeleteCommunityResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"%\n" +
+	"\x13GetJobStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"i\n" +
+	"\x1aCreateFlairTemplateRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\"-\n" +
+	"\x1bCreateFlairTemplateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
+	"\x1aDeleteFlairTemplateRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id2\xea\b\n" +
 	"\x10CommunityService\x12=\n" +
 	"\vCheckHealth\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12n\n" +
 	"\x0fListCommunities\x12!.community.ListCommunitiesRequest\x1a\".community.ListCommunitiesResponse\"\x14\x82\xd3\xe4\x93\x02\x0e\x12\f/communities\x12q\n" +
 	"\x0fCreateCommunity\x12!.community.CreateCommunityRequest\x1a\".community.CreateCommunityResponse\"\x17\x82\xd3\xe4\x93\x02\x11:\x01*\"\f/communities\x12\\\n" +
 	"\fGetCommunity\x12\x1e.community.GetCommunityRequest\x1a\x11.models.Community\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/communities/{id}\x12j\n" +
-	"\x0fUpdateCommunity\x12!.community.UpdateCommunityRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*2\x11/communities/{id}\x12g\n" +
-	"\x0fDeleteCommunity\x12!.community.DeleteCommunityRequest\x1a\x16.google.protobuf.Empty\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/communities/{id}B\x1dZ\x1bgen/community-service/pb;pbb\x06proto3"
+	"\x0fUpdateCommunity\x12!.community.UpdateCommunityRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*2\x11/communities/{id}\x12\x85\x01\n" +
+	"\x18GetCommunityPostingRules\x12*.community.GetCommunityPostingRulesRequest\x1a\x14.models.PostingRules\"'\x82\xd3\xe4\x93\x02!\x12\x1f/communities/{id}/posting-rules\x12s\n" +
+	"\x0fDeleteCommunity\x12!.community.DeleteCommunityRequest\x1a\".community.DeleteCommunityResponse\"\x19\x82\xd3\xe4\x93\x02\x13*\x11/communities/{id}\x12O\n" +
+	"\fGetJobStatus\x12\x1e.community.GetJobStatusRequest\x1a\v.models.Job\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/jobs/{id}\x12\x93\x01\n" +
+	"\x13CreateFlairTemplate\x12%.community.CreateFlairTemplateRequest\x1a&.community.CreateFlairTemplateResponse\"-\x82\xd3\xe4\x93\x02':\x01*\"\"/communities/{community_id}/flairs\x12\x85\x01\n" +
+	"\x13DeleteFlairTemplate\x12%.community.DeleteFlairTemplateRequest\x1a\x16.google.protobuf.Empty\"/\x82\xd3\xe4\x93\x02)*'/communities/{community_id}/flairs/{id}B\x1dZ\x1bgen/community-service/pb;pbb\x06proto3"
 
 var (
 	file_community_service_proto_rawDescOnce sync.Once
@@ -412,37 +749,54 @@ func file_community_service_proto_rawDescGZIP() []byte {
 	return file_community_service_proto_rawDescData
 }
 
-var file_community_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_community_service_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_community_service_proto_goTypes = []any{
-	(*ListCommunitiesRequest)(nil),  // 0: community.ListCommunitiesRequest
-	(*ListCommunitiesResponse)(nil), // 1: community.ListCommunitiesResponse
-	(*CreateCommunityRequest)(nil),  // 2: community.CreateCommunityRequest
-	(*CreateCommunityResponse)(nil), // 3: community.CreateCommunityResponse
-	(*GetCommunityRequest)(nil),     // 4: community.GetCommunityRequest
-	(*UpdateCommunityRequest)(nil),  // 5: community.UpdateCommunityRequest
-	(*DeleteCommunityRequest)(nil),  // 6: community.DeleteCommunityRequest
-	(*pb.Community)(nil),            // 7: models.Community
-	(*emptypb.Empty)(nil),           // 8: google.protobuf.Empty
+	(*ListCommunitiesRequest)(nil),          // 0: community.ListCommunitiesRequest
+	(*ListCommunitiesResponse)(nil),         // 1: community.ListCommunitiesResponse
+	(*CreateCommunityRequest)(nil),          // 2: community.CreateCommunityRequest
+	(*CreateCommunityResponse)(nil),         // 3: community.CreateCommunityResponse
+	(*GetCommunityRequest)(nil),             // 4: community.GetCommunityRequest
+	(*UpdateCommunityRequest)(nil),          // 5: community.UpdateCommunityRequest
+	(*GetCommunityPostingRulesRequest)(nil), // 6: community.GetCommunityPostingRulesRequest
+	(*DeleteCommunityRequest)(nil),          // 7: community.DeleteCommunityRequest
+	(*DeleteCommunityResponse)(nil),         // 8: community.DeleteCommunityResponse
+	(*GetJobStatusRequest)(nil),             // 9: community.GetJobStatusRequest
+	(*CreateFlairTemplateRequest)(nil),      // 10: community.CreateFlairTemplateRequest
+	(*CreateFlairTemplateResponse)(nil),     // 11: community.CreateFlairTemplateResponse
+	(*DeleteFlairTemplateRequest)(nil),      // 12: community.DeleteFlairTemplateRequest
+	(*pb.Community)(nil),                    // 13: models.Community
+	(*pb.PostingRules)(nil),                 // 14: models.PostingRules
+	(*emptypb.Empty)(nil),                   // 15: google.protobuf.Empty
+	(*pb.Job)(nil),                          // 16: models.Job
 }
 var file_community_service_proto_depIdxs = []int32{
-	7, // 0: community.ListCommunitiesResponse.communities:type_name -> models.Community
-	8, // 1: community.CommunityService.CheckHealth:input_type -> google.protobuf.Empty
-	0, // 2: community.CommunityService.ListCommunities:input_type -> community.ListCommunitiesRequest
-	2, // 3: community.CommunityService.CreateCommunity:input_type -> community.CreateCommunityRequest
-	4, // 4: community.CommunityService.GetCommunity:input_type -> community.GetCommunityRequest
-	5, // 5: community.CommunityService.UpdateCommunity:input_type -> community.UpdateCommunityRequest
-	6, // 6: community.CommunityService.DeleteCommunity:input_type -> community.DeleteCommunityRequest
-	8, // 7: community.CommunityService.CheckHealth:output_type -> google.protobuf.Empty
-	1, // 8: community.CommunityService.ListCommunities:output_type -> community.ListCommunitiesResponse
-	3, // 9: community.CommunityService.CreateCommunity:output_type -> community.CreateCommunityResponse
-	7, // 10: community.CommunityService.GetCommunity:output_type -> models.Community
-	8, // 11: community.CommunityService.UpdateCommunity:output_type -> google.protobuf.Empty
-	8, // 12: community.CommunityService.DeleteCommunity:output_type -> google.protobuf.Empty
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	13, // 0: community.ListCommunitiesResponse.communities:type_name -> models.Community
+	14, // 1: community.UpdateCommunityRequest.posting_rules:type_name -> models.PostingRules
+	15, // 2: community.CommunityService.CheckHealth:input_type -> google.protobuf.Empty
+	0,  // 3: community.CommunityService.ListCommunities:input_type -> community.ListCommunitiesRequest
+	2,  // 4: community.CommunityService.CreateCommunity:input_type -> community.CreateCommunityRequest
+	4,  // 5: community.CommunityService.GetCommunity:input_type -> community.GetCommunityRequest
+	5,  // 6: community.CommunityService.UpdateCommunity:input_type -> community.UpdateCommunityRequest
+	6,  // 7: community.CommunityService.GetCommunityPostingRules:input_type -> community.GetCommunityPostingRulesRequest
+	7,  // 8: community.CommunityService.DeleteCommunity:input_type -> community.DeleteCommunityRequest
+	9,  // 9: community.CommunityService.GetJobStatus:input_type -> community.GetJobStatusRequest
+	10, // 10: community.CommunityService.CreateFlairTemplate:input_type -> community.CreateFlairTemplateRequest
+	12, // 11: community.CommunityService.DeleteFlairTemplate:input_type -> community.DeleteFlairTemplateRequest
+	15, // 12: community.CommunityService.CheckHealth:output_type -> google.protobuf.Empty
+	1,  // 13: community.CommunityService.ListCommunities:output_type -> community.ListCommunitiesResponse
+	3,  // 14: community.CommunityService.CreateCommunity:output_type -> community.CreateCommunityResponse
+	13, // 15: community.CommunityService.GetCommunity:output_type -> models.Community
+	15, // 16: community.CommunityService.UpdateCommunity:output_type -> google.protobuf.Empty
+	14, // 17: community.CommunityService.GetCommunityPostingRules:output_type -> models.PostingRules
+	8,  // 18: community.CommunityService.DeleteCommunity:output_type -> community.DeleteCommunityResponse
+	16, // 19: community.CommunityService.GetJobStatus:output_type -> models.Job
+	11, // 20: community.CommunityService.CreateFlairTemplate:output_type -> community.CreateFlairTemplateResponse
+	15, // 21: community.CommunityService.DeleteFlairTemplate:output_type -> google.protobuf.Empty
+	12, // [12:22] is the sub-list for method output_type
+	2,  // [2:12] is the sub-list for method input_type
+	2,  // [2:2] is the sub-list for extension type_name
+	2,  // [2:2] is the sub-list for extension extendee
+	0,  // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_community_service_proto_init() }
@@ -458,7 +812,7 @@ func file_community_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_community_service_proto_rawDesc), len(file_community_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,12 +21,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	CommunityService_CheckHealth_FullMethodName     = "/community.CommunityService/CheckHealth"
-	CommunityService_ListCommunities_FullMethodName = "/community.CommunityService/ListCommunities"
-	CommunityService_CreateCommunity_FullMethodName = "/community.CommunityService/CreateCommunity"
-	CommunityService_GetCommunity_FullMethodName    = "/community.CommunityService/GetCommunity"
-	CommunityService_UpdateCommunity_FullMethodName = "/community.CommunityService/UpdateCommunity"
-	CommunityService_DeleteCommunity_FullMethodName = "/community.CommunityService/DeleteCommunity"
+	CommunityService_CheckHealth_FullMethodName              = "/community.CommunityService/CheckHealth"
+	CommunityService_ListCommunities_FullMethodName          = "/community.CommunityService/ListCommunities"
+	CommunityService_CreateCommunity_FullMethodName          = "/community.CommunityService/CreateCommunity"
+	CommunityService_GetCommunity_FullMethodName             = "/community.CommunityService/GetCommunity"
+	CommunityService_UpdateCommunity_FullMethodName          = "/community.CommunityService/UpdateCommunity"
+	CommunityService_GetCommunityPostingRules_FullMethodName = "/community.CommunityService/GetCommunityPostingRules"
+	CommunityService_DeleteCommunity_FullMethodName          = "/community.CommunityService/DeleteCommunity"
+	CommunityService_GetJobStatus_FullMethodName             = "/community.CommunityService/GetJobStatus"
+	CommunityService_CreateFlairTemplate_FullMethodName      = "/community.CommunityService/CreateFlairTemplate"
+	CommunityService_DeleteFlairTemplate_FullMethodName      = "/community.CommunityService/DeleteFlairTemplate"
 )
 
 // CommunityServiceClient is the client API for CommunityService service.
@@ -38,7 +42,12 @@ type CommunityServiceClient interface {
 	CreateCommunity(ctx context.Context, in *CreateCommunityRequest, opts ...grpc.CallOption) (*CreateCommunityResponse, error)
 	GetCommunity(ctx context.Context, in *GetCommunityRequest, opts ...grpc.CallOption) (*pb.Community, error)
 	UpdateCommunity(ctx context.Context, in *UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	DeleteCommunity(ctx context.Context, in *DeleteCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetCommunityPostingRules(ctx context.Context, in *GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*pb.PostingRules, error)
+	// deletes the community, then its threads and their comments page by page, in a background job
+	DeleteCommunity(ctx context.Context, in *DeleteCommunityRequest, opts ...grpc.CallOption) (*DeleteCommunityResponse, error)
+	GetJobStatus(ctx context.Context, in *GetJobStatusRequest, opts ...grpc.CallOption) (*pb.Job, error)
+	CreateFlairTemplate(ctx context.Context, in *CreateFlairTemplateRequest, opts ...grpc.CallOption) (*CreateFlairTemplateResponse, error)
+	DeleteFlairTemplate(ctx context.Context, in *DeleteFlairTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type communityServiceClient struct {
@@ -99,9 +108,19 @@ func (c *communityServiceClient) UpdateCommunity(ctx context.Context, in *Update
 	return out, nil
 }
 
-func (c *communityServiceClient) DeleteCommunity(ctx context.Context, in *DeleteCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *communityServiceClient) GetCommunityPostingRules(ctx context.Context, in *GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*pb.PostingRules, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
+	out := new(pb.PostingRules)
+	err := c.cc.Invoke(ctx, CommunityService_GetCommunityPostingRules_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *communityServiceClient) DeleteCommunity(ctx context.Context, in *DeleteCommunityRequest, opts ...grpc.CallOption) (*DeleteCommunityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommunityResponse)
 	err := c.cc.Invoke(ctx, CommunityService_DeleteCommunity_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
@@ -109,6 +128,36 @@ func (c *communityServiceClient) DeleteCommunity(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *communityServiceClient) GetJobStatus(ctx context.Context, in *GetJobStatusRequest, opts ...grpc.CallOption) (*pb.Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(pb.Job)
+	err := c.cc.Invoke(ctx, CommunityService_GetJobStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *communityServiceClient) CreateFlairTemplate(ctx context.Context, in *CreateFlairTemplateRequest, opts ...grpc.CallOption) (*CreateFlairTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateFlairTemplateResponse)
+	err := c.cc.Invoke(ctx, CommunityService_CreateFlairTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *communityServiceClient) DeleteFlairTemplate(ctx context.Context, in *DeleteFlairTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, CommunityService_DeleteFlairTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CommunityServiceServer is the server API for CommunityService service.
 // All implementations must embed UnimplementedCommunityServiceServer
 // for forward compatibility.
@@ -118,7 +167,12 @@ type CommunityServiceServer interface {
 	CreateCommunity(context.Context, *CreateCommunityRequest) (*CreateCommunityResponse, error)
 	GetCommunity(context.Context, *GetCommunityRequest) (*pb.Community, error)
 	UpdateCommunity(context.Context, *UpdateCommunityRequest) (*emptypb.Empty, error)
-	DeleteCommunity(context.Context, *DeleteCommunityRequest) (*emptypb.Empty, error)
+	GetCommunityPostingRules(context.Context, *GetCommunityPostingRulesRequest) (*pb.PostingRules, error)
+	// deletes the community, then its threads and their comments page by page, in a background job
+	DeleteCommunity(context.Context, *DeleteCommunityRequest) (*DeleteCommunityResponse, error)
+	GetJobStatus(context.Context, *GetJobStatusRequest) (*pb.Job, error)
+	CreateFlairTemplate(context.Context, *CreateFlairTemplateRequest) (*CreateFlairTemplateResponse, error)
+	DeleteFlairTemplate(context.Context, *DeleteFlairTemplateRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedCommunityServiceServer()
 }
 
@@ -144,9 +198,21 @@ func (UnimplementedCommunityServiceServer) GetCommunity(context.Context, *GetCom
 func (UnimplementedCommunityServiceServer) UpdateCommunity(context.Context, *UpdateCommunityRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateCommunity not implemented")
 }
-func (UnimplementedCommunityServiceServer) DeleteCommunity(context.Context, *DeleteCommunityRequest) (*emptypb.Empty, error) {
+func (UnimplementedCommunityServiceServer) GetCommunityPostingRules(context.Context, *GetCommunityPostingRulesRequest) (*pb.PostingRules, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommunityPostingRules not implemented")
+}
+func (UnimplementedCommunityServiceServer) DeleteCommunity(context.Context, *DeleteCommunityRequest) (*DeleteCommunityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteCommunity not implemented")
 }
+func (UnimplementedCommunityServiceServer) GetJobStatus(context.Context, *GetJobStatusRequest) (*pb.Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobStatus not implemented")
+}
+func (UnimplementedCommunityServiceServer) CreateFlairTemplate(context.Context, *CreateFlairTemplateRequest) (*CreateFlairTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateFlairTemplate not implemented")
+}
+func (UnimplementedCommunityServiceServer) DeleteFlairTemplate(context.Context, *DeleteFlairTemplateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteFlairTemplate not implemented")
+}
 func (UnimplementedCommunityServiceServer) mustEmbedUnimplementedCommunityServiceServer() {}
 func (UnimplementedCommunityServiceServer) testEmbeddedByValue()                          {}
 
@@ -258,6 +324,24 @@ func _CommunityService_UpdateCommunity_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommunityService_GetCommunityPostingRules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommunityPostingRulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommunityServiceServer).GetCommunityPostingRules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommunityService_GetCommunityPostingRules_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommunityServiceServer).GetCommunityPostingRules(ctx, req.(*GetCommunityPostingRulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CommunityService_DeleteCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DeleteCommunityRequest)
 	if err := dec(in); err != nil {
@@ -276,6 +360,60 @@ func _CommunityService_DeleteCommunity_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CommunityService_GetJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommunityServiceServer).GetJobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommunityService_GetJobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommunityServiceServer).GetJobStatus(ctx, req.(*GetJobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommunityService_CreateFlairTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateFlairTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommunityServiceServer).CreateFlairTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommunityService_CreateFlairTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommunityServiceServer).CreateFlairTemplate(ctx, req.(*CreateFlairTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommunityService_DeleteFlairTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteFlairTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommunityServiceServer).DeleteFlairTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CommunityService_DeleteFlairTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommunityServiceServer).DeleteFlairTemplate(ctx, req.(*DeleteFlairTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // CommunityService_ServiceDesc is the grpc.ServiceDesc for CommunityService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -303,10 +441,26 @@ var CommunityService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateCommunity",
 			Handler:    _CommunityService_UpdateCommunity_Handler,
 		},
+		{
+			MethodName: "GetCommunityPostingRules",
+			Handler:    _CommunityService_GetCommunityPostingRules_Handler,
+		},
 		{
 			MethodName: "DeleteCommunity",
 			Handler:    _CommunityService_DeleteCommunity_Handler,
 		},
+		{
+			MethodName: "GetJobStatus",
+			Handler:    _CommunityService_GetJobStatus_Handler,
+		},
+		{
+			MethodName: "CreateFlairTemplate",
+			Handler:    _CommunityService_CreateFlairTemplate_Handler,
+		},
+		{
+			MethodName: "DeleteFlairTemplate",
+			Handler:    _CommunityService_DeleteFlairTemplate_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "community-service.proto",
@@ -11,6 +11,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,11 +24,58 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type SpamSampleSource int32
+
+const (
+	SpamSampleSource_THREADS  SpamSampleSource = 0
+	SpamSampleSource_COMMENTS SpamSampleSource = 1
+)
+
+// Enum value maps for SpamSampleSource.
+var (
+	SpamSampleSource_name = map[int32]string{
+		0: "THREADS",
+		1: "COMMENTS",
+	}
+	SpamSampleSource_value = map[string]int32{
+		"THREADS":  0,
+		"COMMENTS": 1,
+	}
+)
+
+func (x SpamSampleSource) Enum() *SpamSampleSource {
+	p := new(SpamSampleSource)
+	*p = x
+	return p
+}
+
+func (x SpamSampleSource) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SpamSampleSource) Descriptor() protoreflect.EnumDescriptor {
+	return file_db_service_proto_enumTypes[0].Descriptor()
+}
+
+func (SpamSampleSource) Type() protoreflect.EnumType {
+	return &file_db_service_proto_enumTypes[0]
+}
+
+func (x SpamSampleSource) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SpamSampleSource.Descriptor instead.
+func (SpamSampleSource) EnumDescriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{0}
+}
+
 type ListCommunitiesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
 	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	PageToken     *string                `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"` // next_page_token of the previous page, the offset is ignored with it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -83,9 +131,17 @@ func (x *ListCommunitiesRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *ListCommunitiesRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type ListCommunitiesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Communities   []*pb.Community        `protobuf:"bytes,1,rep,name=communities,proto3" json:"communities,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -127,6 +183,13 @@ func (x *ListCommunitiesResponse) GetCommunities() []*pb.Community {
 	return nil
 }
 
+func (x *ListCommunitiesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type CreateCommunityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
@@ -264,6 +327,7 @@ type UpdateCommunityRequest struct {
 	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Name             *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
 	NumThreadsOffset *int32                 `protobuf:"varint,3,opt,name=num_threads_offset,json=numThreadsOffset,proto3,oneof" json:"num_threads_offset,omitempty"`
+	PostingRules     *pb.PostingRules       `protobuf:"bytes,4,opt,name=posting_rules,json=postingRules,proto3" json:"posting_rules,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
@@ -319,6 +383,13 @@ func (x *UpdateCommunityRequest) GetNumThreadsOffset() int32 {
 	return 0
 }
 
+func (x *UpdateCommunityRequest) GetPostingRules() *pb.PostingRules {
+	if x != nil {
+		return x.PostingRules
+	}
+	return nil
+}
+
 type DeleteCommunityRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -363,31 +434,29 @@ func (x *DeleteCommunityRequest) GetId() string {
 	return ""
 }
 
-type ListThreadsRequest struct {
+type AddFlairTemplateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommunityId   *string                `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3,oneof" json:"community_id,omitempty"`
-	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Offset        *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
-	Limit         *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	SortBy        *string                `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListThreadsRequest) Reset() {
-	*x = ListThreadsRequest{}
+func (x *AddFlairTemplateRequest) Reset() {
+	*x = AddFlairTemplateRequest{}
 	mi := &file_db_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListThreadsRequest) String() string {
+func (x *AddFlairTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListThreadsRequest) ProtoMessage() {}
+func (*AddFlairTemplateRequest) ProtoMessage() {}
 
-func (x *ListThreadsRequest) ProtoReflect() protoreflect.Message {
+func (x *AddFlairTemplateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_db_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -399,67 +468,53 @@ func (x *ListThreadsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListThreadsRequest.ProtoReflect.Descriptor instead.
-func (*ListThreadsRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddFlairTemplateRequest.ProtoReflect.Descriptor instead.
+func (*AddFlairTemplateRequest) Descriptor() ([]byte, []int) {
 	return file_db_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ListThreadsRequest) GetCommunityId() string {
-	if x != nil && x.CommunityId != nil {
-		return *x.CommunityId
+func (x *AddFlairTemplateRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
 	}
 	return ""
 }
 
-func (x *ListThreadsRequest) GetTitle() string {
-	if x != nil && x.Title != nil {
-		return *x.Title
+func (x *AddFlairTemplateRequest) GetText() string {
+	if x != nil {
+		return x.Text
 	}
 	return ""
 }
 
-func (x *ListThreadsRequest) GetOffset() int32 {
-	if x != nil && x.Offset != nil {
-		return *x.Offset
-	}
-	return 0
-}
-
-func (x *ListThreadsRequest) GetLimit() int32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
-	}
-	return 0
-}
-
-func (x *ListThreadsRequest) GetSortBy() string {
-	if x != nil && x.SortBy != nil {
-		return *x.SortBy
+func (x *AddFlairTemplateRequest) GetColor() string {
+	if x != nil {
+		return x.Color
 	}
 	return ""
 }
 
-type ListThreadsResponse struct {
+type AddFlairTemplateResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Threads       []*pb.Thread           `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListThreadsResponse) Reset() {
-	*x = ListThreadsResponse{}
+func (x *AddFlairTemplateResponse) Reset() {
+	*x = AddFlairTemplateResponse{}
 	mi := &file_db_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListThreadsResponse) String() string {
+func (x *AddFlairTemplateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListThreadsResponse) ProtoMessage() {}
+func (*AddFlairTemplateResponse) ProtoMessage() {}
 
-func (x *ListThreadsResponse) ProtoReflect() protoreflect.Message {
+func (x *AddFlairTemplateResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_db_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -471,41 +526,40 @@ func (x *ListThreadsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListThreadsResponse.ProtoReflect.Descriptor instead.
-func (*ListThreadsResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use AddFlairTemplateResponse.ProtoReflect.Descriptor instead.
+func (*AddFlairTemplateResponse) Descriptor() ([]byte, []int) {
 	return file_db_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ListThreadsResponse) GetThreads() []*pb.Thread {
+func (x *AddFlairTemplateResponse) GetId() string {
 	if x != nil {
-		return x.Threads
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type CreateThreadRequest struct {
+type RemoveFlairTemplateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateThreadRequest) Reset() {
-	*x = CreateThreadRequest{}
+func (x *RemoveFlairTemplateRequest) Reset() {
+	*x = RemoveFlairTemplateRequest{}
 	mi := &file_db_service_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateThreadRequest) String() string {
+func (x *RemoveFlairTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateThreadRequest) ProtoMessage() {}
+func (*RemoveFlairTemplateRequest) ProtoMessage() {}
 
-func (x *CreateThreadRequest) ProtoReflect() protoreflect.Message {
+func (x *RemoveFlairTemplateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_db_service_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -517,53 +571,57 @@ func (x *CreateThreadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateThreadRequest.ProtoReflect.Descriptor instead.
-func (*CreateThreadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RemoveFlairTemplateRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFlairTemplateRequest) Descriptor() ([]byte, []int) {
 	return file_db_service_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *CreateThreadRequest) GetCommunityId() string {
+func (x *RemoveFlairTemplateRequest) GetCommunityId() string {
 	if x != nil {
 		return x.CommunityId
 	}
 	return ""
 }
 
-func (x *CreateThreadRequest) GetTitle() string {
-	if x != nil {
-		return x.Title
-	}
-	return ""
-}
-
-func (x *CreateThreadRequest) GetContent() string {
+func (x *RemoveFlairTemplateRequest) GetId() string {
 	if x != nil {
-		return x.Content
+		return x.Id
 	}
 	return ""
 }
 
-type CreateThreadResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type ListThreadsRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId       *string                `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3,oneof" json:"community_id,omitempty"`
+	Title             *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Offset            *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Limit             *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	SortBy            *string                `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	Pinned            *bool                  `protobuf:"varint,6,opt,name=pinned,proto3,oneof" json:"pinned,omitempty"`
+	Flair             *string                `protobuf:"bytes,7,opt,name=flair,proto3,oneof" json:"flair,omitempty"`
+	Tag               *string                `protobuf:"bytes,8,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
+	AuthorId          *string                `protobuf:"bytes,9,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+	Drafts            bool                   `protobuf:"varint,10,opt,name=drafts,proto3" json:"drafts,omitempty"` // drafts instead of published threads
+	CrosspostParentId *string                `protobuf:"bytes,11,opt,name=crosspost_parent_id,json=crosspostParentId,proto3,oneof" json:"crosspost_parent_id,omitempty"`
+	PageToken         *string                `protobuf:"bytes,12,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"` // next_page_token of the previous page, the offset is ignored with it
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *CreateThreadResponse) Reset() {
-	*x = CreateThreadResponse{}
+func (x *ListThreadsRequest) Reset() {
+	*x = ListThreadsRequest{}
 	mi := &file_db_service_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateThreadResponse) String() string {
+func (x *ListThreadsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateThreadResponse) ProtoMessage() {}
+func (*ListThreadsRequest) ProtoMessage() {}
 
-func (x *CreateThreadResponse) ProtoReflect() protoreflect.Message {
+func (x *ListThreadsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_db_service_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -575,39 +633,117 @@ func (x *CreateThreadResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateThreadResponse.ProtoReflect.Descriptor instead.
-func (*CreateThreadResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListThreadsRequest.ProtoReflect.Descriptor instead.
+func (*ListThreadsRequest) Descriptor() ([]byte, []int) {
 	return file_db_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *CreateThreadResponse) GetId() string {
+func (x *ListThreadsRequest) GetCommunityId() string {
+	if x != nil && x.CommunityId != nil {
+		return *x.CommunityId
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetOffset() int32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListThreadsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListThreadsRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetPinned() bool {
+	if x != nil && x.Pinned != nil {
+		return *x.Pinned
+	}
+	return false
+}
+
+func (x *ListThreadsRequest) GetFlair() string {
+	if x != nil && x.Flair != nil {
+		return *x.Flair
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetAuthorId() string {
+	if x != nil && x.AuthorId != nil {
+		return *x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetDrafts() bool {
 	if x != nil {
-		return x.Id
+		return x.Drafts
+	}
+	return false
+}
+
+func (x *ListThreadsRequest) GetCrosspostParentId() string {
+	if x != nil && x.CrosspostParentId != nil {
+		return *x.CrosspostParentId
 	}
 	return ""
 }
 
-type GetThreadRequest struct {
+func (x *ListThreadsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListThreadsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Threads       []*pb.Thread           `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetThreadRequest) Reset() {
-	*x = GetThreadRequest{}
+func (x *ListThreadsResponse) Reset() {
+	*x = ListThreadsResponse{}
 	mi := &file_db_service_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetThreadRequest) String() string {
+func (x *ListThreadsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetThreadRequest) ProtoMessage() {}
+func (*ListThreadsResponse) ProtoMessage() {}
 
-func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
+func (x *ListThreadsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_db_service_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -619,43 +755,61 @@ func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
-func (*GetThreadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListThreadsResponse.ProtoReflect.Descriptor instead.
+func (*ListThreadsResponse) Descriptor() ([]byte, []int) {
 	return file_db_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetThreadRequest) GetId() string {
+func (x *ListThreadsResponse) GetThreads() []*pb.Thread {
 	if x != nil {
-		return x.Id
+		return x.Threads
+	}
+	return nil
+}
+
+func (x *ListThreadsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-type UpdateThreadRequest struct {
+type CreateThreadRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Title             *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Content           *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
-	VoteOffset        *int32                 `protobuf:"varint,4,opt,name=vote_offset,json=voteOffset,proto3,oneof" json:"vote_offset,omitempty"`
-	NumCommentsOffset *int32                 `protobuf:"varint,5,opt,name=num_comments_offset,json=numCommentsOffset,proto3,oneof" json:"num_comments_offset,omitempty"`
+	CommunityId       string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Title             string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content           string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	AuthorId          string                 `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Flair             string                 `protobuf:"bytes,5,opt,name=flair,proto3" json:"flair,omitempty"`
+	FlairColor        string                 `protobuf:"bytes,6,opt,name=flair_color,json=flairColor,proto3" json:"flair_color,omitempty"`
+	Tags              []string               `protobuf:"bytes,7,rep,name=tags,proto3" json:"tags,omitempty"`
+	Kind              pb.ThreadKind          `protobuf:"varint,8,opt,name=kind,proto3,enum=models.ThreadKind" json:"kind,omitempty"`
+	Url               string                 `protobuf:"bytes,9,opt,name=url,proto3" json:"url,omitempty"`
+	Domain            string                 `protobuf:"bytes,10,opt,name=domain,proto3" json:"domain,omitempty"`
+	AttachmentId      string                 `protobuf:"bytes,11,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	Poll              *pb.Poll               `protobuf:"bytes,12,opt,name=poll,proto3" json:"poll,omitempty"`
+	ThumbnailUrl      string                 `protobuf:"bytes,13,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	Draft             bool                   `protobuf:"varint,14,opt,name=draft,proto3" json:"draft,omitempty"`
+	PublishAt         *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	CrosspostParentId string                 `protobuf:"bytes,16,opt,name=crosspost_parent_id,json=crosspostParentId,proto3" json:"crosspost_parent_id,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
-func (x *UpdateThreadRequest) Reset() {
-	*x = UpdateThreadRequest{}
+func (x *CreateThreadRequest) Reset() {
+	*x = CreateThreadRequest{}
 	mi := &file_db_service_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateThreadRequest) String() string {
+func (x *CreateThreadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateThreadRequest) ProtoMessage() {}
+func (*CreateThreadRequest) ProtoMessage() {}
 
-func (x *UpdateThreadRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateThreadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_db_service_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -667,34 +821,284 @@ func (x *UpdateThreadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateThreadRequest.ProtoReflect.Descriptor instead.
-func (*UpdateThreadRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateThreadRequest.ProtoReflect.Descriptor instead.
+func (*CreateThreadRequest) Descriptor() ([]byte, []int) {
 	return file_db_service_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *UpdateThreadRequest) GetId() string {
+func (x *CreateThreadRequest) GetCommunityId() string {
 	if x != nil {
-		return x.Id
+		return x.CommunityId
 	}
 	return ""
 }
 
-func (x *UpdateThreadRequest) GetTitle() string {
-	if x != nil && x.Title != nil {
-		return *x.Title
+func (x *CreateThreadRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
 	return ""
 }
 
-func (x *UpdateThreadRequest) GetContent() string {
-	if x != nil && x.Content != nil {
-		return *x.Content
+func (x *CreateThreadRequest) GetContent() string {
+	if x != nil {
+		return x.Content
 	}
 	return ""
 }
 
-func (x *UpdateThreadRequest) GetVoteOffset() int32 {
-	if x != nil && x.VoteOffset != nil {
+func (x *CreateThreadRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetFlair() string {
+	if x != nil {
+		return x.Flair
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetFlairColor() string {
+	if x != nil {
+		return x.FlairColor
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateThreadRequest) GetKind() pb.ThreadKind {
+	if x != nil {
+		return x.Kind
+	}
+	return pb.ThreadKind(0)
+}
+
+func (x *CreateThreadRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetPoll() *pb.Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *CreateThreadRequest) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *CreateThreadRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *CreateThreadRequest) GetCrosspostParentId() string {
+	if x != nil {
+		return x.CrosspostParentId
+	}
+	return ""
+}
+
+type CreateThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateThreadResponse) Reset() {
+	*x = CreateThreadResponse{}
+	mi := &file_db_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateThreadResponse) ProtoMessage() {}
+
+func (x *CreateThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateThreadResponse.ProtoReflect.Descriptor instead.
+func (*CreateThreadResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateThreadResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetThreadRequest) Reset() {
+	*x = GetThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetThreadRequest) ProtoMessage() {}
+
+func (x *GetThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetThreadRequest.ProtoReflect.Descriptor instead.
+func (*GetThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UpdateThreadRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title             *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Content           *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	VoteOffset        *int32                 `protobuf:"varint,4,opt,name=vote_offset,json=voteOffset,proto3,oneof" json:"vote_offset,omitempty"`
+	NumCommentsOffset *int32                 `protobuf:"varint,5,opt,name=num_comments_offset,json=numCommentsOffset,proto3,oneof" json:"num_comments_offset,omitempty"`
+	NumReportsOffset  *int32                 `protobuf:"varint,6,opt,name=num_reports_offset,json=numReportsOffset,proto3,oneof" json:"num_reports_offset,omitempty"`
+	Locked            *bool                  `protobuf:"varint,7,opt,name=locked,proto3,oneof" json:"locked,omitempty"`
+	Flair             *string                `protobuf:"bytes,8,opt,name=flair,proto3,oneof" json:"flair,omitempty"`
+	SpamScore         *float64               `protobuf:"fixed64,9,opt,name=spam_score,json=spamScore,proto3,oneof" json:"spam_score,omitempty"`
+	Pinned            *bool                  `protobuf:"varint,10,opt,name=pinned,proto3,oneof" json:"pinned,omitempty"`
+	FlairColor        *string                `protobuf:"bytes,11,opt,name=flair_color,json=flairColor,proto3,oneof" json:"flair_color,omitempty"`
+	Tags              *pb.TagList            `protobuf:"bytes,12,opt,name=tags,proto3" json:"tags,omitempty"`
+	EditorId          string                 `protobuf:"bytes,13,opt,name=editor_id,json=editorId,proto3" json:"editor_id,omitempty"`
+	PublishAt         *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateThreadRequest) Reset() {
+	*x = UpdateThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateThreadRequest) ProtoMessage() {}
+
+func (x *UpdateThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateThreadRequest.ProtoReflect.Descriptor instead.
+func (*UpdateThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UpdateThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetVoteOffset() int32 {
+	if x != nil && x.VoteOffset != nil {
 		return *x.VoteOffset
 	}
 	return 0
@@ -707,28 +1111,2217 @@ func (x *UpdateThreadRequest) GetNumCommentsOffset() int32 {
 	return 0
 }
 
-type DeleteThreadRequest struct {
+func (x *UpdateThreadRequest) GetNumReportsOffset() int32 {
+	if x != nil && x.NumReportsOffset != nil {
+		return *x.NumReportsOffset
+	}
+	return 0
+}
+
+func (x *UpdateThreadRequest) GetLocked() bool {
+	if x != nil && x.Locked != nil {
+		return *x.Locked
+	}
+	return false
+}
+
+func (x *UpdateThreadRequest) GetFlair() string {
+	if x != nil && x.Flair != nil {
+		return *x.Flair
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetSpamScore() float64 {
+	if x != nil && x.SpamScore != nil {
+		return *x.SpamScore
+	}
+	return 0
+}
+
+func (x *UpdateThreadRequest) GetPinned() bool {
+	if x != nil && x.Pinned != nil {
+		return *x.Pinned
+	}
+	return false
+}
+
+func (x *UpdateThreadRequest) GetFlairColor() string {
+	if x != nil && x.FlairColor != nil {
+		return *x.FlairColor
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetTags() *pb.TagList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *UpdateThreadRequest) GetEditorId() string {
+	if x != nil {
+		return x.EditorId
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+type DeleteThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteThreadRequest) Reset() {
+	*x = DeleteThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteThreadRequest) ProtoMessage() {}
+
+func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteThreadRequest.ProtoReflect.Descriptor instead.
+func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RemoveThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RemovalType   pb.RemovalType         `protobuf:"varint,2,opt,name=removal_type,json=removalType,proto3,enum=models.RemovalType" json:"removal_type,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveThreadRequest) Reset() {
+	*x = RemoveThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveThreadRequest) ProtoMessage() {}
+
+func (x *RemoveThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveThreadRequest.ProtoReflect.Descriptor instead.
+func (*RemoveThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RemoveThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveThreadRequest) GetRemovalType() pb.RemovalType {
+	if x != nil {
+		return x.RemovalType
+	}
+	return pb.RemovalType(0)
+}
+
+func (x *RemoveThreadRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RestoreThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreThreadRequest) Reset() {
+	*x = RestoreThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreThreadRequest) ProtoMessage() {}
+
+func (x *RestoreThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreThreadRequest.ProtoReflect.Descriptor instead.
+func (*RestoreThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RestoreThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PublishThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishThreadRequest) Reset() {
+	*x = PublishThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishThreadRequest) ProtoMessage() {}
+
+func (x *PublishThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishThreadRequest.ProtoReflect.Descriptor instead.
+func (*PublishThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PublishThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListScheduledThreadsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PublishBefore *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=publish_before,json=publishBefore,proto3" json:"publish_before,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledThreadsRequest) Reset() {
+	*x = ListScheduledThreadsRequest{}
+	mi := &file_db_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledThreadsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledThreadsRequest) ProtoMessage() {}
+
+func (x *ListScheduledThreadsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledThreadsRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledThreadsRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListScheduledThreadsRequest) GetPublishBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishBefore
+	}
+	return nil
+}
+
+func (x *ListScheduledThreadsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type MoveThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CommunityId   string                 `protobuf:"bytes,2,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveThreadRequest) Reset() {
+	*x = MoveThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveThreadRequest) ProtoMessage() {}
+
+func (x *MoveThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveThreadRequest.ProtoReflect.Descriptor instead.
+func (*MoveThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *MoveThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MoveThreadRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+type MoveThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedirectId    string                 `protobuf:"bytes,1,opt,name=redirect_id,json=redirectId,proto3" json:"redirect_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveThreadResponse) Reset() {
+	*x = MoveThreadResponse{}
+	mi := &file_db_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveThreadResponse) ProtoMessage() {}
+
+func (x *MoveThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveThreadResponse.ProtoReflect.Descriptor instead.
+func (*MoveThreadResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MoveThreadResponse) GetRedirectId() string {
+	if x != nil {
+		return x.RedirectId
+	}
+	return ""
+}
+
+type PinThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	MaxPinned     int32                  `protobuf:"varint,2,opt,name=max_pinned,json=maxPinned,proto3" json:"max_pinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinThreadRequest) Reset() {
+	*x = PinThreadRequest{}
+	mi := &file_db_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinThreadRequest) ProtoMessage() {}
+
+func (x *PinThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinThreadRequest.ProtoReflect.Descriptor instead.
+func (*PinThreadRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PinThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PinThreadRequest) GetMaxPinned() int32 {
+	if x != nil {
+		return x.MaxPinned
+	}
+	return 0
+}
+
+type CastPollVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	OptionIds     []string               `protobuf:"bytes,3,rep,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CastPollVoteRequest) Reset() {
+	*x = CastPollVoteRequest{}
+	mi := &file_db_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CastPollVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CastPollVoteRequest) ProtoMessage() {}
+
+func (x *CastPollVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CastPollVoteRequest.ProtoReflect.Descriptor instead.
+func (*CastPollVoteRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CastPollVoteRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *CastPollVoteRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *CastPollVoteRequest) GetOptionIds() []string {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+type ListCommentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      *string                `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3,oneof" json:"thread_id,omitempty"`
+	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	SortBy        *string                `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	PageToken     *string                `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"` // next_page_token of the previous page, the offset is ignored with it
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsRequest) Reset() {
+	*x = ListCommentsRequest{}
+	mi := &file_db_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsRequest) ProtoMessage() {}
+
+func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
+func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListCommentsRequest) GetThreadId() string {
+	if x != nil && x.ThreadId != nil {
+		return *x.ThreadId
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetOffset() int32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+func (x *ListCommentsRequest) GetSortBy() string {
+	if x != nil && x.SortBy != nil {
+		return *x.SortBy
+	}
+	return ""
+}
+
+func (x *ListCommentsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
+type ListCommentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comments      []*pb.Comment          `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCommentsResponse) Reset() {
+	*x = ListCommentsResponse{}
+	mi := &file_db_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCommentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCommentsResponse) ProtoMessage() {}
+
+func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
+func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListCommentsResponse) GetComments() []*pb.Comment {
+	if x != nil {
+		return x.Comments
+	}
+	return nil
+}
+
+func (x *ListCommentsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type CreateCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ParentType    pb.CommentParentType   `protobuf:"varint,3,opt,name=parent_type,json=parentType,proto3,enum=models.CommentParentType" json:"parent_type,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	AttachmentIds []string               `protobuf:"bytes,5,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentRequest) Reset() {
+	*x = CreateCommentRequest{}
+	mi := &file_db_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentRequest) ProtoMessage() {}
+
+func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
+func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CreateCommentRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetParentType() pb.CommentParentType {
+	if x != nil {
+		return x.ParentType
+	}
+	return pb.CommentParentType(0)
+}
+
+func (x *CreateCommentRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateCommentRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+type CreateCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateCommentResponse) Reset() {
+	*x = CreateCommentResponse{}
+	mi := &file_db_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateCommentResponse) ProtoMessage() {}
+
+func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateCommentResponse.ProtoReflect.Descriptor instead.
+func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CreateCommentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentRequest) Reset() {
+	*x = GetCommentRequest{}
+	mi := &file_db_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentRequest) ProtoMessage() {}
+
+func (x *GetCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentRequest.ProtoReflect.Descriptor instead.
+func (*GetCommentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCommentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Comment       *pb.Comment            `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCommentResponse) Reset() {
+	*x = GetCommentResponse{}
+	mi := &file_db_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCommentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCommentResponse) ProtoMessage() {}
+
+func (x *GetCommentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCommentResponse.ProtoReflect.Descriptor instead.
+func (*GetCommentResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetCommentResponse) GetComment() *pb.Comment {
+	if x != nil {
+		return x.Comment
+	}
+	return nil
+}
+
+type UpdateCommentRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Content           *string                `protobuf:"bytes,2,opt,name=content,proto3,oneof" json:"content,omitempty"`
+	VoteOffset        *int32                 `protobuf:"varint,3,opt,name=vote_offset,json=voteOffset,proto3,oneof" json:"vote_offset,omitempty"`
+	NumCommentsOffset *int32                 `protobuf:"varint,4,opt,name=num_comments_offset,json=numCommentsOffset,proto3,oneof" json:"num_comments_offset,omitempty"`
+	NumReportsOffset  *int32                 `protobuf:"varint,5,opt,name=num_reports_offset,json=numReportsOffset,proto3,oneof" json:"num_reports_offset,omitempty"`
+	SpamScore         *float64               `protobuf:"fixed64,6,opt,name=spam_score,json=spamScore,proto3,oneof" json:"spam_score,omitempty"`
+	EditorId          string                 `protobuf:"bytes,7,opt,name=editor_id,json=editorId,proto3" json:"editor_id,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *UpdateCommentRequest) Reset() {
+	*x = UpdateCommentRequest{}
+	mi := &file_db_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCommentRequest) ProtoMessage() {}
+
+func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *UpdateCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateCommentRequest) GetContent() string {
+	if x != nil && x.Content != nil {
+		return *x.Content
+	}
+	return ""
+}
+
+func (x *UpdateCommentRequest) GetVoteOffset() int32 {
+	if x != nil && x.VoteOffset != nil {
+		return *x.VoteOffset
+	}
+	return 0
+}
+
+func (x *UpdateCommentRequest) GetNumCommentsOffset() int32 {
+	if x != nil && x.NumCommentsOffset != nil {
+		return *x.NumCommentsOffset
+	}
+	return 0
+}
+
+func (x *UpdateCommentRequest) GetNumReportsOffset() int32 {
+	if x != nil && x.NumReportsOffset != nil {
+		return *x.NumReportsOffset
+	}
+	return 0
+}
+
+func (x *UpdateCommentRequest) GetSpamScore() float64 {
+	if x != nil && x.SpamScore != nil {
+		return *x.SpamScore
+	}
+	return 0
+}
+
+func (x *UpdateCommentRequest) GetEditorId() string {
+	if x != nil {
+		return x.EditorId
+	}
+	return ""
+}
+
+type DeleteCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentRequest) Reset() {
+	*x = DeleteCommentRequest{}
+	mi := &file_db_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentRequest) ProtoMessage() {}
+
+func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteCommentTreeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NumDeleted    int32                  `protobuf:"varint,1,opt,name=num_deleted,json=numDeleted,proto3" json:"num_deleted,omitempty"` // the comment and its replies
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteCommentTreeResponse) Reset() {
+	*x = DeleteCommentTreeResponse{}
+	mi := &file_db_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteCommentTreeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteCommentTreeResponse) ProtoMessage() {}
+
+func (x *DeleteCommentTreeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteCommentTreeResponse.ProtoReflect.Descriptor instead.
+func (*DeleteCommentTreeResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteCommentTreeResponse) GetNumDeleted() int32 {
+	if x != nil {
+		return x.NumDeleted
+	}
+	return 0
+}
+
+type RemoveCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RemovalType   pb.RemovalType         `protobuf:"varint,2,opt,name=removal_type,json=removalType,proto3,enum=models.RemovalType" json:"removal_type,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveCommentRequest) Reset() {
+	*x = RemoveCommentRequest{}
+	mi := &file_db_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveCommentRequest) ProtoMessage() {}
+
+func (x *RemoveCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveCommentRequest.ProtoReflect.Descriptor instead.
+func (*RemoveCommentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *RemoveCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveCommentRequest) GetRemovalType() pb.RemovalType {
+	if x != nil {
+		return x.RemovalType
+	}
+	return pb.RemovalType(0)
+}
+
+func (x *RemoveCommentRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RestoreCommentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreCommentRequest) Reset() {
+	*x = RestoreCommentRequest{}
+	mi := &file_db_service_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreCommentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreCommentRequest) ProtoMessage() {}
+
+func (x *RestoreCommentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreCommentRequest.ProtoReflect.Descriptor instead.
+func (*RestoreCommentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *RestoreCommentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAuthorStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AuthorId      string                 `protobuf:"bytes,1,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorStatsRequest) Reset() {
+	*x = GetAuthorStatsRequest{}
+	mi := &file_db_service_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorStatsRequest) ProtoMessage() {}
+
+func (x *GetAuthorStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetAuthorStatsRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *GetAuthorStatsRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type GetAuthorStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Karma         int32                  `protobuf:"varint,1,opt,name=karma,proto3" json:"karma,omitempty"`
+	FirstSeenAt   *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=first_seen_at,json=firstSeenAt,proto3" json:"first_seen_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAuthorStatsResponse) Reset() {
+	*x = GetAuthorStatsResponse{}
+	mi := &file_db_service_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAuthorStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAuthorStatsResponse) ProtoMessage() {}
+
+func (x *GetAuthorStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAuthorStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetAuthorStatsResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *GetAuthorStatsResponse) GetKarma() int32 {
+	if x != nil {
+		return x.Karma
+	}
+	return 0
+}
+
+func (x *GetAuthorStatsResponse) GetFirstSeenAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeenAt
+	}
+	return nil
+}
+
+type GetAutomodConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAutomodConfigRequest) Reset() {
+	*x = GetAutomodConfigRequest{}
+	mi := &file_db_service_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAutomodConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAutomodConfigRequest) ProtoMessage() {}
+
+func (x *GetAutomodConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAutomodConfigRequest.ProtoReflect.Descriptor instead.
+func (*GetAutomodConfigRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetAutomodConfigRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+type GetAutomodConfigResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Config        string                 `protobuf:"bytes,1,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAutomodConfigResponse) Reset() {
+	*x = GetAutomodConfigResponse{}
+	mi := &file_db_service_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAutomodConfigResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAutomodConfigResponse) ProtoMessage() {}
+
+func (x *GetAutomodConfigResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAutomodConfigResponse.ProtoReflect.Descriptor instead.
+func (*GetAutomodConfigResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetAutomodConfigResponse) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+type SetAutomodConfigRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Config        string                 `protobuf:"bytes,2,opt,name=config,proto3" json:"config,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetAutomodConfigRequest) Reset() {
+	*x = SetAutomodConfigRequest{}
+	mi := &file_db_service_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetAutomodConfigRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetAutomodConfigRequest) ProtoMessage() {}
+
+func (x *SetAutomodConfigRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetAutomodConfigRequest.ProtoReflect.Descriptor instead.
+func (*SetAutomodConfigRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *SetAutomodConfigRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *SetAutomodConfigRequest) GetConfig() string {
+	if x != nil {
+		return x.Config
+	}
+	return ""
+}
+
+type ListModQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId   *string                `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3,oneof" json:"community_id,omitempty"`
+	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModQueueRequest) Reset() {
+	*x = ListModQueueRequest{}
+	mi := &file_db_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModQueueRequest) ProtoMessage() {}
+
+func (x *ListModQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModQueueRequest.ProtoReflect.Descriptor instead.
+func (*ListModQueueRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ListModQueueRequest) GetCommunityId() string {
+	if x != nil && x.CommunityId != nil {
+		return *x.CommunityId
+	}
+	return ""
+}
+
+func (x *ListModQueueRequest) GetOffset() int32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListModQueueRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListModQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*pb.ModQueueItem     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListModQueueResponse) Reset() {
+	*x = ListModQueueResponse{}
+	mi := &file_db_service_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListModQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListModQueueResponse) ProtoMessage() {}
+
+func (x *ListModQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListModQueueResponse.ProtoReflect.Descriptor instead.
+func (*ListModQueueResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ListModQueueResponse) GetItems() []*pb.ModQueueItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type CreateModQueueItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,2,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Rule          string                 `protobuf:"bytes,4,opt,name=rule,proto3" json:"rule,omitempty"`
+	Reason        string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"`
+	DryRun        bool                   `protobuf:"varint,6,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateModQueueItemRequest) Reset() {
+	*x = CreateModQueueItemRequest{}
+	mi := &file_db_service_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateModQueueItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateModQueueItemRequest) ProtoMessage() {}
+
+func (x *CreateModQueueItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateModQueueItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateModQueueItemRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CreateModQueueItemRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *CreateModQueueItemRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *CreateModQueueItemRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *CreateModQueueItemRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *CreateModQueueItemRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateModQueueItemRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type CreateModQueueItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateModQueueItemResponse) Reset() {
+	*x = CreateModQueueItemResponse{}
+	mi := &file_db_service_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateModQueueItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateModQueueItemResponse) ProtoMessage() {}
+
+func (x *CreateModQueueItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateModQueueItemResponse.ProtoReflect.Descriptor instead.
+func (*CreateModQueueItemResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *CreateModQueueItemResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteModQueueItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteModQueueItemRequest) Reset() {
+	*x = DeleteModQueueItemRequest{}
+	mi := &file_db_service_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteModQueueItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteModQueueItemRequest) ProtoMessage() {}
+
+func (x *DeleteModQueueItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteModQueueItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteModQueueItemRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *DeleteModQueueItemRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RecordAutomodActionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // empty when the rule acted on the thread
+	Rule          string                 `protobuf:"bytes,3,opt,name=rule,proto3" json:"rule,omitempty"`
+	DryRun        bool                   `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"` // actions recorded while the community is in dry run do not prevent the actual ones
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordAutomodActionRequest) Reset() {
+	*x = RecordAutomodActionRequest{}
+	mi := &file_db_service_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordAutomodActionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordAutomodActionRequest) ProtoMessage() {}
+
+func (x *RecordAutomodActionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordAutomodActionRequest.ProtoReflect.Descriptor instead.
+func (*RecordAutomodActionRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RecordAutomodActionRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *RecordAutomodActionRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *RecordAutomodActionRequest) GetRule() string {
+	if x != nil {
+		return x.Rule
+	}
+	return ""
+}
+
+func (x *RecordAutomodActionRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type CreateReportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`    // empty when a comment is reported
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // empty when a thread is reported
+	ReporterId    string                 `protobuf:"bytes,3,opt,name=reporter_id,json=reporterId,proto3" json:"reporter_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReportRequest) Reset() {
+	*x = CreateReportRequest{}
+	mi := &file_db_service_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReportRequest) ProtoMessage() {}
+
+func (x *CreateReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReportRequest.ProtoReflect.Descriptor instead.
+func (*CreateReportRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *CreateReportRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *CreateReportRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *CreateReportRequest) GetReporterId() string {
+	if x != nil {
+		return x.ReporterId
+	}
+	return ""
+}
+
+type ListSpamSamplesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        SpamSampleSource       `protobuf:"varint,1,opt,name=source,proto3,enum=db.SpamSampleSource" json:"source,omitempty"`
+	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSpamSamplesRequest) Reset() {
+	*x = ListSpamSamplesRequest{}
+	mi := &file_db_service_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSpamSamplesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpamSamplesRequest) ProtoMessage() {}
+
+func (x *ListSpamSamplesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpamSamplesRequest.ProtoReflect.Descriptor instead.
+func (*ListSpamSamplesRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ListSpamSamplesRequest) GetSource() SpamSampleSource {
+	if x != nil {
+		return x.Source
+	}
+	return SpamSampleSource_THREADS
+}
+
+func (x *ListSpamSamplesRequest) GetOffset() int32 {
+	if x != nil && x.Offset != nil {
+		return *x.Offset
+	}
+	return 0
+}
+
+func (x *ListSpamSamplesRequest) GetLimit() int32 {
+	if x != nil && x.Limit != nil {
+		return *x.Limit
+	}
+	return 0
+}
+
+type ListSpamSamplesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Samples       []*SpamSample          `protobuf:"bytes,1,rep,name=samples,proto3" json:"samples,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSpamSamplesResponse) Reset() {
+	*x = ListSpamSamplesResponse{}
+	mi := &file_db_service_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSpamSamplesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpamSamplesResponse) ProtoMessage() {}
+
+func (x *ListSpamSamplesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpamSamplesResponse.ProtoReflect.Descriptor instead.
+func (*ListSpamSamplesResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ListSpamSamplesResponse) GetSamples() []*SpamSample {
+	if x != nil {
+		return x.Samples
+	}
+	return nil
+}
+
+type SpamSample struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Text          string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Spam          bool                   `protobuf:"varint,2,opt,name=spam,proto3" json:"spam,omitempty"` // removed by a moderator
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpamSample) Reset() {
+	*x = SpamSample{}
+	mi := &file_db_service_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpamSample) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpamSample) ProtoMessage() {}
+
+func (x *SpamSample) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpamSample.ProtoReflect.Descriptor instead.
+func (*SpamSample) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *SpamSample) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SpamSample) GetSpam() bool {
+	if x != nil {
+		return x.Spam
+	}
+	return false
+}
+
+type SpamModel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Model         []byte                 `protobuf:"bytes,1,opt,name=model,proto3" json:"model,omitempty"`
+	TrainedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=trained_at,json=trainedAt,proto3" json:"trained_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpamModel) Reset() {
+	*x = SpamModel{}
+	mi := &file_db_service_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpamModel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpamModel) ProtoMessage() {}
+
+func (x *SpamModel) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpamModel.ProtoReflect.Descriptor instead.
+func (*SpamModel) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *SpamModel) GetModel() []byte {
+	if x != nil {
+		return x.Model
+	}
+	return nil
+}
+
+func (x *SpamModel) GetTrainedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.TrainedAt
+	}
+	return nil
+}
+
+type CreateAttachmentRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Filename             string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType          string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Size                 int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256               string                 `protobuf:"bytes,4,opt,name=sha256,proto3" json:"sha256,omitempty"`
+	AuthorId             string                 `protobuf:"bytes,5,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	Width                int32                  `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height               int32                  `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	ThumbnailSha256      string                 `protobuf:"bytes,8,opt,name=thumbnail_sha256,json=thumbnailSha256,proto3" json:"thumbnail_sha256,omitempty"`
+	ThumbnailContentType string                 `protobuf:"bytes,9,opt,name=thumbnail_content_type,json=thumbnailContentType,proto3" json:"thumbnail_content_type,omitempty"`
+	ThumbnailSize        int64                  `protobuf:"varint,10,opt,name=thumbnail_size,json=thumbnailSize,proto3" json:"thumbnail_size,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *CreateAttachmentRequest) Reset() {
+	*x = CreateAttachmentRequest{}
+	mi := &file_db_service_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttachmentRequest) ProtoMessage() {}
+
+func (x *CreateAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateAttachmentRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *CreateAttachmentRequest) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *CreateAttachmentRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CreateAttachmentRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *CreateAttachmentRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateAttachmentRequest) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *CreateAttachmentRequest) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *CreateAttachmentRequest) GetThumbnailSha256() string {
+	if x != nil {
+		return x.ThumbnailSha256
+	}
+	return ""
+}
+
+func (x *CreateAttachmentRequest) GetThumbnailContentType() string {
+	if x != nil {
+		return x.ThumbnailContentType
+	}
+	return ""
+}
+
+func (x *CreateAttachmentRequest) GetThumbnailSize() int64 {
+	if x != nil {
+		return x.ThumbnailSize
+	}
+	return 0
+}
+
+type CreateAttachmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAttachmentResponse) Reset() {
+	*x = CreateAttachmentResponse{}
+	mi := &file_db_service_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAttachmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAttachmentResponse) ProtoMessage() {}
+
+func (x *CreateAttachmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAttachmentResponse.ProtoReflect.Descriptor instead.
+func (*CreateAttachmentResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CreateAttachmentResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAttachmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentRequest) Reset() {
+	*x = GetAttachmentRequest{}
+	mi := &file_db_service_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentRequest) ProtoMessage() {}
+
+func (x *GetAttachmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetAttachmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// exactly one of thread_id and comment_id is set
+type ListRevisionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,2,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteThreadRequest) Reset() {
-	*x = DeleteThreadRequest{}
-	mi := &file_db_service_proto_msgTypes[13]
+func (x *ListRevisionsRequest) Reset() {
+	*x = ListRevisionsRequest{}
+	mi := &file_db_service_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteThreadRequest) String() string {
+func (x *ListRevisionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteThreadRequest) ProtoMessage() {}
+func (*ListRevisionsRequest) ProtoMessage() {}
 
-func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[13]
+func (x *ListRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -739,43 +3332,47 @@ func (x *DeleteThreadRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteThreadRequest.ProtoReflect.Descriptor instead.
-func (*DeleteThreadRequest) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use ListRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *DeleteThreadRequest) GetId() string {
+func (x *ListRevisionsRequest) GetThreadId() string {
 	if x != nil {
-		return x.Id
+		return x.ThreadId
 	}
 	return ""
 }
 
-type ListCommentsRequest struct {
+func (x *ListRevisionsRequest) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+type ListRevisionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ThreadId      *string                `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3,oneof" json:"thread_id,omitempty"`
-	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
-	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	SortBy        *string                `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	Revisions     []*pb.Revision         `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCommentsRequest) Reset() {
-	*x = ListCommentsRequest{}
-	mi := &file_db_service_proto_msgTypes[14]
+func (x *ListRevisionsResponse) Reset() {
+	*x = ListRevisionsResponse{}
+	mi := &file_db_service_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCommentsRequest) String() string {
+func (x *ListRevisionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCommentsRequest) ProtoMessage() {}
+func (*ListRevisionsResponse) ProtoMessage() {}
 
-func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[14]
+func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -786,61 +3383,40 @@ func (x *ListCommentsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCommentsRequest.ProtoReflect.Descriptor instead.
-func (*ListCommentsRequest) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{14}
-}
-
-func (x *ListCommentsRequest) GetThreadId() string {
-	if x != nil && x.ThreadId != nil {
-		return *x.ThreadId
-	}
-	return ""
-}
-
-func (x *ListCommentsRequest) GetOffset() int32 {
-	if x != nil && x.Offset != nil {
-		return *x.Offset
-	}
-	return 0
-}
-
-func (x *ListCommentsRequest) GetLimit() int32 {
-	if x != nil && x.Limit != nil {
-		return *x.Limit
-	}
-	return 0
+// Deprecated: Use ListRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *ListCommentsRequest) GetSortBy() string {
-	if x != nil && x.SortBy != nil {
-		return *x.SortBy
+func (x *ListRevisionsResponse) GetRevisions() []*pb.Revision {
+	if x != nil {
+		return x.Revisions
 	}
-	return ""
+	return nil
 }
 
-type ListCommentsResponse struct {
+type ReconcileCountersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comments      []*pb.Comment          `protobuf:"bytes,1,rep,name=comments,proto3" json:"comments,omitempty"`
+	Fix           bool                   `protobuf:"varint,1,opt,name=fix,proto3" json:"fix,omitempty"` // store the counted values, the discrepancies are only reported otherwise
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListCommentsResponse) Reset() {
-	*x = ListCommentsResponse{}
-	mi := &file_db_service_proto_msgTypes[15]
+func (x *ReconcileCountersRequest) Reset() {
+	*x = ReconcileCountersRequest{}
+	mi := &file_db_service_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListCommentsResponse) String() string {
+func (x *ReconcileCountersRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListCommentsResponse) ProtoMessage() {}
+func (*ReconcileCountersRequest) ProtoMessage() {}
 
-func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[15]
+func (x *ReconcileCountersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -851,42 +3427,41 @@ func (x *ListCommentsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListCommentsResponse.ProtoReflect.Descriptor instead.
-func (*ListCommentsResponse) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use ReconcileCountersRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileCountersRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *ListCommentsResponse) GetComments() []*pb.Comment {
+func (x *ReconcileCountersRequest) GetFix() bool {
 	if x != nil {
-		return x.Comments
+		return x.Fix
 	}
-	return nil
+	return false
 }
 
-type CreateCommentRequest struct {
+type ReconcileCountersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	ParentId      string                 `protobuf:"bytes,2,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	ParentType    pb.CommentParentType   `protobuf:"varint,3,opt,name=parent_type,json=parentType,proto3,enum=models.CommentParentType" json:"parent_type,omitempty"`
+	Discrepancies []*CounterDiscrepancy  `protobuf:"bytes,1,rep,name=discrepancies,proto3" json:"discrepancies,omitempty"`
+	NumChecked    int32                  `protobuf:"varint,2,opt,name=num_checked,json=numChecked,proto3" json:"num_checked,omitempty"` // number of counters compared with the documents they count
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCommentRequest) Reset() {
-	*x = CreateCommentRequest{}
-	mi := &file_db_service_proto_msgTypes[16]
+func (x *ReconcileCountersResponse) Reset() {
+	*x = ReconcileCountersResponse{}
+	mi := &file_db_service_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCommentRequest) String() string {
+func (x *ReconcileCountersResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCommentRequest) ProtoMessage() {}
+func (*ReconcileCountersResponse) ProtoMessage() {}
 
-func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[16]
+func (x *ReconcileCountersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -897,54 +3472,53 @@ func (x *CreateCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCommentRequest.ProtoReflect.Descriptor instead.
-func (*CreateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{16}
-}
-
-func (x *CreateCommentRequest) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
+// Deprecated: Use ReconcileCountersResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileCountersResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *CreateCommentRequest) GetParentId() string {
+func (x *ReconcileCountersResponse) GetDiscrepancies() []*CounterDiscrepancy {
 	if x != nil {
-		return x.ParentId
+		return x.Discrepancies
 	}
-	return ""
+	return nil
 }
 
-func (x *CreateCommentRequest) GetParentType() pb.CommentParentType {
+func (x *ReconcileCountersResponse) GetNumChecked() int32 {
 	if x != nil {
-		return x.ParentType
+		return x.NumChecked
 	}
-	return pb.CommentParentType(0)
+	return 0
 }
 
-type CreateCommentResponse struct {
+// CounterDiscrepancy is num_threads of a community or num_comments of a thread or comment that differs from the
+// documents it counts, exactly one id is set
+type CounterDiscrepancy struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,2,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,3,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`
+	Stored        int32                  `protobuf:"varint,4,opt,name=stored,proto3" json:"stored,omitempty"`
+	Counted       int32                  `protobuf:"varint,5,opt,name=counted,proto3" json:"counted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateCommentResponse) Reset() {
-	*x = CreateCommentResponse{}
-	mi := &file_db_service_proto_msgTypes[17]
+func (x *CounterDiscrepancy) Reset() {
+	*x = CounterDiscrepancy{}
+	mi := &file_db_service_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateCommentResponse) String() string {
+func (x *CounterDiscrepancy) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateCommentResponse) ProtoMessage() {}
+func (*CounterDiscrepancy) ProtoMessage() {}
 
-func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[17]
+func (x *CounterDiscrepancy) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,40 +3529,68 @@ func (x *CreateCommentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateCommentResponse.ProtoReflect.Descriptor instead.
-func (*CreateCommentResponse) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use CounterDiscrepancy.ProtoReflect.Descriptor instead.
+func (*CounterDiscrepancy) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *CreateCommentResponse) GetId() string {
+func (x *CounterDiscrepancy) GetCommunityId() string {
 	if x != nil {
-		return x.Id
+		return x.CommunityId
 	}
 	return ""
 }
 
-type GetCommentRequest struct {
+func (x *CounterDiscrepancy) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *CounterDiscrepancy) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *CounterDiscrepancy) GetStored() int32 {
+	if x != nil {
+		return x.Stored
+	}
+	return 0
+}
+
+func (x *CounterDiscrepancy) GetCounted() int32 {
+	if x != nil {
+		return x.Counted
+	}
+	return 0
+}
+
+type SubscribeEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AfterOffset   int64                  `protobuf:"varint,1,opt,name=after_offset,json=afterOffset,proto3" json:"after_offset,omitempty"` // offset of the last event the subscriber handled, 0 streams every retained event
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCommentRequest) Reset() {
-	*x = GetCommentRequest{}
-	mi := &file_db_service_proto_msgTypes[18]
+func (x *SubscribeEventsRequest) Reset() {
+	*x = SubscribeEventsRequest{}
+	mi := &file_db_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCommentRequest) String() string {
+func (x *SubscribeEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentRequest) ProtoMessage() {}
+func (*SubscribeEventsRequest) ProtoMessage() {}
 
-func (x *GetCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[18]
+func (x *SubscribeEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -999,40 +3601,41 @@ func (x *GetCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentRequest.ProtoReflect.Descriptor instead.
-func (*GetCommentRequest) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use SubscribeEventsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeEventsRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *GetCommentRequest) GetId() string {
+func (x *SubscribeEventsRequest) GetAfterOffset() int64 {
 	if x != nil {
-		return x.Id
+		return x.AfterOffset
 	}
-	return ""
+	return 0
 }
 
-type GetCommentResponse struct {
+type CreateJobRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Comment       *pb.Comment            `protobuf:"bytes,1,opt,name=comment,proto3" json:"comment,omitempty"`
+	Type          pb.JobType             `protobuf:"varint,1,opt,name=type,proto3,enum=models.JobType" json:"type,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetCommentResponse) Reset() {
-	*x = GetCommentResponse{}
-	mi := &file_db_service_proto_msgTypes[19]
+func (x *CreateJobRequest) Reset() {
+	*x = CreateJobRequest{}
+	mi := &file_db_service_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetCommentResponse) String() string {
+func (x *CreateJobRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetCommentResponse) ProtoMessage() {}
+func (*CreateJobRequest) ProtoMessage() {}
 
-func (x *GetCommentResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[19]
+func (x *CreateJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1043,43 +3646,47 @@ func (x *GetCommentResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetCommentResponse.ProtoReflect.Descriptor instead.
-func (*GetCommentResponse) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use CreateJobRequest.ProtoReflect.Descriptor instead.
+func (*CreateJobRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *GetCommentResponse) GetComment() *pb.Comment {
+func (x *CreateJobRequest) GetType() pb.JobType {
 	if x != nil {
-		return x.Comment
+		return x.Type
 	}
-	return nil
+	return pb.JobType(0)
 }
 
-type UpdateCommentRequest struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Content           *string                `protobuf:"bytes,2,opt,name=content,proto3,oneof" json:"content,omitempty"`
-	VoteOffset        *int32                 `protobuf:"varint,3,opt,name=vote_offset,json=voteOffset,proto3,oneof" json:"vote_offset,omitempty"`
-	NumCommentsOffset *int32                 `protobuf:"varint,4,opt,name=num_comments_offset,json=numCommentsOffset,proto3,oneof" json:"num_comments_offset,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *CreateJobRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
 }
 
-func (x *UpdateCommentRequest) Reset() {
-	*x = UpdateCommentRequest{}
-	mi := &file_db_service_proto_msgTypes[20]
+type CreateJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateJobResponse) Reset() {
+	*x = CreateJobResponse{}
+	mi := &file_db_service_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateCommentRequest) String() string {
+func (x *CreateJobResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateCommentRequest) ProtoMessage() {}
+func (*CreateJobResponse) ProtoMessage() {}
 
-func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[20]
+func (x *CreateJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1090,61 +3697,40 @@ func (x *UpdateCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateCommentRequest.ProtoReflect.Descriptor instead.
-func (*UpdateCommentRequest) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use CreateJobResponse.ProtoReflect.Descriptor instead.
+func (*CreateJobResponse) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *UpdateCommentRequest) GetId() string {
+func (x *CreateJobResponse) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *UpdateCommentRequest) GetContent() string {
-	if x != nil && x.Content != nil {
-		return *x.Content
-	}
-	return ""
-}
-
-func (x *UpdateCommentRequest) GetVoteOffset() int32 {
-	if x != nil && x.VoteOffset != nil {
-		return *x.VoteOffset
-	}
-	return 0
-}
-
-func (x *UpdateCommentRequest) GetNumCommentsOffset() int32 {
-	if x != nil && x.NumCommentsOffset != nil {
-		return *x.NumCommentsOffset
-	}
-	return 0
-}
-
-type DeleteCommentRequest struct {
+type GetJobStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteCommentRequest) Reset() {
-	*x = DeleteCommentRequest{}
-	mi := &file_db_service_proto_msgTypes[21]
+func (x *GetJobStatusRequest) Reset() {
+	*x = GetJobStatusRequest{}
+	mi := &file_db_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteCommentRequest) String() string {
+func (x *GetJobStatusRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteCommentRequest) ProtoMessage() {}
+func (*GetJobStatusRequest) ProtoMessage() {}
 
-func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_db_service_proto_msgTypes[21]
+func (x *GetJobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_db_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1155,12 +3741,12 @@ func (x *DeleteCommentRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteCommentRequest.ProtoReflect.Descriptor instead.
-func (*DeleteCommentRequest) Descriptor() ([]byte, []int) {
-	return file_db_service_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetJobStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetJobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_db_service_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *DeleteCommentRequest) GetId() string {
+func (x *GetJobStatusRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
@@ -1171,119 +3757,377 @@ var File_db_service_proto protoreflect.FileDescriptor
 
 const file_db_service_proto_rawDesc = "" +
 	"\n" +
-	"\x10db-service.proto\x12\x02db\x1a\x1bgoogle/protobuf/empty.proto\x1a\fmodels.proto\"\x87\x01\n" +
+	"\x10db-service.proto\x12\x02db\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\fmodels.proto\"\xba\x01\n" +
 	"\x16ListCommunitiesRequest\x12\x17\n" +
 	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12\x1b\n" +
 	"\x06offset\x18\x02 \x01(\x05H\x01R\x06offset\x88\x01\x01\x12\x19\n" +
-	"\x05limit\x18\x03 \x01(\x05H\x02R\x05limit\x88\x01\x01B\a\n" +
+	"\x05limit\x18\x03 \x01(\x05H\x02R\x05limit\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tH\x03R\tpageToken\x88\x01\x01B\a\n" +
 	"\x05_nameB\t\n" +
 	"\a_offsetB\b\n" +
-	"\x06_limit\"N\n" +
+	"\x06_limitB\r\n" +
+	"\v_page_token\"v\n" +
 	"\x17ListCommunitiesResponse\x123\n" +
-	"\vcommunities\x18\x01 \x03(\v2\x11.models.CommunityR\vcommunities\",\n" +
+	"\vcommunities\x18\x01 \x03(\v2\x11.models.CommunityR\vcommunities\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\",\n" +
 	"\x16CreateCommunityRequest\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\")\n" +
 	"\x17CreateCommunityResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
 	"\x13GetCommunityRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x94\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xcf\x01\n" +
 	"\x16UpdateCommunityRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x121\n" +
-	"\x12num_threads_offset\x18\x03 \x01(\x05H\x01R\x10numThreadsOffset\x88\x01\x01B\a\n" +
+	"\x12num_threads_offset\x18\x03 \x01(\x05H\x01R\x10numThreadsOffset\x88\x01\x01\x129\n" +
+	"\rposting_rules\x18\x04 \x01(\v2\x14.models.PostingRulesR\fpostingRulesB\a\n" +
 	"\x05_nameB\x15\n" +
 	"\x13_num_threads_offset\"(\n" +
 	"\x16DeleteCommunityRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xe9\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"f\n" +
+	"\x17AddFlairTemplateRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\"*\n" +
+	"\x18AddFlairTemplateResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"O\n" +
+	"\x1aRemoveFlairTemplateRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"\x9d\x04\n" +
 	"\x12ListThreadsRequest\x12&\n" +
 	"\fcommunity_id\x18\x01 \x01(\tH\x00R\vcommunityId\x88\x01\x01\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tH\x01R\x05title\x88\x01\x01\x12\x1b\n" +
 	"\x06offset\x18\x03 \x01(\x05H\x02R\x06offset\x88\x01\x01\x12\x19\n" +
 	"\x05limit\x18\x04 \x01(\x05H\x03R\x05limit\x88\x01\x01\x12\x1c\n" +
-	"\asort_by\x18\x05 \x01(\tH\x04R\x06sortBy\x88\x01\x01B\x0f\n" +
+	"\asort_by\x18\x05 \x01(\tH\x04R\x06sortBy\x88\x01\x01\x12\x1b\n" +
+	"\x06pinned\x18\x06 \x01(\bH\x05R\x06pinned\x88\x01\x01\x12\x19\n" +
+	"\x05flair\x18\a \x01(\tH\x06R\x05flair\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\b \x01(\tH\aR\x03tag\x88\x01\x01\x12 \n" +
+	"\tauthor_id\x18\t \x01(\tH\bR\bauthorId\x88\x01\x01\x12\x16\n" +
+	"\x06drafts\x18\n" +
+	" \x01(\bR\x06drafts\x123\n" +
+	"\x13crosspost_parent_id\x18\v \x01(\tH\tR\x11crosspostParentId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\f \x01(\tH\n" +
+	"R\tpageToken\x88\x01\x01B\x0f\n" +
 	"\r_community_idB\b\n" +
 	"\x06_titleB\t\n" +
 	"\a_offsetB\b\n" +
 	"\x06_limitB\n" +
 	"\n" +
-	"\b_sort_by\"?\n" +
+	"\b_sort_byB\t\n" +
+	"\a_pinnedB\b\n" +
+	"\x06_flairB\x06\n" +
+	"\x04_tagB\f\n" +
+	"\n" +
+	"_author_idB\x16\n" +
+	"\x14_crosspost_parent_idB\r\n" +
+	"\v_page_token\"g\n" +
 	"\x13ListThreadsResponse\x12(\n" +
-	"\athreads\x18\x01 \x03(\v2\x0e.models.ThreadR\athreads\"h\n" +
+	"\athreads\x18\x01 \x03(\v2\x0e.models.ThreadR\athreads\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x8f\x04\n" +
 	"\x13CreateThreadRequest\x12!\n" +
 	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"&\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x12\x14\n" +
+	"\x05flair\x18\x05 \x01(\tR\x05flair\x12\x1f\n" +
+	"\vflair_color\x18\x06 \x01(\tR\n" +
+	"flairColor\x12\x12\n" +
+	"\x04tags\x18\a \x03(\tR\x04tags\x12&\n" +
+	"\x04kind\x18\b \x01(\x0e2\x12.models.ThreadKindR\x04kind\x12\x10\n" +
+	"\x03url\x18\t \x01(\tR\x03url\x12\x16\n" +
+	"\x06domain\x18\n" +
+	" \x01(\tR\x06domain\x12#\n" +
+	"\rattachment_id\x18\v \x01(\tR\fattachmentId\x12 \n" +
+	"\x04poll\x18\f \x01(\v2\f.models.PollR\x04poll\x12#\n" +
+	"\rthumbnail_url\x18\r \x01(\tR\fthumbnailUrl\x12\x14\n" +
+	"\x05draft\x18\x0e \x01(\bR\x05draft\x129\n" +
+	"\n" +
+	"publish_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12.\n" +
+	"\x13crosspost_parent_id\x18\x10 \x01(\tR\x11crosspostParentId\"&\n" +
 	"\x14CreateThreadResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
 	"\x10GetThreadRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xf8\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x9d\x05\n" +
 	"\x13UpdateThreadRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
 	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
 	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x12$\n" +
 	"\vvote_offset\x18\x04 \x01(\x05H\x02R\n" +
 	"voteOffset\x88\x01\x01\x123\n" +
-	"\x13num_comments_offset\x18\x05 \x01(\x05H\x03R\x11numCommentsOffset\x88\x01\x01B\b\n" +
+	"\x13num_comments_offset\x18\x05 \x01(\x05H\x03R\x11numCommentsOffset\x88\x01\x01\x121\n" +
+	"\x12num_reports_offset\x18\x06 \x01(\x05H\x04R\x10numReportsOffset\x88\x01\x01\x12\x1b\n" +
+	"\x06locked\x18\a \x01(\bH\x05R\x06locked\x88\x01\x01\x12\x19\n" +
+	"\x05flair\x18\b \x01(\tH\x06R\x05flair\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"spam_score\x18\t \x01(\x01H\aR\tspamScore\x88\x01\x01\x12\x1b\n" +
+	"\x06pinned\x18\n" +
+	" \x01(\bH\bR\x06pinned\x88\x01\x01\x12$\n" +
+	"\vflair_color\x18\v \x01(\tH\tR\n" +
+	"flairColor\x88\x01\x01\x12#\n" +
+	"\x04tags\x18\f \x01(\v2\x0f.models.TagListR\x04tags\x12\x1b\n" +
+	"\teditor_id\x18\r \x01(\tR\beditorId\x129\n" +
+	"\n" +
+	"publish_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAtB\b\n" +
 	"\x06_titleB\n" +
 	"\n" +
 	"\b_contentB\x0e\n" +
 	"\f_vote_offsetB\x16\n" +
-	"\x14_num_comments_offset\"%\n" +
+	"\x14_num_comments_offsetB\x15\n" +
+	"\x13_num_reports_offsetB\t\n" +
+	"\a_lockedB\b\n" +
+	"\x06_flairB\r\n" +
+	"\v_spam_scoreB\t\n" +
+	"\a_pinnedB\x0e\n" +
+	"\f_flair_color\"%\n" +
 	"\x13DeleteThreadRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xbc\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"u\n" +
+	"\x13RemoveThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x126\n" +
+	"\fremoval_type\x18\x02 \x01(\x0e2\x13.models.RemovalTypeR\vremovalType\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"&\n" +
+	"\x14RestoreThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x14PublishThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"v\n" +
+	"\x1bListScheduledThreadsRequest\x12A\n" +
+	"\x0epublish_before\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\rpublishBefore\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"F\n" +
+	"\x11MoveThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fcommunity_id\x18\x02 \x01(\tR\vcommunityId\"5\n" +
+	"\x12MoveThreadResponse\x12\x1f\n" +
+	"\vredirect_id\x18\x01 \x01(\tR\n" +
+	"redirectId\"A\n" +
+	"\x10PinThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"max_pinned\x18\x02 \x01(\x05R\tmaxPinned\"j\n" +
+	"\x13CastPollVoteRequest\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\tR\toptionIds\"\xef\x01\n" +
 	"\x13ListCommentsRequest\x12 \n" +
 	"\tthread_id\x18\x01 \x01(\tH\x00R\bthreadId\x88\x01\x01\x12\x1b\n" +
 	"\x06offset\x18\x02 \x01(\x05H\x01R\x06offset\x88\x01\x01\x12\x19\n" +
 	"\x05limit\x18\x03 \x01(\x05H\x02R\x05limit\x88\x01\x01\x12\x1c\n" +
-	"\asort_by\x18\x04 \x01(\tH\x03R\x06sortBy\x88\x01\x01B\f\n" +
+	"\asort_by\x18\x04 \x01(\tH\x03R\x06sortBy\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x05 \x01(\tH\x04R\tpageToken\x88\x01\x01B\f\n" +
 	"\n" +
 	"_thread_idB\t\n" +
 	"\a_offsetB\b\n" +
 	"\x06_limitB\n" +
 	"\n" +
-	"\b_sort_by\"C\n" +
+	"\b_sort_byB\r\n" +
+	"\v_page_token\"k\n" +
 	"\x14ListCommentsResponse\x12+\n" +
-	"\bcomments\x18\x01 \x03(\v2\x0f.models.CommentR\bcomments\"\x89\x01\n" +
+	"\bcomments\x18\x01 \x03(\v2\x0f.models.CommentR\bcomments\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xcd\x01\n" +
 	"\x14CreateCommentRequest\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x1b\n" +
 	"\tparent_id\x18\x02 \x01(\tR\bparentId\x12:\n" +
 	"\vparent_type\x18\x03 \x01(\x0e2\x19.models.CommentParentTypeR\n" +
-	"parentType\"'\n" +
+	"parentType\x12\x1b\n" +
+	"\tauthor_id\x18\x04 \x01(\tR\bauthorId\x12%\n" +
+	"\x0eattachment_ids\x18\x05 \x03(\tR\rattachmentIds\"'\n" +
 	"\x15CreateCommentResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"#\n" +
 	"\x11GetCommentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"?\n" +
 	"\x12GetCommentResponse\x12)\n" +
-	"\acomment\x18\x01 \x01(\v2\x0f.models.CommentR\acomment\"\xd4\x01\n" +
+	"\acomment\x18\x01 \x01(\v2\x0f.models.CommentR\acomment\"\xee\x02\n" +
 	"\x14UpdateCommentRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
 	"\acontent\x18\x02 \x01(\tH\x00R\acontent\x88\x01\x01\x12$\n" +
 	"\vvote_offset\x18\x03 \x01(\x05H\x01R\n" +
 	"voteOffset\x88\x01\x01\x123\n" +
-	"\x13num_comments_offset\x18\x04 \x01(\x05H\x02R\x11numCommentsOffset\x88\x01\x01B\n" +
+	"\x13num_comments_offset\x18\x04 \x01(\x05H\x02R\x11numCommentsOffset\x88\x01\x01\x121\n" +
+	"\x12num_reports_offset\x18\x05 \x01(\x05H\x03R\x10numReportsOffset\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"spam_score\x18\x06 \x01(\x01H\x04R\tspamScore\x88\x01\x01\x12\x1b\n" +
+	"\teditor_id\x18\a \x01(\tR\beditorIdB\n" +
 	"\n" +
 	"\b_contentB\x0e\n" +
 	"\f_vote_offsetB\x16\n" +
-	"\x14_num_comments_offset\"&\n" +
+	"\x14_num_comments_offsetB\x15\n" +
+	"\x13_num_reports_offsetB\r\n" +
+	"\v_spam_score\"&\n" +
 	"\x14DeleteCommentRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\xea\a\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"<\n" +
+	"\x19DeleteCommentTreeResponse\x12\x1f\n" +
+	"\vnum_deleted\x18\x01 \x01(\x05R\n" +
+	"numDeleted\"v\n" +
+	"\x14RemoveCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x126\n" +
+	"\fremoval_type\x18\x02 \x01(\x0e2\x13.models.RemovalTypeR\vremovalType\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"'\n" +
+	"\x15RestoreCommentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"4\n" +
+	"\x15GetAuthorStatsRequest\x12\x1b\n" +
+	"\tauthor_id\x18\x01 \x01(\tR\bauthorId\"n\n" +
+	"\x16GetAuthorStatsResponse\x12\x14\n" +
+	"\x05karma\x18\x01 \x01(\x05R\x05karma\x12>\n" +
+	"\rfirst_seen_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\vfirstSeenAt\"<\n" +
+	"\x17GetAutomodConfigRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\"2\n" +
+	"\x18GetAutomodConfigResponse\x12\x16\n" +
+	"\x06config\x18\x01 \x01(\tR\x06config\"T\n" +
+	"\x17SetAutomodConfigRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x16\n" +
+	"\x06config\x18\x02 \x01(\tR\x06config\"\x9b\x01\n" +
+	"\x13ListModQueueRequest\x12&\n" +
+	"\fcommunity_id\x18\x01 \x01(\tH\x00R\vcommunityId\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x02 \x01(\x05H\x01R\x06offset\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\x05H\x02R\x05limit\x88\x01\x01B\x0f\n" +
+	"\r_community_idB\t\n" +
+	"\a_offsetB\b\n" +
+	"\x06_limit\"B\n" +
+	"\x14ListModQueueResponse\x12*\n" +
+	"\x05items\x18\x01 \x03(\v2\x14.models.ModQueueItemR\x05items\"\xbf\x01\n" +
+	"\x19CreateModQueueItemRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x1b\n" +
+	"\tthread_id\x18\x02 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x03 \x01(\tR\tcommentId\x12\x12\n" +
+	"\x04rule\x18\x04 \x01(\tR\x04rule\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x17\n" +
+	"\adry_run\x18\x06 \x01(\bR\x06dryRun\",\n" +
+	"\x1aCreateModQueueItemResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x19DeleteModQueueItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x85\x01\n" +
+	"\x1aRecordAutomodActionRequest\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\x12\x12\n" +
+	"\x04rule\x18\x03 \x01(\tR\x04rule\x12\x17\n" +
+	"\adry_run\x18\x04 \x01(\bR\x06dryRun\"r\n" +
+	"\x13CreateReportRequest\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\x12\x1f\n" +
+	"\vreporter_id\x18\x03 \x01(\tR\n" +
+	"reporterId\"\x93\x01\n" +
+	"\x16ListSpamSamplesRequest\x12,\n" +
+	"\x06source\x18\x01 \x01(\x0e2\x14.db.SpamSampleSourceR\x06source\x12\x1b\n" +
+	"\x06offset\x18\x02 \x01(\x05H\x00R\x06offset\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x03 \x01(\x05H\x01R\x05limit\x88\x01\x01B\t\n" +
+	"\a_offsetB\b\n" +
+	"\x06_limit\"C\n" +
+	"\x17ListSpamSamplesResponse\x12(\n" +
+	"\asamples\x18\x01 \x03(\v2\x0e.db.SpamSampleR\asamples\"4\n" +
+	"\n" +
+	"SpamSample\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04spam\x18\x02 \x01(\bR\x04spam\"\\\n" +
+	"\tSpamModel\x12\x14\n" +
+	"\x05model\x18\x01 \x01(\fR\x05model\x129\n" +
+	"\n" +
+	"trained_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttrainedAt\"\xd7\x02\n" +
+	"\x17CreateAttachmentRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x04 \x01(\tR\x06sha256\x12\x1b\n" +
+	"\tauthor_id\x18\x05 \x01(\tR\bauthorId\x12\x14\n" +
+	"\x05width\x18\x06 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\a \x01(\x05R\x06height\x12)\n" +
+	"\x10thumbnail_sha256\x18\b \x01(\tR\x0fthumbnailSha256\x124\n" +
+	"\x16thumbnail_content_type\x18\t \x01(\tR\x14thumbnailContentType\x12%\n" +
+	"\x0ethumbnail_size\x18\n" +
+	" \x01(\x03R\rthumbnailSize\"*\n" +
+	"\x18CreateAttachmentResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"&\n" +
+	"\x14GetAttachmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"R\n" +
+	"\x14ListRevisionsRequest\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x02 \x01(\tR\tcommentId\"G\n" +
+	"\x15ListRevisionsResponse\x12.\n" +
+	"\trevisions\x18\x01 \x03(\v2\x10.models.RevisionR\trevisions\",\n" +
+	"\x18ReconcileCountersRequest\x12\x10\n" +
+	"\x03fix\x18\x01 \x01(\bR\x03fix\"z\n" +
+	"\x19ReconcileCountersResponse\x12<\n" +
+	"\rdiscrepancies\x18\x01 \x03(\v2\x16.db.CounterDiscrepancyR\rdiscrepancies\x12\x1f\n" +
+	"\vnum_checked\x18\x02 \x01(\x05R\n" +
+	"numChecked\"\xa5\x01\n" +
+	"\x12CounterDiscrepancy\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x1b\n" +
+	"\tthread_id\x18\x02 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x03 \x01(\tR\tcommentId\x12\x16\n" +
+	"\x06stored\x18\x04 \x01(\x05R\x06stored\x12\x18\n" +
+	"\acounted\x18\x05 \x01(\x05R\acounted\";\n" +
+	"\x16SubscribeEventsRequest\x12!\n" +
+	"\fafter_offset\x18\x01 \x01(\x03R\vafterOffset\"T\n" +
+	"\x10CreateJobRequest\x12#\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x0f.models.JobTypeR\x04type\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"#\n" +
+	"\x11CreateJobResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"%\n" +
+	"\x13GetJobStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*-\n" +
+	"\x10SpamSampleSource\x12\v\n" +
+	"\aTHREADS\x10\x00\x12\f\n" +
+	"\bCOMMENTS\x10\x012\xa2\x1b\n" +
 	"\tDBService\x12J\n" +
 	"\x0fListCommunities\x12\x1a.db.ListCommunitiesRequest\x1a\x1b.db.ListCommunitiesResponse\x12J\n" +
 	"\x0fCreateCommunity\x12\x1a.db.CreateCommunityRequest\x1a\x1b.db.CreateCommunityResponse\x12:\n" +
 	"\fGetCommunity\x12\x17.db.GetCommunityRequest\x1a\x11.models.Community\x12E\n" +
 	"\x0fUpdateCommunity\x12\x1a.db.UpdateCommunityRequest\x1a\x16.google.protobuf.Empty\x12E\n" +
-	"\x0fDeleteCommunity\x12\x1a.db.DeleteCommunityRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\x0fDeleteCommunity\x12\x1a.db.DeleteCommunityRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x10AddFlairTemplate\x12\x1b.db.AddFlairTemplateRequest\x1a\x1c.db.AddFlairTemplateResponse\x12M\n" +
+	"\x13RemoveFlairTemplate\x12\x1e.db.RemoveFlairTemplateRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
 	"\vListThreads\x12\x16.db.ListThreadsRequest\x1a\x17.db.ListThreadsResponse\x12A\n" +
 	"\fCreateThread\x12\x17.db.CreateThreadRequest\x1a\x18.db.CreateThreadResponse\x121\n" +
 	"\tGetThread\x12\x14.db.GetThreadRequest\x1a\x0e.models.Thread\x12?\n" +
 	"\fUpdateThread\x12\x17.db.UpdateThreadRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
-	"\fDeleteThread\x12\x17.db.DeleteThreadRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\fDeleteThread\x12\x17.db.DeleteThreadRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\fRemoveThread\x12\x17.db.RemoveThreadRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\rRestoreThread\x12\x18.db.RestoreThreadRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\fCastPollVote\x12\x17.db.CastPollVoteRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\rPublishThread\x12\x18.db.PublishThreadRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\x14ListScheduledThreads\x12\x1f.db.ListScheduledThreadsRequest\x1a\x17.db.ListThreadsResponse\x12;\n" +
+	"\n" +
+	"MoveThread\x12\x15.db.MoveThreadRequest\x1a\x16.db.MoveThreadResponse\x129\n" +
+	"\tPinThread\x12\x14.db.PinThreadRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x18CreateThreadAndIncrement\x12\x17.db.CreateThreadRequest\x1a\x18.db.CreateThreadResponse\x12K\n" +
+	"\x18DeleteThreadAndDecrement\x12\x17.db.DeleteThreadRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x19PublishThreadAndIncrement\x12\x18.db.PublishThreadRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
 	"\fListComments\x12\x17.db.ListCommentsRequest\x1a\x18.db.ListCommentsResponse\x12D\n" +
 	"\rCreateComment\x12\x18.db.CreateCommentRequest\x1a\x19.db.CreateCommentResponse\x124\n" +
 	"\n" +
 	"GetComment\x12\x15.db.GetCommentRequest\x1a\x0f.models.Comment\x12A\n" +
 	"\rUpdateComment\x12\x18.db.UpdateCommentRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
-	"\rDeleteComment\x12\x18.db.DeleteCommentRequest\x1a\x16.google.protobuf.EmptyB\x16Z\x14gen/db-service/pb;pbb\x06proto3"
+	"\rDeleteComment\x12\x18.db.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\rRemoveComment\x12\x18.db.RemoveCommentRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x0eRestoreComment\x12\x19.db.RestoreCommentRequest\x1a\x16.google.protobuf.Empty\x12P\n" +
+	"\x19CreateCommentAndIncrement\x12\x18.db.CreateCommentRequest\x1a\x19.db.CreateCommentResponse\x12M\n" +
+	"\x19DeleteCommentAndDecrement\x12\x18.db.DeleteCommentRequest\x1a\x16.google.protobuf.Empty\x12L\n" +
+	"\x11DeleteCommentTree\x12\x18.db.DeleteCommentRequest\x1a\x1d.db.DeleteCommentTreeResponse\x12G\n" +
+	"\x0eGetAuthorStats\x12\x19.db.GetAuthorStatsRequest\x1a\x1a.db.GetAuthorStatsResponse\x12M\n" +
+	"\x10GetAutomodConfig\x12\x1b.db.GetAutomodConfigRequest\x1a\x1c.db.GetAutomodConfigResponse\x12G\n" +
+	"\x10SetAutomodConfig\x12\x1b.db.SetAutomodConfigRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\fListModQueue\x12\x17.db.ListModQueueRequest\x1a\x18.db.ListModQueueResponse\x12S\n" +
+	"\x12CreateModQueueItem\x12\x1d.db.CreateModQueueItemRequest\x1a\x1e.db.CreateModQueueItemResponse\x12K\n" +
+	"\x12DeleteModQueueItem\x12\x1d.db.DeleteModQueueItemRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x13RecordAutomodAction\x12\x1e.db.RecordAutomodActionRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\fCreateReport\x12\x17.db.CreateReportRequest\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\x0fListSpamSamples\x12\x1a.db.ListSpamSamplesRequest\x1a\x1b.db.ListSpamSamplesResponse\x125\n" +
+	"\fGetSpamModel\x12\x16.google.protobuf.Empty\x1a\r.db.SpamModel\x125\n" +
+	"\fSetSpamModel\x12\r.db.SpamModel\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x10CreateAttachment\x12\x1b.db.CreateAttachmentRequest\x1a\x1c.db.CreateAttachmentResponse\x12=\n" +
+	"\rGetAttachment\x12\x18.db.GetAttachmentRequest\x1a\x12.models.Attachment\x12D\n" +
+	"\rListRevisions\x12\x18.db.ListRevisionsRequest\x1a\x19.db.ListRevisionsResponse\x12P\n" +
+	"\x11ReconcileCounters\x12\x1c.db.ReconcileCountersRequest\x1a\x1d.db.ReconcileCountersResponse\x12>\n" +
+	"\x0fSubscribeEvents\x12\x1a.db.SubscribeEventsRequest\x1a\r.models.Event0\x01\x128\n" +
+	"\tCreateJob\x12\x14.db.CreateJobRequest\x1a\x15.db.CreateJobResponse\x124\n" +
+	"\fGetJobStatus\x12\x17.db.GetJobStatusRequest\x1a\v.models.JobB\x16Z\x14gen/db-service/pb;pbb\x06proto3"
 
 var (
 	file_db_service_proto_rawDescOnce sync.Once
@@ -1297,77 +4141,220 @@ func file_db_service_proto_rawDescGZIP() []byte {
 	return file_db_service_proto_rawDescData
 }
 
-var file_db_service_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_db_service_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_db_service_proto_msgTypes = make([]protoimpl.MessageInfo, 64)
 var file_db_service_proto_goTypes = []any{
-	(*ListCommunitiesRequest)(nil),  // 0: db.ListCommunitiesRequest
-	(*ListCommunitiesResponse)(nil), // 1: db.ListCommunitiesResponse
-	(*CreateCommunityRequest)(nil),  // 2: db.CreateCommunityRequest
-	(*CreateCommunityResponse)(nil), // 3: db.CreateCommunityResponse
-	(*GetCommunityRequest)(nil),     // 4: db.GetCommunityRequest
-	(*UpdateCommunityRequest)(nil),  // 5: db.UpdateCommunityRequest
-	(*DeleteCommunityRequest)(nil),  // 6: db.DeleteCommunityRequest
-	(*ListThreadsRequest)(nil),      // 7: db.ListThreadsRequest
-	(*ListThreadsResponse)(nil),     // 8: db.ListThreadsResponse
-	(*CreateThreadRequest)(nil),     // 9: db.CreateThreadRequest
-	(*CreateThreadResponse)(nil),    // 10: db.CreateThreadResponse
-	(*GetThreadRequest)(nil),        // 11: db.GetThreadRequest
-	(*UpdateThreadRequest)(nil),     // 12: db.UpdateThreadRequest
-	(*DeleteThreadRequest)(nil),     // 13: db.DeleteThreadRequest
-	(*ListCommentsRequest)(nil),     // 14: db.ListCommentsRequest
-	(*ListCommentsResponse)(nil),    // 15: db.ListCommentsResponse
-	(*CreateCommentRequest)(nil),    // 16: db.CreateCommentRequest
-	(*CreateCommentResponse)(nil),   // 17: db.CreateCommentResponse
-	(*GetCommentRequest)(nil),       // 18: db.GetCommentRequest
-	(*GetCommentResponse)(nil),      // 19: db.GetCommentResponse
-	(*UpdateCommentRequest)(nil),    // 20: db.UpdateCommentRequest
-	(*DeleteCommentRequest)(nil),    // 21: db.DeleteCommentRequest
-	(*pb.Community)(nil),            // 22: models.Community
-	(*pb.Thread)(nil),               // 23: models.Thread
-	(*pb.Comment)(nil),              // 24: models.Comment
-	(pb.CommentParentType)(0),       // 25: models.CommentParentType
-	(*emptypb.Empty)(nil),           // 26: google.protobuf.Empty
+	(SpamSampleSource)(0),               // 0: db.SpamSampleSource
+	(*ListCommunitiesRequest)(nil),      // 1: db.ListCommunitiesRequest
+	(*ListCommunitiesResponse)(nil),     // 2: db.ListCommunitiesResponse
+	(*CreateCommunityRequest)(nil),      // 3: db.CreateCommunityRequest
+	(*CreateCommunityResponse)(nil),     // 4: db.CreateCommunityResponse
+	(*GetCommunityRequest)(nil),         // 5: db.GetCommunityRequest
+	(*UpdateCommunityRequest)(nil),      // 6: db.UpdateCommunityRequest
+	(*DeleteCommunityRequest)(nil),      // 7: db.DeleteCommunityRequest
+	(*AddFlairTemplateRequest)(nil),     // 8: db.AddFlairTemplateRequest
+	(*AddFlairTemplateResponse)(nil),    // 9: db.AddFlairTemplateResponse
+	(*RemoveFlairTemplateRequest)(nil),  // 10: db.RemoveFlairTemplateRequest
+	(*ListThreadsRequest)(nil),          // 11: db.ListThreadsRequest
+	(*ListThreadsResponse)(nil),         // 12: db.ListThreadsResponse
+	(*CreateThreadRequest)(nil),         // 13: db.CreateThreadRequest
+	(*CreateThreadResponse)(nil),        // 14: db.CreateThreadResponse
+	(*GetThreadRequest)(nil),            // 15: db.GetThreadRequest
+	(*UpdateThreadRequest)(nil),         // 16: db.UpdateThreadRequest
+	(*DeleteThreadRequest)(nil),         // 17: db.DeleteThreadRequest
+	(*RemoveThreadRequest)(nil),         // 18: db.RemoveThreadRequest
+	(*RestoreThreadRequest)(nil),        // 19: db.RestoreThreadRequest
+	(*PublishThreadRequest)(nil),        // 20: db.PublishThreadRequest
+	(*ListScheduledThreadsRequest)(nil), // 21: db.ListScheduledThreadsRequest
+	(*MoveThreadRequest)(nil),           // 22: db.MoveThreadRequest
+	(*MoveThreadResponse)(nil),          // 23: db.MoveThreadResponse
+	(*PinThreadRequest)(nil),            // 24: db.PinThreadRequest
+	(*CastPollVoteRequest)(nil),         // 25: db.CastPollVoteRequest
+	(*ListCommentsRequest)(nil),         // 26: db.ListCommentsRequest
+	(*ListCommentsResponse)(nil),        // 27: db.ListCommentsResponse
+	(*CreateCommentRequest)(nil),        // 28: db.CreateCommentRequest
+	(*CreateCommentResponse)(nil),       // 29: db.CreateCommentResponse
+	(*GetCommentRequest)(nil),           // 30: db.GetCommentRequest
+	(*GetCommentResponse)(nil),          // 31: db.GetCommentResponse
+	(*UpdateCommentRequest)(nil),        // 32: db.UpdateCommentRequest
+	(*DeleteCommentRequest)(nil),        // 33: db.DeleteCommentRequest
+	(*DeleteCommentTreeResponse)(nil),   // 34: db.DeleteCommentTreeResponse
+	(*RemoveCommentRequest)(nil),        // 35: db.RemoveCommentRequest
+	(*RestoreCommentRequest)(nil),       // 36: db.RestoreCommentRequest
+	(*GetAuthorStatsRequest)(nil),       // 37: db.GetAuthorStatsRequest
+	(*GetAuthorStatsResponse)(nil),      // 38: db.GetAuthorStatsResponse
+	(*GetAutomodConfigRequest)(nil),     // 39: db.GetAutomodConfigRequest
+	(*GetAutomodConfigResponse)(nil),    // 40: db.GetAutomodConfigResponse
+	(*SetAutomodConfigRequest)(nil),     // 41: db.SetAutomodConfigRequest
+	(*ListModQueueRequest)(nil),         // 42: db.ListModQueueRequest
+	(*ListModQueueResponse)(nil),        // 43: db.ListModQueueResponse
+	(*CreateModQueueItemRequest)(nil),   // 44: db.CreateModQueueItemRequest
+	(*CreateModQueueItemResponse)(nil),  // 45: db.CreateModQueueItemResponse
+	(*DeleteModQueueItemRequest)(nil),   // 46: db.DeleteModQueueItemRequest
+	(*RecordAutomodActionRequest)(nil),  // 47: db.RecordAutomodActionRequest
+	(*CreateReportRequest)(nil),         // 48: db.CreateReportRequest
+	(*ListSpamSamplesRequest)(nil),      // 49: db.ListSpamSamplesRequest
+	(*ListSpamSamplesResponse)(nil),     // 50: db.ListSpamSamplesResponse
+	(*SpamSample)(nil),                  // 51: db.SpamSample
+	(*SpamModel)(nil),                   // 52: db.SpamModel
+	(*CreateAttachmentRequest)(nil),     // 53: db.CreateAttachmentRequest
+	(*CreateAttachmentResponse)(nil),    // 54: db.CreateAttachmentResponse
+	(*GetAttachmentRequest)(nil),        // 55: db.GetAttachmentRequest
+	(*ListRevisionsRequest)(nil),        // 56: db.ListRevisionsRequest
+	(*ListRevisionsResponse)(nil),       // 57: db.ListRevisionsResponse
+	(*ReconcileCountersRequest)(nil),    // 58: db.ReconcileCountersRequest
+	(*ReconcileCountersResponse)(nil),   // 59: db.ReconcileCountersResponse
+	(*CounterDiscrepancy)(nil),          // 60: db.CounterDiscrepancy
+	(*SubscribeEventsRequest)(nil),      // 61: db.SubscribeEventsRequest
+	(*CreateJobRequest)(nil),            // 62: db.CreateJobRequest
+	(*CreateJobResponse)(nil),           // 63: db.CreateJobResponse
+	(*GetJobStatusRequest)(nil),         // 64: db.GetJobStatusRequest
+	(*pb.Community)(nil),                // 65: models.Community
+	(*pb.PostingRules)(nil),             // 66: models.PostingRules
+	(*pb.Thread)(nil),                   // 67: models.Thread
+	(pb.ThreadKind)(0),                  // 68: models.ThreadKind
+	(*pb.Poll)(nil),                     // 69: models.Poll
+	(*timestamppb.Timestamp)(nil),       // 70: google.protobuf.Timestamp
+	(*pb.TagList)(nil),                  // 71: models.TagList
+	(pb.RemovalType)(0),                 // 72: models.RemovalType
+	(*pb.Comment)(nil),                  // 73: models.Comment
+	(pb.CommentParentType)(0),           // 74: models.CommentParentType
+	(*pb.ModQueueItem)(nil),             // 75: models.ModQueueItem
+	(*pb.Revision)(nil),                 // 76: models.Revision
+	(pb.JobType)(0),                     // 77: models.JobType
+	(*emptypb.Empty)(nil),               // 78: google.protobuf.Empty
+	(*pb.Attachment)(nil),               // 79: models.Attachment
+	(*pb.Event)(nil),                    // 80: models.Event
+	(*pb.Job)(nil),                      // 81: models.Job
 }
 var file_db_service_proto_depIdxs = []int32{
-	22, // 0: db.ListCommunitiesResponse.communities:type_name -> models.Community
-	23, // 1: db.ListThreadsResponse.threads:type_name -> models.Thread
-	24, // 2: db.ListCommentsResponse.comments:type_name -> models.Comment
-	25, // 3: db.CreateCommentRequest.parent_type:type_name -> models.CommentParentType
-	24, // 4: db.GetCommentResponse.comment:type_name -> models.Comment
-	0,  // 5: db.DBService.ListCommunities:input_type -> db.ListCommunitiesRequest
-	2,  // 6: db.DBService.CreateCommunity:input_type -> db.CreateCommunityRequest
-	4,  // 7: db.DBService.GetCommunity:input_type -> db.GetCommunityRequest
-	5,  // 8: db.DBService.UpdateCommunity:input_type -> db.UpdateCommunityRequest
-	6,  // 9: db.DBService.DeleteCommunity:input_type -> db.DeleteCommunityRequest
-	7,  // 10: db.DBService.ListThreads:input_type -> db.ListThreadsRequest
-	9,  // 11: db.DBService.CreateThread:input_type -> db.CreateThreadRequest
-	11, // 12: db.DBService.GetThread:input_type -> db.GetThreadRequest
-	12, // 13: db.DBService.UpdateThread:input_type -> db.UpdateThreadRequest
-	13, // 14: db.DBService.DeleteThread:input_type -> db.DeleteThreadRequest
-	14, // 15: db.DBService.ListComments:input_type -> db.ListCommentsRequest
-	16, // 16: db.DBService.CreateComment:input_type -> db.CreateCommentRequest
-	18, // 17: db.DBService.GetComment:input_type -> db.GetCommentRequest
-	20, // 18: db.DBService.UpdateComment:input_type -> db.UpdateCommentRequest
-	21, // 19: db.DBService.DeleteComment:input_type -> db.DeleteCommentRequest
-	1,  // 20: db.DBService.ListCommunities:output_type -> db.ListCommunitiesResponse
-	3,  // 21: db.DBService.CreateCommunity:output_type -> db.CreateCommunityResponse
-	22, // 22: db.DBService.GetCommunity:output_type -> models.Community
-	26, // 23: db.DBService.UpdateCommunity:output_type -> google.protobuf.Empty
-	26, // 24: db.DBService.DeleteCommunity:output_type -> google.protobuf.Empty
-	8,  // 25: db.DBService.ListThreads:output_type -> db.ListThreadsResponse
-	10, // 26: db.DBService.CreateThread:output_type -> db.CreateThreadResponse
-	23, // 27: db.DBService.GetThread:output_type -> models.Thread
-	26, // 28: db.DBService.UpdateThread:output_type -> google.protobuf.Empty
-	26, // 29: db.DBService.DeleteThread:output_type -> google.protobuf.Empty
-	15, // 30: db.DBService.ListComments:output_type -> db.ListCommentsResponse
-	17, // 31: db.DBService.CreateComment:output_type -> db.CreateCommentResponse
-	24, // 32: db.DBService.GetComment:output_type -> models.Comment
-	26, // 33: db.DBService.UpdateComment:output_type -> google.protobuf.Empty
-	26, // 34: db.DBService.DeleteComment:output_type -> google.protobuf.Empty
-	20, // [20:35] is the sub-list for method output_type
-	5,  // [5:20] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	65, // 0: db.ListCommunitiesResponse.communities:type_name -> models.Community
+	66, // 1: db.UpdateCommunityRequest.posting_rules:type_name -> models.PostingRules
+	67, // 2: db.ListThreadsResponse.threads:type_name -> models.Thread
+	68, // 3: db.CreateThreadRequest.kind:type_name -> models.ThreadKind
+	69, // 4: db.CreateThreadRequest.poll:type_name -> models.Poll
+	70, // 5: db.CreateThreadRequest.publish_at:type_name -> google.protobuf.Timestamp
+	71, // 6: db.UpdateThreadRequest.tags:type_name -> models.TagList
+	70, // 7: db.UpdateThreadRequest.publish_at:type_name -> google.protobuf.Timestamp
+	72, // 8: db.RemoveThreadRequest.removal_type:type_name -> models.RemovalType
+	70, // 9: db.ListScheduledThreadsRequest.publish_before:type_name -> google.protobuf.Timestamp
+	73, // 10: db.ListCommentsResponse.comments:type_name -> models.Comment
+	74, // 11: db.CreateCommentRequest.parent_type:type_name -> models.CommentParentType
+	73, // 12: db.GetCommentResponse.comment:type_name -> models.Comment
+	72, // 13: db.RemoveCommentRequest.removal_type:type_name -> models.RemovalType
+	70, // 14: db.GetAuthorStatsResponse.first_seen_at:type_name -> google.protobuf.Timestamp
+	75, // 15: db.ListModQueueResponse.items:type_name -> models.ModQueueItem
+	0,  // 16: db.ListSpamSamplesRequest.source:type_name -> db.SpamSampleSource
+	51, // 17: db.ListSpamSamplesResponse.samples:type_name -> db.SpamSample
+	70, // 18: db.SpamModel.trained_at:type_name -> google.protobuf.Timestamp
+	76, // 19: db.ListRevisionsResponse.revisions:type_name -> models.Revision
+	60, // 20: db.ReconcileCountersResponse.discrepancies:type_name -> db.CounterDiscrepancy
+	77, // 21: db.CreateJobRequest.type:type_name -> models.JobType
+	1,  // 22: db.DBService.ListCommunities:input_type -> db.ListCommunitiesRequest
+	3,  // 23: db.DBService.CreateCommunity:input_type -> db.CreateCommunityRequest
+	5,  // 24: db.DBService.GetCommunity:input_type -> db.GetCommunityRequest
+	6,  // 25: db.DBService.UpdateCommunity:input_type -> db.UpdateCommunityRequest
+	7,  // 26: db.DBService.DeleteCommunity:input_type -> db.DeleteCommunityRequest
+	8,  // 27: db.DBService.AddFlairTemplate:input_type -> db.AddFlairTemplateRequest
+	10, // 28: db.DBService.RemoveFlairTemplate:input_type -> db.RemoveFlairTemplateRequest
+	11, // 29: db.DBService.ListThreads:input_type -> db.ListThreadsRequest
+	13, // 30: db.DBService.CreateThread:input_type -> db.CreateThreadRequest
+	15, // 31: db.DBService.GetThread:input_type -> db.GetThreadRequest
+	16, // 32: db.DBService.UpdateThread:input_type -> db.UpdateThreadRequest
+	17, // 33: db.DBService.DeleteThread:input_type -> db.DeleteThreadRequest
+	18, // 34: db.DBService.RemoveThread:input_type -> db.RemoveThreadRequest
+	19, // 35: db.DBService.RestoreThread:input_type -> db.RestoreThreadRequest
+	25, // 36: db.DBService.CastPollVote:input_type -> db.CastPollVoteRequest
+	20, // 37: db.DBService.PublishThread:input_type -> db.PublishThreadRequest
+	21, // 38: db.DBService.ListScheduledThreads:input_type -> db.ListScheduledThreadsRequest
+	22, // 39: db.DBService.MoveThread:input_type -> db.MoveThreadRequest
+	24, // 40: db.DBService.PinThread:input_type -> db.PinThreadRequest
+	13, // 41: db.DBService.CreateThreadAndIncrement:input_type -> db.CreateThreadRequest
+	17, // 42: db.DBService.DeleteThreadAndDecrement:input_type -> db.DeleteThreadRequest
+	20, // 43: db.DBService.PublishThreadAndIncrement:input_type -> db.PublishThreadRequest
+	26, // 44: db.DBService.ListComments:input_type -> db.ListCommentsRequest
+	28, // 45: db.DBService.CreateComment:input_type -> db.CreateCommentRequest
+	30, // 46: db.DBService.GetComment:input_type -> db.GetCommentRequest
+	32, // 47: db.DBService.UpdateComment:input_type -> db.UpdateCommentRequest
+	33, // 48: db.DBService.DeleteComment:input_type -> db.DeleteCommentRequest
+	35, // 49: db.DBService.RemoveComment:input_type -> db.RemoveCommentRequest
+	36, // 50: db.DBService.RestoreComment:input_type -> db.RestoreCommentRequest
+	28, // 51: db.DBService.CreateCommentAndIncrement:input_type -> db.CreateCommentRequest
+	33, // 52: db.DBService.DeleteCommentAndDecrement:input_type -> db.DeleteCommentRequest
+	33, // 53: db.DBService.DeleteCommentTree:input_type -> db.DeleteCommentRequest
+	37, // 54: db.DBService.GetAuthorStats:input_type -> db.GetAuthorStatsRequest
+	39, // 55: db.DBService.GetAutomodConfig:input_type -> db.GetAutomodConfigRequest
+	41, // 56: db.DBService.SetAutomodConfig:input_type -> db.SetAutomodConfigRequest
+	42, // 57: db.DBService.ListModQueue:input_type -> db.ListModQueueRequest
+	44, // 58: db.DBService.CreateModQueueItem:input_type -> db.CreateModQueueItemRequest
+	46, // 59: db.DBService.DeleteModQueueItem:input_type -> db.DeleteModQueueItemRequest
+	47, // 60: db.DBService.RecordAutomodAction:input_type -> db.RecordAutomodActionRequest
+	48, // 61: db.DBService.CreateReport:input_type -> db.CreateReportRequest
+	49, // 62: db.DBService.ListSpamSamples:input_type -> db.ListSpamSamplesRequest
+	78, // 63: db.DBService.GetSpamModel:input_type -> google.protobuf.Empty
+	52, // 64: db.DBService.SetSpamModel:input_type -> db.SpamModel
+	53, // 65: db.DBService.CreateAttachment:input_type -> db.CreateAttachmentRequest
+	55, // 66: db.DBService.GetAttachment:input_type -> db.GetAttachmentRequest
+	56, // 67: db.DBService.ListRevisions:input_type -> db.ListRevisionsRequest
+	58, // 68: db.DBService.ReconcileCounters:input_type -> db.ReconcileCountersRequest
+	61, // 69: db.DBService.SubscribeEvents:input_type -> db.SubscribeEventsRequest
+	62, // 70: db.DBService.CreateJob:input_type -> db.CreateJobRequest
+	64, // 71: db.DBService.GetJobStatus:input_type -> db.GetJobStatusRequest
+	2,  // 72: db.DBService.ListCommunities:output_type -> db.ListCommunitiesResponse
+	4,  // 73: db.DBService.CreateCommunity:output_type -> db.CreateCommunityResponse
+	65, // 74: db.DBService.GetCommunity:output_type -> models.Community
+	78, // 75: db.DBService.UpdateCommunity:output_type -> google.protobuf.Empty
+	78, // 76: db.DBService.DeleteCommunity:output_type -> google.protobuf.Empty
+	9,  // 77: db.DBService.AddFlairTemplate:output_type -> db.AddFlairTemplateResponse
+	78, // 78: db.DBService.RemoveFlairTemplate:output_type -> google.protobuf.Empty
+	12, // 79: db.DBService.ListThreads:output_type -> db.ListThreadsResponse
+	14, // 80: db.DBService.CreateThread:output_type -> db.CreateThreadResponse
+	67, // 81: db.DBService.GetThread:output_type -> models.Thread
+	78, // 82: db.DBService.UpdateThread:output_type -> google.protobuf.Empty
+	78, // 83: db.DBService.DeleteThread:output_type -> google.protobuf.Empty
+	78, // 84: db.DBService.RemoveThread:output_type -> google.protobuf.Empty
+	78, // 85: db.DBService.RestoreThread:output_type -> google.protobuf.Empty
+	78, // 86: db.DBService.CastPollVote:output_type -> google.protobuf.Empty
+	78, // 87: db.DBService.PublishThread:output_type -> google.protobuf.Empty
+	12, // 88: db.DBService.ListScheduledThreads:output_type -> db.ListThreadsResponse
+	23, // 89: db.DBService.MoveThread:output_type -> db.MoveThreadResponse
+	78, // 90: db.DBService.PinThread:output_type -> google.protobuf.Empty
+	14, // 91: db.DBService.CreateThreadAndIncrement:output_type -> db.CreateThreadResponse
+	78, // 92: db.DBService.DeleteThreadAndDecrement:output_type -> google.protobuf.Empty
+	78, // 93: db.DBService.PublishThreadAndIncrement:output_type -> google.protobuf.Empty
+	27, // 94: db.DBService.ListComments:output_type -> db.ListCommentsResponse
+	29, // 95: db.DBService.CreateComment:output_type -> db.CreateCommentResponse
+	73, // 96: db.DBService.GetComment:output_type -> models.Comment
+	78, // 97: db.DBService.UpdateComment:output_type -> google.protobuf.Empty
+	78, // 98: db.DBService.DeleteComment:output_type -> google.protobuf.Empty
+	78, // 99: db.DBService.RemoveComment:output_type -> google.protobuf.Empty
+	78, // 100: db.DBService.RestoreComment:output_type -> google.protobuf.Empty
+	29, // 101: db.DBService.CreateCommentAndIncrement:output_type -> db.CreateCommentResponse
+	78, // 102: db.DBService.DeleteCommentAndDecrement:output_type -> google.protobuf.Empty
+	34, // 103: db.DBService.DeleteCommentTree:output_type -> db.DeleteCommentTreeResponse
+	38, // 104: db.DBService.GetAuthorStats:output_type -> db.GetAuthorStatsResponse
+	40, // 105: db.DBService.GetAutomodConfig:output_type -> db.GetAutomodConfigResponse
+	78, // 106: db.DBService.SetAutomodConfig:output_type -> google.protobuf.Empty
+	43, // 107: db.DBService.ListModQueue:output_type -> db.ListModQueueResponse
+	45, // 108: db.DBService.CreateModQueueItem:output_type -> db.CreateModQueueItemResponse
+	78, // 109: db.DBService.DeleteModQueueItem:output_type -> google.protobuf.Empty
+	78, // 110: db.DBService.RecordAutomodAction:output_type -> google.protobuf.Empty
+	78, // 111: db.DBService.CreateReport:output_type -> google.protobuf.Empty
+	50, // 112: db.DBService.ListSpamSamples:output_type -> db.ListSpamSamplesResponse
+	52, // 113: db.DBService.GetSpamModel:output_type -> db.SpamModel
+	78, // 114: db.DBService.SetSpamModel:output_type -> google.protobuf.Empty
+	54, // 115: db.DBService.CreateAttachment:output_type -> db.CreateAttachmentResponse
+	79, // 116: db.DBService.GetAttachment:output_type -> models.Attachment
+	57, // 117: db.DBService.ListRevisions:output_type -> db.ListRevisionsResponse
+	59, // 118: db.DBService.ReconcileCounters:output_type -> db.ReconcileCountersResponse
+	80, // 119: db.DBService.SubscribeEvents:output_type -> models.Event
+	63, // 120: db.DBService.CreateJob:output_type -> db.CreateJobResponse
+	81, // 121: db.DBService.GetJobStatus:output_type -> models.Job
+	72, // [72:122] is the sub-list for method output_type
+	22, // [22:72] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_db_service_proto_init() }
@@ -1377,22 +4364,25 @@ func file_db_service_proto_init() {
 	}
 	file_db_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_db_service_proto_msgTypes[5].OneofWrappers = []any{}
-	file_db_service_proto_msgTypes[7].OneofWrappers = []any{}
-	file_db_service_proto_msgTypes[12].OneofWrappers = []any{}
-	file_db_service_proto_msgTypes[14].OneofWrappers = []any{}
-	file_db_service_proto_msgTypes[20].OneofWrappers = []any{}
+	file_db_service_proto_msgTypes[10].OneofWrappers = []any{}
+	file_db_service_proto_msgTypes[15].OneofWrappers = []any{}
+	file_db_service_proto_msgTypes[25].OneofWrappers = []any{}
+	file_db_service_proto_msgTypes[31].OneofWrappers = []any{}
+	file_db_service_proto_msgTypes[41].OneofWrappers = []any{}
+	file_db_service_proto_msgTypes[48].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_db_service_proto_rawDesc), len(file_db_service_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   22,
+			NumEnums:      1,
+			NumMessages:   64,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_db_service_proto_goTypes,
 		DependencyIndexes: file_db_service_proto_depIdxs,
+		EnumInfos:         file_db_service_proto_enumTypes,
 		MessageInfos:      file_db_service_proto_msgTypes,
 	}.Build()
 	File_db_service_proto = out.File
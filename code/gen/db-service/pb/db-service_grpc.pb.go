@@ -21,21 +21,56 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	DBService_ListCommunities_FullMethodName = "/db.DBService/ListCommunities"
-	DBService_CreateCommunity_FullMethodName = "/db.DBService/CreateCommunity"
-	DBService_GetCommunity_FullMethodName    = "/db.DBService/GetCommunity"
-	DBService_UpdateCommunity_FullMethodName = "/db.DBService/UpdateCommunity"
-	DBService_DeleteCommunity_FullMethodName = "/db.DBService/DeleteCommunity"
-	DBService_ListThreads_FullMethodName     = "/db.DBService/ListThreads"
-	DBService_CreateThread_FullMethodName    = "/db.DBService/CreateThread"
-	DBService_GetThread_FullMethodName       = "/db.DBService/GetThread"
-	DBService_UpdateThread_FullMethodName    = "/db.DBService/UpdateThread"
-	DBService_DeleteThread_FullMethodName    = "/db.DBService/DeleteThread"
-	DBService_ListComments_FullMethodName    = "/db.DBService/ListComments"
-	DBService_CreateComment_FullMethodName   = "/db.DBService/CreateComment"
-	DBService_GetComment_FullMethodName      = "/db.DBService/GetComment"
-	DBService_UpdateComment_FullMethodName   = "/db.DBService/UpdateComment"
-	DBService_DeleteComment_FullMethodName   = "/db.DBService/DeleteComment"
+	DBService_ListCommunities_FullMethodName           = "/db.DBService/ListCommunities"
+	DBService_CreateCommunity_FullMethodName           = "/db.DBService/CreateCommunity"
+	DBService_GetCommunity_FullMethodName              = "/db.DBService/GetCommunity"
+	DBService_UpdateCommunity_FullMethodName           = "/db.DBService/UpdateCommunity"
+	DBService_DeleteCommunity_FullMethodName           = "/db.DBService/DeleteCommunity"
+	DBService_AddFlairTemplate_FullMethodName          = "/db.DBService/AddFlairTemplate"
+	DBService_RemoveFlairTemplate_FullMethodName       = "/db.DBService/RemoveFlairTemplate"
+	DBService_ListThreads_FullMethodName               = "/db.DBService/ListThreads"
+	DBService_CreateThread_FullMethodName              = "/db.DBService/CreateThread"
+	DBService_GetThread_FullMethodName                 = "/db.DBService/GetThread"
+	DBService_UpdateThread_FullMethodName              = "/db.DBService/UpdateThread"
+	DBService_DeleteThread_FullMethodName              = "/db.DBService/DeleteThread"
+	DBService_RemoveThread_FullMethodName              = "/db.DBService/RemoveThread"
+	DBService_RestoreThread_FullMethodName             = "/db.DBService/RestoreThread"
+	DBService_CastPollVote_FullMethodName              = "/db.DBService/CastPollVote"
+	DBService_PublishThread_FullMethodName             = "/db.DBService/PublishThread"
+	DBService_ListScheduledThreads_FullMethodName      = "/db.DBService/ListScheduledThreads"
+	DBService_MoveThread_FullMethodName                = "/db.DBService/MoveThread"
+	DBService_PinThread_FullMethodName                 = "/db.DBService/PinThread"
+	DBService_CreateThreadAndIncrement_FullMethodName  = "/db.DBService/CreateThreadAndIncrement"
+	DBService_DeleteThreadAndDecrement_FullMethodName  = "/db.DBService/DeleteThreadAndDecrement"
+	DBService_PublishThreadAndIncrement_FullMethodName = "/db.DBService/PublishThreadAndIncrement"
+	DBService_ListComments_FullMethodName              = "/db.DBService/ListComments"
+	DBService_CreateComment_FullMethodName             = "/db.DBService/CreateComment"
+	DBService_GetComment_FullMethodName                = "/db.DBService/GetComment"
+	DBService_UpdateComment_FullMethodName             = "/db.DBService/UpdateComment"
+	DBService_DeleteComment_FullMethodName             = "/db.DBService/DeleteComment"
+	DBService_RemoveComment_FullMethodName             = "/db.DBService/RemoveComment"
+	DBService_RestoreComment_FullMethodName            = "/db.DBService/RestoreComment"
+	DBService_CreateCommentAndIncrement_FullMethodName = "/db.DBService/CreateCommentAndIncrement"
+	DBService_DeleteCommentAndDecrement_FullMethodName = "/db.DBService/DeleteCommentAndDecrement"
+	DBService_DeleteCommentTree_FullMethodName         = "/db.DBService/DeleteCommentTree"
+	DBService_GetAuthorStats_FullMethodName            = "/db.DBService/GetAuthorStats"
+	DBService_GetAutomodConfig_FullMethodName          = "/db.DBService/GetAutomodConfig"
+	DBService_SetAutomodConfig_FullMethodName          = "/db.DBService/SetAutomodConfig"
+	DBService_ListModQueue_FullMethodName              = "/db.DBService/ListModQueue"
+	DBService_CreateModQueueItem_FullMethodName        = "/db.DBService/CreateModQueueItem"
+	DBService_DeleteModQueueItem_FullMethodName        = "/db.DBService/DeleteModQueueItem"
+	DBService_RecordAutomodAction_FullMethodName       = "/db.DBService/RecordAutomodAction"
+	DBService_CreateReport_FullMethodName              = "/db.DBService/CreateReport"
+	DBService_ListSpamSamples_FullMethodName           = "/db.DBService/ListSpamSamples"
+	DBService_GetSpamModel_FullMethodName              = "/db.DBService/GetSpamModel"
+	DBService_SetSpamModel_FullMethodName              = "/db.DBService/SetSpamModel"
+	DBService_CreateAttachment_FullMethodName          = "/db.DBService/CreateAttachment"
+	DBService_GetAttachment_FullMethodName             = "/db.DBService/GetAttachment"
+	DBService_ListRevisions_FullMethodName             = "/db.DBService/ListRevisions"
+	DBService_ReconcileCounters_FullMethodName         = "/db.DBService/ReconcileCounters"
+	DBService_SubscribeEvents_FullMethodName           = "/db.DBService/SubscribeEvents"
+	DBService_CreateJob_FullMethodName                 = "/db.DBService/CreateJob"
+	DBService_GetJobStatus_FullMethodName              = "/db.DBService/GetJobStatus"
 )
 
 // DBServiceClient is the client API for DBService service.
@@ -48,18 +83,65 @@ type DBServiceClient interface {
 	GetCommunity(ctx context.Context, in *GetCommunityRequest, opts ...grpc.CallOption) (*pb.Community, error)
 	UpdateCommunity(ctx context.Context, in *UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteCommunity(ctx context.Context, in *DeleteCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddFlairTemplate(ctx context.Context, in *AddFlairTemplateRequest, opts ...grpc.CallOption) (*AddFlairTemplateResponse, error)
+	RemoveFlairTemplate(ctx context.Context, in *RemoveFlairTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// thread crud operations
 	ListThreads(ctx context.Context, in *ListThreadsRequest, opts ...grpc.CallOption) (*ListThreadsResponse, error)
 	CreateThread(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*CreateThreadResponse, error)
 	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*pb.Thread, error)
 	UpdateThread(ctx context.Context, in *UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveThread(ctx context.Context, in *RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreThread(ctx context.Context, in *RestoreThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CastPollVote(ctx context.Context, in *CastPollVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PublishThread(ctx context.Context, in *PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListScheduledThreads(ctx context.Context, in *ListScheduledThreadsRequest, opts ...grpc.CallOption) (*ListThreadsResponse, error)
+	MoveThread(ctx context.Context, in *MoveThreadRequest, opts ...grpc.CallOption) (*MoveThreadResponse, error)
+	// pins the thread unless its community already has max_pinned pinned threads, counted in the same transaction
+	PinThread(ctx context.Context, in *PinThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// the operations below change num_threads of the community in the same transaction
+	CreateThreadAndIncrement(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*CreateThreadResponse, error)
+	DeleteThreadAndDecrement(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PublishThreadAndIncrement(ctx context.Context, in *PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	// comment crud operations
 	ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error)
 	CreateComment(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
 	GetComment(ctx context.Context, in *GetCommentRequest, opts ...grpc.CallOption) (*pb.Comment, error)
 	UpdateComment(ctx context.Context, in *UpdateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteComment(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveComment(ctx context.Context, in *RemoveCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreComment(ctx context.Context, in *RestoreCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// the operations below change num_comments of the parent in the same transaction
+	CreateCommentAndIncrement(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error)
+	DeleteCommentAndDecrement(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// deletes the comment and every reply under it, found by their ancestor path
+	DeleteCommentTree(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentTreeResponse, error)
+	// moderation operations
+	GetAuthorStats(ctx context.Context, in *GetAuthorStatsRequest, opts ...grpc.CallOption) (*GetAuthorStatsResponse, error)
+	GetAutomodConfig(ctx context.Context, in *GetAutomodConfigRequest, opts ...grpc.CallOption) (*GetAutomodConfigResponse, error)
+	SetAutomodConfig(ctx context.Context, in *SetAutomodConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListModQueue(ctx context.Context, in *ListModQueueRequest, opts ...grpc.CallOption) (*ListModQueueResponse, error)
+	CreateModQueueItem(ctx context.Context, in *CreateModQueueItemRequest, opts ...grpc.CallOption) (*CreateModQueueItemResponse, error)
+	DeleteModQueueItem(ctx context.Context, in *DeleteModQueueItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// records that an automod rule acted on a thread or comment, fails with AlreadyExists when it already did
+	RecordAutomodAction(ctx context.Context, in *RecordAutomodActionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// counts a report of a thread or comment in its num_reports, fails with AlreadyExists when the user already reported it
+	CreateReport(ctx context.Context, in *CreateReportRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSpamSamples(ctx context.Context, in *ListSpamSamplesRequest, opts ...grpc.CallOption) (*ListSpamSamplesResponse, error)
+	GetSpamModel(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SpamModel, error)
+	SetSpamModel(ctx context.Context, in *SpamModel, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// attachment operations
+	CreateAttachment(ctx context.Context, in *CreateAttachmentRequest, opts ...grpc.CallOption) (*CreateAttachmentResponse, error)
+	GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*pb.Attachment, error)
+	// revision operations
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	// maintenance operations
+	ReconcileCounters(ctx context.Context, in *ReconcileCountersRequest, opts ...grpc.CallOption) (*ReconcileCountersResponse, error)
+	// event operations
+	SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.Event], error)
+	// job operations, jobs run in the background
+	CreateJob(ctx context.Context, in *CreateJobRequest, opts ...grpc.CallOption) (*CreateJobResponse, error)
+	GetJobStatus(ctx context.Context, in *GetJobStatusRequest, opts ...grpc.CallOption) (*pb.Job, error)
 }
 
 type dBServiceClient struct {
@@ -120,6 +202,26 @@ func (c *dBServiceClient) DeleteCommunity(ctx context.Context, in *DeleteCommuni
 	return out, nil
 }
 
+func (c *dBServiceClient) AddFlairTemplate(ctx context.Context, in *AddFlairTemplateRequest, opts ...grpc.CallOption) (*AddFlairTemplateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddFlairTemplateResponse)
+	err := c.cc.Invoke(ctx, DBService_AddFlairTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) RemoveFlairTemplate(ctx context.Context, in *RemoveFlairTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RemoveFlairTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBServiceClient) ListThreads(ctx context.Context, in *ListThreadsRequest, opts ...grpc.CallOption) (*ListThreadsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListThreadsResponse)
@@ -170,6 +272,106 @@ func (c *dBServiceClient) DeleteThread(ctx context.Context, in *DeleteThreadRequ
 	return out, nil
 }
 
+func (c *dBServiceClient) RemoveThread(ctx context.Context, in *RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RemoveThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) RestoreThread(ctx context.Context, in *RestoreThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RestoreThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) CastPollVote(ctx context.Context, in *CastPollVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_CastPollVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) PublishThread(ctx context.Context, in *PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_PublishThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) ListScheduledThreads(ctx context.Context, in *ListScheduledThreadsRequest, opts ...grpc.CallOption) (*ListThreadsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListThreadsResponse)
+	err := c.cc.Invoke(ctx, DBService_ListScheduledThreads_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) MoveThread(ctx context.Context, in *MoveThreadRequest, opts ...grpc.CallOption) (*MoveThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveThreadResponse)
+	err := c.cc.Invoke(ctx, DBService_MoveThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) PinThread(ctx context.Context, in *PinThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_PinThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) CreateThreadAndIncrement(ctx context.Context, in *CreateThreadRequest, opts ...grpc.CallOption) (*CreateThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateThreadResponse)
+	err := c.cc.Invoke(ctx, DBService_CreateThreadAndIncrement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) DeleteThreadAndDecrement(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_DeleteThreadAndDecrement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) PublishThreadAndIncrement(ctx context.Context, in *PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_PublishThreadAndIncrement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dBServiceClient) ListComments(ctx context.Context, in *ListCommentsRequest, opts ...grpc.CallOption) (*ListCommentsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListCommentsResponse)
@@ -220,370 +422,1384 @@ func (c *dBServiceClient) DeleteComment(ctx context.Context, in *DeleteCommentRe
 	return out, nil
 }
 
-// DBServiceServer is the server API for DBService service.
-// All implementations must embed UnimplementedDBServiceServer
-// for forward compatibility.
-type DBServiceServer interface {
-	// community crud operations
-	ListCommunities(context.Context, *ListCommunitiesRequest) (*ListCommunitiesResponse, error)
-	CreateCommunity(context.Context, *CreateCommunityRequest) (*CreateCommunityResponse, error)
-	GetCommunity(context.Context, *GetCommunityRequest) (*pb.Community, error)
-	UpdateCommunity(context.Context, *UpdateCommunityRequest) (*emptypb.Empty, error)
-	DeleteCommunity(context.Context, *DeleteCommunityRequest) (*emptypb.Empty, error)
-	// thread crud operations
-	ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error)
-	CreateThread(context.Context, *CreateThreadRequest) (*CreateThreadResponse, error)
-	GetThread(context.Context, *GetThreadRequest) (*pb.Thread, error)
-	UpdateThread(context.Context, *UpdateThreadRequest) (*emptypb.Empty, error)
-	DeleteThread(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error)
-	// comment crud operations
-	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
-	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
-	GetComment(context.Context, *GetCommentRequest) (*pb.Comment, error)
-	UpdateComment(context.Context, *UpdateCommentRequest) (*emptypb.Empty, error)
-	DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
-	mustEmbedUnimplementedDBServiceServer()
+func (c *dBServiceClient) RemoveComment(ctx context.Context, in *RemoveCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RemoveComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedDBServiceServer must be embedded to have
-// forward compatible implementations.
-//
-// NOTE: this should be embedded by value instead of pointer to avoid a nil
-// pointer dereference when methods are called.
-type UnimplementedDBServiceServer struct{}
+func (c *dBServiceClient) RestoreComment(ctx context.Context, in *RestoreCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RestoreComment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-func (UnimplementedDBServiceServer) ListCommunities(context.Context, *ListCommunitiesRequest) (*ListCommunitiesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListCommunities not implemented")
+func (c *dBServiceClient) CreateCommentAndIncrement(ctx context.Context, in *CreateCommentRequest, opts ...grpc.CallOption) (*CreateCommentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateCommentResponse)
+	err := c.cc.Invoke(ctx, DBService_CreateCommentAndIncrement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CreateCommunity(context.Context, *CreateCommunityRequest) (*CreateCommunityResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateCommunity not implemented")
+
+func (c *dBServiceClient) DeleteCommentAndDecrement(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_DeleteCommentAndDecrement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetCommunity(context.Context, *GetCommunityRequest) (*pb.Community, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCommunity not implemented")
+
+func (c *dBServiceClient) DeleteCommentTree(ctx context.Context, in *DeleteCommentRequest, opts ...grpc.CallOption) (*DeleteCommentTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteCommentTreeResponse)
+	err := c.cc.Invoke(ctx, DBService_DeleteCommentTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateCommunity(context.Context, *UpdateCommunityRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateCommunity not implemented")
+
+func (c *dBServiceClient) GetAuthorStats(ctx context.Context, in *GetAuthorStatsRequest, opts ...grpc.CallOption) (*GetAuthorStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAuthorStatsResponse)
+	err := c.cc.Invoke(ctx, DBService_GetAuthorStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) DeleteCommunity(context.Context, *DeleteCommunityRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteCommunity not implemented")
+
+func (c *dBServiceClient) GetAutomodConfig(ctx context.Context, in *GetAutomodConfigRequest, opts ...grpc.CallOption) (*GetAutomodConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAutomodConfigResponse)
+	err := c.cc.Invoke(ctx, DBService_GetAutomodConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListThreads not implemented")
+
+func (c *dBServiceClient) SetAutomodConfig(ctx context.Context, in *SetAutomodConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_SetAutomodConfig_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) CreateThread(context.Context, *CreateThreadRequest) (*CreateThreadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateThread not implemented")
+
+func (c *dBServiceClient) ListModQueue(ctx context.Context, in *ListModQueueRequest, opts ...grpc.CallOption) (*ListModQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListModQueueResponse)
+	err := c.cc.Invoke(ctx, DBService_ListModQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) GetThread(context.Context, *GetThreadRequest) (*pb.Thread, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+
+func (c *dBServiceClient) CreateModQueueItem(ctx context.Context, in *CreateModQueueItemRequest, opts ...grpc.CallOption) (*CreateModQueueItemResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateModQueueItemResponse)
+	err := c.cc.Invoke(ctx, DBService_CreateModQueueItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) UpdateThread(context.Context, *UpdateThreadRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateThread not implemented")
+
+func (c *dBServiceClient) DeleteModQueueItem(ctx context.Context, in *DeleteModQueueItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_DeleteModQueueItem_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) DeleteThread(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
+
+func (c *dBServiceClient) RecordAutomodAction(ctx context.Context, in *RecordAutomodActionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_RecordAutomodAction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
-func (UnimplementedDBServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+
+func (c *dBServiceClient) CreateReport(ctx context.Context, in *CreateReportRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_CreateReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) ListSpamSamples(ctx context.Context, in *ListSpamSamplesRequest, opts ...grpc.CallOption) (*ListSpamSamplesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSpamSamplesResponse)
+	err := c.cc.Invoke(ctx, DBService_ListSpamSamples_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) GetSpamModel(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SpamModel, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpamModel)
+	err := c.cc.Invoke(ctx, DBService_GetSpamModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) SetSpamModel(ctx context.Context, in *SpamModel, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, DBService_SetSpamModel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) CreateAttachment(ctx context.Context, in *CreateAttachmentRequest, opts ...grpc.CallOption) (*CreateAttachmentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateAttachmentResponse)
+	err := c.cc.Invoke(ctx, DBService_CreateAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) GetAttachment(ctx context.Context, in *GetAttachmentRequest, opts ...grpc.CallOption) (*pb.Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(pb.Attachment)
+	err := c.cc.Invoke(ctx, DBService_GetAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, DBService_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) ReconcileCounters(ctx context.Context, in *ReconcileCountersRequest, opts ...grpc.CallOption) (*ReconcileCountersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileCountersResponse)
+	err := c.cc.Invoke(ctx, DBService_ReconcileCounters_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) SubscribeEvents(ctx context.Context, in *SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[pb.Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &DBService_ServiceDesc.Streams[0], DBService_SubscribeEvents_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeEventsRequest, pb.Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBService_SubscribeEventsClient = grpc.ServerStreamingClient[pb.Event]
+
+func (c *dBServiceClient) CreateJob(ctx context.Context, in *CreateJobRequest, opts ...grpc.CallOption) (*CreateJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateJobResponse)
+	err := c.cc.Invoke(ctx, DBService_CreateJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *dBServiceClient) GetJobStatus(ctx context.Context, in *GetJobStatusRequest, opts ...grpc.CallOption) (*pb.Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(pb.Job)
+	err := c.cc.Invoke(ctx, DBService_GetJobStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// DBServiceServer is the server API for DBService service.
+// All implementations must embed UnimplementedDBServiceServer
+// for forward compatibility.
+type DBServiceServer interface {
+	// community crud operations
+	ListCommunities(context.Context, *ListCommunitiesRequest) (*ListCommunitiesResponse, error)
+	CreateCommunity(context.Context, *CreateCommunityRequest) (*CreateCommunityResponse, error)
+	GetCommunity(context.Context, *GetCommunityRequest) (*pb.Community, error)
+	UpdateCommunity(context.Context, *UpdateCommunityRequest) (*emptypb.Empty, error)
+	DeleteCommunity(context.Context, *DeleteCommunityRequest) (*emptypb.Empty, error)
+	AddFlairTemplate(context.Context, *AddFlairTemplateRequest) (*AddFlairTemplateResponse, error)
+	RemoveFlairTemplate(context.Context, *RemoveFlairTemplateRequest) (*emptypb.Empty, error)
+	// thread crud operations
+	ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error)
+	CreateThread(context.Context, *CreateThreadRequest) (*CreateThreadResponse, error)
+	GetThread(context.Context, *GetThreadRequest) (*pb.Thread, error)
+	UpdateThread(context.Context, *UpdateThreadRequest) (*emptypb.Empty, error)
+	DeleteThread(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error)
+	RemoveThread(context.Context, *RemoveThreadRequest) (*emptypb.Empty, error)
+	RestoreThread(context.Context, *RestoreThreadRequest) (*emptypb.Empty, error)
+	CastPollVote(context.Context, *CastPollVoteRequest) (*emptypb.Empty, error)
+	PublishThread(context.Context, *PublishThreadRequest) (*emptypb.Empty, error)
+	ListScheduledThreads(context.Context, *ListScheduledThreadsRequest) (*ListThreadsResponse, error)
+	MoveThread(context.Context, *MoveThreadRequest) (*MoveThreadResponse, error)
+	// pins the thread unless its community already has max_pinned pinned threads, counted in the same transaction
+	PinThread(context.Context, *PinThreadRequest) (*emptypb.Empty, error)
+	// the operations below change num_threads of the community in the same transaction
+	CreateThreadAndIncrement(context.Context, *CreateThreadRequest) (*CreateThreadResponse, error)
+	DeleteThreadAndDecrement(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error)
+	PublishThreadAndIncrement(context.Context, *PublishThreadRequest) (*emptypb.Empty, error)
+	// comment crud operations
+	ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error)
+	CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	GetComment(context.Context, *GetCommentRequest) (*pb.Comment, error)
+	UpdateComment(context.Context, *UpdateCommentRequest) (*emptypb.Empty, error)
+	DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
+	RemoveComment(context.Context, *RemoveCommentRequest) (*emptypb.Empty, error)
+	RestoreComment(context.Context, *RestoreCommentRequest) (*emptypb.Empty, error)
+	// the operations below change num_comments of the parent in the same transaction
+	CreateCommentAndIncrement(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error)
+	DeleteCommentAndDecrement(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error)
+	// deletes the comment and every reply under it, found by their ancestor path
+	DeleteCommentTree(context.Context, *DeleteCommentRequest) (*DeleteCommentTreeResponse, error)
+	// moderation operations
+	GetAuthorStats(context.Context, *GetAuthorStatsRequest) (*GetAuthorStatsResponse, error)
+	GetAutomodConfig(context.Context, *GetAutomodConfigRequest) (*GetAutomodConfigResponse, error)
+	SetAutomodConfig(context.Context, *SetAutomodConfigRequest) (*emptypb.Empty, error)
+	ListModQueue(context.Context, *ListModQueueRequest) (*ListModQueueResponse, error)
+	CreateModQueueItem(context.Context, *CreateModQueueItemRequest) (*CreateModQueueItemResponse, error)
+	DeleteModQueueItem(context.Context, *DeleteModQueueItemRequest) (*emptypb.Empty, error)
+	// records that an automod rule acted on a thread or comment, fails with AlreadyExists when it already did
+	RecordAutomodAction(context.Context, *RecordAutomodActionRequest) (*emptypb.Empty, error)
+	// counts a report of a thread or comment in its num_reports, fails with AlreadyExists when the user already reported it
+	CreateReport(context.Context, *CreateReportRequest) (*emptypb.Empty, error)
+	ListSpamSamples(context.Context, *ListSpamSamplesRequest) (*ListSpamSamplesResponse, error)
+	GetSpamModel(context.Context, *emptypb.Empty) (*SpamModel, error)
+	SetSpamModel(context.Context, *SpamModel) (*emptypb.Empty, error)
+	// attachment operations
+	CreateAttachment(context.Context, *CreateAttachmentRequest) (*CreateAttachmentResponse, error)
+	GetAttachment(context.Context, *GetAttachmentRequest) (*pb.Attachment, error)
+	// revision operations
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	// maintenance operations
+	ReconcileCounters(context.Context, *ReconcileCountersRequest) (*ReconcileCountersResponse, error)
+	// event operations
+	SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[pb.Event]) error
+	// job operations, jobs run in the background
+	CreateJob(context.Context, *CreateJobRequest) (*CreateJobResponse, error)
+	GetJobStatus(context.Context, *GetJobStatusRequest) (*pb.Job, error)
+	mustEmbedUnimplementedDBServiceServer()
+}
+
+// UnimplementedDBServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedDBServiceServer struct{}
+
+func (UnimplementedDBServiceServer) ListCommunities(context.Context, *ListCommunitiesRequest) (*ListCommunitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCommunities not implemented")
+}
+func (UnimplementedDBServiceServer) CreateCommunity(context.Context, *CreateCommunityRequest) (*CreateCommunityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCommunity not implemented")
+}
+func (UnimplementedDBServiceServer) GetCommunity(context.Context, *GetCommunityRequest) (*pb.Community, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommunity not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateCommunity(context.Context, *UpdateCommunityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCommunity not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteCommunity(context.Context, *DeleteCommunityRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCommunity not implemented")
+}
+func (UnimplementedDBServiceServer) AddFlairTemplate(context.Context, *AddFlairTemplateRequest) (*AddFlairTemplateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddFlairTemplate not implemented")
+}
+func (UnimplementedDBServiceServer) RemoveFlairTemplate(context.Context, *RemoveFlairTemplateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFlairTemplate not implemented")
+}
+func (UnimplementedDBServiceServer) ListThreads(context.Context, *ListThreadsRequest) (*ListThreadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListThreads not implemented")
+}
+func (UnimplementedDBServiceServer) CreateThread(context.Context, *CreateThreadRequest) (*CreateThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateThread not implemented")
+}
+func (UnimplementedDBServiceServer) GetThread(context.Context, *GetThreadRequest) (*pb.Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetThread not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateThread(context.Context, *UpdateThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateThread not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteThread(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
+}
+func (UnimplementedDBServiceServer) RemoveThread(context.Context, *RemoveThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveThread not implemented")
+}
+func (UnimplementedDBServiceServer) RestoreThread(context.Context, *RestoreThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreThread not implemented")
+}
+func (UnimplementedDBServiceServer) CastPollVote(context.Context, *CastPollVoteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CastPollVote not implemented")
+}
+func (UnimplementedDBServiceServer) PublishThread(context.Context, *PublishThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishThread not implemented")
+}
+func (UnimplementedDBServiceServer) ListScheduledThreads(context.Context, *ListScheduledThreadsRequest) (*ListThreadsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListScheduledThreads not implemented")
+}
+func (UnimplementedDBServiceServer) MoveThread(context.Context, *MoveThreadRequest) (*MoveThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveThread not implemented")
+}
+func (UnimplementedDBServiceServer) PinThread(context.Context, *PinThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinThread not implemented")
+}
+func (UnimplementedDBServiceServer) CreateThreadAndIncrement(context.Context, *CreateThreadRequest) (*CreateThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateThreadAndIncrement not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteThreadAndDecrement(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteThreadAndDecrement not implemented")
+}
+func (UnimplementedDBServiceServer) PublishThreadAndIncrement(context.Context, *PublishThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishThreadAndIncrement not implemented")
+}
+func (UnimplementedDBServiceServer) ListComments(context.Context, *ListCommentsRequest) (*ListCommentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListComments not implemented")
+}
+func (UnimplementedDBServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+}
+func (UnimplementedDBServiceServer) GetComment(context.Context, *GetCommentRequest) (*pb.Comment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetComment not implemented")
+}
+func (UnimplementedDBServiceServer) UpdateComment(context.Context, *UpdateCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+}
+func (UnimplementedDBServiceServer) RemoveComment(context.Context, *RemoveCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveComment not implemented")
+}
+func (UnimplementedDBServiceServer) RestoreComment(context.Context, *RestoreCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreComment not implemented")
+}
+func (UnimplementedDBServiceServer) CreateCommentAndIncrement(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCommentAndIncrement not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteCommentAndDecrement(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCommentAndDecrement not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteCommentTree(context.Context, *DeleteCommentRequest) (*DeleteCommentTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCommentTree not implemented")
+}
+func (UnimplementedDBServiceServer) GetAuthorStats(context.Context, *GetAuthorStatsRequest) (*GetAuthorStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAuthorStats not implemented")
+}
+func (UnimplementedDBServiceServer) GetAutomodConfig(context.Context, *GetAutomodConfigRequest) (*GetAutomodConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAutomodConfig not implemented")
+}
+func (UnimplementedDBServiceServer) SetAutomodConfig(context.Context, *SetAutomodConfigRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetAutomodConfig not implemented")
+}
+func (UnimplementedDBServiceServer) ListModQueue(context.Context, *ListModQueueRequest) (*ListModQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListModQueue not implemented")
+}
+func (UnimplementedDBServiceServer) CreateModQueueItem(context.Context, *CreateModQueueItemRequest) (*CreateModQueueItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateModQueueItem not implemented")
+}
+func (UnimplementedDBServiceServer) DeleteModQueueItem(context.Context, *DeleteModQueueItemRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteModQueueItem not implemented")
+}
+func (UnimplementedDBServiceServer) RecordAutomodAction(context.Context, *RecordAutomodActionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordAutomodAction not implemented")
+}
+func (UnimplementedDBServiceServer) CreateReport(context.Context, *CreateReportRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateReport not implemented")
+}
+func (UnimplementedDBServiceServer) ListSpamSamples(context.Context, *ListSpamSamplesRequest) (*ListSpamSamplesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpamSamples not implemented")
+}
+func (UnimplementedDBServiceServer) GetSpamModel(context.Context, *emptypb.Empty) (*SpamModel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSpamModel not implemented")
+}
+func (UnimplementedDBServiceServer) SetSpamModel(context.Context, *SpamModel) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetSpamModel not implemented")
+}
+func (UnimplementedDBServiceServer) CreateAttachment(context.Context, *CreateAttachmentRequest) (*CreateAttachmentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateAttachment not implemented")
+}
+func (UnimplementedDBServiceServer) GetAttachment(context.Context, *GetAttachmentRequest) (*pb.Attachment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachment not implemented")
+}
+func (UnimplementedDBServiceServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedDBServiceServer) ReconcileCounters(context.Context, *ReconcileCountersRequest) (*ReconcileCountersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileCounters not implemented")
+}
+func (UnimplementedDBServiceServer) SubscribeEvents(*SubscribeEventsRequest, grpc.ServerStreamingServer[pb.Event]) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeEvents not implemented")
+}
+func (UnimplementedDBServiceServer) CreateJob(context.Context, *CreateJobRequest) (*CreateJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJob not implemented")
+}
+func (UnimplementedDBServiceServer) GetJobStatus(context.Context, *GetJobStatusRequest) (*pb.Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobStatus not implemented")
+}
+func (UnimplementedDBServiceServer) mustEmbedUnimplementedDBServiceServer() {}
+func (UnimplementedDBServiceServer) testEmbeddedByValue()                   {}
+
+// UnsafeDBServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to DBServiceServer will
+// result in compilation errors.
+type UnsafeDBServiceServer interface {
+	mustEmbedUnimplementedDBServiceServer()
+}
+
+func RegisterDBServiceServer(s grpc.ServiceRegistrar, srv DBServiceServer) {
+	// If the following call pancis, it indicates UnimplementedDBServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&DBService_ServiceDesc, srv)
+}
+
+func _DBService_ListCommunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommunitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListCommunities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListCommunities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListCommunities(ctx, req.(*ListCommunitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CreateCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommunityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateCommunity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateCommunity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateCommunity(ctx, req.(*CreateCommunityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommunityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetCommunity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetCommunity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetCommunity(ctx, req.(*GetCommunityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommunityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateCommunity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateCommunity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateCommunity(ctx, req.(*UpdateCommunityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_DeleteCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommunityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteCommunity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteCommunity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteCommunity(ctx, req.(*DeleteCommunityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_AddFlairTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddFlairTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).AddFlairTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_AddFlairTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).AddFlairTemplate(ctx, req.(*AddFlairTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_RemoveFlairTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFlairTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RemoveFlairTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RemoveFlairTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RemoveFlairTemplate(ctx, req.(*RemoveFlairTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListThreads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListThreads(ctx, req.(*ListThreadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateThread(ctx, req.(*CreateThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateThread(ctx, req.(*UpdateThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_DeleteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteThread(ctx, req.(*DeleteThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_RemoveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RemoveThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RemoveThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RemoveThread(ctx, req.(*RemoveThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_RestoreThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RestoreThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RestoreThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RestoreThread(ctx, req.(*RestoreThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CastPollVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CastPollVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CastPollVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CastPollVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CastPollVote(ctx, req.(*CastPollVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_PublishThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).PublishThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_PublishThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).PublishThread(ctx, req.(*PublishThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListScheduledThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledThreadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListScheduledThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListScheduledThreads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListScheduledThreads(ctx, req.(*ListScheduledThreadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_MoveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).MoveThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_MoveThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).MoveThread(ctx, req.(*MoveThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_PinThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).PinThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_PinThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).PinThread(ctx, req.(*PinThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CreateThreadAndIncrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateThreadAndIncrement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateThreadAndIncrement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateThreadAndIncrement(ctx, req.(*CreateThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_DeleteThreadAndDecrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteThreadAndDecrement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteThreadAndDecrement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteThreadAndDecrement(ctx, req.(*DeleteThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_PublishThreadAndIncrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).PublishThreadAndIncrement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_PublishThreadAndIncrement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).PublishThreadAndIncrement(ctx, req.(*PublishThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCommentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).ListComments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_ListComments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_GetComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetComment(ctx, req.(*GetCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).UpdateComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_UpdateComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DBService_RemoveComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RemoveComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RemoveComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RemoveComment(ctx, req.(*RemoveCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) CreateComment(context.Context, *CreateCommentRequest) (*CreateCommentResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateComment not implemented")
+
+func _DBService_RestoreComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).RestoreComment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_RestoreComment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).RestoreComment(ctx, req.(*RestoreCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) GetComment(context.Context, *GetCommentRequest) (*pb.Comment, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetComment not implemented")
+
+func _DBService_CreateCommentAndIncrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).CreateCommentAndIncrement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_CreateCommentAndIncrement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).CreateCommentAndIncrement(ctx, req.(*CreateCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) UpdateComment(context.Context, *UpdateCommentRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateComment not implemented")
+
+func _DBService_DeleteCommentAndDecrement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteCommentAndDecrement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteCommentAndDecrement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteCommentAndDecrement(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) DeleteComment(context.Context, *DeleteCommentRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteComment not implemented")
+
+func _DBService_DeleteCommentTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCommentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).DeleteCommentTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_DeleteCommentTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).DeleteCommentTree(ctx, req.(*DeleteCommentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
-func (UnimplementedDBServiceServer) mustEmbedUnimplementedDBServiceServer() {}
-func (UnimplementedDBServiceServer) testEmbeddedByValue()                   {}
 
-// UnsafeDBServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to DBServiceServer will
-// result in compilation errors.
-type UnsafeDBServiceServer interface {
-	mustEmbedUnimplementedDBServiceServer()
+func _DBService_GetAuthorStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAuthorStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetAuthorStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetAuthorStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetAuthorStats(ctx, req.(*GetAuthorStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterDBServiceServer(s grpc.ServiceRegistrar, srv DBServiceServer) {
-	// If the following call pancis, it indicates UnimplementedDBServiceServer was
-	// embedded by pointer and is nil.  This will cause panics if an
-	// unimplemented method is ever invoked, so we test this at initialization
-	// time to prevent it from happening at runtime later due to I/O.
-	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
-		t.testEmbeddedByValue()
+func _DBService_GetAutomodConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAutomodConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	s.RegisterService(&DBService_ServiceDesc, srv)
+	if interceptor == nil {
+		return srv.(DBServiceServer).GetAutomodConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DBService_GetAutomodConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DBServiceServer).GetAutomodConfig(ctx, req.(*GetAutomodConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_ListCommunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListCommunitiesRequest)
+func _DBService_SetAutomodConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetAutomodConfigRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).ListCommunities(ctx, in)
+		return srv.(DBServiceServer).SetAutomodConfig(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_ListCommunities_FullMethodName,
+		FullMethod: DBService_SetAutomodConfig_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).ListCommunities(ctx, req.(*ListCommunitiesRequest))
+		return srv.(DBServiceServer).SetAutomodConfig(ctx, req.(*SetAutomodConfigRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CreateCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateCommunityRequest)
+func _DBService_ListModQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListModQueueRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CreateCommunity(ctx, in)
+		return srv.(DBServiceServer).ListModQueue(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CreateCommunity_FullMethodName,
+		FullMethod: DBService_ListModQueue_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CreateCommunity(ctx, req.(*CreateCommunityRequest))
+		return srv.(DBServiceServer).ListModQueue(ctx, req.(*ListModQueueRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCommunityRequest)
+func _DBService_CreateModQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateModQueueItemRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetCommunity(ctx, in)
+		return srv.(DBServiceServer).CreateModQueueItem(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetCommunity_FullMethodName,
+		FullMethod: DBService_CreateModQueueItem_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetCommunity(ctx, req.(*GetCommunityRequest))
+		return srv.(DBServiceServer).CreateModQueueItem(ctx, req.(*CreateModQueueItemRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateCommunityRequest)
+func _DBService_DeleteModQueueItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteModQueueItemRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateCommunity(ctx, in)
+		return srv.(DBServiceServer).DeleteModQueueItem(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateCommunity_FullMethodName,
+		FullMethod: DBService_DeleteModQueueItem_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateCommunity(ctx, req.(*UpdateCommunityRequest))
+		return srv.(DBServiceServer).DeleteModQueueItem(ctx, req.(*DeleteModQueueItemRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_DeleteCommunity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteCommunityRequest)
+func _DBService_RecordAutomodAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordAutomodActionRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).DeleteCommunity(ctx, in)
+		return srv.(DBServiceServer).RecordAutomodAction(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_DeleteCommunity_FullMethodName,
+		FullMethod: DBService_RecordAutomodAction_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).DeleteCommunity(ctx, req.(*DeleteCommunityRequest))
+		return srv.(DBServiceServer).RecordAutomodAction(ctx, req.(*RecordAutomodActionRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_ListThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListThreadsRequest)
+func _DBService_CreateReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateReportRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).ListThreads(ctx, in)
+		return srv.(DBServiceServer).CreateReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_ListThreads_FullMethodName,
+		FullMethod: DBService_CreateReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).ListThreads(ctx, req.(*ListThreadsRequest))
+		return srv.(DBServiceServer).CreateReport(ctx, req.(*CreateReportRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateThreadRequest)
+func _DBService_ListSpamSamples_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSpamSamplesRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CreateThread(ctx, in)
+		return srv.(DBServiceServer).ListSpamSamples(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CreateThread_FullMethodName,
+		FullMethod: DBService_ListSpamSamples_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CreateThread(ctx, req.(*CreateThreadRequest))
+		return srv.(DBServiceServer).ListSpamSamples(ctx, req.(*ListSpamSamplesRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetThreadRequest)
+func _DBService_GetSpamModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetThread(ctx, in)
+		return srv.(DBServiceServer).GetSpamModel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetThread_FullMethodName,
+		FullMethod: DBService_GetSpamModel_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetThread(ctx, req.(*GetThreadRequest))
+		return srv.(DBServiceServer).GetSpamModel(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateThreadRequest)
+func _DBService_SetSpamModel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpamModel)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateThread(ctx, in)
+		return srv.(DBServiceServer).SetSpamModel(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateThread_FullMethodName,
+		FullMethod: DBService_SetSpamModel_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateThread(ctx, req.(*UpdateThreadRequest))
+		return srv.(DBServiceServer).SetSpamModel(ctx, req.(*SpamModel))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_DeleteThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteThreadRequest)
+func _DBService_CreateAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateAttachmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).DeleteThread(ctx, in)
+		return srv.(DBServiceServer).CreateAttachment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_DeleteThread_FullMethodName,
+		FullMethod: DBService_CreateAttachment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).DeleteThread(ctx, req.(*DeleteThreadRequest))
+		return srv.(DBServiceServer).CreateAttachment(ctx, req.(*CreateAttachmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_ListComments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ListCommentsRequest)
+func _DBService_GetAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).ListComments(ctx, in)
+		return srv.(DBServiceServer).GetAttachment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_ListComments_FullMethodName,
+		FullMethod: DBService_GetAttachment_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).ListComments(ctx, req.(*ListCommentsRequest))
+		return srv.(DBServiceServer).GetAttachment(ctx, req.(*GetAttachmentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_CreateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateCommentRequest)
+func _DBService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).CreateComment(ctx, in)
+		return srv.(DBServiceServer).ListRevisions(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_CreateComment_FullMethodName,
+		FullMethod: DBService_ListRevisions_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).CreateComment(ctx, req.(*CreateCommentRequest))
+		return srv.(DBServiceServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_GetComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetCommentRequest)
+func _DBService_ReconcileCounters_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileCountersRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).GetComment(ctx, in)
+		return srv.(DBServiceServer).ReconcileCounters(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_GetComment_FullMethodName,
+		FullMethod: DBService_ReconcileCounters_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).GetComment(ctx, req.(*GetCommentRequest))
+		return srv.(DBServiceServer).ReconcileCounters(ctx, req.(*ReconcileCountersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_UpdateComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateCommentRequest)
+func _DBService_SubscribeEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeEventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DBServiceServer).SubscribeEvents(m, &grpc.GenericServerStream[SubscribeEventsRequest, pb.Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type DBService_SubscribeEventsServer = grpc.ServerStreamingServer[pb.Event]
+
+func _DBService_CreateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).UpdateComment(ctx, in)
+		return srv.(DBServiceServer).CreateJob(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_UpdateComment_FullMethodName,
+		FullMethod: DBService_CreateJob_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).UpdateComment(ctx, req.(*UpdateCommentRequest))
+		return srv.(DBServiceServer).CreateJob(ctx, req.(*CreateJobRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _DBService_DeleteComment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteCommentRequest)
+func _DBService_GetJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(DBServiceServer).DeleteComment(ctx, in)
+		return srv.(DBServiceServer).GetJobStatus(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: DBService_DeleteComment_FullMethodName,
+		FullMethod: DBService_GetJobStatus_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(DBServiceServer).DeleteComment(ctx, req.(*DeleteCommentRequest))
+		return srv.(DBServiceServer).GetJobStatus(ctx, req.(*GetJobStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -615,6 +1831,14 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteCommunity",
 			Handler:    _DBService_DeleteCommunity_Handler,
 		},
+		{
+			MethodName: "AddFlairTemplate",
+			Handler:    _DBService_AddFlairTemplate_Handler,
+		},
+		{
+			MethodName: "RemoveFlairTemplate",
+			Handler:    _DBService_RemoveFlairTemplate_Handler,
+		},
 		{
 			MethodName: "ListThreads",
 			Handler:    _DBService_ListThreads_Handler,
@@ -635,6 +1859,46 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteThread",
 			Handler:    _DBService_DeleteThread_Handler,
 		},
+		{
+			MethodName: "RemoveThread",
+			Handler:    _DBService_RemoveThread_Handler,
+		},
+		{
+			MethodName: "RestoreThread",
+			Handler:    _DBService_RestoreThread_Handler,
+		},
+		{
+			MethodName: "CastPollVote",
+			Handler:    _DBService_CastPollVote_Handler,
+		},
+		{
+			MethodName: "PublishThread",
+			Handler:    _DBService_PublishThread_Handler,
+		},
+		{
+			MethodName: "ListScheduledThreads",
+			Handler:    _DBService_ListScheduledThreads_Handler,
+		},
+		{
+			MethodName: "MoveThread",
+			Handler:    _DBService_MoveThread_Handler,
+		},
+		{
+			MethodName: "PinThread",
+			Handler:    _DBService_PinThread_Handler,
+		},
+		{
+			MethodName: "CreateThreadAndIncrement",
+			Handler:    _DBService_CreateThreadAndIncrement_Handler,
+		},
+		{
+			MethodName: "DeleteThreadAndDecrement",
+			Handler:    _DBService_DeleteThreadAndDecrement_Handler,
+		},
+		{
+			MethodName: "PublishThreadAndIncrement",
+			Handler:    _DBService_PublishThreadAndIncrement_Handler,
+		},
 		{
 			MethodName: "ListComments",
 			Handler:    _DBService_ListComments_Handler,
@@ -655,7 +1919,101 @@ var DBService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteComment",
 			Handler:    _DBService_DeleteComment_Handler,
 		},
+		{
+			MethodName: "RemoveComment",
+			Handler:    _DBService_RemoveComment_Handler,
+		},
+		{
+			MethodName: "RestoreComment",
+			Handler:    _DBService_RestoreComment_Handler,
+		},
+		{
+			MethodName: "CreateCommentAndIncrement",
+			Handler:    _DBService_CreateCommentAndIncrement_Handler,
+		},
+		{
+			MethodName: "DeleteCommentAndDecrement",
+			Handler:    _DBService_DeleteCommentAndDecrement_Handler,
+		},
+		{
+			MethodName: "DeleteCommentTree",
+			Handler:    _DBService_DeleteCommentTree_Handler,
+		},
+		{
+			MethodName: "GetAuthorStats",
+			Handler:    _DBService_GetAuthorStats_Handler,
+		},
+		{
+			MethodName: "GetAutomodConfig",
+			Handler:    _DBService_GetAutomodConfig_Handler,
+		},
+		{
+			MethodName: "SetAutomodConfig",
+			Handler:    _DBService_SetAutomodConfig_Handler,
+		},
+		{
+			MethodName: "ListModQueue",
+			Handler:    _DBService_ListModQueue_Handler,
+		},
+		{
+			MethodName: "CreateModQueueItem",
+			Handler:    _DBService_CreateModQueueItem_Handler,
+		},
+		{
+			MethodName: "DeleteModQueueItem",
+			Handler:    _DBService_DeleteModQueueItem_Handler,
+		},
+		{
+			MethodName: "RecordAutomodAction",
+			Handler:    _DBService_RecordAutomodAction_Handler,
+		},
+		{
+			MethodName: "CreateReport",
+			Handler:    _DBService_CreateReport_Handler,
+		},
+		{
+			MethodName: "ListSpamSamples",
+			Handler:    _DBService_ListSpamSamples_Handler,
+		},
+		{
+			MethodName: "GetSpamModel",
+			Handler:    _DBService_GetSpamModel_Handler,
+		},
+		{
+			MethodName: "SetSpamModel",
+			Handler:    _DBService_SetSpamModel_Handler,
+		},
+		{
+			MethodName: "CreateAttachment",
+			Handler:    _DBService_CreateAttachment_Handler,
+		},
+		{
+			MethodName: "GetAttachment",
+			Handler:    _DBService_GetAttachment_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _DBService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "ReconcileCounters",
+			Handler:    _DBService_ReconcileCounters_Handler,
+		},
+		{
+			MethodName: "CreateJob",
+			Handler:    _DBService_CreateJob_Handler,
+		},
+		{
+			MethodName: "GetJobStatus",
+			Handler:    _DBService_GetJobStatus_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeEvents",
+			Handler:       _DBService_SubscribeEvents_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "db-service.proto",
 }
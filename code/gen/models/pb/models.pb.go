@@ -9,6 +9,7 @@ package models
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -67,30 +68,1440 @@ func (CommentParentType) EnumDescriptor() ([]byte, []int) {
 	return file_models_proto_rawDescGZIP(), []int{0}
 }
 
+type ThreadKind int32
+
+const (
+	ThreadKind_TEXT      ThreadKind = 0
+	ThreadKind_LINK      ThreadKind = 1
+	ThreadKind_IMAGE     ThreadKind = 2
+	ThreadKind_POLL      ThreadKind = 3
+	ThreadKind_CROSSPOST ThreadKind = 4
+	ThreadKind_REDIRECT  ThreadKind = 5 // left in the previous community of a moved thread
+)
+
+// Enum value maps for ThreadKind.
+var (
+	ThreadKind_name = map[int32]string{
+		0: "TEXT",
+		1: "LINK",
+		2: "IMAGE",
+		3: "POLL",
+		4: "CROSSPOST",
+		5: "REDIRECT",
+	}
+	ThreadKind_value = map[string]int32{
+		"TEXT":      0,
+		"LINK":      1,
+		"IMAGE":     2,
+		"POLL":      3,
+		"CROSSPOST": 4,
+		"REDIRECT":  5,
+	}
+)
+
+func (x ThreadKind) Enum() *ThreadKind {
+	p := new(ThreadKind)
+	*p = x
+	return p
+}
+
+func (x ThreadKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ThreadKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[1].Descriptor()
+}
+
+func (ThreadKind) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[1]
+}
+
+func (x ThreadKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ThreadKind.Descriptor instead.
+func (ThreadKind) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{1}
+}
+
+type RemovalType int32
+
+const (
+	RemovalType_NOT_REMOVED RemovalType = 0
+	RemovalType_DELETED     RemovalType = 1 // deleted by its author
+	RemovalType_REMOVED     RemovalType = 2 // removed by a moderator
+)
+
+// Enum value maps for RemovalType.
+var (
+	RemovalType_name = map[int32]string{
+		0: "NOT_REMOVED",
+		1: "DELETED",
+		2: "REMOVED",
+	}
+	RemovalType_value = map[string]int32{
+		"NOT_REMOVED": 0,
+		"DELETED":     1,
+		"REMOVED":     2,
+	}
+)
+
+func (x RemovalType) Enum() *RemovalType {
+	p := new(RemovalType)
+	*p = x
+	return p
+}
+
+func (x RemovalType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RemovalType) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[2].Descriptor()
+}
+
+func (RemovalType) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[2]
+}
+
+func (x RemovalType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RemovalType.Descriptor instead.
+func (RemovalType) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{2}
+}
+
+type EventType int32
+
+const (
+	EventType_EVENT_UNSPECIFIED EventType = 0
+	EventType_COMMUNITY_CREATED EventType = 1
+	EventType_COMMUNITY_DELETED EventType = 2
+	EventType_THREAD_CREATED    EventType = 3 // drafts are not created until they are published
+	EventType_THREAD_PUBLISHED  EventType = 4
+	EventType_THREAD_UPDATED    EventType = 5 // changes of the title, content, flair, tags, lock, pin or schedule, not of counters
+	EventType_THREAD_MOVED      EventType = 6
+	EventType_THREAD_REMOVED    EventType = 7
+	EventType_THREAD_RESTORED   EventType = 8
+	EventType_THREAD_DELETED    EventType = 9
+	EventType_COMMENT_CREATED   EventType = 10
+	EventType_COMMENT_UPDATED   EventType = 11
+	EventType_COMMENT_REMOVED   EventType = 12
+	EventType_COMMENT_RESTORED  EventType = 13
+	EventType_COMMENT_DELETED   EventType = 14
+	EventType_VOTE_CAST         EventType = 15
+	EventType_POLL_VOTE_CAST    EventType = 16 // thread_id is set, the options a user chose are not
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0:  "EVENT_UNSPECIFIED",
+		1:  "COMMUNITY_CREATED",
+		2:  "COMMUNITY_DELETED",
+		3:  "THREAD_CREATED",
+		4:  "THREAD_PUBLISHED",
+		5:  "THREAD_UPDATED",
+		6:  "THREAD_MOVED",
+		7:  "THREAD_REMOVED",
+		8:  "THREAD_RESTORED",
+		9:  "THREAD_DELETED",
+		10: "COMMENT_CREATED",
+		11: "COMMENT_UPDATED",
+		12: "COMMENT_REMOVED",
+		13: "COMMENT_RESTORED",
+		14: "COMMENT_DELETED",
+		15: "VOTE_CAST",
+		16: "POLL_VOTE_CAST",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_UNSPECIFIED": 0,
+		"COMMUNITY_CREATED": 1,
+		"COMMUNITY_DELETED": 2,
+		"THREAD_CREATED":    3,
+		"THREAD_PUBLISHED":  4,
+		"THREAD_UPDATED":    5,
+		"THREAD_MOVED":      6,
+		"THREAD_REMOVED":    7,
+		"THREAD_RESTORED":   8,
+		"THREAD_DELETED":    9,
+		"COMMENT_CREATED":   10,
+		"COMMENT_UPDATED":   11,
+		"COMMENT_REMOVED":   12,
+		"COMMENT_RESTORED":  13,
+		"COMMENT_DELETED":   14,
+		"VOTE_CAST":         15,
+		"POLL_VOTE_CAST":    16,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[3].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[3]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{3}
+}
+
+type JobType int32
+
+const (
+	JobType_DELETE_COMMUNITY   JobType = 0
+	JobType_DELETE_THREAD      JobType = 1
+	JobType_RECONCILE_COUNTERS JobType = 2 // compares every counter with the documents it counts, has no target
+)
+
+// Enum value maps for JobType.
+var (
+	JobType_name = map[int32]string{
+		0: "DELETE_COMMUNITY",
+		1: "DELETE_THREAD",
+		2: "RECONCILE_COUNTERS",
+	}
+	JobType_value = map[string]int32{
+		"DELETE_COMMUNITY":   0,
+		"DELETE_THREAD":      1,
+		"RECONCILE_COUNTERS": 2,
+	}
+)
+
+func (x JobType) Enum() *JobType {
+	p := new(JobType)
+	*p = x
+	return p
+}
+
+func (x JobType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobType) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[4].Descriptor()
+}
+
+func (JobType) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[4]
+}
+
+func (x JobType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobType.Descriptor instead.
+func (JobType) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{4}
+}
+
+type JobState int32
+
+const (
+	JobState_JOB_PENDING   JobState = 0
+	JobState_JOB_RUNNING   JobState = 1
+	JobState_JOB_SUCCEEDED JobState = 2
+	JobState_JOB_FAILED    JobState = 3
+)
+
+// Enum value maps for JobState.
+var (
+	JobState_name = map[int32]string{
+		0: "JOB_PENDING",
+		1: "JOB_RUNNING",
+		2: "JOB_SUCCEEDED",
+		3: "JOB_FAILED",
+	}
+	JobState_value = map[string]int32{
+		"JOB_PENDING":   0,
+		"JOB_RUNNING":   1,
+		"JOB_SUCCEEDED": 2,
+		"JOB_FAILED":    3,
+	}
+)
+
+func (x JobState) Enum() *JobState {
+	p := new(JobState)
+	*p = x
+	return p
+}
+
+func (x JobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_models_proto_enumTypes[5].Descriptor()
+}
+
+func (JobState) Type() protoreflect.EnumType {
+	return &file_models_proto_enumTypes[5]
+}
+
+func (x JobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JobState.Descriptor instead.
+func (JobState) EnumDescriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{5}
+}
+
 type Community struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	NumThreads     int32                  `protobuf:"varint,3,opt,name=num_threads,json=numThreads,proto3" json:"num_threads,omitempty"`
+	FlairTemplates []*FlairTemplate       `protobuf:"bytes,4,rep,name=flair_templates,json=flairTemplates,proto3" json:"flair_templates,omitempty"`
+	PostingRules   *PostingRules          `protobuf:"bytes,5,opt,name=posting_rules,json=postingRules,proto3" json:"posting_rules,omitempty"` // overrides of the site-wide rules, 0 keeps the site default
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Community) Reset() {
+	*x = Community{}
+	mi := &file_models_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Community) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Community) ProtoMessage() {}
+
+func (x *Community) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Community.ProtoReflect.Descriptor instead.
+func (*Community) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Community) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Community) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Community) GetNumThreads() int32 {
+	if x != nil {
+		return x.NumThreads
+	}
+	return 0
+}
+
+func (x *Community) GetFlairTemplates() []*FlairTemplate {
+	if x != nil {
+		return x.FlairTemplates
+	}
+	return nil
+}
+
+func (x *Community) GetPostingRules() *PostingRules {
+	if x != nil {
+		return x.PostingRules
+	}
+	return nil
+}
+
+// length limits in characters (unicode code points)
+type PostingRules struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	MinTitleLength   int32                  `protobuf:"varint,1,opt,name=min_title_length,json=minTitleLength,proto3" json:"min_title_length,omitempty"`
+	MaxTitleLength   int32                  `protobuf:"varint,2,opt,name=max_title_length,json=maxTitleLength,proto3" json:"max_title_length,omitempty"`
+	MinContentLength int32                  `protobuf:"varint,3,opt,name=min_content_length,json=minContentLength,proto3" json:"min_content_length,omitempty"`
+	MaxContentLength int32                  `protobuf:"varint,4,opt,name=max_content_length,json=maxContentLength,proto3" json:"max_content_length,omitempty"`
+	MaxCommentLength int32                  `protobuf:"varint,5,opt,name=max_comment_length,json=maxCommentLength,proto3" json:"max_comment_length,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *PostingRules) Reset() {
+	*x = PostingRules{}
+	mi := &file_models_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostingRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostingRules) ProtoMessage() {}
+
+func (x *PostingRules) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostingRules.ProtoReflect.Descriptor instead.
+func (*PostingRules) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PostingRules) GetMinTitleLength() int32 {
+	if x != nil {
+		return x.MinTitleLength
+	}
+	return 0
+}
+
+func (x *PostingRules) GetMaxTitleLength() int32 {
+	if x != nil {
+		return x.MaxTitleLength
+	}
+	return 0
+}
+
+func (x *PostingRules) GetMinContentLength() int32 {
+	if x != nil {
+		return x.MinContentLength
+	}
+	return 0
+}
+
+func (x *PostingRules) GetMaxContentLength() int32 {
+	if x != nil {
+		return x.MaxContentLength
+	}
+	return 0
+}
+
+func (x *PostingRules) GetMaxCommentLength() int32 {
+	if x != nil {
+		return x.MaxCommentLength
+	}
+	return 0
+}
+
+type FlairTemplate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	Color         string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"` // hex colour, e.g. #ff4500
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlairTemplate) Reset() {
+	*x = FlairTemplate{}
+	mi := &file_models_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlairTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlairTemplate) ProtoMessage() {}
+
+func (x *FlairTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlairTemplate.ProtoReflect.Descriptor instead.
+func (*FlairTemplate) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FlairTemplate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FlairTemplate) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *FlairTemplate) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type Thread struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Id                  string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CommunityId         string                 `protobuf:"bytes,2,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Title               string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
+	Content             string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	Ups                 int32                  `protobuf:"varint,5,opt,name=ups,proto3" json:"ups,omitempty"`
+	Downs               int32                  `protobuf:"varint,6,opt,name=downs,proto3" json:"downs,omitempty"`
+	NumComments         int32                  `protobuf:"varint,7,opt,name=num_comments,json=numComments,proto3" json:"num_comments,omitempty"`
+	RemovalType         RemovalType            `protobuf:"varint,8,opt,name=removal_type,json=removalType,proto3,enum=models.RemovalType" json:"removal_type,omitempty"`
+	RemovalReason       string                 `protobuf:"bytes,9,opt,name=removal_reason,json=removalReason,proto3" json:"removal_reason,omitempty"`
+	RemovedAt           *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=removed_at,json=removedAt,proto3" json:"removed_at,omitempty"`
+	AuthorId            string                 `protobuf:"bytes,11,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAt           *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	NumReports          int32                  `protobuf:"varint,13,opt,name=num_reports,json=numReports,proto3" json:"num_reports,omitempty"`
+	Locked              bool                   `protobuf:"varint,14,opt,name=locked,proto3" json:"locked,omitempty"`
+	Flair               string                 `protobuf:"bytes,15,opt,name=flair,proto3" json:"flair,omitempty"`
+	SpamScore           float64                `protobuf:"fixed64,16,opt,name=spam_score,json=spamScore,proto3" json:"spam_score,omitempty"`
+	Pinned              bool                   `protobuf:"varint,17,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	Archived            bool                   `protobuf:"varint,18,opt,name=archived,proto3" json:"archived,omitempty"` // read-only because of its age, computed when the thread is read
+	FlairColor          string                 `protobuf:"bytes,19,opt,name=flair_color,json=flairColor,proto3" json:"flair_color,omitempty"`
+	Tags                []string               `protobuf:"bytes,20,rep,name=tags,proto3" json:"tags,omitempty"`
+	Kind                ThreadKind             `protobuf:"varint,21,opt,name=kind,proto3,enum=models.ThreadKind" json:"kind,omitempty"`
+	Url                 string                 `protobuf:"bytes,22,opt,name=url,proto3" json:"url,omitempty"`                                                              // link threads
+	Domain              string                 `protobuf:"bytes,23,opt,name=domain,proto3" json:"domain,omitempty"`                                                        // normalized domain of the url, e.g. example.com
+	AttachmentId        string                 `protobuf:"bytes,24,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`                        // image threads
+	Poll                *Poll                  `protobuf:"bytes,25,opt,name=poll,proto3" json:"poll,omitempty"`                                                            // poll threads
+	ThumbnailUrl        string                 `protobuf:"bytes,26,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`                        // image threads with a thumbnail
+	ContentHtml         string                 `protobuf:"bytes,27,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"`                           // content rendered from markdown and sanitized
+	EditedAt            *timestamppb.Timestamp `protobuf:"bytes,28,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`                                    // last edit of the title or content
+	Draft               bool                   `protobuf:"varint,29,opt,name=draft,proto3" json:"draft,omitempty"`                                                         // only visible to its author until published
+	PublishAt           *timestamppb.Timestamp `protobuf:"bytes,30,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`                                 // drafts scheduled to be published
+	CrosspostParentId   string                 `protobuf:"bytes,31,opt,name=crosspost_parent_id,json=crosspostParentId,proto3" json:"crosspost_parent_id,omitempty"`       // crossposts, the original thread
+	CrosspostParent     *CrosspostParent       `protobuf:"bytes,32,opt,name=crosspost_parent,json=crosspostParent,proto3" json:"crosspost_parent,omitempty"`               // computed when the crosspost is read
+	RedirectThreadId    string                 `protobuf:"bytes,33,opt,name=redirect_thread_id,json=redirectThreadId,proto3" json:"redirect_thread_id,omitempty"`          // redirects, the thread that was moved
+	RedirectCommunityId string                 `protobuf:"bytes,34,opt,name=redirect_community_id,json=redirectCommunityId,proto3" json:"redirect_community_id,omitempty"` // redirects, the community the thread was moved to
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *Thread) Reset() {
+	*x = Thread{}
+	mi := &file_models_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Thread) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Thread) ProtoMessage() {}
+
+func (x *Thread) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Thread.ProtoReflect.Descriptor instead.
+func (*Thread) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Thread) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Thread) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *Thread) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Thread) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Thread) GetUps() int32 {
+	if x != nil {
+		return x.Ups
+	}
+	return 0
+}
+
+func (x *Thread) GetDowns() int32 {
+	if x != nil {
+		return x.Downs
+	}
+	return 0
+}
+
+func (x *Thread) GetNumComments() int32 {
+	if x != nil {
+		return x.NumComments
+	}
+	return 0
+}
+
+func (x *Thread) GetRemovalType() RemovalType {
+	if x != nil {
+		return x.RemovalType
+	}
+	return RemovalType_NOT_REMOVED
+}
+
+func (x *Thread) GetRemovalReason() string {
+	if x != nil {
+		return x.RemovalReason
+	}
+	return ""
+}
+
+func (x *Thread) GetRemovedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemovedAt
+	}
+	return nil
+}
+
+func (x *Thread) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Thread) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Thread) GetNumReports() int32 {
+	if x != nil {
+		return x.NumReports
+	}
+	return 0
+}
+
+func (x *Thread) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+func (x *Thread) GetFlair() string {
+	if x != nil {
+		return x.Flair
+	}
+	return ""
+}
+
+func (x *Thread) GetSpamScore() float64 {
+	if x != nil {
+		return x.SpamScore
+	}
+	return 0
+}
+
+func (x *Thread) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Thread) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Thread) GetFlairColor() string {
+	if x != nil {
+		return x.FlairColor
+	}
+	return ""
+}
+
+func (x *Thread) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Thread) GetKind() ThreadKind {
+	if x != nil {
+		return x.Kind
+	}
+	return ThreadKind_TEXT
+}
+
+func (x *Thread) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Thread) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Thread) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *Thread) GetPoll() *Poll {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+func (x *Thread) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Thread) GetContentHtml() string {
+	if x != nil {
+		return x.ContentHtml
+	}
+	return ""
+}
+
+func (x *Thread) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+func (x *Thread) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *Thread) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+func (x *Thread) GetCrosspostParentId() string {
+	if x != nil {
+		return x.CrosspostParentId
+	}
+	return ""
+}
+
+func (x *Thread) GetCrosspostParent() *CrosspostParent {
+	if x != nil {
+		return x.CrosspostParent
+	}
+	return nil
+}
+
+func (x *Thread) GetRedirectThreadId() string {
+	if x != nil {
+		return x.RedirectThreadId
+	}
+	return ""
+}
+
+func (x *Thread) GetRedirectCommunityId() string {
+	if x != nil {
+		return x.RedirectCommunityId
+	}
+	return ""
+}
+
+// the original thread of a crosspost
+type CrosspostParent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	CommunityId   string                 `protobuf:"bytes,2,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	CommunityName string                 `protobuf:"bytes,3,opt,name=community_name,json=communityName,proto3" json:"community_name,omitempty"`
+	Ups           int32                  `protobuf:"varint,4,opt,name=ups,proto3" json:"ups,omitempty"`
+	Downs         int32                  `protobuf:"varint,5,opt,name=downs,proto3" json:"downs,omitempty"`
+	Removed       bool                   `protobuf:"varint,6,opt,name=removed,proto3" json:"removed,omitempty"` // the original was deleted or removed, only its id is kept
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrosspostParent) Reset() {
+	*x = CrosspostParent{}
+	mi := &file_models_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrosspostParent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrosspostParent) ProtoMessage() {}
+
+func (x *CrosspostParent) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrosspostParent.ProtoReflect.Descriptor instead.
+func (*CrosspostParent) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CrosspostParent) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *CrosspostParent) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *CrosspostParent) GetCommunityName() string {
+	if x != nil {
+		return x.CommunityName
+	}
+	return ""
+}
+
+func (x *CrosspostParent) GetUps() int32 {
+	if x != nil {
+		return x.Ups
+	}
+	return 0
+}
+
+func (x *CrosspostParent) GetDowns() int32 {
+	if x != nil {
+		return x.Downs
+	}
+	return 0
+}
+
+func (x *CrosspostParent) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+type Poll struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Options        []*PollOption          `protobuf:"bytes,1,rep,name=options,proto3" json:"options,omitempty"`
+	EndsAt         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	MultipleChoice bool                   `protobuf:"varint,3,opt,name=multiple_choice,json=multipleChoice,proto3" json:"multiple_choice,omitempty"`
+	HideResults    bool                   `protobuf:"varint,4,opt,name=hide_results,json=hideResults,proto3" json:"hide_results,omitempty"` // vote counts are hidden until the poll is closed
+	Closed         bool                   `protobuf:"varint,5,opt,name=closed,proto3" json:"closed,omitempty"`                              // computed when the thread is read
+	NumVoters      int32                  `protobuf:"varint,6,opt,name=num_voters,json=numVoters,proto3" json:"num_voters,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Poll) Reset() {
+	*x = Poll{}
+	mi := &file_models_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Poll) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Poll) ProtoMessage() {}
+
+func (x *Poll) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Poll.ProtoReflect.Descriptor instead.
+func (*Poll) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Poll) GetOptions() []*PollOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *Poll) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Poll) GetMultipleChoice() bool {
+	if x != nil {
+		return x.MultipleChoice
+	}
+	return false
+}
+
+func (x *Poll) GetHideResults() bool {
+	if x != nil {
+		return x.HideResults
+	}
+	return false
+}
+
+func (x *Poll) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Poll) GetNumVoters() int32 {
+	if x != nil {
+		return x.NumVoters
+	}
+	return 0
+}
+
+type PollOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Text          string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	NumVotes      int32                  `protobuf:"varint,3,opt,name=num_votes,json=numVotes,proto3" json:"num_votes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollOption) Reset() {
+	*x = PollOption{}
+	mi := &file_models_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollOption) ProtoMessage() {}
+
+func (x *PollOption) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollOption.ProtoReflect.Descriptor instead.
+func (*PollOption) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PollOption) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PollOption) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *PollOption) GetNumVotes() int32 {
+	if x != nil {
+		return x.NumVotes
+	}
+	return 0
+}
+
+// wraps tags in update requests to tell clearing them apart from leaving them unchanged
+type TagList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TagList) Reset() {
+	*x = TagList{}
+	mi := &file_models_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TagList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TagList) ProtoMessage() {}
+
+func (x *TagList) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TagList.ProtoReflect.Descriptor instead.
+func (*TagList) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TagList) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type Comment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Ups           int32                  `protobuf:"varint,3,opt,name=ups,proto3" json:"ups,omitempty"`
+	Downs         int32                  `protobuf:"varint,4,opt,name=downs,proto3" json:"downs,omitempty"`
+	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
+	ParentType    CommentParentType      `protobuf:"varint,6,opt,name=parent_type,json=parentType,proto3,enum=models.CommentParentType" json:"parent_type,omitempty"`
+	NumComments   int32                  `protobuf:"varint,8,opt,name=num_comments,json=numComments,proto3" json:"num_comments,omitempty"`
+	RemovalType   RemovalType            `protobuf:"varint,9,opt,name=removal_type,json=removalType,proto3,enum=models.RemovalType" json:"removal_type,omitempty"`
+	RemovalReason string                 `protobuf:"bytes,10,opt,name=removal_reason,json=removalReason,proto3" json:"removal_reason,omitempty"`
+	RemovedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=removed_at,json=removedAt,proto3" json:"removed_at,omitempty"`
+	AuthorId      string                 `protobuf:"bytes,12,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	NumReports    int32                  `protobuf:"varint,14,opt,name=num_reports,json=numReports,proto3" json:"num_reports,omitempty"`
+	SpamScore     float64                `protobuf:"fixed64,15,opt,name=spam_score,json=spamScore,proto3" json:"spam_score,omitempty"`
+	AttachmentIds []string               `protobuf:"bytes,16,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	ContentHtml   string                 `protobuf:"bytes,17,opt,name=content_html,json=contentHtml,proto3" json:"content_html,omitempty"` // content rendered from markdown and sanitized
+	EditedAt      *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=edited_at,json=editedAt,proto3" json:"edited_at,omitempty"`          // last edit of the content
+	AncestorIds   []string               `protobuf:"bytes,19,rep,name=ancestor_ids,json=ancestorIds,proto3" json:"ancestor_ids,omitempty"` // comments the comment replies to, from the top-level comment down
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Comment) Reset() {
+	*x = Comment{}
+	mi := &file_models_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Comment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Comment) ProtoMessage() {}
+
+func (x *Comment) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
+func (*Comment) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Comment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Comment) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Comment) GetUps() int32 {
+	if x != nil {
+		return x.Ups
+	}
+	return 0
+}
+
+func (x *Comment) GetDowns() int32 {
+	if x != nil {
+		return x.Downs
+	}
+	return 0
+}
+
+func (x *Comment) GetParentId() string {
+	if x != nil {
+		return x.ParentId
+	}
+	return ""
+}
+
+func (x *Comment) GetParentType() CommentParentType {
+	if x != nil {
+		return x.ParentType
+	}
+	return CommentParentType_THREAD
+}
+
+func (x *Comment) GetNumComments() int32 {
+	if x != nil {
+		return x.NumComments
+	}
+	return 0
+}
+
+func (x *Comment) GetRemovalType() RemovalType {
+	if x != nil {
+		return x.RemovalType
+	}
+	return RemovalType_NOT_REMOVED
+}
+
+func (x *Comment) GetRemovalReason() string {
+	if x != nil {
+		return x.RemovalReason
+	}
+	return ""
+}
+
+func (x *Comment) GetRemovedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RemovedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Comment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetNumReports() int32 {
+	if x != nil {
+		return x.NumReports
+	}
+	return 0
+}
+
+func (x *Comment) GetSpamScore() float64 {
+	if x != nil {
+		return x.SpamScore
+	}
+	return 0
+}
+
+func (x *Comment) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *Comment) GetContentHtml() string {
+	if x != nil {
+		return x.ContentHtml
+	}
+	return ""
+}
+
+func (x *Comment) GetEditedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EditedAt
+	}
+	return nil
+}
+
+func (x *Comment) GetAncestorIds() []string {
+	if x != nil {
+		return x.AncestorIds
+	}
+	return nil
+}
+
+type Attachment struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Id                   string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename             string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	ContentType          string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // sniffed from the content
+	Size                 int64                  `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Sha256               string                 `protobuf:"bytes,5,opt,name=sha256,proto3" json:"sha256,omitempty"` // hex digest, the content is stored once per digest
+	AuthorId             string                 `protobuf:"bytes,6,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Width                int32                  `protobuf:"varint,8,opt,name=width,proto3" json:"width,omitempty"` // images with a thumbnail
+	Height               int32                  `protobuf:"varint,9,opt,name=height,proto3" json:"height,omitempty"`
+	ThumbnailSha256      string                 `protobuf:"bytes,10,opt,name=thumbnail_sha256,json=thumbnailSha256,proto3" json:"thumbnail_sha256,omitempty"` // empty when no thumbnail could be generated
+	ThumbnailContentType string                 `protobuf:"bytes,11,opt,name=thumbnail_content_type,json=thumbnailContentType,proto3" json:"thumbnail_content_type,omitempty"`
+	ThumbnailSize        int64                  `protobuf:"varint,12,opt,name=thumbnail_size,json=thumbnailSize,proto3" json:"thumbnail_size,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_models_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *Attachment) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *Attachment) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *Attachment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Attachment) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Attachment) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Attachment) GetThumbnailSha256() string {
+	if x != nil {
+		return x.ThumbnailSha256
+	}
+	return ""
+}
+
+func (x *Attachment) GetThumbnailContentType() string {
+	if x != nil {
+		return x.ThumbnailContentType
+	}
+	return ""
+}
+
+func (x *Attachment) GetThumbnailSize() int64 {
+	if x != nil {
+		return x.ThumbnailSize
+	}
+	return 0
+}
+
+// a version of the text of a thread or comment, recorded when it is edited
+type Revision struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	NumThreads    int32                  `protobuf:"varint,3,opt,name=num_threads,json=numThreads,proto3" json:"num_threads,omitempty"`
+	Version       int32                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"` // 1 is the text before the first edit
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`      // threads only
+	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	EditorId      string                 `protobuf:"bytes,4,opt,name=editor_id,json=editorId,proto3" json:"editor_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Diff          string                 `protobuf:"bytes,6,opt,name=diff,proto3" json:"diff,omitempty"` // unified diff of the content from the previous version
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Community) Reset() {
-	*x = Community{}
-	mi := &file_models_proto_msgTypes[0]
+func (x *Revision) Reset() {
+	*x = Revision{}
+	mi := &file_models_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Community) String() string {
+func (x *Revision) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Community) ProtoMessage() {}
+func (*Revision) ProtoMessage() {}
 
-func (x *Community) ProtoReflect() protoreflect.Message {
-	mi := &file_models_proto_msgTypes[0]
+func (x *Revision) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -101,60 +1512,82 @@ func (x *Community) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Community.ProtoReflect.Descriptor instead.
-func (*Community) Descriptor() ([]byte, []int) {
-	return file_models_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use Revision.ProtoReflect.Descriptor instead.
+func (*Revision) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Community) GetId() string {
+func (x *Revision) GetVersion() int32 {
 	if x != nil {
-		return x.Id
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Revision) GetTitle() string {
+	if x != nil {
+		return x.Title
 	}
 	return ""
 }
 
-func (x *Community) GetName() string {
+func (x *Revision) GetContent() string {
 	if x != nil {
-		return x.Name
+		return x.Content
 	}
 	return ""
 }
 
-func (x *Community) GetNumThreads() int32 {
+func (x *Revision) GetEditorId() string {
 	if x != nil {
-		return x.NumThreads
+		return x.EditorId
 	}
-	return 0
+	return ""
 }
 
-type Thread struct {
+func (x *Revision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Revision) GetDiff() string {
+	if x != nil {
+		return x.Diff
+	}
+	return ""
+}
+
+type ModQueueItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	CommunityId   string                 `protobuf:"bytes,2,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
-	Title         string                 `protobuf:"bytes,3,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
-	Ups           int32                  `protobuf:"varint,5,opt,name=ups,proto3" json:"ups,omitempty"`
-	Downs         int32                  `protobuf:"varint,6,opt,name=downs,proto3" json:"downs,omitempty"`
-	NumComments   int32                  `protobuf:"varint,7,opt,name=num_comments,json=numComments,proto3" json:"num_comments,omitempty"`
+	ThreadId      string                 `protobuf:"bytes,3,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	CommentId     string                 `protobuf:"bytes,4,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"` // empty when the item is about a thread
+	Rule          string                 `protobuf:"bytes,5,opt,name=rule,proto3" json:"rule,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`
+	DryRun        bool                   `protobuf:"varint,7,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Thread) Reset() {
-	*x = Thread{}
-	mi := &file_models_proto_msgTypes[1]
+func (x *ModQueueItem) Reset() {
+	*x = ModQueueItem{}
+	mi := &file_models_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Thread) String() string {
+func (x *ModQueueItem) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Thread) ProtoMessage() {}
+func (*ModQueueItem) ProtoMessage() {}
 
-func (x *Thread) ProtoReflect() protoreflect.Message {
-	mi := &file_models_proto_msgTypes[1]
+func (x *ModQueueItem) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -165,88 +1598,96 @@ func (x *Thread) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Thread.ProtoReflect.Descriptor instead.
-func (*Thread) Descriptor() ([]byte, []int) {
-	return file_models_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ModQueueItem.ProtoReflect.Descriptor instead.
+func (*ModQueueItem) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Thread) GetId() string {
+func (x *ModQueueItem) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Thread) GetCommunityId() string {
+func (x *ModQueueItem) GetCommunityId() string {
 	if x != nil {
 		return x.CommunityId
 	}
 	return ""
 }
 
-func (x *Thread) GetTitle() string {
+func (x *ModQueueItem) GetThreadId() string {
 	if x != nil {
-		return x.Title
+		return x.ThreadId
 	}
 	return ""
 }
 
-func (x *Thread) GetContent() string {
+func (x *ModQueueItem) GetCommentId() string {
 	if x != nil {
-		return x.Content
+		return x.CommentId
 	}
 	return ""
 }
 
-func (x *Thread) GetUps() int32 {
+func (x *ModQueueItem) GetRule() string {
 	if x != nil {
-		return x.Ups
+		return x.Rule
 	}
-	return 0
+	return ""
 }
 
-func (x *Thread) GetDowns() int32 {
+func (x *ModQueueItem) GetReason() string {
 	if x != nil {
-		return x.Downs
+		return x.Reason
 	}
-	return 0
+	return ""
 }
 
-func (x *Thread) GetNumComments() int32 {
+func (x *ModQueueItem) GetDryRun() bool {
 	if x != nil {
-		return x.NumComments
+		return x.DryRun
 	}
-	return 0
+	return false
 }
 
-type Comment struct {
+func (x *ModQueueItem) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// Event is a change written to the outbox of db-service in the same write as the change
+type Event struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
-	Ups           int32                  `protobuf:"varint,3,opt,name=ups,proto3" json:"ups,omitempty"`
-	Downs         int32                  `protobuf:"varint,4,opt,name=downs,proto3" json:"downs,omitempty"`
-	ParentId      string                 `protobuf:"bytes,5,opt,name=parent_id,json=parentId,proto3" json:"parent_id,omitempty"`
-	ParentType    CommentParentType      `protobuf:"varint,6,opt,name=parent_type,json=parentType,proto3,enum=models.CommentParentType" json:"parent_type,omitempty"`
-	NumComments   int32                  `protobuf:"varint,8,opt,name=num_comments,json=numComments,proto3" json:"num_comments,omitempty"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"` // position in the outbox, offsets increase in the order the writes committed
+	Type          EventType              `protobuf:"varint,2,opt,name=type,proto3,enum=models.EventType" json:"type,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CommunityId   string                 `protobuf:"bytes,4,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"` // set by community events, and by THREAD_MOVED to the community the thread moved to
+	ThreadId      string                 `protobuf:"bytes,5,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`          // set by thread events and by votes on a thread
+	CommentId     string                 `protobuf:"bytes,6,opt,name=comment_id,json=commentId,proto3" json:"comment_id,omitempty"`       // set by comment events and by votes on a comment
+	Vote          int32                  `protobuf:"varint,7,opt,name=vote,proto3" json:"vote,omitempty"`                                 // 1 for an upvote and -1 for a downvote, set by VOTE_CAST
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Comment) Reset() {
-	*x = Comment{}
-	mi := &file_models_proto_msgTypes[2]
+func (x *Event) Reset() {
+	*x = Event{}
+	mi := &file_models_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Comment) String() string {
+func (x *Event) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Comment) ProtoMessage() {}
+func (*Event) ProtoMessage() {}
 
-func (x *Comment) ProtoReflect() protoreflect.Message {
-	mi := &file_models_proto_msgTypes[2]
+func (x *Event) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -257,70 +1698,215 @@ func (x *Comment) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Comment.ProtoReflect.Descriptor instead.
-func (*Comment) Descriptor() ([]byte, []int) {
-	return file_models_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use Event.ProtoReflect.Descriptor instead.
+func (*Event) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *Comment) GetId() string {
+func (x *Event) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *Event) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_UNSPECIFIED
+}
+
+func (x *Event) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Event) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+func (x *Event) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *Event) GetCommentId() string {
+	if x != nil {
+		return x.CommentId
+	}
+	return ""
+}
+
+func (x *Event) GetVote() int32 {
+	if x != nil {
+		return x.Vote
+	}
+	return 0
+}
+
+// Job is a background operation of db-service, which resumes after a crash until it succeeds or fails
+type Job struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Id                 string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type               JobType                `protobuf:"varint,2,opt,name=type,proto3,enum=models.JobType" json:"type,omitempty"`
+	TargetId           string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"` // community or thread the job deletes
+	State              JobState               `protobuf:"varint,4,opt,name=state,proto3,enum=models.JobState" json:"state,omitempty"`
+	NumThreadsDeleted  int32                  `protobuf:"varint,5,opt,name=num_threads_deleted,json=numThreadsDeleted,proto3" json:"num_threads_deleted,omitempty"`
+	NumCommentsDeleted int32                  `protobuf:"varint,6,opt,name=num_comments_deleted,json=numCommentsDeleted,proto3" json:"num_comments_deleted,omitempty"`
+	Attempts           int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"` // failed runs, the job fails for good after too many
+	Error              string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`        // error of the last failed run
+	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LeaseExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=lease_expires_at,json=leaseExpiresAt,proto3" json:"lease_expires_at,omitempty"` // no runner claims the job before, unset for new jobs
+	LeaseId            string                 `protobuf:"bytes,12,opt,name=lease_id,json=leaseId,proto3" json:"lease_id,omitempty"`                        // lease of the runner that claimed the job last, only it updates the job
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Job) Reset() {
+	*x = Job{}
+	mi := &file_models_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Job) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Job) ProtoMessage() {}
+
+func (x *Job) ProtoReflect() protoreflect.Message {
+	mi := &file_models_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Job.ProtoReflect.Descriptor instead.
+func (*Job) Descriptor() ([]byte, []int) {
+	return file_models_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Job) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Comment) GetContent() string {
+func (x *Job) GetType() JobType {
 	if x != nil {
-		return x.Content
+		return x.Type
+	}
+	return JobType_DELETE_COMMUNITY
+}
+
+func (x *Job) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
 	}
 	return ""
 }
 
-func (x *Comment) GetUps() int32 {
+func (x *Job) GetState() JobState {
 	if x != nil {
-		return x.Ups
+		return x.State
+	}
+	return JobState_JOB_PENDING
+}
+
+func (x *Job) GetNumThreadsDeleted() int32 {
+	if x != nil {
+		return x.NumThreadsDeleted
 	}
 	return 0
 }
 
-func (x *Comment) GetDowns() int32 {
+func (x *Job) GetNumCommentsDeleted() int32 {
 	if x != nil {
-		return x.Downs
+		return x.NumCommentsDeleted
 	}
 	return 0
 }
 
-func (x *Comment) GetParentId() string {
+func (x *Job) GetAttempts() int32 {
 	if x != nil {
-		return x.ParentId
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Job) GetError() string {
+	if x != nil {
+		return x.Error
 	}
 	return ""
 }
 
-func (x *Comment) GetParentType() CommentParentType {
+func (x *Job) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.ParentType
+		return x.CreatedAt
 	}
-	return CommentParentType_THREAD
+	return nil
 }
 
-func (x *Comment) GetNumComments() int32 {
+func (x *Job) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.NumComments
+		return x.UpdatedAt
 	}
-	return 0
+	return nil
+}
+
+func (x *Job) GetLeaseExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LeaseExpiresAt
+	}
+	return nil
+}
+
+func (x *Job) GetLeaseId() string {
+	if x != nil {
+		return x.LeaseId
+	}
+	return ""
 }
 
 var File_models_proto protoreflect.FileDescriptor
 
 const file_models_proto_rawDesc = "" +
 	"\n" +
-	"\fmodels.proto\x12\x06models\"P\n" +
+	"\fmodels.proto\x12\x06models\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcb\x01\n" +
 	"\tCommunity\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1f\n" +
 	"\vnum_threads\x18\x03 \x01(\x05R\n" +
-	"numThreads\"\xb6\x01\n" +
+	"numThreads\x12>\n" +
+	"\x0fflair_templates\x18\x04 \x03(\v2\x15.models.FlairTemplateR\x0eflairTemplates\x129\n" +
+	"\rposting_rules\x18\x05 \x01(\v2\x14.models.PostingRulesR\fpostingRules\"\xec\x01\n" +
+	"\fPostingRules\x12(\n" +
+	"\x10min_title_length\x18\x01 \x01(\x05R\x0eminTitleLength\x12(\n" +
+	"\x10max_title_length\x18\x02 \x01(\x05R\x0emaxTitleLength\x12,\n" +
+	"\x12min_content_length\x18\x03 \x01(\x05R\x10minContentLength\x12,\n" +
+	"\x12max_content_length\x18\x04 \x01(\x05R\x10maxContentLength\x12,\n" +
+	"\x12max_comment_length\x18\x05 \x01(\x05R\x10maxCommentLength\"I\n" +
+	"\rFlairTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\"\xc0\t\n" +
 	"\x06Thread\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
 	"\fcommunity_id\x18\x02 \x01(\tR\vcommunityId\x12\x14\n" +
@@ -328,7 +1914,63 @@ const file_models_proto_rawDesc = "" +
 	"\acontent\x18\x04 \x01(\tR\acontent\x12\x10\n" +
 	"\x03ups\x18\x05 \x01(\x05R\x03ups\x12\x14\n" +
 	"\x05downs\x18\x06 \x01(\x05R\x05downs\x12!\n" +
-	"\fnum_comments\x18\a \x01(\x05R\vnumComments\"\xd7\x01\n" +
+	"\fnum_comments\x18\a \x01(\x05R\vnumComments\x126\n" +
+	"\fremoval_type\x18\b \x01(\x0e2\x13.models.RemovalTypeR\vremovalType\x12%\n" +
+	"\x0eremoval_reason\x18\t \x01(\tR\rremovalReason\x129\n" +
+	"\n" +
+	"removed_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tremovedAt\x12\x1b\n" +
+	"\tauthor_id\x18\v \x01(\tR\bauthorId\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vnum_reports\x18\r \x01(\x05R\n" +
+	"numReports\x12\x16\n" +
+	"\x06locked\x18\x0e \x01(\bR\x06locked\x12\x14\n" +
+	"\x05flair\x18\x0f \x01(\tR\x05flair\x12\x1d\n" +
+	"\n" +
+	"spam_score\x18\x10 \x01(\x01R\tspamScore\x12\x16\n" +
+	"\x06pinned\x18\x11 \x01(\bR\x06pinned\x12\x1a\n" +
+	"\barchived\x18\x12 \x01(\bR\barchived\x12\x1f\n" +
+	"\vflair_color\x18\x13 \x01(\tR\n" +
+	"flairColor\x12\x12\n" +
+	"\x04tags\x18\x14 \x03(\tR\x04tags\x12&\n" +
+	"\x04kind\x18\x15 \x01(\x0e2\x12.models.ThreadKindR\x04kind\x12\x10\n" +
+	"\x03url\x18\x16 \x01(\tR\x03url\x12\x16\n" +
+	"\x06domain\x18\x17 \x01(\tR\x06domain\x12#\n" +
+	"\rattachment_id\x18\x18 \x01(\tR\fattachmentId\x12 \n" +
+	"\x04poll\x18\x19 \x01(\v2\f.models.PollR\x04poll\x12#\n" +
+	"\rthumbnail_url\x18\x1a \x01(\tR\fthumbnailUrl\x12!\n" +
+	"\fcontent_html\x18\x1b \x01(\tR\vcontentHtml\x127\n" +
+	"\tedited_at\x18\x1c \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\x12\x14\n" +
+	"\x05draft\x18\x1d \x01(\bR\x05draft\x129\n" +
+	"\n" +
+	"publish_at\x18\x1e \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\x12.\n" +
+	"\x13crosspost_parent_id\x18\x1f \x01(\tR\x11crosspostParentId\x12B\n" +
+	"\x10crosspost_parent\x18  \x01(\v2\x17.models.CrosspostParentR\x0fcrosspostParent\x12,\n" +
+	"\x12redirect_thread_id\x18! \x01(\tR\x10redirectThreadId\x122\n" +
+	"\x15redirect_community_id\x18\" \x01(\tR\x13redirectCommunityId\"\xba\x01\n" +
+	"\x0fCrosspostParent\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\x12!\n" +
+	"\fcommunity_id\x18\x02 \x01(\tR\vcommunityId\x12%\n" +
+	"\x0ecommunity_name\x18\x03 \x01(\tR\rcommunityName\x12\x10\n" +
+	"\x03ups\x18\x04 \x01(\x05R\x03ups\x12\x14\n" +
+	"\x05downs\x18\x05 \x01(\x05R\x05downs\x12\x18\n" +
+	"\aremoved\x18\x06 \x01(\bR\aremoved\"\xec\x01\n" +
+	"\x04Poll\x12,\n" +
+	"\aoptions\x18\x01 \x03(\v2\x12.models.PollOptionR\aoptions\x123\n" +
+	"\aends_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12'\n" +
+	"\x0fmultiple_choice\x18\x03 \x01(\bR\x0emultipleChoice\x12!\n" +
+	"\fhide_results\x18\x04 \x01(\bR\vhideResults\x12\x16\n" +
+	"\x06closed\x18\x05 \x01(\bR\x06closed\x12\x1d\n" +
+	"\n" +
+	"num_voters\x18\x06 \x01(\x05R\tnumVoters\"M\n" +
+	"\n" +
+	"PollOption\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1b\n" +
+	"\tnum_votes\x18\x03 \x01(\x05R\bnumVotes\"\x1d\n" +
+	"\aTagList\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"\xaf\x05\n" +
 	"\aComment\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x10\n" +
@@ -337,11 +1979,129 @@ const file_models_proto_rawDesc = "" +
 	"\tparent_id\x18\x05 \x01(\tR\bparentId\x12:\n" +
 	"\vparent_type\x18\x06 \x01(\x0e2\x19.models.CommentParentTypeR\n" +
 	"parentType\x12!\n" +
-	"\fnum_comments\x18\b \x01(\x05R\vnumComments*,\n" +
+	"\fnum_comments\x18\b \x01(\x05R\vnumComments\x126\n" +
+	"\fremoval_type\x18\t \x01(\x0e2\x13.models.RemovalTypeR\vremovalType\x12%\n" +
+	"\x0eremoval_reason\x18\n" +
+	" \x01(\tR\rremovalReason\x129\n" +
+	"\n" +
+	"removed_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tremovedAt\x12\x1b\n" +
+	"\tauthor_id\x18\f \x01(\tR\bauthorId\x129\n" +
+	"\n" +
+	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1f\n" +
+	"\vnum_reports\x18\x0e \x01(\x05R\n" +
+	"numReports\x12\x1d\n" +
+	"\n" +
+	"spam_score\x18\x0f \x01(\x01R\tspamScore\x12%\n" +
+	"\x0eattachment_ids\x18\x10 \x03(\tR\rattachmentIds\x12!\n" +
+	"\fcontent_html\x18\x11 \x01(\tR\vcontentHtml\x127\n" +
+	"\tedited_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\beditedAt\x12!\n" +
+	"\fancestor_ids\x18\x13 \x03(\tR\vancestorIds\"\x95\x03\n" +
+	"\n" +
+	"Attachment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x05 \x01(\tR\x06sha256\x12\x1b\n" +
+	"\tauthor_id\x18\x06 \x01(\tR\bauthorId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05width\x18\b \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\t \x01(\x05R\x06height\x12)\n" +
+	"\x10thumbnail_sha256\x18\n" +
+	" \x01(\tR\x0fthumbnailSha256\x124\n" +
+	"\x16thumbnail_content_type\x18\v \x01(\tR\x14thumbnailContentType\x12%\n" +
+	"\x0ethumbnail_size\x18\f \x01(\x03R\rthumbnailSize\"\xc0\x01\n" +
+	"\bRevision\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x05R\aversion\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1b\n" +
+	"\teditor_id\x18\x04 \x01(\tR\beditorId\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x12\n" +
+	"\x04diff\x18\x06 \x01(\tR\x04diff\"\xfd\x01\n" +
+	"\fModQueueItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fcommunity_id\x18\x02 \x01(\tR\vcommunityId\x12\x1b\n" +
+	"\tthread_id\x18\x03 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x04 \x01(\tR\tcommentId\x12\x12\n" +
+	"\x04rule\x18\x05 \x01(\tR\x04rule\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x17\n" +
+	"\adry_run\x18\a \x01(\bR\x06dryRun\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xf4\x01\n" +
+	"\x05Event\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12%\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x11.models.EventTypeR\x04type\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12!\n" +
+	"\fcommunity_id\x18\x04 \x01(\tR\vcommunityId\x12\x1b\n" +
+	"\tthread_id\x18\x05 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"comment_id\x18\x06 \x01(\tR\tcommentId\x12\x12\n" +
+	"\x04vote\x18\a \x01(\x05R\x04vote\"\xea\x03\n" +
+	"\x03Job\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x0f.models.JobTypeR\x04type\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12&\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x10.models.JobStateR\x05state\x12.\n" +
+	"\x13num_threads_deleted\x18\x05 \x01(\x05R\x11numThreadsDeleted\x120\n" +
+	"\x14num_comments_deleted\x18\x06 \x01(\x05R\x12numCommentsDeleted\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12D\n" +
+	"\x10lease_expires_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0eleaseExpiresAt\x12\x19\n" +
+	"\blease_id\x18\f \x01(\tR\aleaseId*,\n" +
 	"\x11CommentParentType\x12\n" +
 	"\n" +
 	"\x06THREAD\x10\x00\x12\v\n" +
-	"\aCOMMENT\x10\x01B\x16Z\x14gen/models/pb;modelsb\x06proto3"
+	"\aCOMMENT\x10\x01*R\n" +
+	"\n" +
+	"ThreadKind\x12\b\n" +
+	"\x04TEXT\x10\x00\x12\b\n" +
+	"\x04LINK\x10\x01\x12\t\n" +
+	"\x05IMAGE\x10\x02\x12\b\n" +
+	"\x04POLL\x10\x03\x12\r\n" +
+	"\tCROSSPOST\x10\x04\x12\f\n" +
+	"\bREDIRECT\x10\x05*8\n" +
+	"\vRemovalType\x12\x0f\n" +
+	"\vNOT_REMOVED\x10\x00\x12\v\n" +
+	"\aDELETED\x10\x01\x12\v\n" +
+	"\aREMOVED\x10\x02*\xea\x02\n" +
+	"\tEventType\x12\x15\n" +
+	"\x11EVENT_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11COMMUNITY_CREATED\x10\x01\x12\x15\n" +
+	"\x11COMMUNITY_DELETED\x10\x02\x12\x12\n" +
+	"\x0eTHREAD_CREATED\x10\x03\x12\x14\n" +
+	"\x10THREAD_PUBLISHED\x10\x04\x12\x12\n" +
+	"\x0eTHREAD_UPDATED\x10\x05\x12\x10\n" +
+	"\fTHREAD_MOVED\x10\x06\x12\x12\n" +
+	"\x0eTHREAD_REMOVED\x10\a\x12\x13\n" +
+	"\x0fTHREAD_RESTORED\x10\b\x12\x12\n" +
+	"\x0eTHREAD_DELETED\x10\t\x12\x13\n" +
+	"\x0fCOMMENT_CREATED\x10\n" +
+	"\x12\x13\n" +
+	"\x0fCOMMENT_UPDATED\x10\v\x12\x13\n" +
+	"\x0fCOMMENT_REMOVED\x10\f\x12\x14\n" +
+	"\x10COMMENT_RESTORED\x10\r\x12\x13\n" +
+	"\x0fCOMMENT_DELETED\x10\x0e\x12\r\n" +
+	"\tVOTE_CAST\x10\x0f\x12\x12\n" +
+	"\x0ePOLL_VOTE_CAST\x10\x10*J\n" +
+	"\aJobType\x12\x14\n" +
+	"\x10DELETE_COMMUNITY\x10\x00\x12\x11\n" +
+	"\rDELETE_THREAD\x10\x01\x12\x16\n" +
+	"\x12RECONCILE_COUNTERS\x10\x02*O\n" +
+	"\bJobState\x12\x0f\n" +
+	"\vJOB_PENDING\x10\x00\x12\x0f\n" +
+	"\vJOB_RUNNING\x10\x01\x12\x11\n" +
+	"\rJOB_SUCCEEDED\x10\x02\x12\x0e\n" +
+	"\n" +
+	"JOB_FAILED\x10\x03B\x16Z\x14gen/models/pb;modelsb\x06proto3"
 
 var (
 	file_models_proto_rawDescOnce sync.Once
@@ -355,21 +2115,64 @@ func file_models_proto_rawDescGZIP() []byte {
 	return file_models_proto_rawDescData
 }
 
-var file_models_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_models_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_models_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_models_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_models_proto_goTypes = []any{
-	(CommentParentType)(0), // 0: models.CommentParentType
-	(*Community)(nil),      // 1: models.Community
-	(*Thread)(nil),         // 2: models.Thread
-	(*Comment)(nil),        // 3: models.Comment
+	(CommentParentType)(0),        // 0: models.CommentParentType
+	(ThreadKind)(0),               // 1: models.ThreadKind
+	(RemovalType)(0),              // 2: models.RemovalType
+	(EventType)(0),                // 3: models.EventType
+	(JobType)(0),                  // 4: models.JobType
+	(JobState)(0),                 // 5: models.JobState
+	(*Community)(nil),             // 6: models.Community
+	(*PostingRules)(nil),          // 7: models.PostingRules
+	(*FlairTemplate)(nil),         // 8: models.FlairTemplate
+	(*Thread)(nil),                // 9: models.Thread
+	(*CrosspostParent)(nil),       // 10: models.CrosspostParent
+	(*Poll)(nil),                  // 11: models.Poll
+	(*PollOption)(nil),            // 12: models.PollOption
+	(*TagList)(nil),               // 13: models.TagList
+	(*Comment)(nil),               // 14: models.Comment
+	(*Attachment)(nil),            // 15: models.Attachment
+	(*Revision)(nil),              // 16: models.Revision
+	(*ModQueueItem)(nil),          // 17: models.ModQueueItem
+	(*Event)(nil),                 // 18: models.Event
+	(*Job)(nil),                   // 19: models.Job
+	(*timestamppb.Timestamp)(nil), // 20: google.protobuf.Timestamp
 }
 var file_models_proto_depIdxs = []int32{
-	0, // 0: models.Comment.parent_type:type_name -> models.CommentParentType
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	8,  // 0: models.Community.flair_templates:type_name -> models.FlairTemplate
+	7,  // 1: models.Community.posting_rules:type_name -> models.PostingRules
+	2,  // 2: models.Thread.removal_type:type_name -> models.RemovalType
+	20, // 3: models.Thread.removed_at:type_name -> google.protobuf.Timestamp
+	20, // 4: models.Thread.created_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: models.Thread.kind:type_name -> models.ThreadKind
+	11, // 6: models.Thread.poll:type_name -> models.Poll
+	20, // 7: models.Thread.edited_at:type_name -> google.protobuf.Timestamp
+	20, // 8: models.Thread.publish_at:type_name -> google.protobuf.Timestamp
+	10, // 9: models.Thread.crosspost_parent:type_name -> models.CrosspostParent
+	12, // 10: models.Poll.options:type_name -> models.PollOption
+	20, // 11: models.Poll.ends_at:type_name -> google.protobuf.Timestamp
+	0,  // 12: models.Comment.parent_type:type_name -> models.CommentParentType
+	2,  // 13: models.Comment.removal_type:type_name -> models.RemovalType
+	20, // 14: models.Comment.removed_at:type_name -> google.protobuf.Timestamp
+	20, // 15: models.Comment.created_at:type_name -> google.protobuf.Timestamp
+	20, // 16: models.Comment.edited_at:type_name -> google.protobuf.Timestamp
+	20, // 17: models.Attachment.created_at:type_name -> google.protobuf.Timestamp
+	20, // 18: models.Revision.created_at:type_name -> google.protobuf.Timestamp
+	20, // 19: models.ModQueueItem.created_at:type_name -> google.protobuf.Timestamp
+	3,  // 20: models.Event.type:type_name -> models.EventType
+	20, // 21: models.Event.created_at:type_name -> google.protobuf.Timestamp
+	4,  // 22: models.Job.type:type_name -> models.JobType
+	5,  // 23: models.Job.state:type_name -> models.JobState
+	20, // 24: models.Job.created_at:type_name -> google.protobuf.Timestamp
+	20, // 25: models.Job.updated_at:type_name -> google.protobuf.Timestamp
+	20, // 26: models.Job.lease_expires_at:type_name -> google.protobuf.Timestamp
+	27, // [27:27] is the sub-list for method output_type
+	27, // [27:27] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_models_proto_init() }
@@ -382,8 +2185,8 @@ func file_models_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_models_proto_rawDesc), len(file_models_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   3,
+			NumEnums:      6,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -29,6 +29,9 @@ type SearchRequest struct {
 	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
 	Offset        *int32                 `protobuf:"varint,2,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
 	Limit         *int32                 `protobuf:"varint,3,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	Flair         *string                `protobuf:"bytes,4,opt,name=flair,proto3,oneof" json:"flair,omitempty"`
+	Tag           *string                `protobuf:"bytes,5,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
+	PageToken     *string                `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"` // next_page_token of the previous page of a thread or community search, the offset is ignored with it
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -84,6 +87,27 @@ func (x *SearchRequest) GetLimit() int32 {
 	return 0
 }
 
+func (x *SearchRequest) GetFlair() string {
+	if x != nil && x.Flair != nil {
+		return *x.Flair
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
+func (x *SearchRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type GlobalSearchResponse struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	ThreadResults    []*pb.Thread           `protobuf:"bytes,1,rep,name=thread_results,json=threadResults,proto3" json:"thread_results,omitempty"`
@@ -139,6 +163,7 @@ func (x *GlobalSearchResponse) GetCommunityResults() []*pb.Community {
 type CommunitySearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Results       []*pb.Community        `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -180,9 +205,17 @@ func (x *CommunitySearchResponse) GetResults() []*pb.Community {
 	return nil
 }
 
+func (x *CommunitySearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type ThreadSearchResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Results       []*pb.Thread           `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -224,24 +257,40 @@ func (x *ThreadSearchResponse) GetResults() []*pb.Thread {
 	return nil
 }
 
+func (x *ThreadSearchResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_search_service_proto protoreflect.FileDescriptor
 
 const file_search_service_proto_rawDesc = "" +
 	"\n" +
-	"\x14search-service.proto\x12\x06search\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"r\n" +
+	"\x14search-service.proto\x12\x06search\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"\xe9\x01\n" +
 	"\rSearchRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
 	"\x06offset\x18\x02 \x01(\x05H\x00R\x06offset\x88\x01\x01\x12\x19\n" +
-	"\x05limit\x18\x03 \x01(\x05H\x01R\x05limit\x88\x01\x01B\t\n" +
+	"\x05limit\x18\x03 \x01(\x05H\x01R\x05limit\x88\x01\x01\x12\x19\n" +
+	"\x05flair\x18\x04 \x01(\tH\x02R\x05flair\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\x05 \x01(\tH\x03R\x03tag\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tH\x04R\tpageToken\x88\x01\x01B\t\n" +
 	"\a_offsetB\b\n" +
-	"\x06_limit\"\x8d\x01\n" +
+	"\x06_limitB\b\n" +
+	"\x06_flairB\x06\n" +
+	"\x04_tagB\r\n" +
+	"\v_page_token\"\x8d\x01\n" +
 	"\x14GlobalSearchResponse\x125\n" +
 	"\x0ethread_results\x18\x01 \x03(\v2\x0e.models.ThreadR\rthreadResults\x12>\n" +
-	"\x11community_results\x18\x02 \x03(\v2\x11.models.CommunityR\x10communityResults\"F\n" +
+	"\x11community_results\x18\x02 \x03(\v2\x11.models.CommunityR\x10communityResults\"n\n" +
 	"\x17CommunitySearchResponse\x12+\n" +
-	"\aresults\x18\x01 \x03(\v2\x11.models.CommunityR\aresults\"@\n" +
+	"\aresults\x18\x01 \x03(\v2\x11.models.CommunityR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"h\n" +
 	"\x14ThreadSearchResponse\x12(\n" +
-	"\aresults\x18\x01 \x03(\v2\x0e.models.ThreadR\aresults2\xe7\x02\n" +
+	"\aresults\x18\x01 \x03(\v2\x0e.models.ThreadR\aresults\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xe7\x02\n" +
 	"\rSearchService\x12=\n" +
 	"\vCheckHealth\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12T\n" +
 	"\fGlobalSearch\x12\x15.search.SearchRequest\x1a\x1c.search.GlobalSearchResponse\"\x0f\x82\xd3\xe4\x93\x02\t\x12\a/search\x12d\n" +
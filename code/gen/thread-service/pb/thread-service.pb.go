@@ -12,6 +12,7 @@ import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -25,14 +26,20 @@ const (
 )
 
 type ListThreadsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommunityId   *string                `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3,oneof" json:"community_id,omitempty"`
-	Title         *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
-	Offset        *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
-	Limit         *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
-	SortBy        *string                `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId       *string                `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3,oneof" json:"community_id,omitempty"`
+	Title             *string                `protobuf:"bytes,2,opt,name=title,proto3,oneof" json:"title,omitempty"`
+	Offset            *int32                 `protobuf:"varint,3,opt,name=offset,proto3,oneof" json:"offset,omitempty"`
+	Limit             *int32                 `protobuf:"varint,4,opt,name=limit,proto3,oneof" json:"limit,omitempty"`
+	SortBy            *string                `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3,oneof" json:"sort_by,omitempty"`
+	Flair             *string                `protobuf:"bytes,6,opt,name=flair,proto3,oneof" json:"flair,omitempty"`
+	Tag               *string                `protobuf:"bytes,7,opt,name=tag,proto3,oneof" json:"tag,omitempty"`
+	AuthorId          *string                `protobuf:"bytes,8,opt,name=author_id,json=authorId,proto3,oneof" json:"author_id,omitempty"`
+	Drafts            bool                   `protobuf:"varint,9,opt,name=drafts,proto3" json:"drafts,omitempty"` // lists the drafts of author_id instead of published threads, only to that user
+	CrosspostParentId *string                `protobuf:"bytes,10,opt,name=crosspost_parent_id,json=crosspostParentId,proto3,oneof" json:"crosspost_parent_id,omitempty"`
+	PageToken         *string                `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3,oneof" json:"page_token,omitempty"` // next_page_token of the previous page, the offset is ignored with it
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
 func (x *ListThreadsRequest) Reset() {
@@ -100,9 +107,52 @@ func (x *ListThreadsRequest) GetSortBy() string {
 	return ""
 }
 
+func (x *ListThreadsRequest) GetFlair() string {
+	if x != nil && x.Flair != nil {
+		return *x.Flair
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetTag() string {
+	if x != nil && x.Tag != nil {
+		return *x.Tag
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetAuthorId() string {
+	if x != nil && x.AuthorId != nil {
+		return *x.AuthorId
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetDrafts() bool {
+	if x != nil {
+		return x.Drafts
+	}
+	return false
+}
+
+func (x *ListThreadsRequest) GetCrosspostParentId() string {
+	if x != nil && x.CrosspostParentId != nil {
+		return *x.CrosspostParentId
+	}
+	return ""
+}
+
+func (x *ListThreadsRequest) GetPageToken() string {
+	if x != nil && x.PageToken != nil {
+		return *x.PageToken
+	}
+	return ""
+}
+
 type ListThreadsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Threads       []*pb.Thread           `protobuf:"bytes,1,rep,name=threads,proto3" json:"threads,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // empty after the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -144,13 +194,33 @@ func (x *ListThreadsResponse) GetThreads() []*pb.Thread {
 	return nil
 }
 
+func (x *ListThreadsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type CreateThreadRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CommunityId   string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Content       string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CommunityId string                 `protobuf:"bytes,1,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"`
+	Title       string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content     string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// Deprecated: Marked as deprecated in thread-service.proto.
+	AuthorId           string                 `protobuf:"bytes,4,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // ignored, threads are authored by the user the request is authenticated as
+	FlairId            string                 `protobuf:"bytes,5,opt,name=flair_id,json=flairId,proto3" json:"flair_id,omitempty"`
+	Tags               []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Kind               pb.ThreadKind          `protobuf:"varint,7,opt,name=kind,proto3,enum=models.ThreadKind" json:"kind,omitempty"`
+	Url                string                 `protobuf:"bytes,8,opt,name=url,proto3" json:"url,omitempty"`                                       // link threads
+	AttachmentId       string                 `protobuf:"bytes,9,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"` // image threads
+	PollOptions        []string               `protobuf:"bytes,10,rep,name=poll_options,json=pollOptions,proto3" json:"poll_options,omitempty"`   // poll threads
+	PollEndsAt         *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=poll_ends_at,json=pollEndsAt,proto3" json:"poll_ends_at,omitempty"`    // defaults to a week after creation
+	PollMultipleChoice bool                   `protobuf:"varint,12,opt,name=poll_multiple_choice,json=pollMultipleChoice,proto3" json:"poll_multiple_choice,omitempty"`
+	PollHideResults    bool                   `protobuf:"varint,13,opt,name=poll_hide_results,json=pollHideResults,proto3" json:"poll_hide_results,omitempty"` // hide vote counts until the poll is closed
+	Draft              bool                   `protobuf:"varint,14,opt,name=draft,proto3" json:"draft,omitempty"`                                              // keeps the thread visible only to its author until published
+	PublishAt          *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`                      // creates a draft published at this time
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *CreateThreadRequest) Reset() {
@@ -204,6 +274,91 @@ func (x *CreateThreadRequest) GetContent() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in thread-service.proto.
+func (x *CreateThreadRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetFlairId() string {
+	if x != nil {
+		return x.FlairId
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateThreadRequest) GetKind() pb.ThreadKind {
+	if x != nil {
+		return x.Kind
+	}
+	return pb.ThreadKind(0)
+}
+
+func (x *CreateThreadRequest) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *CreateThreadRequest) GetPollOptions() []string {
+	if x != nil {
+		return x.PollOptions
+	}
+	return nil
+}
+
+func (x *CreateThreadRequest) GetPollEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PollEndsAt
+	}
+	return nil
+}
+
+func (x *CreateThreadRequest) GetPollMultipleChoice() bool {
+	if x != nil {
+		return x.PollMultipleChoice
+	}
+	return false
+}
+
+func (x *CreateThreadRequest) GetPollHideResults() bool {
+	if x != nil {
+		return x.PollHideResults
+	}
+	return false
+}
+
+func (x *CreateThreadRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *CreateThreadRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
 type CreateThreadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -249,8 +404,10 @@ func (x *CreateThreadResponse) GetId() string {
 }
 
 type GetThreadRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: Marked as deprecated in thread-service.proto.
+	ViewerId      string `protobuf:"bytes,2,opt,name=viewer_id,json=viewerId,proto3" json:"viewer_id,omitempty"` // ignored, drafts are only returned to the user the request is authenticated as
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -292,6 +449,14 @@ func (x *GetThreadRequest) GetId() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in thread-service.proto.
+func (x *GetThreadRequest) GetViewerId() string {
+	if x != nil {
+		return x.ViewerId
+	}
+	return ""
+}
+
 type UpdateThreadRequest struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	Id                string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -299,6 +464,8 @@ type UpdateThreadRequest struct {
 	Content           *string                `protobuf:"bytes,3,opt,name=content,proto3,oneof" json:"content,omitempty"`
 	VoteOffset        *int32                 `protobuf:"varint,4,opt,name=vote_offset,json=voteOffset,proto3,oneof" json:"vote_offset,omitempty"`
 	NumCommentsOffset *int32                 `protobuf:"varint,5,opt,name=num_comments_offset,json=numCommentsOffset,proto3,oneof" json:"num_comments_offset,omitempty"`
+	FlairId           *string                `protobuf:"bytes,6,opt,name=flair_id,json=flairId,proto3,oneof" json:"flair_id,omitempty"` // empty to clear the flair
+	Tags              *pb.TagList            `protobuf:"bytes,7,opt,name=tags,proto3" json:"tags,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -368,6 +535,20 @@ func (x *UpdateThreadRequest) GetNumCommentsOffset() int32 {
 	return 0
 }
 
+func (x *UpdateThreadRequest) GetFlairId() string {
+	if x != nil && x.FlairId != nil {
+		return *x.FlairId
+	}
+	return ""
+}
+
+func (x *UpdateThreadRequest) GetTags() *pb.TagList {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
 type DeleteThreadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -412,55 +593,844 @@ func (x *DeleteThreadRequest) GetId() string {
 	return ""
 }
 
-var File_thread_service_proto protoreflect.FileDescriptor
+type RemoveThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_thread_service_proto_rawDesc = "" +
-	"\n" +
-	"\x14thread-service.proto\x12\x06thread\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"\xe9\x01\n" +
-	"\x12ListThreadsRequest\x12&\n" +
-	"\fcommunity_id\x18\x01 \x01(\tH\x00R\vcommunityId\x88\x01\x01\x12\x19\n" +
-	"\x05title\x18\x02 \x01(\tH\x01R\x05title\x88\x01\x01\x12\x1b\n" +
-	"\x06offset\x18\x03 \x01(\x05H\x02R\x06offset\x88\x01\x01\x12\x19\n" +
-	"\x05limit\x18\x04 \x01(\x05H\x03R\x05limit\x88\x01\x01\x12\x1c\n" +
-	"\asort_by\x18\x05 \x01(\tH\x04R\x06sortBy\x88\x01\x01B\x0f\n" +
-	"\r_community_idB\b\n" +
-	"\x06_titleB\t\n" +
-	"\a_offsetB\b\n" +
-	"\x06_limitB\n" +
-	"\n" +
-	"\b_sort_by\"?\n" +
-	"\x13ListThreadsResponse\x12(\n" +
-	"\athreads\x18\x01 \x03(\v2\x0e.models.ThreadR\athreads\"h\n" +
-	"\x13CreateThreadRequest\x12!\n" +
-	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x14\n" +
-	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
-	"\acontent\x18\x03 \x01(\tR\acontent\"&\n" +
-	"\x14CreateThreadResponse\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\"\n" +
-	"\x10GetThreadRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xf8\x01\n" +
-	"\x13UpdateThreadRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
-	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
-	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x12$\n" +
-	"\vvote_offset\x18\x04 \x01(\x05H\x02R\n" +
-	"voteOffset\x88\x01\x01\x123\n" +
-	"\x13num_comments_offset\x18\x05 \x01(\x05H\x03R\x11numCommentsOffset\x88\x01\x01B\b\n" +
-	"\x06_titleB\n" +
-	"\n" +
-	"\b_contentB\x0e\n" +
-	"\f_vote_offsetB\x16\n" +
-	"\x14_num_comments_offset\"%\n" +
-	"\x13DeleteThreadRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id2\x91\x04\n" +
-	"\rThreadService\x12=\n" +
-	"\vCheckHealth\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12X\n" +
-	"\vListThreads\x12\x1a.thread.ListThreadsRequest\x1a\x1b.thread.ListThreadsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
-	"\x12\b/threads\x12^\n" +
-	"\fCreateThread\x12\x1b.thread.CreateThreadRequest\x1a\x1c.thread.CreateThreadResponse\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/threads\x12L\n" +
-	"\tGetThread\x12\x18.thread.GetThreadRequest\x1a\x0e.models.Thread\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/threads/{id}\x12]\n" +
-	"\fUpdateThread\x12\x1b.thread.UpdateThreadRequest\x1a\x16.google.protobuf.Empty\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*2\r/threads/{id}\x12Z\n" +
-	"\fDeleteThread\x12\x1b.thread.DeleteThreadRequest\x1a\x16.google.protobuf.Empty\"\x15\x82\xd3\xe4\x93\x02\x0f*\r/threads/{id}B\x1aZ\x18gen/thread-service/pb;pbb\x06proto3"
+func (x *RemoveThreadRequest) Reset() {
+	*x = RemoveThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveThreadRequest) ProtoMessage() {}
+
+func (x *RemoveThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveThreadRequest.ProtoReflect.Descriptor instead.
+func (*RemoveThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoveThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveThreadRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RestoreThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreThreadRequest) Reset() {
+	*x = RestoreThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreThreadRequest) ProtoMessage() {}
+
+func (x *RestoreThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreThreadRequest.ProtoReflect.Descriptor instead.
+func (*RestoreThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RestoreThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PinThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pinned        bool                   `protobuf:"varint,2,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinThreadRequest) Reset() {
+	*x = PinThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinThreadRequest) ProtoMessage() {}
+
+func (x *PinThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinThreadRequest.ProtoReflect.Descriptor instead.
+func (*PinThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PinThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PinThreadRequest) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+type LockThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Locked        bool                   `protobuf:"varint,2,opt,name=locked,proto3" json:"locked,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LockThreadRequest) Reset() {
+	*x = LockThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LockThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LockThreadRequest) ProtoMessage() {}
+
+func (x *LockThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LockThreadRequest.ProtoReflect.Descriptor instead.
+func (*LockThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LockThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LockThreadRequest) GetLocked() bool {
+	if x != nil {
+		return x.Locked
+	}
+	return false
+}
+
+type CrosspostThreadRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CommunityId string                 `protobuf:"bytes,2,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"` // community the thread is shared into
+	// Deprecated: Marked as deprecated in thread-service.proto.
+	AuthorId      string  `protobuf:"bytes,3,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"` // ignored, crossposts are made by the user the request is authenticated as
+	Title         *string `protobuf:"bytes,4,opt,name=title,proto3,oneof" json:"title,omitempty"`                 // defaults to the title of the original
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CrosspostThreadRequest) Reset() {
+	*x = CrosspostThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CrosspostThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CrosspostThreadRequest) ProtoMessage() {}
+
+func (x *CrosspostThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CrosspostThreadRequest.ProtoReflect.Descriptor instead.
+func (*CrosspostThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CrosspostThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CrosspostThreadRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in thread-service.proto.
+func (x *CrosspostThreadRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *CrosspostThreadRequest) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
+	}
+	return ""
+}
+
+type MoveThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CommunityId   string                 `protobuf:"bytes,2,opt,name=community_id,json=communityId,proto3" json:"community_id,omitempty"` // community the thread is moved to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveThreadRequest) Reset() {
+	*x = MoveThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveThreadRequest) ProtoMessage() {}
+
+func (x *MoveThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveThreadRequest.ProtoReflect.Descriptor instead.
+func (*MoveThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MoveThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MoveThreadRequest) GetCommunityId() string {
+	if x != nil {
+		return x.CommunityId
+	}
+	return ""
+}
+
+type MoveThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RedirectId    string                 `protobuf:"bytes,1,opt,name=redirect_id,json=redirectId,proto3" json:"redirect_id,omitempty"` // redirect left in the previous community
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveThreadResponse) Reset() {
+	*x = MoveThreadResponse{}
+	mi := &file_thread_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveThreadResponse) ProtoMessage() {}
+
+func (x *MoveThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveThreadResponse.ProtoReflect.Descriptor instead.
+func (*MoveThreadResponse) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *MoveThreadResponse) GetRedirectId() string {
+	if x != nil {
+		return x.RedirectId
+	}
+	return ""
+}
+
+type PublishThreadRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Deprecated: Marked as deprecated in thread-service.proto.
+	AuthorId      string                 `protobuf:"bytes,2,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`    // ignored, drafts are only published by the user the request is authenticated as
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"` // schedules the draft instead of publishing it now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishThreadRequest) Reset() {
+	*x = PublishThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishThreadRequest) ProtoMessage() {}
+
+func (x *PublishThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishThreadRequest.ProtoReflect.Descriptor instead.
+func (*PublishThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PublishThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in thread-service.proto.
+func (x *PublishThreadRequest) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+func (x *PublishThreadRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+type PurgeThreadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeThreadRequest) Reset() {
+	*x = PurgeThreadRequest{}
+	mi := &file_thread_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeThreadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeThreadRequest) ProtoMessage() {}
+
+func (x *PurgeThreadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeThreadRequest.ProtoReflect.Descriptor instead.
+func (*PurgeThreadRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *PurgeThreadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PurgeThreadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JobId         string                 `protobuf:"bytes,1,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"` // see GetJobStatus
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeThreadResponse) Reset() {
+	*x = PurgeThreadResponse{}
+	mi := &file_thread_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeThreadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeThreadResponse) ProtoMessage() {}
+
+func (x *PurgeThreadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeThreadResponse.ProtoReflect.Descriptor instead.
+func (*PurgeThreadResponse) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PurgeThreadResponse) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+type GetJobStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetJobStatusRequest) Reset() {
+	*x = GetJobStatusRequest{}
+	mi := &file_thread_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetJobStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatusRequest) ProtoMessage() {}
+
+func (x *GetJobStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetJobStatusRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetJobStatusRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CastPollVoteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ThreadId      string                 `protobuf:"bytes,1,opt,name=thread_id,json=threadId,proto3" json:"thread_id,omitempty"`
+	OptionIds     []string               `protobuf:"bytes,3,rep,name=option_ids,json=optionIds,proto3" json:"option_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CastPollVoteRequest) Reset() {
+	*x = CastPollVoteRequest{}
+	mi := &file_thread_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CastPollVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CastPollVoteRequest) ProtoMessage() {}
+
+func (x *CastPollVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CastPollVoteRequest.ProtoReflect.Descriptor instead.
+func (*CastPollVoteRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CastPollVoteRequest) GetThreadId() string {
+	if x != nil {
+		return x.ThreadId
+	}
+	return ""
+}
+
+func (x *CastPollVoteRequest) GetOptionIds() []string {
+	if x != nil {
+		return x.OptionIds
+	}
+	return nil
+}
+
+type ListRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsRequest) Reset() {
+	*x = ListRevisionsRequest{}
+	mi := &file_thread_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsRequest) ProtoMessage() {}
+
+func (x *ListRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListRevisionsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListRevisionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*pb.Revision         `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRevisionsResponse) Reset() {
+	*x = ListRevisionsResponse{}
+	mi := &file_thread_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRevisionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRevisionsResponse) ProtoMessage() {}
+
+func (x *ListRevisionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_thread_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRevisionsResponse.ProtoReflect.Descriptor instead.
+func (*ListRevisionsResponse) Descriptor() ([]byte, []int) {
+	return file_thread_service_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListRevisionsResponse) GetRevisions() []*pb.Revision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+var File_thread_service_proto protoreflect.FileDescriptor
+
+const file_thread_service_proto_rawDesc = "" +
+	"\n" +
+	"\x14thread-service.proto\x12\x06thread\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1cgoogle/api/annotations.proto\x1a\fmodels.proto\"\xf5\x03\n" +
+	"\x12ListThreadsRequest\x12&\n" +
+	"\fcommunity_id\x18\x01 \x01(\tH\x00R\vcommunityId\x88\x01\x01\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x01R\x05title\x88\x01\x01\x12\x1b\n" +
+	"\x06offset\x18\x03 \x01(\x05H\x02R\x06offset\x88\x01\x01\x12\x19\n" +
+	"\x05limit\x18\x04 \x01(\x05H\x03R\x05limit\x88\x01\x01\x12\x1c\n" +
+	"\asort_by\x18\x05 \x01(\tH\x04R\x06sortBy\x88\x01\x01\x12\x19\n" +
+	"\x05flair\x18\x06 \x01(\tH\x05R\x05flair\x88\x01\x01\x12\x15\n" +
+	"\x03tag\x18\a \x01(\tH\x06R\x03tag\x88\x01\x01\x12 \n" +
+	"\tauthor_id\x18\b \x01(\tH\aR\bauthorId\x88\x01\x01\x12\x16\n" +
+	"\x06drafts\x18\t \x01(\bR\x06drafts\x123\n" +
+	"\x13crosspost_parent_id\x18\n" +
+	" \x01(\tH\bR\x11crosspostParentId\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"page_token\x18\v \x01(\tH\tR\tpageToken\x88\x01\x01B\x0f\n" +
+	"\r_community_idB\b\n" +
+	"\x06_titleB\t\n" +
+	"\a_offsetB\b\n" +
+	"\x06_limitB\n" +
+	"\n" +
+	"\b_sort_byB\b\n" +
+	"\x06_flairB\x06\n" +
+	"\x04_tagB\f\n" +
+	"\n" +
+	"_author_idB\x16\n" +
+	"\x14_crosspost_parent_idB\r\n" +
+	"\v_page_token\"g\n" +
+	"\x13ListThreadsResponse\x12(\n" +
+	"\athreads\x18\x01 \x03(\v2\x0e.models.ThreadR\athreads\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xa7\x04\n" +
+	"\x13CreateThreadRequest\x12!\n" +
+	"\fcommunity_id\x18\x01 \x01(\tR\vcommunityId\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1f\n" +
+	"\tauthor_id\x18\x04 \x01(\tB\x02\x18\x01R\bauthorId\x12\x19\n" +
+	"\bflair_id\x18\x05 \x01(\tR\aflairId\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12&\n" +
+	"\x04kind\x18\a \x01(\x0e2\x12.models.ThreadKindR\x04kind\x12\x10\n" +
+	"\x03url\x18\b \x01(\tR\x03url\x12#\n" +
+	"\rattachment_id\x18\t \x01(\tR\fattachmentId\x12!\n" +
+	"\fpoll_options\x18\n" +
+	" \x03(\tR\vpollOptions\x12<\n" +
+	"\fpoll_ends_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"pollEndsAt\x120\n" +
+	"\x14poll_multiple_choice\x18\f \x01(\bR\x12pollMultipleChoice\x12*\n" +
+	"\x11poll_hide_results\x18\r \x01(\bR\x0fpollHideResults\x12\x14\n" +
+	"\x05draft\x18\x0e \x01(\bR\x05draft\x129\n" +
+	"\n" +
+	"publish_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\"&\n" +
+	"\x14CreateThreadResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"C\n" +
+	"\x10GetThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\tviewer_id\x18\x02 \x01(\tB\x02\x18\x01R\bviewerId\"\xdb\x02\n" +
+	"\x13UpdateThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\x05title\x18\x02 \x01(\tH\x00R\x05title\x88\x01\x01\x12\x1d\n" +
+	"\acontent\x18\x03 \x01(\tH\x01R\acontent\x88\x01\x01\x12$\n" +
+	"\vvote_offset\x18\x04 \x01(\x05H\x02R\n" +
+	"voteOffset\x88\x01\x01\x123\n" +
+	"\x13num_comments_offset\x18\x05 \x01(\x05H\x03R\x11numCommentsOffset\x88\x01\x01\x12\x1e\n" +
+	"\bflair_id\x18\x06 \x01(\tH\x04R\aflairId\x88\x01\x01\x12#\n" +
+	"\x04tags\x18\a \x01(\v2\x0f.models.TagListR\x04tagsB\b\n" +
+	"\x06_titleB\n" +
+	"\n" +
+	"\b_contentB\x0e\n" +
+	"\f_vote_offsetB\x16\n" +
+	"\x14_num_comments_offsetB\v\n" +
+	"\t_flair_idJ\x04\b\b\x10\tR\teditor_id\"%\n" +
+	"\x13DeleteThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"=\n" +
+	"\x13RemoveThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"&\n" +
+	"\x14RestoreThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
+	"\x10PinThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06pinned\x18\x02 \x01(\bR\x06pinned\";\n" +
+	"\x11LockThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06locked\x18\x02 \x01(\bR\x06locked\"\x91\x01\n" +
+	"\x16CrosspostThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fcommunity_id\x18\x02 \x01(\tR\vcommunityId\x12\x1f\n" +
+	"\tauthor_id\x18\x03 \x01(\tB\x02\x18\x01R\bauthorId\x12\x19\n" +
+	"\x05title\x18\x04 \x01(\tH\x00R\x05title\x88\x01\x01B\b\n" +
+	"\x06_title\"F\n" +
+	"\x11MoveThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12!\n" +
+	"\fcommunity_id\x18\x02 \x01(\tR\vcommunityId\"5\n" +
+	"\x12MoveThreadResponse\x12\x1f\n" +
+	"\vredirect_id\x18\x01 \x01(\tR\n" +
+	"redirectId\"\x82\x01\n" +
+	"\x14PublishThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\tauthor_id\x18\x02 \x01(\tB\x02\x18\x01R\bauthorId\x129\n" +
+	"\n" +
+	"publish_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\"$\n" +
+	"\x12PurgeThreadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\",\n" +
+	"\x13PurgeThreadResponse\x12\x15\n" +
+	"\x06job_id\x18\x01 \x01(\tR\x05jobId\"%\n" +
+	"\x13GetJobStatusRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"`\n" +
+	"\x13CastPollVoteRequest\x12\x1b\n" +
+	"\tthread_id\x18\x01 \x01(\tR\bthreadId\x12\x1d\n" +
+	"\n" +
+	"option_ids\x18\x03 \x03(\tR\toptionIdsJ\x04\b\x02\x10\x03R\auser_id\"&\n" +
+	"\x14ListRevisionsRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x15ListRevisionsResponse\x12.\n" +
+	"\trevisions\x18\x01 \x03(\v2\x10.models.RevisionR\trevisions2\xdd\f\n" +
+	"\rThreadService\x12=\n" +
+	"\vCheckHealth\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12X\n" +
+	"\vListThreads\x12\x1a.thread.ListThreadsRequest\x1a\x1b.thread.ListThreadsResponse\"\x10\x82\xd3\xe4\x93\x02\n" +
+	"\x12\b/threads\x12^\n" +
+	"\fCreateThread\x12\x1b.thread.CreateThreadRequest\x1a\x1c.thread.CreateThreadResponse\"\x13\x82\xd3\xe4\x93\x02\r:\x01*\"\b/threads\x12L\n" +
+	"\tGetThread\x12\x18.thread.GetThreadRequest\x1a\x0e.models.Thread\"\x15\x82\xd3\xe4\x93\x02\x0f\x12\r/threads/{id}\x12]\n" +
+	"\fUpdateThread\x12\x1b.thread.UpdateThreadRequest\x1a\x16.google.protobuf.Empty\"\x18\x82\xd3\xe4\x93\x02\x12:\x01*2\r/threads/{id}\x12Z\n" +
+	"\fDeleteThread\x12\x1b.thread.DeleteThreadRequest\x1a\x16.google.protobuf.Empty\"\x15\x82\xd3\xe4\x93\x02\x0f*\r/threads/{id}\x12d\n" +
+	"\fRemoveThread\x12\x1b.thread.RemoveThreadRequest\x1a\x16.google.protobuf.Empty\"\x1f\x82\xd3\xe4\x93\x02\x19:\x01*\"\x14/threads/{id}/remove\x12g\n" +
+	"\rRestoreThread\x12\x1c.thread.RestoreThreadRequest\x1a\x16.google.protobuf.Empty\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/threads/{id}/restore\x12[\n" +
+	"\tPinThread\x12\x18.thread.PinThreadRequest\x1a\x16.google.protobuf.Empty\"\x1c\x82\xd3\xe4\x93\x02\x16:\x01*\"\x11/threads/{id}/pin\x12^\n" +
+	"\n" +
+	"LockThread\x12\x19.thread.LockThreadRequest\x1a\x16.google.protobuf.Empty\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/threads/{id}/lock\x12s\n" +
+	"\x0fCrosspostThread\x12\x1e.thread.CrosspostThreadRequest\x1a\x1c.thread.CreateThreadResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/threads/{id}/crosspost\x12b\n" +
+	"\n" +
+	"MoveThread\x12\x19.thread.MoveThreadRequest\x1a\x1a.thread.MoveThreadResponse\"\x1d\x82\xd3\xe4\x93\x02\x17:\x01*\"\x12/threads/{id}/move\x12g\n" +
+	"\rPublishThread\x12\x1c.thread.PublishThreadRequest\x1a\x16.google.protobuf.Empty\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/threads/{id}/publish\x12o\n" +
+	"\fCastPollVote\x12\x1b.thread.CastPollVoteRequest\x1a\x16.google.protobuf.Empty\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/threads/{thread_id}/poll/votes\x12m\n" +
+	"\rListRevisions\x12\x1c.thread.ListRevisionsRequest\x1a\x1d.thread.ListRevisionsResponse\"\x1f\x82\xd3\xe4\x93\x02\x19\x12\x17/threads/{id}/revisions\x12F\n" +
+	"\vPurgeThread\x12\x1a.thread.PurgeThreadRequest\x1a\x1b.thread.PurgeThreadResponse\x12T\n" +
+	"\fGetJobStatus\x12\x1b.thread.GetJobStatusRequest\x1a\v.models.Job\"\x1a\x82\xd3\xe4\x93\x02\x14\x12\x12/threads/jobs/{id}B\x1aZ\x18gen/thread-service/pb;pbb\x06proto3"
 
 var (
 	file_thread_service_proto_rawDescOnce sync.Once
@@ -474,37 +1444,84 @@ func file_thread_service_proto_rawDescGZIP() []byte {
 	return file_thread_service_proto_rawDescData
 }
 
-var file_thread_service_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_thread_service_proto_msgTypes = make([]protoimpl.MessageInfo, 21)
 var file_thread_service_proto_goTypes = []any{
-	(*ListThreadsRequest)(nil),   // 0: thread.ListThreadsRequest
-	(*ListThreadsResponse)(nil),  // 1: thread.ListThreadsResponse
-	(*CreateThreadRequest)(nil),  // 2: thread.CreateThreadRequest
-	(*CreateThreadResponse)(nil), // 3: thread.CreateThreadResponse
-	(*GetThreadRequest)(nil),     // 4: thread.GetThreadRequest
-	(*UpdateThreadRequest)(nil),  // 5: thread.UpdateThreadRequest
-	(*DeleteThreadRequest)(nil),  // 6: thread.DeleteThreadRequest
-	(*pb.Thread)(nil),            // 7: models.Thread
-	(*emptypb.Empty)(nil),        // 8: google.protobuf.Empty
+	(*ListThreadsRequest)(nil),     // 0: thread.ListThreadsRequest
+	(*ListThreadsResponse)(nil),    // 1: thread.ListThreadsResponse
+	(*CreateThreadRequest)(nil),    // 2: thread.CreateThreadRequest
+	(*CreateThreadResponse)(nil),   // 3: thread.CreateThreadResponse
+	(*GetThreadRequest)(nil),       // 4: thread.GetThreadRequest
+	(*UpdateThreadRequest)(nil),    // 5: thread.UpdateThreadRequest
+	(*DeleteThreadRequest)(nil),    // 6: thread.DeleteThreadRequest
+	(*RemoveThreadRequest)(nil),    // 7: thread.RemoveThreadRequest
+	(*RestoreThreadRequest)(nil),   // 8: thread.RestoreThreadRequest
+	(*PinThreadRequest)(nil),       // 9: thread.PinThreadRequest
+	(*LockThreadRequest)(nil),      // 10: thread.LockThreadRequest
+	(*CrosspostThreadRequest)(nil), // 11: thread.CrosspostThreadRequest
+	(*MoveThreadRequest)(nil),      // 12: thread.MoveThreadRequest
+	(*MoveThreadResponse)(nil),     // 13: thread.MoveThreadResponse
+	(*PublishThreadRequest)(nil),   // 14: thread.PublishThreadRequest
+	(*PurgeThreadRequest)(nil),     // 15: thread.PurgeThreadRequest
+	(*PurgeThreadResponse)(nil),    // 16: thread.PurgeThreadResponse
+	(*GetJobStatusRequest)(nil),    // 17: thread.GetJobStatusRequest
+	(*CastPollVoteRequest)(nil),    // 18: thread.CastPollVoteRequest
+	(*ListRevisionsRequest)(nil),   // 19: thread.ListRevisionsRequest
+	(*ListRevisionsResponse)(nil),  // 20: thread.ListRevisionsResponse
+	(*pb.Thread)(nil),              // 21: models.Thread
+	(pb.ThreadKind)(0),             // 22: models.ThreadKind
+	(*timestamppb.Timestamp)(nil),  // 23: google.protobuf.Timestamp
+	(*pb.TagList)(nil),             // 24: models.TagList
+	(*pb.Revision)(nil),            // 25: models.Revision
+	(*emptypb.Empty)(nil),          // 26: google.protobuf.Empty
+	(*pb.Job)(nil),                 // 27: models.Job
 }
 var file_thread_service_proto_depIdxs = []int32{
-	7, // 0: thread.ListThreadsResponse.threads:type_name -> models.Thread
-	8, // 1: thread.ThreadService.CheckHealth:input_type -> google.protobuf.Empty
-	0, // 2: thread.ThreadService.ListThreads:input_type -> thread.ListThreadsRequest
-	2, // 3: thread.ThreadService.CreateThread:input_type -> thread.CreateThreadRequest
-	4, // 4: thread.ThreadService.GetThread:input_type -> thread.GetThreadRequest
-	5, // 5: thread.ThreadService.UpdateThread:input_type -> thread.UpdateThreadRequest
-	6, // 6: thread.ThreadService.DeleteThread:input_type -> thread.DeleteThreadRequest
-	8, // 7: thread.ThreadService.CheckHealth:output_type -> google.protobuf.Empty
-	1, // 8: thread.ThreadService.ListThreads:output_type -> thread.ListThreadsResponse
-	3, // 9: thread.ThreadService.CreateThread:output_type -> thread.CreateThreadResponse
-	7, // 10: thread.ThreadService.GetThread:output_type -> models.Thread
-	8, // 11: thread.ThreadService.UpdateThread:output_type -> google.protobuf.Empty
-	8, // 12: thread.ThreadService.DeleteThread:output_type -> google.protobuf.Empty
-	7, // [7:13] is the sub-list for method output_type
-	1, // [1:7] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	21, // 0: thread.ListThreadsResponse.threads:type_name -> models.Thread
+	22, // 1: thread.CreateThreadRequest.kind:type_name -> models.ThreadKind
+	23, // 2: thread.CreateThreadRequest.poll_ends_at:type_name -> google.protobuf.Timestamp
+	23, // 3: thread.CreateThreadRequest.publish_at:type_name -> google.protobuf.Timestamp
+	24, // 4: thread.UpdateThreadRequest.tags:type_name -> models.TagList
+	23, // 5: thread.PublishThreadRequest.publish_at:type_name -> google.protobuf.Timestamp
+	25, // 6: thread.ListRevisionsResponse.revisions:type_name -> models.Revision
+	26, // 7: thread.ThreadService.CheckHealth:input_type -> google.protobuf.Empty
+	0,  // 8: thread.ThreadService.ListThreads:input_type -> thread.ListThreadsRequest
+	2,  // 9: thread.ThreadService.CreateThread:input_type -> thread.CreateThreadRequest
+	4,  // 10: thread.ThreadService.GetThread:input_type -> thread.GetThreadRequest
+	5,  // 11: thread.ThreadService.UpdateThread:input_type -> thread.UpdateThreadRequest
+	6,  // 12: thread.ThreadService.DeleteThread:input_type -> thread.DeleteThreadRequest
+	7,  // 13: thread.ThreadService.RemoveThread:input_type -> thread.RemoveThreadRequest
+	8,  // 14: thread.ThreadService.RestoreThread:input_type -> thread.RestoreThreadRequest
+	9,  // 15: thread.ThreadService.PinThread:input_type -> thread.PinThreadRequest
+	10, // 16: thread.ThreadService.LockThread:input_type -> thread.LockThreadRequest
+	11, // 17: thread.ThreadService.CrosspostThread:input_type -> thread.CrosspostThreadRequest
+	12, // 18: thread.ThreadService.MoveThread:input_type -> thread.MoveThreadRequest
+	14, // 19: thread.ThreadService.PublishThread:input_type -> thread.PublishThreadRequest
+	18, // 20: thread.ThreadService.CastPollVote:input_type -> thread.CastPollVoteRequest
+	19, // 21: thread.ThreadService.ListRevisions:input_type -> thread.ListRevisionsRequest
+	15, // 22: thread.ThreadService.PurgeThread:input_type -> thread.PurgeThreadRequest
+	17, // 23: thread.ThreadService.GetJobStatus:input_type -> thread.GetJobStatusRequest
+	26, // 24: thread.ThreadService.CheckHealth:output_type -> google.protobuf.Empty
+	1,  // 25: thread.ThreadService.ListThreads:output_type -> thread.ListThreadsResponse
+	3,  // 26: thread.ThreadService.CreateThread:output_type -> thread.CreateThreadResponse
+	21, // 27: thread.ThreadService.GetThread:output_type -> models.Thread
+	26, // 28: thread.ThreadService.UpdateThread:output_type -> google.protobuf.Empty
+	26, // 29: thread.ThreadService.DeleteThread:output_type -> google.protobuf.Empty
+	26, // 30: thread.ThreadService.RemoveThread:output_type -> google.protobuf.Empty
+	26, // 31: thread.ThreadService.RestoreThread:output_type -> google.protobuf.Empty
+	26, // 32: thread.ThreadService.PinThread:output_type -> google.protobuf.Empty
+	26, // 33: thread.ThreadService.LockThread:output_type -> google.protobuf.Empty
+	3,  // 34: thread.ThreadService.CrosspostThread:output_type -> thread.CreateThreadResponse
+	13, // 35: thread.ThreadService.MoveThread:output_type -> thread.MoveThreadResponse
+	26, // 36: thread.ThreadService.PublishThread:output_type -> google.protobuf.Empty
+	26, // 37: thread.ThreadService.CastPollVote:output_type -> google.protobuf.Empty
+	20, // 38: thread.ThreadService.ListRevisions:output_type -> thread.ListRevisionsResponse
+	16, // 39: thread.ThreadService.PurgeThread:output_type -> thread.PurgeThreadResponse
+	27, // 40: thread.ThreadService.GetJobStatus:output_type -> models.Job
+	24, // [24:41] is the sub-list for method output_type
+	7,  // [7:24] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_thread_service_proto_init() }
@@ -514,13 +1531,14 @@ func file_thread_service_proto_init() {
 	}
 	file_thread_service_proto_msgTypes[0].OneofWrappers = []any{}
 	file_thread_service_proto_msgTypes[5].OneofWrappers = []any{}
+	file_thread_service_proto_msgTypes[11].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_thread_service_proto_rawDesc), len(file_thread_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   21,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,12 +21,23 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ThreadService_CheckHealth_FullMethodName  = "/thread.ThreadService/CheckHealth"
-	ThreadService_ListThreads_FullMethodName  = "/thread.ThreadService/ListThreads"
-	ThreadService_CreateThread_FullMethodName = "/thread.ThreadService/CreateThread"
-	ThreadService_GetThread_FullMethodName    = "/thread.ThreadService/GetThread"
-	ThreadService_UpdateThread_FullMethodName = "/thread.ThreadService/UpdateThread"
-	ThreadService_DeleteThread_FullMethodName = "/thread.ThreadService/DeleteThread"
+	ThreadService_CheckHealth_FullMethodName     = "/thread.ThreadService/CheckHealth"
+	ThreadService_ListThreads_FullMethodName     = "/thread.ThreadService/ListThreads"
+	ThreadService_CreateThread_FullMethodName    = "/thread.ThreadService/CreateThread"
+	ThreadService_GetThread_FullMethodName       = "/thread.ThreadService/GetThread"
+	ThreadService_UpdateThread_FullMethodName    = "/thread.ThreadService/UpdateThread"
+	ThreadService_DeleteThread_FullMethodName    = "/thread.ThreadService/DeleteThread"
+	ThreadService_RemoveThread_FullMethodName    = "/thread.ThreadService/RemoveThread"
+	ThreadService_RestoreThread_FullMethodName   = "/thread.ThreadService/RestoreThread"
+	ThreadService_PinThread_FullMethodName       = "/thread.ThreadService/PinThread"
+	ThreadService_LockThread_FullMethodName      = "/thread.ThreadService/LockThread"
+	ThreadService_CrosspostThread_FullMethodName = "/thread.ThreadService/CrosspostThread"
+	ThreadService_MoveThread_FullMethodName      = "/thread.ThreadService/MoveThread"
+	ThreadService_PublishThread_FullMethodName   = "/thread.ThreadService/PublishThread"
+	ThreadService_CastPollVote_FullMethodName    = "/thread.ThreadService/CastPollVote"
+	ThreadService_ListRevisions_FullMethodName   = "/thread.ThreadService/ListRevisions"
+	ThreadService_PurgeThread_FullMethodName     = "/thread.ThreadService/PurgeThread"
+	ThreadService_GetJobStatus_FullMethodName    = "/thread.ThreadService/GetJobStatus"
 )
 
 // ThreadServiceClient is the client API for ThreadService service.
@@ -39,6 +50,19 @@ type ThreadServiceClient interface {
 	GetThread(ctx context.Context, in *GetThreadRequest, opts ...grpc.CallOption) (*pb.Thread, error)
 	UpdateThread(ctx context.Context, in *UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteThread(ctx context.Context, in *DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveThread(ctx context.Context, in *RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreThread(ctx context.Context, in *RestoreThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	PinThread(ctx context.Context, in *PinThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	LockThread(ctx context.Context, in *LockThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CrosspostThread(ctx context.Context, in *CrosspostThreadRequest, opts ...grpc.CallOption) (*CreateThreadResponse, error)
+	MoveThread(ctx context.Context, in *MoveThreadRequest, opts ...grpc.CallOption) (*MoveThreadResponse, error)
+	PublishThread(ctx context.Context, in *PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CastPollVote(ctx context.Context, in *CastPollVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error)
+	// permanently deletes the thread and all of its replies in a background job, used by cascading deletes
+	PurgeThread(ctx context.Context, in *PurgeThreadRequest, opts ...grpc.CallOption) (*PurgeThreadResponse, error)
+	// reports the progress of a job deleting a thread
+	GetJobStatus(ctx context.Context, in *GetJobStatusRequest, opts ...grpc.CallOption) (*pb.Job, error)
 }
 
 type threadServiceClient struct {
@@ -109,6 +133,116 @@ func (c *threadServiceClient) DeleteThread(ctx context.Context, in *DeleteThread
 	return out, nil
 }
 
+func (c *threadServiceClient) RemoveThread(ctx context.Context, in *RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ThreadService_RemoveThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) RestoreThread(ctx context.Context, in *RestoreThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ThreadService_RestoreThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) PinThread(ctx context.Context, in *PinThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ThreadService_PinThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) LockThread(ctx context.Context, in *LockThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ThreadService_LockThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) CrosspostThread(ctx context.Context, in *CrosspostThreadRequest, opts ...grpc.CallOption) (*CreateThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateThreadResponse)
+	err := c.cc.Invoke(ctx, ThreadService_CrosspostThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) MoveThread(ctx context.Context, in *MoveThreadRequest, opts ...grpc.CallOption) (*MoveThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MoveThreadResponse)
+	err := c.cc.Invoke(ctx, ThreadService_MoveThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) PublishThread(ctx context.Context, in *PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ThreadService_PublishThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) CastPollVote(ctx context.Context, in *CastPollVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ThreadService_CastPollVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) ListRevisions(ctx context.Context, in *ListRevisionsRequest, opts ...grpc.CallOption) (*ListRevisionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRevisionsResponse)
+	err := c.cc.Invoke(ctx, ThreadService_ListRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) PurgeThread(ctx context.Context, in *PurgeThreadRequest, opts ...grpc.CallOption) (*PurgeThreadResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeThreadResponse)
+	err := c.cc.Invoke(ctx, ThreadService_PurgeThread_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *threadServiceClient) GetJobStatus(ctx context.Context, in *GetJobStatusRequest, opts ...grpc.CallOption) (*pb.Job, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(pb.Job)
+	err := c.cc.Invoke(ctx, ThreadService_GetJobStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ThreadServiceServer is the server API for ThreadService service.
 // All implementations must embed UnimplementedThreadServiceServer
 // for forward compatibility.
@@ -119,6 +253,19 @@ type ThreadServiceServer interface {
 	GetThread(context.Context, *GetThreadRequest) (*pb.Thread, error)
 	UpdateThread(context.Context, *UpdateThreadRequest) (*emptypb.Empty, error)
 	DeleteThread(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error)
+	RemoveThread(context.Context, *RemoveThreadRequest) (*emptypb.Empty, error)
+	RestoreThread(context.Context, *RestoreThreadRequest) (*emptypb.Empty, error)
+	PinThread(context.Context, *PinThreadRequest) (*emptypb.Empty, error)
+	LockThread(context.Context, *LockThreadRequest) (*emptypb.Empty, error)
+	CrosspostThread(context.Context, *CrosspostThreadRequest) (*CreateThreadResponse, error)
+	MoveThread(context.Context, *MoveThreadRequest) (*MoveThreadResponse, error)
+	PublishThread(context.Context, *PublishThreadRequest) (*emptypb.Empty, error)
+	CastPollVote(context.Context, *CastPollVoteRequest) (*emptypb.Empty, error)
+	ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error)
+	// permanently deletes the thread and all of its replies in a background job, used by cascading deletes
+	PurgeThread(context.Context, *PurgeThreadRequest) (*PurgeThreadResponse, error)
+	// reports the progress of a job deleting a thread
+	GetJobStatus(context.Context, *GetJobStatusRequest) (*pb.Job, error)
 	mustEmbedUnimplementedThreadServiceServer()
 }
 
@@ -147,6 +294,39 @@ func (UnimplementedThreadServiceServer) UpdateThread(context.Context, *UpdateThr
 func (UnimplementedThreadServiceServer) DeleteThread(context.Context, *DeleteThreadRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteThread not implemented")
 }
+func (UnimplementedThreadServiceServer) RemoveThread(context.Context, *RemoveThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveThread not implemented")
+}
+func (UnimplementedThreadServiceServer) RestoreThread(context.Context, *RestoreThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreThread not implemented")
+}
+func (UnimplementedThreadServiceServer) PinThread(context.Context, *PinThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PinThread not implemented")
+}
+func (UnimplementedThreadServiceServer) LockThread(context.Context, *LockThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LockThread not implemented")
+}
+func (UnimplementedThreadServiceServer) CrosspostThread(context.Context, *CrosspostThreadRequest) (*CreateThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CrosspostThread not implemented")
+}
+func (UnimplementedThreadServiceServer) MoveThread(context.Context, *MoveThreadRequest) (*MoveThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveThread not implemented")
+}
+func (UnimplementedThreadServiceServer) PublishThread(context.Context, *PublishThreadRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublishThread not implemented")
+}
+func (UnimplementedThreadServiceServer) CastPollVote(context.Context, *CastPollVoteRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CastPollVote not implemented")
+}
+func (UnimplementedThreadServiceServer) ListRevisions(context.Context, *ListRevisionsRequest) (*ListRevisionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRevisions not implemented")
+}
+func (UnimplementedThreadServiceServer) PurgeThread(context.Context, *PurgeThreadRequest) (*PurgeThreadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PurgeThread not implemented")
+}
+func (UnimplementedThreadServiceServer) GetJobStatus(context.Context, *GetJobStatusRequest) (*pb.Job, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobStatus not implemented")
+}
 func (UnimplementedThreadServiceServer) mustEmbedUnimplementedThreadServiceServer() {}
 func (UnimplementedThreadServiceServer) testEmbeddedByValue()                       {}
 
@@ -276,6 +456,204 @@ func _ThreadService_DeleteThread_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ThreadService_RemoveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).RemoveThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_RemoveThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).RemoveThread(ctx, req.(*RemoveThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_RestoreThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).RestoreThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_RestoreThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).RestoreThread(ctx, req.(*RestoreThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_PinThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).PinThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_PinThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).PinThread(ctx, req.(*PinThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_LockThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LockThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).LockThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_LockThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).LockThread(ctx, req.(*LockThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_CrosspostThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CrosspostThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).CrosspostThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_CrosspostThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).CrosspostThread(ctx, req.(*CrosspostThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_MoveThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).MoveThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_MoveThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).MoveThread(ctx, req.(*MoveThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_PublishThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).PublishThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_PublishThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).PublishThread(ctx, req.(*PublishThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_CastPollVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CastPollVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).CastPollVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_CastPollVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).CastPollVote(ctx, req.(*CastPollVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_ListRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).ListRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_ListRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).ListRevisions(ctx, req.(*ListRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_PurgeThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).PurgeThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_PurgeThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).PurgeThread(ctx, req.(*PurgeThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ThreadService_GetJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ThreadServiceServer).GetJobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ThreadService_GetJobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ThreadServiceServer).GetJobStatus(ctx, req.(*GetJobStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ThreadService_ServiceDesc is the grpc.ServiceDesc for ThreadService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -307,6 +685,50 @@ var ThreadService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteThread",
 			Handler:    _ThreadService_DeleteThread_Handler,
 		},
+		{
+			MethodName: "RemoveThread",
+			Handler:    _ThreadService_RemoveThread_Handler,
+		},
+		{
+			MethodName: "RestoreThread",
+			Handler:    _ThreadService_RestoreThread_Handler,
+		},
+		{
+			MethodName: "PinThread",
+			Handler:    _ThreadService_PinThread_Handler,
+		},
+		{
+			MethodName: "LockThread",
+			Handler:    _ThreadService_LockThread_Handler,
+		},
+		{
+			MethodName: "CrosspostThread",
+			Handler:    _ThreadService_CrosspostThread_Handler,
+		},
+		{
+			MethodName: "MoveThread",
+			Handler:    _ThreadService_MoveThread_Handler,
+		},
+		{
+			MethodName: "PublishThread",
+			Handler:    _ThreadService_PublishThread_Handler,
+		},
+		{
+			MethodName: "CastPollVote",
+			Handler:    _ThreadService_CastPollVote_Handler,
+		},
+		{
+			MethodName: "ListRevisions",
+			Handler:    _ThreadService_ListRevisions_Handler,
+		},
+		{
+			MethodName: "PurgeThread",
+			Handler:    _ThreadService_PurgeThread_Handler,
+		},
+		{
+			MethodName: "GetJobStatus",
+			Handler:    _ThreadService_GetJobStatus_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "thread-service.proto",
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"errors"
+	attachmentpb "gen/attachment-service/pb"
+	models "gen/models/pb"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const uploadChunkSize = 64 * 1024
+
+// handles multipart POST /attachments, the file part is streamed to the attachment service.
+// attachments are uploaded by the user the request is authenticated as
+func handleUploadAttachment(mux *runtime.ServeMux, client attachmentpb.AttachmentServiceClient) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+
+		reader, err := r.MultipartReader()
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, "Request must be a multipart form"))
+			return
+		}
+		info := &attachmentpb.AttachmentInfo{
+			AuthorId: r.Header.Get("X-User-Id"),
+		}
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, "File is required"))
+				return
+			}
+			if err != nil {
+				runtime.HTTPError(ctx, mux, outbound, w, r, status.Error(codes.InvalidArgument, "Invalid multipart form"))
+				return
+			}
+
+			if part.FormName() == "file" {
+				info.Filename = part.FileName()
+				attachment, err := uploadAttachment(ctx, client, info, part)
+				if err != nil {
+					runtime.HTTPError(ctx, mux, outbound, w, r, err)
+					return
+				}
+				runtime.ForwardResponseMessage(ctx, mux, outbound, w, r, attachment)
+				return
+			}
+		}
+	}
+}
+
+func uploadAttachment(ctx context.Context, client attachmentpb.AttachmentServiceClient, info *attachmentpb.AttachmentInfo, content io.Reader) (*models.Attachment, error) {
+	stream, err := client.UploadAttachment(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = stream.Send(&attachmentpb.UploadAttachmentRequest{
+		Data: &attachmentpb.UploadAttachmentRequest_Info{Info: info},
+	})
+	if err != nil {
+		// the service rejected the upload, its status is returned when closing the stream
+		return stream.CloseAndRecv()
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			sendErr := stream.Send(&attachmentpb.UploadAttachmentRequest{
+				Data: &attachmentpb.UploadAttachmentRequest_Chunk{Chunk: buf[:n]},
+			})
+			if sendErr != nil {
+				return stream.CloseAndRecv()
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return stream.CloseAndRecv()
+		}
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, "Failed to read file")
+		}
+	}
+}
+
+// handles GET /attachments/{id}/content, the content is streamed from the attachment service
+func handleDownloadAttachment(mux *runtime.ServeMux, client attachmentpb.AttachmentServiceClient) runtime.HandlerFunc {
+	return handleDownload(mux, client, func(ctx context.Context, attachment *models.Attachment) (attachmentpb.AttachmentService_DownloadAttachmentClient, string, int64, error) {
+		stream, err := client.DownloadAttachment(ctx, &attachmentpb.DownloadAttachmentRequest{Id: attachment.Id})
+		return stream, attachment.ContentType, attachment.Size, err
+	})
+}
+
+// handles GET /attachments/{id}/thumbnail, the thumbnail of an image is streamed from the attachment service
+func handleDownloadThumbnail(mux *runtime.ServeMux, client attachmentpb.AttachmentServiceClient) runtime.HandlerFunc {
+	return handleDownload(mux, client, func(ctx context.Context, attachment *models.Attachment) (attachmentpb.AttachmentService_DownloadAttachmentClient, string, int64, error) {
+		stream, err := client.DownloadThumbnail(ctx, &attachmentpb.DownloadAttachmentRequest{Id: attachment.Id})
+		return stream, attachment.ThumbnailContentType, attachment.ThumbnailSize, err
+	})
+}
+
+// openDownload starts streaming a download of attachment and returns its content type and size
+type openDownload func(ctx context.Context, attachment *models.Attachment) (attachmentpb.AttachmentService_DownloadAttachmentClient, string, int64, error)
+
+func handleDownload(mux *runtime.ServeMux, client attachmentpb.AttachmentServiceClient, open openDownload) runtime.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request, pathParams map[string]string) {
+		ctx := r.Context()
+		_, outbound := runtime.MarshalerForRequest(mux, r)
+
+		attachment, err := client.GetAttachment(ctx, &attachmentpb.GetAttachmentRequest{Id: pathParams["id"]})
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+		stream, contentType, size, err := open(ctx, attachment)
+		if err != nil {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		// receive the first chunk before writing headers so errors can still be reported
+		res, err := stream.Recv()
+		if err != nil && !errors.Is(err, io.EOF) {
+			runtime.HTTPError(ctx, mux, outbound, w, r, err)
+			return
+		}
+
+		// content is addressed by its digest and never changes
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		if attachment.Filename != "" {
+			w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": attachment.Filename}))
+		}
+		w.WriteHeader(http.StatusOK)
+		for err == nil {
+			if _, err := w.Write(res.Chunk); err != nil {
+				return
+			}
+			res, err = stream.Recv()
+		}
+		if !errors.Is(err, io.EOF) {
+			log.Printf("failed to stream attachment %s: %v", attachment.Id, err)
+		}
+	}
+}
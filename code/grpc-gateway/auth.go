@@ -0,0 +1,98 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// claims of the tokens the gateway accepts, signed with HS256 by the identity provider sharing AUTH_TOKEN_SECRET
+type tokenClaims struct {
+	Subject   string   `json:"sub"`   // id of the user
+	Roles     []string `json:"roles"` // site-wide roles of the user, e.g. moderator or admin
+	ExpiresAt int64    `json:"exp"`   // unix time after which the token is rejected
+}
+
+var errInvalidToken = errors.New("invalid token")
+
+// verifyToken checks the signature and the expiry of a JWT and returns its claims
+func verifyToken(token string, secret []byte, now time.Time) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errInvalidToken
+	}
+
+	// header
+	headerJson, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	var header struct {
+		Alg string `json:"alg"`
+	}
+	if err := json.Unmarshal(headerJson, &header); err != nil || header.Alg != "HS256" {
+		return nil, errInvalidToken
+	}
+
+	// signature
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(parts[0] + "." + parts[1]))
+	if !hmac.Equal(signature, mac.Sum(nil)) {
+		return nil, errInvalidToken
+	}
+
+	// claims
+	claimsJson, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, errInvalidToken
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(claimsJson, &claims); err != nil || claims.Subject == "" {
+		return nil, errInvalidToken
+	}
+	if claims.ExpiresAt == 0 || !now.Before(time.Unix(claims.ExpiresAt, 0)) {
+		return nil, errInvalidToken
+	}
+	return &claims, nil
+}
+
+// authenticateRequests replaces the user headers clients send with the user of the bearer token of a request, requests
+// without a token are forwarded anonymously and requests with an invalid one are rejected
+func authenticateRequests(next http.Handler, secret []byte) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for header := range authenticatedHeaders {
+			r.Header.Del(header)
+		}
+
+		authorization := r.Header.Get("Authorization")
+		if authorization != "" {
+			token, ok := strings.CutPrefix(authorization, "Bearer ")
+			if !ok {
+				http.Error(w, "Unsupported authorization scheme", http.StatusUnauthorized)
+				return
+			}
+			claims, err := verifyToken(token, secret, time.Now())
+			if err != nil {
+				http.Error(w, "Invalid or expired token", http.StatusUnauthorized)
+				return
+			}
+			r.Header.Set("X-User-Id", claims.Subject)
+			if len(claims.Roles) > 0 {
+				r.Header.Set("X-User-Roles", strings.Join(claims.Roles, ","))
+			}
+			// the token is verified here, services only see the user it carries
+			r.Header.Del("Authorization")
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -4,20 +4,26 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	attachmentpb "gen/attachment-service/pb"
+	"gen/auth"
 	commentpb "gen/comment-service/pb"
 	communitypb "gen/community-service/pb"
+	moderationpb "gen/moderation-service/pb"
 	popularpb "gen/popular-service/pb"
 	searchpb "gen/search-service/pb"
 	threadpb "gen/thread-service/pb"
 	votepb "gen/vote-service/pb"
 	"log"
 	"net/http"
+	"net/textproto"
 	"os"
 	gorun "runtime"
+	"strings"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -48,16 +54,45 @@ func connectGrpcClient(hostEnvVar string, portEnvVar string) *grpc.ClientConn {
 	return conn
 }
 
+// headers set from the bearer token by authenticateRequests, with the metadata keys they are forwarded as
+var authenticatedHeaders = map[string]string{
+	"X-User-Id":    auth.UserIdMetadataKey,    // id of the user a request was authenticated as
+	"X-User-Roles": auth.UserRolesMetadataKey, // comma separated site-wide roles of the user, e.g. moderator or admin
+}
+
+// markGatewayRequest sets the gateway metadata on every request the gateway forwards, so services tell clients apart
+// from other services calling them directly
+func markGatewayRequest(ctx context.Context, r *http.Request) metadata.MD {
+	return metadata.Pairs(auth.GatewayMetadataKey, "true")
+}
+
+// matchIncomingHeader forwards the authenticated user to the services as metadata, which clients cannot set
+// themselves through Grpc-Metadata- headers
+func matchIncomingHeader(key string) (string, bool) {
+	if name, ok := authenticatedHeaders[textproto.CanonicalMIMEHeaderKey(key)]; ok {
+		return name, true
+	}
+	name, ok := runtime.DefaultHeaderMatcher(key)
+	for _, authenticated := range authenticatedHeaders {
+		if strings.EqualFold(name, authenticated) {
+			return "", false
+		}
+	}
+	return name, ok
+}
+
 func handleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	ctx := context.Background()
 
 	health := map[string]bool{
-		"community-service": false,
-		"thread-service":    false,
-		"comment-service":   false,
-		"vote-service":      false,
-		"search-service":    false,
-		"popular-service":   false,
+		"community-service":  false,
+		"thread-service":     false,
+		"comment-service":    false,
+		"vote-service":       false,
+		"search-service":     false,
+		"popular-service":    false,
+		"moderation-service": false,
+		"attachment-service": false,
 	}
 
 	communityConn := connectGrpcClient("COMMUNITY_SERVICE_HOST", "COMMUNITY_SERVICE_PORT")
@@ -96,6 +131,18 @@ func handleHealthCheck(w http.ResponseWriter, r *http.Request) {
 	_, err = popularClient.CheckHealth(ctx, &emptypb.Empty{})
 	health["popular-service"] = err == nil
 
+	moderationConn := connectGrpcClient("MODERATION_SERVICE_HOST", "MODERATION_SERVICE_PORT")
+	defer moderationConn.Close()
+	moderationClient := moderationpb.NewModerationServiceClient(moderationConn)
+	_, err = moderationClient.CheckHealth(ctx, &emptypb.Empty{})
+	health["moderation-service"] = err == nil
+
+	attachmentConn := connectGrpcClient("ATTACHMENT_SERVICE_HOST", "ATTACHMENT_SERVICE_PORT")
+	defer attachmentConn.Close()
+	attachmentClient := attachmentpb.NewAttachmentServiceClient(attachmentConn)
+	_, err = attachmentClient.CheckHealth(ctx, &emptypb.Empty{})
+	health["attachment-service"] = err == nil
+
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 
@@ -112,7 +159,7 @@ func main() {
 	// Set maximum number of CPUs to use
 	gorun.GOMAXPROCS(gorun.NumCPU())
 
-	gwmux := runtime.NewServeMux()
+	gwmux := runtime.NewServeMux(runtime.WithIncomingHeaderMatcher(matchIncomingHeader), runtime.WithMetadata(markGatewayRequest))
 
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -152,9 +199,40 @@ func main() {
 		log.Fatalf("Failed to register gRPC gateway: %v", err)
 	}
 
+	err = moderationpb.RegisterModerationServiceHandlerFromEndpoint(context.Background(), gwmux, getGrpcServerAddress("MODERATION_SERVICE_HOST", "MODERATION_SERVICE_PORT"), opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC gateway: %v", err)
+	}
+
+	err = attachmentpb.RegisterAttachmentServiceHandlerFromEndpoint(context.Background(), gwmux, getGrpcServerAddress("ATTACHMENT_SERVICE_HOST", "ATTACHMENT_SERVICE_PORT"), opts)
+	if err != nil {
+		log.Fatalf("Failed to register gRPC gateway: %v", err)
+	}
+
+	// uploads and downloads are streamed, which the generated handlers cannot do with raw bodies
+	attachmentConn := connectGrpcClient("ATTACHMENT_SERVICE_HOST", "ATTACHMENT_SERVICE_PORT")
+	defer attachmentConn.Close()
+	attachmentClient := attachmentpb.NewAttachmentServiceClient(attachmentConn)
+	err = gwmux.HandlePath("POST", "/attachments", handleUploadAttachment(gwmux, attachmentClient))
+	if err != nil {
+		log.Fatalf("Failed to register attachment upload handler: %v", err)
+	}
+	err = gwmux.HandlePath("GET", "/attachments/{id}/content", handleDownloadAttachment(gwmux, attachmentClient))
+	if err != nil {
+		log.Fatalf("Failed to register attachment download handler: %v", err)
+	}
+	err = gwmux.HandlePath("GET", "/attachments/{id}/thumbnail", handleDownloadThumbnail(gwmux, attachmentClient))
+	if err != nil {
+		log.Fatalf("Failed to register attachment thumbnail handler: %v", err)
+	}
+
 	http.HandleFunc("/health", handleHealthCheck)
 
-	http.Handle("/", gwmux)
+	secret := os.Getenv("AUTH_TOKEN_SECRET")
+	if secret == "" {
+		log.Fatalf("missing AUTH_TOKEN_SECRET env var")
+	}
+	http.Handle("/", authenticateRequests(gwmux, []byte(secret)))
 
 	port := os.Getenv("GRPC_GATEWAY_PORT")
 	if port == "" {
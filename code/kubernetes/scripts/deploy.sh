@@ -6,7 +6,7 @@ SCRIPT_DIR="$(cd "$(dirname "$0")" && pwd)"
 PROJECT_ID="threadit-api"
 CLUSTER_NAME="threadit-cluster"
 ZONE="europe-west1-b"
-SERVICES=(db community thread comment vote search popular)
+SERVICES=(db community thread comment vote search popular moderation attachment)
 
 # Set project and set up cluster context
 gcloud config set project $PROJECT_ID
@@ -16,6 +16,7 @@ GCS_KEY="gcs-key"
 BUCKET_SECRET=$(gcloud secrets versions access latest --secret=$GCS_KEY)
 MONGO_USER=$(gcloud secrets versions access latest --secret="mongo-user")
 MONGO_PASS=$(gcloud secrets versions access latest --secret="mongo-pass")
+AUTH_TOKEN_SECRET=$(gcloud secrets versions access latest --secret="auth-token-secret")
 
 # Check for --build flag
 if [[ "$1" == "--build" ]]; then
@@ -65,6 +66,10 @@ kubectl create secret generic "mongo-secret" \
   --from-literal="MONGO_INITDB_ROOT_PASSWORD=$MONGO_PASS" \
   -n $CLUSTER_NAME --dry-run=client -o yaml | kubectl apply -f -
 
+kubectl create secret generic "auth-secret" \
+  --from-literal="AUTH_TOKEN_SECRET=$AUTH_TOKEN_SECRET" \
+  -n $CLUSTER_NAME --dry-run=client -o yaml | kubectl apply -f -
+
 kubectl apply -n $CLUSTER_NAME -f config.yaml
 kubectl apply -n $CLUSTER_NAME -f mongo/
 
@@ -10,7 +10,7 @@ ZONE="europe-west1-b"
 NAMESPACE=$CLUSTER_NAME
 
 # List of all services
-SERVICES=(db-service community-service thread-service comment-service vote-service search-service popular-service grpc-gateway)
+SERVICES=(db-service community-service thread-service comment-service vote-service search-service popular-service moderation-service attachment-service grpc-gateway)
 
 print_usage() {
   echo "Usage:"
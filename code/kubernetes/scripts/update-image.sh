@@ -9,7 +9,7 @@ CLUSTER_NAME="threadit-cluster"
 ZONE="europe-west1-b"
 NAMESPACE=$CLUSTER_NAME
 
-SERVICES=(db-service community-service thread-service comment-service vote-service search-service popular-service grpc-gateway)
+SERVICES=(db-service community-service thread-service comment-service vote-service search-service popular-service moderation-service attachment-service grpc-gateway)
 
 print_usage() {
   echo "Usage:"
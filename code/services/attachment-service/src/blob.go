@@ -0,0 +1,26 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore stores attachment content under its hex sha256 digest, so identical uploads share a blob
+type BlobStore interface {
+	// Put stores content under key, content already stored under key is kept
+	Put(ctx context.Context, key string, content io.Reader) error
+
+	// Get opens the content stored under key, ErrBlobNotFound if there is none
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// blobKey is the key content is stored under
+func blobKey(content []byte) string {
+	digest := sha256.Sum256(content)
+	return hex.EncodeToString(digest[:])
+}
@@ -0,0 +1,371 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	ThumbnailSize    = 320 // thumbnails fit in a ThumbnailSize x ThumbnailSize box
+	ThumbnailQuality = 85
+	OrientedQuality  = 95 // photos turned upright are encoded again, close to their original quality
+	// bounds the memory used by an image, decoding takes up to 4 bytes per pixel and turning it upright 4 more
+	MaxImagePixels = 16_000_000
+)
+
+var errInvalidImage = errors.New("invalid image")
+
+// processedImage is an uploaded image once its metadata has been stripped
+type processedImage struct {
+	content []byte
+	width   int
+	height  int
+
+	// empty for formats that cannot be decoded
+	thumbnail            []byte
+	thumbnailContentType string
+}
+
+// processImage strips the metadata of an image and generates its thumbnail. The exif orientation is applied to the
+// pixels first, so photos taken sideways still display upright once it is stripped
+func processImage(content []byte, contentType string) (*processedImage, error) {
+	var exif []byte
+	var err error
+	switch contentType {
+	case "image/jpeg":
+		content, exif, err = stripJPEGMetadata(content)
+	case "image/png":
+		content, exif, err = stripPNGMetadata(content)
+	case "image/webp":
+		content, err = stripWebPMetadata(content)
+	}
+	if err != nil {
+		return nil, err
+	}
+	res := &processedImage{content: content}
+	if contentType == "image/webp" {
+		// there is no webp decoder in the standard library, so webp photos cannot be turned upright either
+		return res, nil
+	}
+
+	// check the dimensions before decoding so huge images are not allocated
+	config, _, err := image.DecodeConfig(bytes.NewReader(content))
+	if err != nil {
+		return nil, errInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > MaxImagePixels {
+		return nil, errInvalidImage
+	}
+	res.width, res.height = config.Width, config.Height
+
+	// gif decoding keeps the first frame only
+	var img image.Image
+	switch contentType {
+	case "image/jpeg":
+		img, err = jpeg.Decode(bytes.NewReader(content))
+	case "image/png":
+		img, err = png.Decode(bytes.NewReader(content))
+	case "image/gif":
+		img, err = gif.Decode(bytes.NewReader(content))
+	}
+	if err != nil {
+		return nil, errInvalidImage
+	}
+
+	// the stored image is encoded again once upright, losslessly for png
+	if orientation := exifOrientation(exif); orientation > 1 {
+		img = orientImage(img, orientation)
+		var buf bytes.Buffer
+		if contentType == "image/jpeg" {
+			err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: OrientedQuality})
+		} else {
+			err = png.Encode(&buf, img)
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.content = buf.Bytes()
+		res.width, res.height = img.Bounds().Dx(), img.Bounds().Dy()
+	}
+
+	// jpeg thumbnails for photos, png for formats that can be transparent
+	thumbnail := resizeImage(img, ThumbnailSize)
+	var buf bytes.Buffer
+	if contentType == "image/jpeg" {
+		err = jpeg.Encode(&buf, thumbnail, &jpeg.Options{Quality: ThumbnailQuality})
+		res.thumbnailContentType = "image/jpeg"
+	} else {
+		err = png.Encode(&buf, thumbnail)
+		res.thumbnailContentType = "image/png"
+	}
+	if err != nil {
+		return nil, err
+	}
+	res.thumbnail = buf.Bytes()
+	return res, nil
+}
+
+// resizeImage scales img down to fit in a size x size box, averaging the source pixels covered by each pixel
+func resizeImage(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := srcW, srcH
+	if srcW > size || srcH > size {
+		if srcW >= srcH {
+			dstW, dstH = size, max(1, srcH*size/srcW)
+		} else {
+			dstW, dstH = max(1, srcW*size/srcH), size
+		}
+	}
+
+	// the source rows covered by a row of the thumbnail are converted to rgba at a time
+	var band *image.RGBA
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		band = rowBand(img, y0, y1, band)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+			var sum [4]uint64
+			for sy := 0; sy < y1-y0; sy++ {
+				row := band.Pix[sy*band.Stride+x0*4 : sy*band.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += uint64(row[i])
+					sum[1] += uint64(row[i+1])
+					sum[2] += uint64(row[i+2])
+					sum[3] += uint64(row[i+3])
+				}
+			}
+			n := uint64((y1 - y0) * (x1 - x0))
+			i := dst.PixOffset(x, y)
+			for c := 0; c < 4; c++ {
+				dst.Pix[i+c] = uint8(sum[c] / n)
+			}
+		}
+	}
+	return dst
+}
+
+// orientImage turns img upright according to its exif orientation, mirrored orientations are flipped as well
+func orientImage(img image.Image, orientation int) *image.RGBA {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	if orientation >= 5 {
+		// orientations 5 to 8 swap the sides
+		dst = image.NewRGBA(image.Rect(0, 0, h, w))
+	}
+
+	// the source is converted to rgba a band of rows at a time, so only the upright image is allocated in full
+	const bandRows = 64
+	var band *image.RGBA
+	for y0 := 0; y0 < h; y0 += bandRows {
+		y1 := min(y0+bandRows, h)
+		band = rowBand(img, y0, y1, band)
+		for y := y0; y < y1; y++ {
+			for x := 0; x < w; x++ {
+				var dx, dy int
+				switch orientation {
+				case 2: // flipped horizontally
+					dx, dy = w-1-x, y
+				case 3: // turned 180 degrees
+					dx, dy = w-1-x, h-1-y
+				case 4: // flipped vertically
+					dx, dy = x, h-1-y
+				case 5: // flipped along the main diagonal
+					dx, dy = y, x
+				case 6: // turned 90 degrees clockwise
+					dx, dy = h-1-y, x
+				case 7: // flipped along the other diagonal
+					dx, dy = h-1-y, w-1-x
+				case 8: // turned 90 degrees counterclockwise
+					dx, dy = y, w-1-x
+				default:
+					dx, dy = x, y
+				}
+				i := (y-y0)*band.Stride + x*4
+				copy(dst.Pix[dst.PixOffset(dx, dy):], band.Pix[i:i+4])
+			}
+		}
+	}
+	return dst
+}
+
+// rowBand converts the rows y0 to y1 of img to rgba, reusing the pixels of band when they fit. draw has fast paths
+// for the decoded image types
+func rowBand(img image.Image, y0, y1 int, band *image.RGBA) *image.RGBA {
+	bounds := img.Bounds()
+	rect := image.Rect(0, 0, bounds.Dx(), y1-y0)
+	if band == nil || cap(band.Pix) < 4*rect.Dx()*rect.Dy() {
+		band = image.NewRGBA(rect)
+	} else {
+		band = &image.RGBA{Pix: band.Pix[:4*rect.Dx()*rect.Dy()], Stride: 4 * rect.Dx(), Rect: rect}
+	}
+	draw.Draw(band, rect, img, image.Pt(bounds.Min.X, bounds.Min.Y+y0), draw.Src)
+	return band
+}
+
+// exifOrientation reads the orientation tag of the first image directory of exif tiff data, 1 is upright and is also
+// returned when there is no valid tag
+func exifOrientation(exif []byte) int {
+	if len(exif) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(exif[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(exif[4:]))
+	if ifd < 8 || ifd+2 > len(exif) {
+		return 1
+	}
+	count := int(order.Uint16(exif[ifd:]))
+	for i := 0; i < count; i++ {
+		// tag, type, count and value of 12 bytes each
+		entry := ifd + 2 + i*12
+		if entry+12 > len(exif) {
+			return 1
+		}
+		const orientationTag, shortType = 0x0112, 3
+		if order.Uint16(exif[entry:]) == orientationTag && order.Uint16(exif[entry+2:]) == shortType {
+			if orientation := int(order.Uint16(exif[entry+8:])); orientation >= 1 && orientation <= 8 {
+				return orientation
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// stripJPEGMetadata drops the APP1 (exif, xmp) and APP13 (iptc) segments of a jpeg, and returns the tiff data of
+// the exif segment
+func stripJPEGMetadata(content []byte) ([]byte, []byte, error) {
+	if len(content) < 2 || content[0] != 0xFF || content[1] != 0xD8 {
+		return nil, nil, errInvalidImage
+	}
+	const exifHeader = "Exif\x00\x00"
+	var exif []byte
+	res := []byte{0xFF, 0xD8}
+	i := 2
+	for {
+		if i+2 > len(content) || content[i] != 0xFF {
+			return nil, nil, errInvalidImage
+		}
+		marker := content[i+1]
+		switch {
+		case marker == 0xFF:
+			// fill byte
+			i++
+			continue
+		case marker == 0xD9 || marker == 0xDA:
+			// metadata only comes before the scan data, copy everything from the first scan
+			return append(res, content[i:]...), exif, nil
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			// markers without a payload
+			res = append(res, content[i:i+2]...)
+			i += 2
+			continue
+		}
+		if i+4 > len(content) {
+			return nil, nil, errInvalidImage
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(content[i+2:]))
+		if end < i+4 || end > len(content) {
+			return nil, nil, errInvalidImage
+		}
+		if marker != 0xE1 && marker != 0xED {
+			res = append(res, content[i:end]...)
+		} else if payload := content[i+4 : end]; marker == 0xE1 && exif == nil && bytes.HasPrefix(payload, []byte(exifHeader)) {
+			exif = payload[len(exifHeader):]
+		}
+		i = end
+	}
+}
+
+// png chunks dropped from uploads, exif and text chunks which also carry xmp
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+}
+
+// stripPNGMetadata drops the exif and text chunks of a png, and returns the tiff data of the exif chunk
+func stripPNGMetadata(content []byte) ([]byte, []byte, error) {
+	const signatureLength = 8
+	if len(content) < signatureLength {
+		return nil, nil, errInvalidImage
+	}
+	var exif []byte
+	res := append([]byte{}, content[:signatureLength]...)
+	i := signatureLength
+	for i < len(content) {
+		// length, type, data and crc
+		if i+8 > len(content) {
+			return nil, nil, errInvalidImage
+		}
+		end := i + 12 + int(binary.BigEndian.Uint32(content[i:]))
+		if end < i+12 || end > len(content) {
+			return nil, nil, errInvalidImage
+		}
+		chunkType := string(content[i+4 : i+8])
+		if !pngMetadataChunks[chunkType] {
+			res = append(res, content[i:end]...)
+		} else if chunkType == "eXIf" {
+			exif = content[i+8 : end-4]
+		}
+		i = end
+		if chunkType == "IEND" {
+			break
+		}
+	}
+	return res, exif, nil
+}
+
+// stripWebPMetadata drops the EXIF and XMP chunks of a webp and clears their flags in the VP8X header
+func stripWebPMetadata(content []byte) ([]byte, error) {
+	const headerLength = 12
+	if len(content) < headerLength || string(content[:4]) != "RIFF" || string(content[8:12]) != "WEBP" {
+		return nil, errInvalidImage
+	}
+	res := append([]byte{}, content[:headerLength]...)
+	i := headerLength
+	for i < len(content) {
+		if i+8 > len(content) {
+			return nil, errInvalidImage
+		}
+		// chunks are padded to an even size
+		size := int(binary.LittleEndian.Uint32(content[i+4:]))
+		end := i + 8 + size + size%2
+		if end < i+8 || end > len(content) {
+			return nil, errInvalidImage
+		}
+		chunkType := string(content[i : i+4])
+		switch chunkType {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			start := len(res)
+			res = append(res, content[i:end]...)
+			if size > 0 {
+				const exifFlag, xmpFlag = 0x08, 0x04
+				res[start+8] &^= exifFlag | xmpFlag
+			}
+		default:
+			res = append(res, content[i:end]...)
+		}
+		i = end
+	}
+	binary.LittleEndian.PutUint32(res[4:], uint32(len(res)-8))
+	return res, nil
+}
@@ -0,0 +1,251 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	attachmentpb "gen/attachment-service/pb"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type AttachmentServer struct {
+	attachmentpb.UnimplementedAttachmentServiceServer
+	DBClient dbpb.DBServiceClient
+	Blobs    BlobStore
+}
+
+const (
+	MaxAttachmentSize = 10 * 1024 * 1024 // 10MB
+	MaxFilenameLength = 255
+	DownloadChunkSize = 64 * 1024
+
+	// http.DetectContentType never reads more than this
+	sniffLength = 512
+)
+
+// content types accepted for attachments, sniffed from the content rather than trusted from the client
+var allowedContentTypes = map[string]bool{
+	"image/png":                 true,
+	"image/jpeg":                true,
+	"image/gif":                 true,
+	"image/webp":                true,
+	"application/pdf":           true,
+	"text/plain; charset=utf-8": true,
+}
+
+func (s *AttachmentServer) CheckHealth(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AttachmentServer) UploadAttachment(stream attachmentpb.AttachmentService_UploadAttachmentServer) error {
+	ctx := stream.Context()
+
+	// the first message carries the attachment info
+	req, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return status.Error(codes.InvalidArgument, "Attachment info is required")
+	}
+	if err != nil {
+		return err
+	}
+	info := req.GetInfo()
+	if info == nil {
+		return status.Error(codes.InvalidArgument, "Attachment info must be sent first")
+	}
+	if len(info.GetFilename()) > MaxFilenameLength {
+		return status.Errorf(codes.InvalidArgument, "Filename exceeds maximum length of %d characters", MaxFilenameLength)
+	}
+
+	// buffer the content in a temporary file while hashing it, the digest is only known at the end
+	tmp, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to buffer attachment")
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	head := make([]byte, 0, sniffLength)
+	var size int64
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if req.GetInfo() != nil {
+			return status.Error(codes.InvalidArgument, "Attachment info can only be sent once")
+		}
+		chunk := req.GetChunk()
+		size += int64(len(chunk))
+		if size > MaxAttachmentSize {
+			return status.Errorf(codes.InvalidArgument, "Attachment exceeds maximum size of %d bytes", MaxAttachmentSize)
+		}
+		if len(head) < sniffLength {
+			head = append(head, chunk[:min(len(chunk), sniffLength-len(head))]...)
+		}
+		hash.Write(chunk)
+		if _, err := tmp.Write(chunk); err != nil {
+			return status.Error(codes.Internal, "Failed to buffer attachment")
+		}
+	}
+	if size == 0 {
+		return status.Error(codes.InvalidArgument, "Attachment is empty")
+	}
+	contentType := http.DetectContentType(head)
+	if !allowedContentTypes[contentType] {
+		return status.Errorf(codes.InvalidArgument, "Unsupported attachment type %s", contentType)
+	}
+
+	digest := hex.EncodeToString(hash.Sum(nil))
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return status.Error(codes.Internal, "Failed to buffer attachment")
+	}
+	createReq := &dbpb.CreateAttachmentRequest{
+		Filename:    info.Filename,
+		ContentType: contentType,
+		Size:        size,
+		Sha256:      digest,
+		AuthorId:    info.AuthorId,
+	}
+
+	// images are stored without their metadata, the digest is the one of the stripped content
+	var content io.Reader = tmp
+	if strings.HasPrefix(contentType, "image/") {
+		data, err := io.ReadAll(tmp)
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to buffer attachment")
+		}
+		img, err := processImage(data, contentType)
+		if errors.Is(err, errInvalidImage) {
+			return status.Error(codes.InvalidArgument, "Attachment is not a valid image")
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to process image")
+		}
+		content = bytes.NewReader(img.content)
+		createReq.Size = int64(len(img.content))
+		createReq.Sha256 = blobKey(img.content)
+		if img.thumbnail != nil {
+			createReq.Width = int32(img.width)
+			createReq.Height = int32(img.height)
+			createReq.ThumbnailSha256 = blobKey(img.thumbnail)
+			createReq.ThumbnailContentType = img.thumbnailContentType
+			createReq.ThumbnailSize = int64(len(img.thumbnail))
+			if err := s.Blobs.Put(ctx, createReq.ThumbnailSha256, bytes.NewReader(img.thumbnail)); err != nil {
+				return status.Error(codes.Internal, "Failed to store attachment")
+			}
+		}
+	}
+
+	// store content, identical uploads share the same blob
+	if err := s.Blobs.Put(ctx, createReq.Sha256, content); err != nil {
+		return status.Error(codes.Internal, "Failed to store attachment")
+	}
+
+	// create attachment
+	res, err := s.DBClient.CreateAttachment(ctx, createReq)
+	if err != nil {
+		return err
+	}
+	attachment, err := s.DBClient.GetAttachment(ctx, &dbpb.GetAttachmentRequest{
+		Id: res.Id,
+	})
+	if err != nil {
+		return err
+	}
+	return stream.SendAndClose(attachment)
+}
+
+func (s *AttachmentServer) GetAttachment(ctx context.Context, req *attachmentpb.GetAttachmentRequest) (*models.Attachment, error) {
+	// validate inputs
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Attachment id is required")
+	}
+
+	// fetch attachment
+	return s.DBClient.GetAttachment(ctx, &dbpb.GetAttachmentRequest{
+		Id: req.Id,
+	})
+}
+
+func (s *AttachmentServer) DownloadAttachment(req *attachmentpb.DownloadAttachmentRequest, stream attachmentpb.AttachmentService_DownloadAttachmentServer) error {
+	ctx := stream.Context()
+
+	// validate inputs
+	if req.GetId() == "" {
+		return status.Error(codes.InvalidArgument, "Attachment id is required")
+	}
+
+	// fetch attachment
+	attachment, err := s.DBClient.GetAttachment(ctx, &dbpb.GetAttachmentRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return err
+	}
+	return s.sendBlob(stream, attachment.Sha256)
+}
+
+func (s *AttachmentServer) DownloadThumbnail(req *attachmentpb.DownloadAttachmentRequest, stream attachmentpb.AttachmentService_DownloadThumbnailServer) error {
+	ctx := stream.Context()
+
+	// validate inputs
+	if req.GetId() == "" {
+		return status.Error(codes.InvalidArgument, "Attachment id is required")
+	}
+
+	// fetch attachment
+	attachment, err := s.DBClient.GetAttachment(ctx, &dbpb.GetAttachmentRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return err
+	}
+	if attachment.ThumbnailSha256 == "" {
+		return status.Error(codes.NotFound, "Attachment has no thumbnail")
+	}
+	return s.sendBlob(stream, attachment.ThumbnailSha256)
+}
+
+// sendBlob streams the blob stored under key in chunks
+func (s *AttachmentServer) sendBlob(stream attachmentpb.AttachmentService_DownloadAttachmentServer, key string) error {
+	content, err := s.Blobs.Get(stream.Context(), key)
+	if errors.Is(err, ErrBlobNotFound) {
+		return status.Error(codes.NotFound, "Attachment content not found")
+	}
+	if err != nil {
+		return status.Error(codes.Internal, "Failed to read attachment")
+	}
+	defer content.Close()
+
+	// stream content in chunks
+	buf := make([]byte, DownloadChunkSize)
+	for {
+		n, err := content.Read(buf)
+		if n > 0 {
+			if err := stream.Send(&attachmentpb.DownloadAttachmentResponse{Chunk: buf[:n]}); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return status.Error(codes.Internal, "Failed to read attachment")
+		}
+	}
+}
@@ -0,0 +1,483 @@
+package test
+
+import (
+	src "attachment-service/src"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+
+	attachmentpb "gen/attachment-service/pb"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MockDBClient struct {
+	dbpb.DBServiceClient
+	CreateAttachmentFunc func(ctx context.Context, req *dbpb.CreateAttachmentRequest, opts ...grpc.CallOption) (*dbpb.CreateAttachmentResponse, error)
+	GetAttachmentFunc    func(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error)
+}
+
+func (m *MockDBClient) CreateAttachment(ctx context.Context, req *dbpb.CreateAttachmentRequest, opts ...grpc.CallOption) (*dbpb.CreateAttachmentResponse, error) {
+	return m.CreateAttachmentFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetAttachment(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error) {
+	return m.GetAttachmentFunc(ctx, req, opts...)
+}
+
+// MockUploadStream replays requests to the server and records the response
+type MockUploadStream struct {
+	grpc.ServerStream
+	requests []*attachmentpb.UploadAttachmentRequest
+	response *models.Attachment
+}
+
+func (m *MockUploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *MockUploadStream) Recv() (*attachmentpb.UploadAttachmentRequest, error) {
+	if len(m.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := m.requests[0]
+	m.requests = m.requests[1:]
+	return req, nil
+}
+
+func (m *MockUploadStream) SendAndClose(res *models.Attachment) error {
+	m.response = res
+	return nil
+}
+
+// MockDownloadStream records the chunks sent by the server
+type MockDownloadStream struct {
+	grpc.ServerStream
+	content bytes.Buffer
+}
+
+func (m *MockDownloadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *MockDownloadStream) Send(res *attachmentpb.DownloadAttachmentResponse) error {
+	m.content.Write(res.Chunk)
+	return nil
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+var pdfHeader = []byte("%PDF-1.7\n")
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i)
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func encodeJPEG(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, nil))
+	return buf.Bytes()
+}
+
+func upload(t *testing.T, server *src.AttachmentServer, filename string, content []byte) *models.Attachment {
+	stream := &MockUploadStream{requests: []*attachmentpb.UploadAttachmentRequest{infoRequest(filename), chunkRequest(content)}}
+	assert.NoError(t, server.UploadAttachment(stream))
+	return stream.response
+}
+
+func infoRequest(filename string) *attachmentpb.UploadAttachmentRequest {
+	return &attachmentpb.UploadAttachmentRequest{
+		Data: &attachmentpb.UploadAttachmentRequest_Info{Info: &attachmentpb.AttachmentInfo{Filename: filename, AuthorId: "123"}},
+	}
+}
+
+func chunkRequest(chunk []byte) *attachmentpb.UploadAttachmentRequest {
+	return &attachmentpb.UploadAttachmentRequest{
+		Data: &attachmentpb.UploadAttachmentRequest_Chunk{Chunk: chunk},
+	}
+}
+
+func newTestServer(t *testing.T) (*src.AttachmentServer, map[string]*dbpb.CreateAttachmentRequest) {
+	blobs, err := src.NewFileBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	attachments := map[string]*dbpb.CreateAttachmentRequest{}
+	server := &src.AttachmentServer{
+		DBClient: &MockDBClient{
+			CreateAttachmentFunc: func(ctx context.Context, req *dbpb.CreateAttachmentRequest, opts ...grpc.CallOption) (*dbpb.CreateAttachmentResponse, error) {
+				attachments["1"] = req
+				return &dbpb.CreateAttachmentResponse{Id: "1"}, nil
+			},
+			GetAttachmentFunc: func(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error) {
+				attachment, ok := attachments[req.GetId()]
+				if !ok {
+					return nil, status.Error(codes.NotFound, "Attachment not found")
+				}
+				return &models.Attachment{
+					Id:                   req.GetId(),
+					Filename:             attachment.Filename,
+					ContentType:          attachment.ContentType,
+					Size:                 attachment.Size,
+					Sha256:               attachment.Sha256,
+					AuthorId:             attachment.AuthorId,
+					Width:                attachment.Width,
+					Height:               attachment.Height,
+					ThumbnailSha256:      attachment.ThumbnailSha256,
+					ThumbnailContentType: attachment.ThumbnailContentType,
+					ThumbnailSize:        attachment.ThumbnailSize,
+				}, nil
+			},
+		},
+		Blobs: blobs,
+	}
+	return server, attachments
+}
+
+func TestUploadAttachment_Validation(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests []*attachmentpb.UploadAttachmentRequest
+		wantErr  error
+	}{
+		{
+			name:     "no info",
+			requests: nil,
+			wantErr:  status.Error(codes.InvalidArgument, "Attachment info is required"),
+		},
+		{
+			name:     "chunk before info",
+			requests: []*attachmentpb.UploadAttachmentRequest{chunkRequest(pngHeader)},
+			wantErr:  status.Error(codes.InvalidArgument, "Attachment info must be sent first"),
+		},
+		{
+			name:     "info sent twice",
+			requests: []*attachmentpb.UploadAttachmentRequest{infoRequest("a.png"), infoRequest("a.png")},
+			wantErr:  status.Error(codes.InvalidArgument, "Attachment info can only be sent once"),
+		},
+		{
+			name:     "empty attachment",
+			requests: []*attachmentpb.UploadAttachmentRequest{infoRequest("a.png")},
+			wantErr:  status.Error(codes.InvalidArgument, "Attachment is empty"),
+		},
+		{
+			name:     "too large",
+			requests: []*attachmentpb.UploadAttachmentRequest{infoRequest("a.png"), chunkRequest(pngHeader), chunkRequest(make([]byte, src.MaxAttachmentSize))},
+			wantErr:  status.Errorf(codes.InvalidArgument, "Attachment exceeds maximum size of %d bytes", src.MaxAttachmentSize),
+		},
+		{
+			name:     "unsupported type",
+			requests: []*attachmentpb.UploadAttachmentRequest{infoRequest("a.png"), chunkRequest([]byte("<html><script>alert(1)</script></html>"))},
+			wantErr:  status.Error(codes.InvalidArgument, "Unsupported attachment type text/html; charset=utf-8"),
+		},
+		{
+			name:     "invalid image",
+			requests: []*attachmentpb.UploadAttachmentRequest{infoRequest("a.png"), chunkRequest(pngHeader)},
+			wantErr:  status.Error(codes.InvalidArgument, "Attachment is not a valid image"),
+		},
+		{
+			name:     "valid request",
+			requests: []*attachmentpb.UploadAttachmentRequest{infoRequest("a.png"), chunkRequest(encodePNG(t, 10, 10))},
+			wantErr:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t)
+			err := server.UploadAttachment(&MockUploadStream{requests: tt.requests})
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUploadAttachment_RoundTrip(t *testing.T) {
+	server, _ := newTestServer(t)
+	content := append(append([]byte{}, pdfHeader...), bytes.Repeat([]byte{1}, 3*src.DownloadChunkSize)...)
+
+	// upload content in uneven chunks
+	requests := []*attachmentpb.UploadAttachmentRequest{infoRequest("cat.pdf")}
+	for i := 0; i < len(content); i += 1000 {
+		requests = append(requests, chunkRequest(content[i:min(i+1000, len(content))]))
+	}
+	upload := &MockUploadStream{requests: requests}
+	assert.NoError(t, server.UploadAttachment(upload))
+
+	digest := sha256.Sum256(content)
+	assert.Equal(t, "application/pdf", upload.response.GetContentType())
+	assert.Equal(t, int64(len(content)), upload.response.GetSize())
+	assert.Equal(t, hex.EncodeToString(digest[:]), upload.response.GetSha256())
+	assert.Equal(t, "cat.pdf", upload.response.GetFilename())
+	assert.Empty(t, upload.response.GetThumbnailSha256())
+
+	// download it again
+	download := &MockDownloadStream{}
+	assert.NoError(t, server.DownloadAttachment(&attachmentpb.DownloadAttachmentRequest{Id: upload.response.GetId()}, download))
+	assert.Equal(t, content, download.content.Bytes())
+}
+
+func TestUploadAttachment_Thumbnail(t *testing.T) {
+	tests := []struct {
+		name          string
+		content       []byte
+		wantType      string
+		width, height int
+	}{
+		{
+			name:     "landscape png",
+			content:  encodePNG(t, 800, 400),
+			wantType: "image/png",
+			width:    src.ThumbnailSize,
+			height:   src.ThumbnailSize / 2,
+		},
+		{
+			name:     "portrait jpeg",
+			content:  encodeJPEG(t, 300, 900),
+			wantType: "image/jpeg",
+			width:    src.ThumbnailSize / 3,
+			height:   src.ThumbnailSize,
+		},
+		{
+			name:     "small images are not upscaled",
+			content:  encodePNG(t, 20, 10),
+			wantType: "image/png",
+			width:    20,
+			height:   10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t)
+			attachment := upload(t, server, "image", tt.content)
+			assert.Equal(t, tt.wantType, attachment.GetThumbnailContentType())
+
+			download := &MockDownloadStream{}
+			assert.NoError(t, server.DownloadThumbnail(&attachmentpb.DownloadAttachmentRequest{Id: attachment.GetId()}, download))
+			assert.Equal(t, attachment.GetThumbnailSize(), int64(download.content.Len()))
+			config, format, err := image.DecodeConfig(&download.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantType, "image/"+format)
+			assert.Equal(t, tt.width, config.Width)
+			assert.Equal(t, tt.height, config.Height)
+		})
+	}
+}
+
+func TestUploadAttachment_StripsMetadata(t *testing.T) {
+	const secret = "GPS 48.8584 N 2.2945 E"
+
+	// exif segment right after the start of image marker
+	jpegContent := encodeJPEG(t, 16, 16)
+	exif := append([]byte("Exif\x00\x00"), secret...)
+	segment := append([]byte{0xFF, 0xE1, 0, byte(len(exif) + 2)}, exif...)
+	jpegContent = append(append(append([]byte{}, jpegContent[:2]...), segment...), jpegContent[2:]...)
+
+	// text chunk right after the header chunk
+	pngContent := encodePNG(t, 16, 16)
+	text := append([]byte("Comment\x00"), secret...)
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(text)))
+	chunk = append(append(chunk, "tEXt"...), text...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	const ihdrEnd = 8 + 12 + 13
+	pngContent = append(append(append([]byte{}, pngContent[:ihdrEnd]...), chunk...), pngContent[ihdrEnd:]...)
+
+	// extended header with the exif flag followed by an exif chunk
+	webpChunk := func(chunkType string, data []byte) []byte {
+		return append(binary.LittleEndian.AppendUint32([]byte(chunkType), uint32(len(data))), data...)
+	}
+	webpBody := append([]byte("WEBP"), webpChunk("VP8X", []byte{0x08, 0, 0, 0, 0, 0, 0, 0, 0, 0})...)
+	webpBody = append(webpBody, webpChunk("VP8L", []byte{0x2f, 0, 0, 0, 0, 0})...)
+	webpBody = append(webpBody, webpChunk("EXIF", []byte(secret))...)
+	webpContent := append(binary.LittleEndian.AppendUint32([]byte("RIFF"), uint32(len(webpBody))), webpBody...)
+
+	tests := []struct {
+		name    string
+		content []byte
+	}{
+		{name: "jpeg", content: jpegContent},
+		{name: "png", content: pngContent},
+		{name: "webp", content: webpContent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t)
+			attachment := upload(t, server, "image", tt.content)
+
+			download := &MockDownloadStream{}
+			assert.NoError(t, server.DownloadAttachment(&attachmentpb.DownloadAttachmentRequest{Id: attachment.GetId()}, download))
+			content := download.content.Bytes()
+			assert.NotContains(t, string(content), secret)
+			assert.Equal(t, int64(len(content)), attachment.GetSize())
+			digest := sha256.Sum256(content)
+			assert.Equal(t, hex.EncodeToString(digest[:]), attachment.GetSha256())
+			if tt.name == "webp" {
+				assert.Equal(t, uint32(len(content)-8), binary.LittleEndian.Uint32(content[4:]))
+				assert.Zero(t, content[20]&0x08)
+			} else {
+				_, _, err := image.Decode(bytes.NewReader(content))
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUploadAttachment_Orientation(t *testing.T) {
+	// big-endian tiff with a single orientation entry
+	exif := func(orientation byte) []byte {
+		return []byte{'M', 'M', 0, 42, 0, 0, 0, 8, 0, 1, 0x01, 0x12, 0, 3, 0, 0, 0, 1, 0, orientation, 0, 0, 0, 0, 0, 0}
+	}
+
+	// red left half and blue right half
+	img := image.NewRGBA(image.Rect(0, 0, 32, 16))
+	for y := 0; y < 16; y++ {
+		for x := 0; x < 32; x++ {
+			if x < 16 {
+				img.Set(x, y, color.RGBA{R: 255, A: 255})
+			} else {
+				img.Set(x, y, color.RGBA{B: 255, A: 255})
+			}
+		}
+	}
+	var buf bytes.Buffer
+	assert.NoError(t, jpeg.Encode(&buf, img, &jpeg.Options{Quality: 100}))
+	jpegExif := append([]byte("Exif\x00\x00"), exif(6)...)
+	segment := append([]byte{0xFF, 0xE1, 0, byte(len(jpegExif) + 2)}, jpegExif...)
+	jpegContent := append(append(append([]byte{}, buf.Bytes()[:2]...), segment...), buf.Bytes()[2:]...)
+
+	buf.Reset()
+	assert.NoError(t, png.Encode(&buf, img))
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(exif(3))))
+	chunk = append(append(chunk, "eXIf"...), exif(3)...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+	const ihdrEnd = 8 + 12 + 13
+	pngContent := append(append(append([]byte{}, buf.Bytes()[:ihdrEnd]...), chunk...), buf.Bytes()[ihdrEnd:]...)
+
+	tests := []struct {
+		name          string
+		content       []byte
+		width, height int
+		// points expected red and blue once upright
+		red, blue image.Point
+	}{
+		{
+			name:    "jpeg turned clockwise",
+			content: jpegContent,
+			width:   16,
+			height:  32,
+			red:     image.Pt(8, 4),
+			blue:    image.Pt(8, 28),
+		},
+		{
+			name:    "png turned upside down",
+			content: pngContent,
+			width:   32,
+			height:  16,
+			red:     image.Pt(28, 8),
+			blue:    image.Pt(4, 8),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t)
+			attachment := upload(t, server, "image", tt.content)
+			assert.Equal(t, int32(tt.width), attachment.GetWidth())
+			assert.Equal(t, int32(tt.height), attachment.GetHeight())
+
+			download := &MockDownloadStream{}
+			assert.NoError(t, server.DownloadAttachment(&attachmentpb.DownloadAttachmentRequest{Id: attachment.GetId()}, download))
+			upright, _, err := image.Decode(&download.content)
+			assert.NoError(t, err)
+			assert.Equal(t, image.Rect(0, 0, tt.width, tt.height), upright.Bounds())
+			r, _, b, _ := upright.At(tt.red.X, tt.red.Y).RGBA()
+			assert.Greater(t, r, b)
+			r, _, b, _ = upright.At(tt.blue.X, tt.blue.Y).RGBA()
+			assert.Greater(t, b, r)
+		})
+	}
+}
+
+func TestDownloadThumbnail_Validation(t *testing.T) {
+	server, _ := newTestServer(t)
+	pdf := upload(t, server, "a.pdf", pdfHeader)
+
+	err := server.DownloadThumbnail(&attachmentpb.DownloadAttachmentRequest{}, &MockDownloadStream{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Attachment id is required").Error(), err.Error())
+	err = server.DownloadThumbnail(&attachmentpb.DownloadAttachmentRequest{Id: pdf.GetId()}, &MockDownloadStream{})
+	assert.Equal(t, status.Error(codes.NotFound, "Attachment has no thumbnail").Error(), err.Error())
+}
+
+func TestDownloadAttachment_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *attachmentpb.DownloadAttachmentRequest
+		wantErr error
+	}{
+		{
+			name:    "missing id",
+			req:     &attachmentpb.DownloadAttachmentRequest{},
+			wantErr: status.Error(codes.InvalidArgument, "Attachment id is required"),
+		},
+		{
+			name:    "unknown attachment",
+			req:     &attachmentpb.DownloadAttachmentRequest{Id: "404"},
+			wantErr: status.Error(codes.NotFound, "Attachment not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newTestServer(t)
+			err := server.DownloadAttachment(tt.req, &MockDownloadStream{})
+			assert.Equal(t, tt.wantErr.Error(), err.Error())
+		})
+	}
+}
+
+func TestFileBlobStore(t *testing.T) {
+	blobs, err := src.NewFileBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	ctx := context.Background()
+	key := hex.EncodeToString(make([]byte, sha256.Size))
+
+	_, err = blobs.Get(ctx, key)
+	assert.ErrorIs(t, err, src.ErrBlobNotFound)
+
+	// the first content stored under a key is kept
+	assert.NoError(t, blobs.Put(ctx, key, bytes.NewReader([]byte("first"))))
+	assert.NoError(t, blobs.Put(ctx, key, bytes.NewReader([]byte("second"))))
+	content, err := blobs.Get(ctx, key)
+	assert.NoError(t, err)
+	data, err := io.ReadAll(content)
+	content.Close()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", string(data))
+
+	// keys cannot escape the storage root
+	assert.Error(t, blobs.Put(ctx, "../../etc/passwd", bytes.NewReader(nil)))
+}
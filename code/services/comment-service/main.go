@@ -4,7 +4,9 @@ import (
 	server "comment-service/src"
 	"fmt"
 	commentpb "gen/comment-service/pb"
+	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
+	moderationpb "gen/moderation-service/pb"
 	threadpb "gen/thread-service/pb"
 	"log"
 	"net"
@@ -50,10 +52,20 @@ func main() {
 	threadConn := connectGrpcClient("THREAD_SERVICE_HOST", "THREAD_SERVICE_PORT")
 	defer threadConn.Close()
 
+	// connect to community service
+	communityConn := connectGrpcClient("COMMUNITY_SERVICE_HOST", "COMMUNITY_SERVICE_PORT")
+	defer communityConn.Close()
+
+	// connect to moderation service
+	moderationConn := connectGrpcClient("MODERATION_SERVICE_HOST", "MODERATION_SERVICE_PORT")
+	defer moderationConn.Close()
+
 	// create comment service with database service and thread
 	commentService := &server.CommentServer{
-		DBClient:     dbpb.NewDBServiceClient(dbConn),
-		ThreadClient: threadpb.NewThreadServiceClient(threadConn),
+		DBClient:         dbpb.NewDBServiceClient(dbConn),
+		ThreadClient:     threadpb.NewThreadServiceClient(threadConn),
+		CommunityClient:  communitypb.NewCommunityServiceClient(communityConn),
+		ModerationClient: moderationpb.NewModerationServiceClient(moderationConn),
 	}
 
 	// get env port
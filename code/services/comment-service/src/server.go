@@ -2,11 +2,16 @@ package server
 
 import (
 	"context"
+	"gen/auth"
 	commentpb "gen/comment-service/pb"
+	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
+	moderationpb "gen/moderation-service/pb"
 	threadpb "gen/thread-service/pb"
+	"log"
 	"math"
+	"unicode/utf8"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -15,12 +20,15 @@ import (
 
 type CommentServer struct {
 	commentpb.UnimplementedCommentServiceServer
-	DBClient     dbpb.DBServiceClient
-	ThreadClient threadpb.ThreadServiceClient
+	DBClient         dbpb.DBServiceClient
+	ThreadClient     threadpb.ThreadServiceClient
+	CommunityClient  communitypb.CommunityServiceClient
+	ModerationClient moderationpb.ModerationServiceClient
 }
 
 const (
-	MaxCommentLength = 500
+	// maximum number of attachments embedded in a comment
+	MaxCommentAttachments = 4
 )
 
 func (s *CommentServer) CheckHealth(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
@@ -41,19 +49,27 @@ func (s *CommentServer) ListComments(ctx context.Context, req *commentpb.ListCom
 	if req.SortBy != nil && req.GetSortBy() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Sort cannot be empty")
 	}
+	if req.PageToken != nil && req.GetPageToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Page token cannot be empty")
+	}
 
 	// fetch comments
 	res, err := s.DBClient.ListComments(ctx, &dbpb.ListCommentsRequest{
-		ThreadId: req.ThreadId,
-		Offset:   req.Offset,
-		Limit:    req.Limit,
-		SortBy:   req.SortBy,
+		ThreadId:  req.ThreadId,
+		Offset:    req.Offset,
+		Limit:     req.Limit,
+		SortBy:    req.SortBy,
+		PageToken: req.PageToken,
 	})
 	if err != nil {
 		return nil, err
 	}
+	for _, comment := range res.Comments {
+		maskRemovedComment(comment)
+	}
 	return &commentpb.ListCommentsResponse{
-		Comments: res.Comments,
+		Comments:      res.Comments,
+		NextPageToken: res.NextPageToken,
 	}, nil
 }
 
@@ -65,35 +81,57 @@ func (s *CommentServer) CreateComment(ctx context.Context, req *commentpb.Create
 	if req.GetContent() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Content is required")
 	}
-	if len(req.GetContent()) > MaxCommentLength {
-		return nil, status.Errorf(codes.InvalidArgument, "Content exceeds maximum length of %d characters", MaxCommentLength)
+	if len(req.GetAttachmentIds()) > MaxCommentAttachments {
+		return nil, status.Errorf(codes.InvalidArgument, "Comment cannot have more than %d attachments", MaxCommentAttachments)
+	}
+	for _, id := range req.GetAttachmentIds() {
+		_, err := s.DBClient.GetAttachment(ctx, &dbpb.GetAttachmentRequest{
+			Id: id,
+		})
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.InvalidArgument, "Attachment not found")
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	// create comment
-	res, err := s.DBClient.CreateComment(ctx, &dbpb.CreateCommentRequest{
-		Content:    req.Content,
-		ParentId:   req.ParentId,
-		ParentType: req.ParentType,
+	// deleted, removed, locked and archived threads do not accept new comments
+	thread, err := s.getThread(ctx, req.ParentId, req.ParentType)
+	if err != nil {
+		return nil, err
+	}
+	if thread.RemovalType != models.RemovalType_NOT_REMOVED {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is removed")
+	}
+	if thread.Archived {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is archived")
+	}
+	if thread.Locked {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is locked")
+	}
+	if err := s.validateContent(ctx, req.GetContent(), thread.CommunityId); err != nil {
+		return nil, err
+	}
+
+	// create comment, with the parent num_comments in the same transaction
+	res, err := s.DBClient.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{
+		Content:       req.Content,
+		ParentId:      req.ParentId,
+		ParentType:    req.ParentType,
+		AuthorId:      auth.RequesterId(ctx),
+		AttachmentIds: req.AttachmentIds,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// update parent num_comments
-	numCommentsOffset := int32(1)
-	if req.ParentType == models.CommentParentType_THREAD {
-		_, err = s.ThreadClient.UpdateThread(ctx, &threadpb.UpdateThreadRequest{
-			Id:                req.ParentId,
-			NumCommentsOffset: &numCommentsOffset,
-		})
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		_, err = s.UpdateComment(ctx, &commentpb.UpdateCommentRequest{
-			Id:                req.ParentId,
-			NumCommentsOffset: &numCommentsOffset,
-		})
+	// run automod rules, the comment is already created so failures are only logged
+	_, err = s.ModerationClient.EvaluateComment(ctx, &moderationpb.EvaluateCommentRequest{
+		CommentId: res.Id,
+	})
+	if err != nil {
+		log.Printf("failed to evaluate automod rules for comment %s: %v", res.Id, err)
 	}
 
 	return &commentpb.CreateCommentResponse{
@@ -114,7 +152,7 @@ func (s *CommentServer) GetComment(ctx context.Context, req *commentpb.GetCommen
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	return maskRemovedComment(res), nil
 }
 
 func (s *CommentServer) UpdateComment(ctx context.Context, req *commentpb.UpdateCommentRequest) (*emptypb.Empty, error) {
@@ -122,9 +160,6 @@ func (s *CommentServer) UpdateComment(ctx context.Context, req *commentpb.Update
 	if req.GetId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "Comment id is required")
 	}
-	if req.Content != nil && len(req.GetContent()) > MaxCommentLength {
-		return nil, status.Errorf(codes.InvalidArgument, "Content exceeds maximum length of %d characters", MaxCommentLength)
-	}
 	if req.VoteOffset != nil && math.Abs(float64(req.GetVoteOffset())) != 1 {
 		return nil, status.Errorf(codes.InvalidArgument, "Vote offset must be either -1 or 1")
 	}
@@ -132,12 +167,43 @@ func (s *CommentServer) UpdateComment(ctx context.Context, req *commentpb.Update
 		return nil, status.Errorf(codes.InvalidArgument, "Number comments offset must be either -1 or 1")
 	}
 
+	// comments of archived threads are read-only and comments of locked threads cannot be voted on
+	if req.Content != nil || req.VoteOffset != nil {
+		comment, err := s.DBClient.GetComment(ctx, &dbpb.GetCommentRequest{
+			Id: req.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		thread, err := s.getThread(ctx, comment.ParentId, comment.ParentType)
+		if err != nil {
+			return nil, err
+		}
+		if thread.Archived {
+			return nil, status.Error(codes.FailedPrecondition, "Thread is archived")
+		}
+		if thread.Locked && req.VoteOffset != nil {
+			return nil, status.Error(codes.FailedPrecondition, "Thread is locked")
+		}
+		if req.Content != nil {
+			// only the author or a moderator can edit a comment, comments created before they were attributed have no
+			// author to check against and are only edited by moderators
+			if (comment.GetAuthorId() == "" || comment.GetAuthorId() != auth.RequesterId(ctx)) && !auth.RequesterIsModerator(ctx) {
+				return nil, status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a comment")
+			}
+			if err := s.validateContent(ctx, req.GetContent(), thread.CommunityId); err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	// update comment
 	_, err := s.DBClient.UpdateComment(ctx, &dbpb.UpdateCommentRequest{
 		Id:                req.Id,
 		Content:           req.Content,
 		VoteOffset:        req.VoteOffset,
 		NumCommentsOffset: req.NumCommentsOffset,
+		EditorId:          auth.RequesterId(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -146,62 +212,174 @@ func (s *CommentServer) UpdateComment(ctx context.Context, req *commentpb.Update
 	return &emptypb.Empty{}, nil
 }
 
-func (s *CommentServer) DeleteComment(ctx context.Context, req *commentpb.DeleteCommentRequest) (*emptypb.Empty, error) {
+func (s *CommentServer) ListRevisions(ctx context.Context, req *commentpb.ListRevisionsRequest) (*commentpb.ListRevisionsResponse, error) {
 	// validate input
 	if req.GetId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "Comment id is required")
 	}
 
-	// fetch comment
-	res, err := s.DBClient.GetComment(ctx, &dbpb.GetCommentRequest{
+	// the history of a comment goes away with it when its author deletes it
+	comment, err := s.DBClient.GetComment(ctx, &dbpb.GetCommentRequest{
 		Id: req.Id,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if comment.GetRemovalType() == models.RemovalType_DELETED {
+		return nil, status.Errorf(codes.FailedPrecondition, "Comment is deleted")
+	}
+	// removed comments are masked, their history would reveal the removed content to anyone but moderators
+	if comment.GetRemovalType() != models.RemovalType_NOT_REMOVED && !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can list the revisions of removed comments")
+	}
 
-	// find all child comments
-	comments, err := s.ListComments(ctx, &commentpb.ListCommentsRequest{
-		ThreadId: &res.Id,
+	// fetch revisions
+	res, err := s.DBClient.ListRevisions(ctx, &dbpb.ListRevisionsRequest{
+		CommentId: req.Id,
 	})
 	if err != nil {
 		return nil, err
 	}
+	return &commentpb.ListRevisionsResponse{
+		Revisions: res.Revisions,
+	}, nil
+}
+
+func (s *CommentServer) DeleteComment(ctx context.Context, req *commentpb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Comment id is required")
+	}
 
-	// delete child comments
-	for _, comment := range comments.Comments {
-		_, err = s.DeleteComment(ctx, &commentpb.DeleteCommentRequest{
-			Id: comment.Id,
-		})
-		if err != nil {
-			return nil, err
+	// only the author or a moderator can delete a comment. Comments of other users and comments created before they
+	// were attributed, which have no author to check against, are removed as a moderator
+	comment, err := s.DBClient.GetComment(ctx, &dbpb.GetCommentRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	removalType := models.RemovalType_DELETED
+	if comment.GetAuthorId() == "" || comment.GetAuthorId() != auth.RequesterId(ctx) {
+		if !auth.RequesterIsModerator(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a comment")
 		}
+		removalType = models.RemovalType_REMOVED
+	}
+
+	// soft delete comment, replies are kept so the tree stays intact
+	_, err = s.DBClient.RemoveComment(ctx, &dbpb.RemoveCommentRequest{
+		Id:          req.Id,
+		RemovalType: removalType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CommentServer) RemoveComment(ctx context.Context, req *commentpb.RemoveCommentRequest) (*emptypb.Empty, error) {
+	// validate inputs
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Comment id is required")
+	}
+	if req.GetReason() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Removal reason is required")
+	}
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can remove comments")
+	}
+
+	// soft delete comment as a moderator
+	_, err := s.DBClient.RemoveComment(ctx, &dbpb.RemoveCommentRequest{
+		Id:          req.Id,
+		RemovalType: models.RemovalType_REMOVED,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CommentServer) RestoreComment(ctx context.Context, req *commentpb.RestoreCommentRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Comment id is required")
+	}
+
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can restore comments")
 	}
 
-	// delete comment
-	_, err = s.DBClient.DeleteComment(ctx, &dbpb.DeleteCommentRequest{
+	// restore comment, the db service rejects comments past their grace period
+	_, err := s.DBClient.RestoreComment(ctx, &dbpb.RestoreCommentRequest{
 		Id: req.Id,
 	})
 	if err != nil {
 		return nil, err
 	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *CommentServer) PurgeComment(ctx context.Context, req *commentpb.PurgeCommentRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Comment id is required")
+	}
+
+	// delete comment and its replies at any depth, with the parent num_comments in the same transaction
+	_, err := s.DBClient.DeleteCommentTree(ctx, &dbpb.DeleteCommentRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &emptypb.Empty{}, nil
+}
 
-	// update parent num_comments
-	numCommentsOffset := int32(-1)
-	if res.ParentType == models.CommentParentType_THREAD {
-		_, err = s.ThreadClient.UpdateThread(ctx, &threadpb.UpdateThreadRequest{
-			Id:                res.ParentId,
-			NumCommentsOffset: &numCommentsOffset,
+// getThread walks up the reply chain to the thread a comment belongs to
+func (s *CommentServer) getThread(ctx context.Context, parentId string, parentType models.CommentParentType) (*models.Thread, error) {
+	for parentType == models.CommentParentType_COMMENT {
+		parent, err := s.DBClient.GetComment(ctx, &dbpb.GetCommentRequest{
+			Id: parentId,
 		})
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		_, err = s.UpdateComment(ctx, &commentpb.UpdateCommentRequest{
-			Id:                res.ParentId,
-			NumCommentsOffset: &numCommentsOffset,
-		})
+		parentId, parentType = parent.ParentId, parent.ParentType
 	}
+	return s.ThreadClient.GetThread(ctx, &threadpb.GetThreadRequest{
+		Id: parentId,
+	})
+}
 
-	return &emptypb.Empty{}, nil
+// checks the length of a comment in characters against the posting rules of the community of its thread
+func (s *CommentServer) validateContent(ctx context.Context, content string, communityId string) error {
+	rules, err := s.CommunityClient.GetCommunityPostingRules(ctx, &communitypb.GetCommunityPostingRulesRequest{
+		Id: communityId,
+	})
+	if err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(content) > int(rules.GetMaxCommentLength()) {
+		return status.Errorf(codes.InvalidArgument, "Content exceeds maximum length of %d characters", rules.GetMaxCommentLength())
+	}
+	return nil
+}
+
+// replaces the content of deleted and removed comments with a marker
+func maskRemovedComment(comment *models.Comment) *models.Comment {
+	switch comment.GetRemovalType() {
+	case models.RemovalType_DELETED:
+		comment.Content = "[deleted]"
+	case models.RemovalType_REMOVED:
+		comment.Content = "[removed]"
+	}
+	if comment.GetRemovalType() != models.RemovalType_NOT_REMOVED {
+		comment.ContentHtml = "<p>" + comment.Content + "</p>"
+		comment.AttachmentIds = nil
+	}
+	return comment
 }
@@ -2,36 +2,45 @@ package test
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	src "comment-service/src"
+	"gen/auth"
 	commentpb "gen/comment-service/pb"
+	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
+	moderationpb "gen/moderation-service/pb"
 	threadpb "gen/thread-service/pb"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 type MockDBClient struct {
 	dbpb.DBServiceClient
-	ListCommentsFunc  func(ctx context.Context, req *dbpb.ListCommentsRequest, opts ...grpc.CallOption) (*dbpb.ListCommentsResponse, error)
-	CreateCommentFunc func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error)
-	GetCommentFunc    func(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error)
-	UpdateCommentFunc func(ctx context.Context, req *dbpb.UpdateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	DeleteCommentFunc func(ctx context.Context, req *dbpb.DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCommentsFunc              func(ctx context.Context, req *dbpb.ListCommentsRequest, opts ...grpc.CallOption) (*dbpb.ListCommentsResponse, error)
+	CreateCommentAndIncrementFunc func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error)
+	GetCommentFunc                func(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error)
+	UpdateCommentFunc             func(ctx context.Context, req *dbpb.UpdateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteCommentTreeFunc         func(ctx context.Context, req *dbpb.DeleteCommentRequest, opts ...grpc.CallOption) (*dbpb.DeleteCommentTreeResponse, error)
+	RemoveCommentFunc             func(ctx context.Context, req *dbpb.RemoveCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreCommentFunc            func(ctx context.Context, req *dbpb.RestoreCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAttachmentFunc             func(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error)
+	ListRevisionsFunc             func(ctx context.Context, req *dbpb.ListRevisionsRequest, opts ...grpc.CallOption) (*dbpb.ListRevisionsResponse, error)
 }
 
 func (m *MockDBClient) ListComments(ctx context.Context, req *dbpb.ListCommentsRequest, opts ...grpc.CallOption) (*dbpb.ListCommentsResponse, error) {
 	return m.ListCommentsFunc(ctx, req, opts...)
 }
 
-func (m *MockDBClient) CreateComment(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
-	return m.CreateCommentFunc(ctx, req, opts...)
+func (m *MockDBClient) CreateCommentAndIncrement(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
+	return m.CreateCommentAndIncrementFunc(ctx, req, opts...)
 }
 
 func (m *MockDBClient) GetComment(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error) {
@@ -42,17 +51,56 @@ func (m *MockDBClient) UpdateComment(ctx context.Context, req *dbpb.UpdateCommen
 	return m.UpdateCommentFunc(ctx, req, opts...)
 }
 
-func (m *MockDBClient) DeleteComment(ctx context.Context, req *dbpb.DeleteCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	return m.DeleteCommentFunc(ctx, req, opts...)
+func (m *MockDBClient) DeleteCommentTree(ctx context.Context, req *dbpb.DeleteCommentRequest, opts ...grpc.CallOption) (*dbpb.DeleteCommentTreeResponse, error) {
+	return m.DeleteCommentTreeFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) RemoveComment(ctx context.Context, req *dbpb.RemoveCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.RemoveCommentFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) RestoreComment(ctx context.Context, req *dbpb.RestoreCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.RestoreCommentFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetAttachment(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error) {
+	return m.GetAttachmentFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) ListRevisions(ctx context.Context, req *dbpb.ListRevisionsRequest, opts ...grpc.CallOption) (*dbpb.ListRevisionsResponse, error) {
+	return m.ListRevisionsFunc(ctx, req, opts...)
 }
 
 type MockThreadClient struct {
 	threadpb.ThreadServiceClient
-	UpdateThreadFunc func(ctx context.Context, req *threadpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetThreadFunc func(ctx context.Context, req *threadpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error)
+}
+
+func (m *MockThreadClient) GetThread(ctx context.Context, req *threadpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+	return m.GetThreadFunc(ctx, req, opts...)
+}
+
+type MockCommunityClient struct {
+	communitypb.CommunityServiceClient
+	GetCommunityPostingRulesFunc func(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*models.PostingRules, error)
+}
+
+func (m *MockCommunityClient) GetCommunityPostingRules(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*models.PostingRules, error) {
+	return m.GetCommunityPostingRulesFunc(ctx, req, opts...)
 }
 
-func (m *MockThreadClient) UpdateThread(ctx context.Context, req *threadpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	return m.UpdateThreadFunc(ctx, req, opts...)
+// posting rules of a community without overrides
+func getDefaultPostingRules(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*models.PostingRules, error) {
+	return &models.PostingRules{MinTitleLength: 3, MaxTitleLength: 50, MinContentLength: 3, MaxContentLength: 500, MaxCommentLength: 500}, nil
+}
+
+type MockModerationClient struct {
+	moderationpb.ModerationServiceClient
+	EvaluateCommentFunc func(ctx context.Context, req *moderationpb.EvaluateCommentRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error)
+}
+
+func (m *MockModerationClient) EvaluateComment(ctx context.Context, req *moderationpb.EvaluateCommentRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+	return m.EvaluateCommentFunc(ctx, req, opts...)
 }
 
 func TestCreateComment_Validation(t *testing.T) {
@@ -77,10 +125,37 @@ func TestCreateComment_Validation(t *testing.T) {
 			name: "content too long",
 			req: &commentpb.CreateCommentRequest{
 				ParentId: "123",
-				Content:  string(make([]byte, 501)),
+				Content:  strings.Repeat("a", 501),
 			},
 			wantErr: status.Error(codes.InvalidArgument, "Content exceeds maximum length of 500 characters"),
 		},
+		{
+			name: "multibyte content counted in characters",
+			req: &commentpb.CreateCommentRequest{
+				ParentId:   "123",
+				Content:    strings.Repeat("日本語", 150),
+				ParentType: models.CommentParentType_THREAD,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "too many attachments",
+			req: &commentpb.CreateCommentRequest{
+				ParentId:      "123",
+				Content:       "test comment",
+				AttachmentIds: []string{"1", "2", "3", "4", "5"},
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "Comment cannot have more than %d attachments", src.MaxCommentAttachments),
+		},
+		{
+			name: "unknown attachment",
+			req: &commentpb.CreateCommentRequest{
+				ParentId:      "123",
+				Content:       "test comment",
+				AttachmentIds: []string{"404"},
+			},
+			wantErr: status.Error(codes.InvalidArgument, "Attachment not found"),
+		},
 		{
 			name: "valid request",
 			req: &commentpb.CreateCommentRequest{
@@ -90,21 +165,45 @@ func TestCreateComment_Validation(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "valid request with attachment",
+			req: &commentpb.CreateCommentRequest{
+				ParentId:      "123",
+				Content:       "test comment",
+				ParentType:    models.CommentParentType_THREAD,
+				AttachmentIds: []string{"1"},
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := &src.CommentServer{
 				DBClient: &MockDBClient{
-					CreateCommentFunc: func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
+					CreateCommentAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
 						return &dbpb.CreateCommentResponse{
 							Id: "123",
 						}, nil
 					},
+					GetAttachmentFunc: func(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error) {
+						if req.GetId() == "404" {
+							return nil, status.Error(codes.NotFound, "Attachment not found")
+						}
+						return &models.Attachment{Id: req.GetId(), ContentType: "image/png"}, nil
+					},
 				},
 				ThreadClient: &MockThreadClient{
-					UpdateThreadFunc: func(ctx context.Context, req *threadpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-						return &emptypb.Empty{}, nil
+					GetThreadFunc: func(ctx context.Context, req *threadpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return &models.Thread{Id: "123"}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateCommentFunc: func(ctx context.Context, req *moderationpb.EvaluateCommentRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						return &moderationpb.EvaluateResponse{}, nil
 					},
 				},
 			}
@@ -162,3 +261,576 @@ func TestGetComment_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestListRevisions_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		comment *models.Comment
+		req     *commentpb.ListRevisionsRequest
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "missing id",
+			req:     &commentpb.ListRevisionsRequest{},
+			wantErr: status.Error(codes.InvalidArgument, "Comment id is required"),
+		},
+		{
+			name:    "deleted comment",
+			comment: &models.Comment{Id: "123", RemovalType: models.RemovalType_DELETED},
+			req:     &commentpb.ListRevisionsRequest{Id: "123"},
+			wantErr: status.Error(codes.FailedPrecondition, "Comment is deleted"),
+		},
+		{
+			name:    "removed comment",
+			comment: &models.Comment{Id: "123", RemovalType: models.RemovalType_REMOVED},
+			req:     &commentpb.ListRevisionsRequest{Id: "123"},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can list the revisions of removed comments"),
+		},
+		{
+			name:    "removed comment as moderator",
+			comment: &models.Comment{Id: "123", RemovalType: models.RemovalType_REMOVED},
+			req:     &commentpb.ListRevisionsRequest{Id: "123"},
+			roles:   "moderator",
+			wantErr: nil,
+		},
+		{
+			name:    "valid request",
+			comment: &models.Comment{Id: "123"},
+			req:     &commentpb.ListRevisionsRequest{Id: "123"},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommentServer{
+				DBClient: &MockDBClient{
+					GetCommentFunc: func(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error) {
+						return tt.comment, nil
+					},
+					ListRevisionsFunc: func(ctx context.Context, req *dbpb.ListRevisionsRequest, opts ...grpc.CallOption) (*dbpb.ListRevisionsResponse, error) {
+						assert.Equal(t, "123", req.GetCommentId())
+						assert.Empty(t, req.GetThreadId())
+						return &dbpb.ListRevisionsResponse{Revisions: []*models.Revision{{Version: 1}, {Version: 2}}}, nil
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, tt.roles))
+			res, err := server.ListRevisions(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.GetRevisions(), 2)
+			}
+		})
+	}
+}
+
+func TestDeleteComment_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *commentpb.DeleteCommentRequest
+		user        string
+		roles       string
+		wantRemoval models.RemovalType
+		wantErr     error
+	}{
+		{
+			name:    "missing id",
+			req:     &commentpb.DeleteCommentRequest{},
+			user:    "author",
+			wantErr: status.Error(codes.InvalidArgument, "Comment id is required"),
+		},
+		{
+			name:    "another user",
+			req:     &commentpb.DeleteCommentRequest{Id: "123"},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a comment"),
+		},
+		{
+			name:    "anonymous",
+			req:     &commentpb.DeleteCommentRequest{Id: "123"},
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a comment"),
+		},
+		{
+			name:        "author",
+			req:         &commentpb.DeleteCommentRequest{Id: "123"},
+			user:        "author",
+			wantRemoval: models.RemovalType_DELETED,
+		},
+		{
+			name:        "moderator",
+			req:         &commentpb.DeleteCommentRequest{Id: "123"},
+			user:        "other",
+			roles:       "moderator",
+			wantRemoval: models.RemovalType_REMOVED,
+		},
+		{
+			name:        "moderator deleting their own comment",
+			req:         &commentpb.DeleteCommentRequest{Id: "123"},
+			user:        "author",
+			roles:       "moderator",
+			wantRemoval: models.RemovalType_DELETED,
+		},
+		{
+			name:    "comment without author",
+			req:     &commentpb.DeleteCommentRequest{Id: "legacy"},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a comment"),
+		},
+		{
+			name:    "anonymous comment without author",
+			req:     &commentpb.DeleteCommentRequest{Id: "legacy"},
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a comment"),
+		},
+		{
+			name:        "moderator comment without author",
+			req:         &commentpb.DeleteCommentRequest{Id: "legacy"},
+			user:        "other",
+			roles:       "moderator",
+			wantRemoval: models.RemovalType_REMOVED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removal := models.RemovalType_NOT_REMOVED
+			server := &src.CommentServer{
+				DBClient: &MockDBClient{
+					GetCommentFunc: func(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error) {
+						if req.GetId() == "legacy" {
+							return &models.Comment{Id: req.GetId()}, nil
+						}
+						return &models.Comment{Id: req.GetId(), AuthorId: "author"}, nil
+					},
+					RemoveCommentFunc: func(ctx context.Context, req *dbpb.RemoveCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						removal = req.GetRemovalType()
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, tt.user, auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.DeleteComment(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRemoval, removal)
+		})
+	}
+}
+
+func TestRemoveComment_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *commentpb.RemoveCommentRequest
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "missing id",
+			req:     &commentpb.RemoveCommentRequest{},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Comment id is required"),
+		},
+		{
+			name: "missing reason",
+			req: &commentpb.RemoveCommentRequest{
+				Id: "123",
+			},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Removal reason is required"),
+		},
+		{
+			name: "not a moderator",
+			req: &commentpb.RemoveCommentRequest{
+				Id:     "123",
+				Reason: "harassment",
+			},
+			roles:   "member",
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can remove comments"),
+		},
+		{
+			name: "valid request",
+			req: &commentpb.RemoveCommentRequest{
+				Id:     "123",
+				Reason: "harassment",
+			},
+			roles:   "moderator",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommentServer{
+				DBClient: &MockDBClient{
+					RemoveCommentFunc: func(ctx context.Context, req *dbpb.RemoveCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						return &emptypb.Empty{}, nil
+					},
+				},
+				ThreadClient: &MockThreadClient{},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.RemoveComment(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestoreComment_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *commentpb.RestoreCommentRequest
+		roles      string
+		restoreErr error
+		wantErr    error
+	}{
+		{
+			name:    "missing id",
+			req:     &commentpb.RestoreCommentRequest{},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Comment id is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &commentpb.RestoreCommentRequest{Id: "123"},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can restore comments"),
+		},
+		{
+			name:       "not removed",
+			req:        &commentpb.RestoreCommentRequest{Id: "123"},
+			roles:      "moderator",
+			restoreErr: status.Error(codes.FailedPrecondition, "Comment is not removed"),
+			wantErr:    status.Error(codes.FailedPrecondition, "Comment is not removed"),
+		},
+		{
+			name:    "valid request",
+			req:     &commentpb.RestoreCommentRequest{Id: "123"},
+			roles:   "admin",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommentServer{
+				DBClient: &MockDBClient{
+					RestoreCommentFunc: func(ctx context.Context, req *dbpb.RestoreCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						return &emptypb.Empty{}, tt.restoreErr
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.RestoreComment(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPurgeComment(t *testing.T) {
+	var deleted []string
+	server := &src.CommentServer{
+		DBClient: &MockDBClient{
+			DeleteCommentTreeFunc: func(ctx context.Context, req *dbpb.DeleteCommentRequest, opts ...grpc.CallOption) (*dbpb.DeleteCommentTreeResponse, error) {
+				deleted = append(deleted, req.Id)
+				return &dbpb.DeleteCommentTreeResponse{NumDeleted: 3}, nil
+			},
+		},
+	}
+
+	_, err := server.PurgeComment(context.Background(), &commentpb.PurgeCommentRequest{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Comment id is required").Error(), err.Error())
+
+	// the replies are deleted by db-service in the same call, not listed here
+	_, err = server.PurgeComment(context.Background(), &commentpb.PurgeCommentRequest{Id: "123"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"123"}, deleted)
+}
+
+func TestCreateComment_ThreadState(t *testing.T) {
+	tests := []struct {
+		name    string
+		thread  *models.Thread
+		wantErr error
+	}{
+		{
+			name:    "locked thread",
+			thread:  &models.Thread{Id: "123", Locked: true},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is locked"),
+		},
+		{
+			name:    "archived thread",
+			thread:  &models.Thread{Id: "123", Archived: true},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is archived"),
+		},
+		{
+			name:    "deleted thread",
+			thread:  &models.Thread{Id: "123", RemovalType: models.RemovalType_DELETED},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is removed"),
+		},
+		{
+			name:    "removed thread",
+			thread:  &models.Thread{Id: "123", RemovalType: models.RemovalType_REMOVED},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is removed"),
+		},
+		{
+			name:    "open thread",
+			thread:  &models.Thread{Id: "123"},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommentServer{
+				DBClient: &MockDBClient{
+					GetCommentFunc: func(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error) {
+						return &models.Comment{Id: req.Id, ParentId: "123", ParentType: models.CommentParentType_THREAD}, nil
+					},
+					CreateCommentAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
+						return &dbpb.CreateCommentResponse{Id: "789"}, nil
+					},
+				},
+				ThreadClient: &MockThreadClient{
+					GetThreadFunc: func(ctx context.Context, req *threadpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						assert.Equal(t, "123", req.Id)
+						return tt.thread, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateCommentFunc: func(ctx context.Context, req *moderationpb.EvaluateCommentRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						return &moderationpb.EvaluateResponse{}, nil
+					},
+				},
+			}
+
+			// reply to a comment, the thread is found through the parent comment
+			_, err := server.CreateComment(context.Background(), &commentpb.CreateCommentRequest{
+				ParentId:   "456",
+				ParentType: models.CommentParentType_COMMENT,
+				Content:    "test comment",
+			})
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateComment_AuthorIsRequester(t *testing.T) {
+	server := &src.CommentServer{
+		DBClient: &MockDBClient{
+			CreateCommentAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
+				assert.Equal(t, "author", req.GetAuthorId())
+				return &dbpb.CreateCommentResponse{Id: "789"}, nil
+			},
+		},
+		ThreadClient: &MockThreadClient{
+			GetThreadFunc: func(ctx context.Context, req *threadpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				return &models.Thread{Id: "123"}, nil
+			},
+		},
+		CommunityClient: &MockCommunityClient{
+			GetCommunityPostingRulesFunc: getDefaultPostingRules,
+		},
+		ModerationClient: &MockModerationClient{
+			EvaluateCommentFunc: func(ctx context.Context, req *moderationpb.EvaluateCommentRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+				return &moderationpb.EvaluateResponse{}, nil
+			},
+		},
+	}
+
+	// the author id of the body cannot attribute the comment to another user
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, "author"))
+	_, err := server.CreateComment(ctx, &commentpb.CreateCommentRequest{
+		ParentId:   "123",
+		ParentType: models.CommentParentType_THREAD,
+		Content:    "test comment",
+		AuthorId:   "someone-else",
+	})
+	assert.NoError(t, err)
+}
+
+func TestUpdateComment_ThreadState(t *testing.T) {
+	voteOffset := int32(1)
+	content := "new content"
+	longContent := strings.Repeat("a", 501)
+
+	tests := []struct {
+		name    string
+		thread  *models.Thread
+		req     *commentpb.UpdateCommentRequest
+		wantErr error
+	}{
+		{
+			name:    "vote in locked thread",
+			thread:  &models.Thread{Id: "123", Locked: true},
+			req:     &commentpb.UpdateCommentRequest{Id: "456", VoteOffset: &voteOffset},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is locked"),
+		},
+		{
+			name:    "edit in locked thread",
+			thread:  &models.Thread{Id: "123", Locked: true},
+			req:     &commentpb.UpdateCommentRequest{Id: "456", Content: &content},
+			wantErr: nil,
+		},
+		{
+			name:    "edit in archived thread",
+			thread:  &models.Thread{Id: "123", Archived: true},
+			req:     &commentpb.UpdateCommentRequest{Id: "456", Content: &content},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is archived"),
+		},
+		{
+			name:    "edit too long",
+			thread:  &models.Thread{Id: "123"},
+			req:     &commentpb.UpdateCommentRequest{Id: "456", Content: &longContent},
+			wantErr: status.Error(codes.InvalidArgument, "Content exceeds maximum length of 500 characters"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommentServer{
+				DBClient: &MockDBClient{
+					GetCommentFunc: func(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error) {
+						return &models.Comment{Id: req.Id, AuthorId: "author", ParentId: "123", ParentType: models.CommentParentType_THREAD}, nil
+					},
+					UpdateCommentFunc: func(ctx context.Context, req *dbpb.UpdateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						return &emptypb.Empty{}, nil
+					},
+				},
+				ThreadClient: &MockThreadClient{
+					GetThreadFunc: func(ctx context.Context, req *threadpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return tt.thread, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, "author"))
+			_, err := server.UpdateComment(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUpdateComment_Ownership(t *testing.T) {
+	content := "new content"
+	voteOffset := int32(1)
+
+	tests := []struct {
+		name       string
+		req        *commentpb.UpdateCommentRequest
+		user       string
+		roles      string
+		wantEditor string
+		wantErr    error
+	}{
+		{
+			name:    "edit by another user",
+			req:     &commentpb.UpdateCommentRequest{Id: "456", Content: &content},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a comment"),
+		},
+		{
+			name:    "anonymous edit",
+			req:     &commentpb.UpdateCommentRequest{Id: "456", Content: &content},
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a comment"),
+		},
+		{
+			name:    "edit of a comment without author",
+			req:     &commentpb.UpdateCommentRequest{Id: "legacy", Content: &content},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a comment"),
+		},
+		{
+			name:       "edit by the author",
+			req:        &commentpb.UpdateCommentRequest{Id: "456", Content: &content},
+			user:       "author",
+			wantEditor: "author",
+		},
+		{
+			name:       "edit by a moderator",
+			req:        &commentpb.UpdateCommentRequest{Id: "456", Content: &content},
+			user:       "mod",
+			roles:      "moderator",
+			wantEditor: "mod",
+		},
+		{
+			name:       "moderator edit of a comment without author",
+			req:        &commentpb.UpdateCommentRequest{Id: "legacy", Content: &content},
+			user:       "mod",
+			roles:      "moderator",
+			wantEditor: "mod",
+		},
+		{
+			name: "vote by another user",
+			req:  &commentpb.UpdateCommentRequest{Id: "456", VoteOffset: &voteOffset},
+			user: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommentServer{
+				DBClient: &MockDBClient{
+					GetCommentFunc: func(ctx context.Context, req *dbpb.GetCommentRequest, opts ...grpc.CallOption) (*models.Comment, error) {
+						if req.GetId() == "legacy" {
+							return &models.Comment{Id: req.Id, ParentId: "123", ParentType: models.CommentParentType_THREAD}, nil
+						}
+						return &models.Comment{Id: req.Id, AuthorId: "author", ParentId: "123", ParentType: models.CommentParentType_THREAD}, nil
+					},
+					UpdateCommentFunc: func(ctx context.Context, req *dbpb.UpdateCommentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						if tt.wantEditor != "" {
+							assert.Equal(t, tt.wantEditor, req.GetEditorId())
+						}
+						return &emptypb.Empty{}, nil
+					},
+				},
+				ThreadClient: &MockThreadClient{
+					GetThreadFunc: func(ctx context.Context, req *threadpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return &models.Thread{Id: "123"}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, tt.user, auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.UpdateComment(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
 	threadpb "gen/thread-service/pb"
 	"log"
 	"net"
 	"os"
 	"runtime"
+	"strconv"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -38,6 +40,19 @@ func connectGrpcClient(hostEnvVar string, portEnvVar string) *grpc.ClientConn {
 	return conn
 }
 
+// lengthEnv reads a length limit from an env var, 0 if it is not set
+func lengthEnv(envVar string) int32 {
+	value := os.Getenv(envVar)
+	if value == "" {
+		return 0
+	}
+	length, err := strconv.Atoi(value)
+	if err != nil || length <= 0 {
+		log.Fatalf("invalid %s env var: must be a positive integer", envVar)
+	}
+	return int32(length)
+}
+
 func main() {
 	// Set maximum number of CPUs to use
 	runtime.GOMAXPROCS(runtime.NumCPU())
@@ -55,6 +70,20 @@ func main() {
 		ThreadClient: threadpb.NewThreadServiceClient(threadConn),
 	}
 
+	// site-wide limits, communities can override the posting rules
+	communityService.DefaultPostingRules = &models.PostingRules{
+		MinTitleLength:   lengthEnv("MIN_TITLE_LENGTH"),
+		MaxTitleLength:   lengthEnv("MAX_TITLE_LENGTH"),
+		MinContentLength: lengthEnv("MIN_CONTENT_LENGTH"),
+		MaxContentLength: lengthEnv("MAX_CONTENT_LENGTH"),
+		MaxCommentLength: lengthEnv("MAX_COMMENT_LENGTH"),
+	}
+	communityService.MinNameLength = int(lengthEnv("MIN_COMMUNITY_NAME_LENGTH"))
+	communityService.MaxNameLength = int(lengthEnv("MAX_COMMUNITY_NAME_LENGTH"))
+	if err := communityService.ValidateLimits(); err != nil {
+		log.Fatalf("invalid length env vars: %v", err)
+	}
+
 	// get env port
 	port := os.Getenv("SERVICE_PORT")
 	if port == "" {
@@ -0,0 +1,119 @@
+package server
+
+import (
+	"errors"
+	models "gen/models/pb"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// site-wide defaults used when no limit is configured, lengths are counted in characters
+const (
+	DefaultMinNameLength = 3
+	DefaultMaxNameLength = 50
+
+	DefaultMinTitleLength   = 3
+	DefaultMaxTitleLength   = 50
+	DefaultMinContentLength = 3
+	DefaultMaxContentLength = 500
+	DefaultMaxCommentLength = 500
+
+	// upper bounds of the limits a community can set
+	MaxTitleLengthCap   = 300
+	MaxContentLengthCap = 40000
+	MaxCommentLengthCap = 10000
+)
+
+// postingRules returns the site-wide rules overridden by the non-zero limits of the community
+func (s *CommunityServer) postingRules(overrides *models.PostingRules) *models.PostingRules {
+	res := &models.PostingRules{
+		MinTitleLength:   DefaultMinTitleLength,
+		MaxTitleLength:   DefaultMaxTitleLength,
+		MinContentLength: DefaultMinContentLength,
+		MaxContentLength: DefaultMaxContentLength,
+		MaxCommentLength: DefaultMaxCommentLength,
+	}
+	mergePostingRules(res, s.DefaultPostingRules)
+	mergePostingRules(res, overrides)
+	return res
+}
+
+// nameLength returns the site-wide bounds of community names
+func (s *CommunityServer) nameLength() (int, int) {
+	minLength, maxLength := s.MinNameLength, s.MaxNameLength
+	if minLength == 0 {
+		minLength = DefaultMinNameLength
+	}
+	if maxLength == 0 {
+		maxLength = DefaultMaxNameLength
+	}
+	return minLength, maxLength
+}
+
+// ValidateLimits checks the configured site-wide limits against the caps and each other, so every community can be
+// updated while it inherits them
+func (s *CommunityServer) ValidateLimits() error {
+	if err := s.validatePostingRules(nil); err != nil {
+		// status messages are capitalized for clients, errors are not
+		message := status.Convert(err).Message()
+		return errors.New(strings.ToLower(message[:1]) + message[1:])
+	}
+	if minLength, maxLength := s.nameLength(); minLength > maxLength {
+		return errors.New("minimum community name length cannot exceed the maximum community name length")
+	}
+	return nil
+}
+
+// copies the non-zero limits of src into dst
+func mergePostingRules(dst *models.PostingRules, src *models.PostingRules) {
+	if src.GetMinTitleLength() > 0 {
+		dst.MinTitleLength = src.GetMinTitleLength()
+	}
+	if src.GetMaxTitleLength() > 0 {
+		dst.MaxTitleLength = src.GetMaxTitleLength()
+	}
+	if src.GetMinContentLength() > 0 {
+		dst.MinContentLength = src.GetMinContentLength()
+	}
+	if src.GetMaxContentLength() > 0 {
+		dst.MaxContentLength = src.GetMaxContentLength()
+	}
+	if src.GetMaxCommentLength() > 0 {
+		dst.MaxCommentLength = src.GetMaxCommentLength()
+	}
+}
+
+// validatePostingRules checks the overrides of a community against the rules they are merged into
+func (s *CommunityServer) validatePostingRules(overrides *models.PostingRules) error {
+	for _, limit := range []int32{
+		overrides.GetMinTitleLength(),
+		overrides.GetMaxTitleLength(),
+		overrides.GetMinContentLength(),
+		overrides.GetMaxContentLength(),
+		overrides.GetMaxCommentLength(),
+	} {
+		if limit < 0 {
+			return status.Error(codes.InvalidArgument, "Posting rule limits must be non-negative integers")
+		}
+	}
+
+	rules := s.postingRules(overrides)
+	if rules.MaxTitleLength > MaxTitleLengthCap {
+		return status.Errorf(codes.InvalidArgument, "Maximum title length cannot exceed %d characters", MaxTitleLengthCap)
+	}
+	if rules.MaxContentLength > MaxContentLengthCap {
+		return status.Errorf(codes.InvalidArgument, "Maximum content length cannot exceed %d characters", MaxContentLengthCap)
+	}
+	if rules.MaxCommentLength > MaxCommentLengthCap {
+		return status.Errorf(codes.InvalidArgument, "Maximum comment length cannot exceed %d characters", MaxCommentLengthCap)
+	}
+	if rules.MinTitleLength > rules.MaxTitleLength {
+		return status.Error(codes.InvalidArgument, "Minimum title length cannot exceed the maximum title length")
+	}
+	if rules.MinContentLength > rules.MaxContentLength {
+		return status.Error(codes.InvalidArgument, "Minimum content length cannot exceed the maximum content length")
+	}
+	return nil
+}
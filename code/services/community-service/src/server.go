@@ -2,11 +2,14 @@ package server
 
 import (
 	"context"
+	"gen/auth"
 	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
 	threadpb "gen/thread-service/pb"
 	"math"
+	"regexp"
+	"unicode/utf8"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,13 +20,20 @@ type CommunityServer struct {
 	communitypb.UnimplementedCommunityServiceServer
 	DBClient     dbpb.DBServiceClient
 	ThreadClient threadpb.ThreadServiceClient
+
+	// site-wide limits, communities can override the posting rules, 0 keeps the built-in default
+	DefaultPostingRules *models.PostingRules
+	MinNameLength       int
+	MaxNameLength       int
 }
 
 const (
-	MinLength = 3
-	MaxLength = 50
+	MaxFlairLength    = 30
+	MaxFlairTemplates = 20
 )
 
+var flairColorRegex = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
 func (s *CommunityServer) CheckHealth(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
@@ -36,18 +46,23 @@ func (s *CommunityServer) ListCommunities(ctx context.Context, req *communitypb.
 	if req.Limit != nil && req.GetLimit() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "Limit must be a positive integer")
 	}
+	if req.PageToken != nil && req.GetPageToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Page token cannot be empty")
+	}
 
 	// fetch communities
 	res, err := s.DBClient.ListCommunities(ctx, &dbpb.ListCommunitiesRequest{
-		Name:   req.Name,
-		Offset: req.Offset,
-		Limit:  req.Limit,
+		Name:      req.Name,
+		Offset:    req.Offset,
+		Limit:     req.Limit,
+		PageToken: req.PageToken,
 	})
 	if err != nil {
 		return nil, err
 	}
 	return &communitypb.ListCommunitiesResponse{
-		Communities: res.Communities,
+		Communities:   res.Communities,
+		NextPageToken: res.NextPageToken,
 	}, nil
 }
 
@@ -56,8 +71,9 @@ func (s *CommunityServer) CreateCommunity(ctx context.Context, req *communitypb.
 	if req.GetName() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Community name is required")
 	}
-	if len(req.GetName()) < MinLength || len(req.GetName()) > MaxLength {
-		return nil, status.Errorf(codes.InvalidArgument, "Name must be between %d and %d characters long", MinLength, MaxLength)
+	minLength, maxLength := s.nameLength()
+	if nameLen := utf8.RuneCountInString(req.GetName()); nameLen < minLength || nameLen > maxLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Name must be between %d and %d characters long", minLength, maxLength)
 	}
 
 	// create community
@@ -93,19 +109,29 @@ func (s *CommunityServer) UpdateCommunity(ctx context.Context, req *communitypb.
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Community id is required")
 	}
-	nameLen := len(req.GetName())
-	if req.Name != nil && (nameLen < MinLength || nameLen > MaxLength) {
-		return nil, status.Errorf(codes.InvalidArgument, "Name must be between %d and %d characters long", MinLength, MaxLength)
+	minLength, maxLength := s.nameLength()
+	nameLen := utf8.RuneCountInString(req.GetName())
+	if req.Name != nil && (nameLen < minLength || nameLen > maxLength) {
+		return nil, status.Errorf(codes.InvalidArgument, "Name must be between %d and %d characters long", minLength, maxLength)
 	}
 	if req.NumThreadsOffset != nil && math.Abs(float64(req.GetNumThreadsOffset())) != 1 {
 		return nil, status.Error(codes.InvalidArgument, "Number of threads offset must be either -1 or 1")
 	}
+	if req.PostingRules != nil {
+		if err := s.validatePostingRules(req.PostingRules); err != nil {
+			return nil, err
+		}
+		if !auth.RequesterIsModerator(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "Only moderators can change posting rules")
+		}
+	}
 
 	// update community
 	_, err := s.DBClient.UpdateCommunity(ctx, &dbpb.UpdateCommunityRequest{
 		Id:               req.Id,
 		Name:             req.Name,
 		NumThreadsOffset: req.NumThreadsOffset,
+		PostingRules:     req.PostingRules,
 	})
 	if err != nil {
 		return nil, err
@@ -113,37 +139,126 @@ func (s *CommunityServer) UpdateCommunity(ctx context.Context, req *communitypb.
 	return &emptypb.Empty{}, nil
 }
 
-func (s *CommunityServer) DeleteCommunity(ctx context.Context, req *communitypb.DeleteCommunityRequest) (*emptypb.Empty, error) {
+func (s *CommunityServer) GetCommunityPostingRules(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest) (*models.PostingRules, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id is required")
+	}
+
+	// merge the overrides of the community with the site-wide rules
+	community, err := s.DBClient.GetCommunity(ctx, &dbpb.GetCommunityRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.postingRules(community.PostingRules), nil
+}
+
+func (s *CommunityServer) DeleteCommunity(ctx context.Context, req *communitypb.DeleteCommunityRequest) (*communitypb.DeleteCommunityResponse, error) {
 	// validate input
 	if req.GetId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "Community id is required")
 	}
+	if !auth.RequesterHasRole(ctx, "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only admins can delete communities")
+	}
 
-	// find all threads from community
-	res, err := s.ThreadClient.ListThreads(ctx, &threadpb.ListThreadsRequest{
-		CommunityId: &req.Id,
+	// the community, its threads and their comments are deleted page by page in the background
+	res, err := s.DBClient.CreateJob(ctx, &dbpb.CreateJobRequest{
+		Type:     models.JobType_DELETE_COMMUNITY,
+		TargetId: req.Id,
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	// delete threads
-	for _, thread := range res.Threads {
-		_, err = s.ThreadClient.DeleteThread(ctx, &threadpb.DeleteThreadRequest{
-			Id: thread.Id,
-		})
-		if err != nil {
-			return nil, err
-		}
+	return &communitypb.DeleteCommunityResponse{
+		JobId: res.Id,
+	}, nil
+}
+
+func (s *CommunityServer) GetJobStatus(ctx context.Context, req *communitypb.GetJobStatusRequest) (*models.Job, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Job id is required")
 	}
 
-	// delete community
-	_, err = s.DBClient.DeleteCommunity(ctx, &dbpb.DeleteCommunityRequest{
+	job, err := s.DBClient.GetJobStatus(ctx, &dbpb.GetJobStatusRequest{
 		Id: req.Id,
 	})
 	if err != nil {
 		return nil, err
 	}
+	// the jobs of other services are not reported here
+	if job.GetType() != models.JobType_DELETE_COMMUNITY {
+		return nil, status.Errorf(codes.NotFound, "Job not found")
+	}
+	return job, nil
+}
+
+func (s *CommunityServer) CreateFlairTemplate(ctx context.Context, req *communitypb.CreateFlairTemplateRequest) (*communitypb.CreateFlairTemplateResponse, error) {
+	// validate inputs
+	if req.GetCommunityId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id is required")
+	}
+	if req.GetText() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Flair text is required")
+	}
+	if utf8.RuneCountInString(req.GetText()) > MaxFlairLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Flair text exceeds maximum length of %d characters", MaxFlairLength)
+	}
+	if !flairColorRegex.MatchString(req.GetColor()) {
+		return nil, status.Error(codes.InvalidArgument, "Flair color must be a hex color like #ff4500")
+	}
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can create flair templates")
+	}
+
+	// check community flair limit
+	community, err := s.DBClient.GetCommunity(ctx, &dbpb.GetCommunityRequest{
+		Id: req.CommunityId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(community.FlairTemplates) >= MaxFlairTemplates {
+		return nil, status.Errorf(codes.FailedPrecondition, "Community already has %d flair templates", MaxFlairTemplates)
+	}
 
+	// create flair template
+	res, err := s.DBClient.AddFlairTemplate(ctx, &dbpb.AddFlairTemplateRequest{
+		CommunityId: req.CommunityId,
+		Text:        req.Text,
+		Color:       req.Color,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &communitypb.CreateFlairTemplateResponse{
+		Id: res.Id,
+	}, nil
+}
+
+func (s *CommunityServer) DeleteFlairTemplate(ctx context.Context, req *communitypb.DeleteFlairTemplateRequest) (*emptypb.Empty, error) {
+	// validate inputs
+	if req.GetCommunityId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id is required")
+	}
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Flair template id is required")
+	}
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can delete flair templates")
+	}
+
+	// delete flair template, threads keep the flair they were given
+	_, err := s.DBClient.RemoveFlairTemplate(ctx, &dbpb.RemoveFlairTemplateRequest{
+		CommunityId: req.CommunityId,
+		Id:          req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &emptypb.Empty{}, nil
 }
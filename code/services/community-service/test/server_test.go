@@ -2,9 +2,12 @@ package test
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 
 	src "community-service/src"
+	"gen/auth"
 	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
@@ -12,6 +15,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/grpc"
@@ -19,11 +23,14 @@ import (
 
 type MockDBClient struct {
 	dbpb.DBServiceClient
-	ListCommunitiesFunc  func(ctx context.Context, req *dbpb.ListCommunitiesRequest, opts ...grpc.CallOption) (*dbpb.ListCommunitiesResponse, error)
-	CreateCommunityFunc  func(ctx context.Context, req *dbpb.CreateCommunityRequest, opts ...grpc.CallOption) (*dbpb.CreateCommunityResponse, error)
-	GetCommunityFunc     func(ctx context.Context, req *dbpb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error)
-	UpdateCommunityFunc  func(ctx context.Context, req *dbpb.UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	DeleteCommunityFunc  func(ctx context.Context, req *dbpb.DeleteCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListCommunitiesFunc     func(ctx context.Context, req *dbpb.ListCommunitiesRequest, opts ...grpc.CallOption) (*dbpb.ListCommunitiesResponse, error)
+	CreateCommunityFunc     func(ctx context.Context, req *dbpb.CreateCommunityRequest, opts ...grpc.CallOption) (*dbpb.CreateCommunityResponse, error)
+	GetCommunityFunc        func(ctx context.Context, req *dbpb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error)
+	UpdateCommunityFunc     func(ctx context.Context, req *dbpb.UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateJobFunc           func(ctx context.Context, req *dbpb.CreateJobRequest, opts ...grpc.CallOption) (*dbpb.CreateJobResponse, error)
+	AddFlairTemplateFunc    func(ctx context.Context, req *dbpb.AddFlairTemplateRequest, opts ...grpc.CallOption) (*dbpb.AddFlairTemplateResponse, error)
+	RemoveFlairTemplateFunc func(ctx context.Context, req *dbpb.RemoveFlairTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetJobStatusFunc        func(ctx context.Context, req *dbpb.GetJobStatusRequest, opts ...grpc.CallOption) (*models.Job, error)
 }
 
 func (m *MockDBClient) ListCommunities(ctx context.Context, req *dbpb.ListCommunitiesRequest, opts ...grpc.CallOption) (*dbpb.ListCommunitiesResponse, error) {
@@ -42,24 +49,31 @@ func (m *MockDBClient) UpdateCommunity(ctx context.Context, req *dbpb.UpdateComm
 	return m.UpdateCommunityFunc(ctx, req, opts...)
 }
 
-func (m *MockDBClient) DeleteCommunity(ctx context.Context, req *dbpb.DeleteCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	return m.DeleteCommunityFunc(ctx, req, opts...)
+func (m *MockDBClient) CreateJob(ctx context.Context, req *dbpb.CreateJobRequest, opts ...grpc.CallOption) (*dbpb.CreateJobResponse, error) {
+	return m.CreateJobFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) AddFlairTemplate(ctx context.Context, req *dbpb.AddFlairTemplateRequest, opts ...grpc.CallOption) (*dbpb.AddFlairTemplateResponse, error) {
+	return m.AddFlairTemplateFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) RemoveFlairTemplate(ctx context.Context, req *dbpb.RemoveFlairTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.RemoveFlairTemplateFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetJobStatus(ctx context.Context, req *dbpb.GetJobStatusRequest, opts ...grpc.CallOption) (*models.Job, error) {
+	return m.GetJobStatusFunc(ctx, req, opts...)
 }
 
 type MockThreadClient struct {
 	threadpb.ThreadServiceClient
-	ListThreadsFunc   func(ctx context.Context, req *threadpb.ListThreadsRequest, opts ...grpc.CallOption) (*threadpb.ListThreadsResponse, error)
-	DeleteThreadFunc  func(ctx context.Context, req *threadpb.DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListThreadsFunc func(ctx context.Context, req *threadpb.ListThreadsRequest, opts ...grpc.CallOption) (*threadpb.ListThreadsResponse, error)
 }
 
 func (m *MockThreadClient) ListThreads(ctx context.Context, req *threadpb.ListThreadsRequest, opts ...grpc.CallOption) (*threadpb.ListThreadsResponse, error) {
 	return m.ListThreadsFunc(ctx, req, opts...)
 }
 
-func (m *MockThreadClient) DeleteThread(ctx context.Context, req *threadpb.DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	return m.DeleteThreadFunc(ctx, req, opts...)
-}
-
 func TestCreateCommunity_Validation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -92,6 +106,13 @@ func TestCreateCommunity_Validation(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "name length counted in characters",
+			req: &communitypb.CreateCommunityRequest{
+				Name: strings.Repeat("日本語", 10),
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
@@ -160,3 +181,333 @@ func TestGetCommunity_Validation(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteCommunity(t *testing.T) {
+	var created *dbpb.CreateJobRequest
+	server := &src.CommunityServer{
+		DBClient: &MockDBClient{
+			CreateJobFunc: func(ctx context.Context, req *dbpb.CreateJobRequest, opts ...grpc.CallOption) (*dbpb.CreateJobResponse, error) {
+				created = req
+				return &dbpb.CreateJobResponse{Id: "job"}, nil
+			},
+		},
+		ThreadClient: &MockThreadClient{},
+	}
+
+	_, err := server.DeleteCommunity(context.Background(), &communitypb.DeleteCommunityRequest{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Community id is required").Error(), err.Error())
+	assert.Nil(t, created)
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, "moderator"))
+	_, err = server.DeleteCommunity(ctx, &communitypb.DeleteCommunityRequest{Id: "123"})
+	assert.Equal(t, status.Error(codes.PermissionDenied, "Only admins can delete communities").Error(), err.Error())
+	assert.Nil(t, created)
+
+	// the threads are deleted by the job, not listed here
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, "admin"))
+	res, err := server.DeleteCommunity(ctx, &communitypb.DeleteCommunityRequest{Id: "123"})
+	assert.NoError(t, err)
+	assert.Equal(t, "job", res.JobId)
+	assert.Equal(t, models.JobType_DELETE_COMMUNITY, created.Type)
+	assert.Equal(t, "123", created.TargetId)
+}
+
+func TestGetJobStatus(t *testing.T) {
+	jobs := map[string]*models.Job{
+		"delete": {Id: "delete", Type: models.JobType_DELETE_COMMUNITY, State: models.JobState_JOB_RUNNING, NumThreadsDeleted: 2},
+		"purge":  {Id: "purge", Type: models.JobType_DELETE_THREAD},
+	}
+	server := &src.CommunityServer{
+		DBClient: &MockDBClient{
+			GetJobStatusFunc: func(ctx context.Context, req *dbpb.GetJobStatusRequest, opts ...grpc.CallOption) (*models.Job, error) {
+				job, ok := jobs[req.Id]
+				if !ok {
+					return nil, status.Error(codes.NotFound, "Job not found")
+				}
+				return job, nil
+			},
+		},
+	}
+
+	_, err := server.GetJobStatus(context.Background(), &communitypb.GetJobStatusRequest{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Job id is required").Error(), err.Error())
+
+	job, err := server.GetJobStatus(context.Background(), &communitypb.GetJobStatusRequest{Id: "delete"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobState_JOB_RUNNING, job.State)
+	assert.Equal(t, int32(2), job.NumThreadsDeleted)
+
+	// thread purges are reported by thread-service
+	for _, id := range []string{"purge", "missing"} {
+		_, err = server.GetJobStatus(context.Background(), &communitypb.GetJobStatusRequest{Id: id})
+		assert.Equal(t, status.Error(codes.NotFound, "Job not found").Error(), err.Error())
+	}
+}
+
+func TestCreateFlairTemplate_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *communitypb.CreateFlairTemplateRequest
+		roles        string
+		numTemplates int
+		wantErr      error
+	}{
+		{
+			name:    "missing community id",
+			req:     &communitypb.CreateFlairTemplateRequest{},
+			wantErr: status.Error(codes.InvalidArgument, "Community id is required"),
+		},
+		{
+			name:    "missing text",
+			req:     &communitypb.CreateFlairTemplateRequest{CommunityId: "123"},
+			wantErr: status.Error(codes.InvalidArgument, "Flair text is required"),
+		},
+		{
+			name:    "invalid color",
+			req:     &communitypb.CreateFlairTemplateRequest{CommunityId: "123", Text: "Question", Color: "red"},
+			wantErr: status.Error(codes.InvalidArgument, "Flair color must be a hex color like #ff4500"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &communitypb.CreateFlairTemplateRequest{CommunityId: "123", Text: "Question", Color: "#ff4500"},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can create flair templates"),
+		},
+		{
+			name:         "too many templates",
+			req:          &communitypb.CreateFlairTemplateRequest{CommunityId: "123", Text: "Question", Color: "#ff4500"},
+			roles:        "moderator",
+			numTemplates: src.MaxFlairTemplates,
+			wantErr:      status.Errorf(codes.FailedPrecondition, "Community already has %d flair templates", src.MaxFlairTemplates),
+		},
+		{
+			name:    "valid request",
+			req:     &communitypb.CreateFlairTemplateRequest{CommunityId: "123", Text: "Question", Color: "#ff4500"},
+			roles:   "moderator",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommunityServer{
+				DBClient: &MockDBClient{
+					GetCommunityFunc: func(ctx context.Context, req *dbpb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
+						return &models.Community{Id: "123", FlairTemplates: make([]*models.FlairTemplate, tt.numTemplates)}, nil
+					},
+					AddFlairTemplateFunc: func(ctx context.Context, req *dbpb.AddFlairTemplateRequest, opts ...grpc.CallOption) (*dbpb.AddFlairTemplateResponse, error) {
+						return &dbpb.AddFlairTemplateResponse{Id: "456"}, nil
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.CreateFlairTemplate(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestDeleteFlairTemplate_Validation(t *testing.T) {
+	tests := []struct {
+		name        string
+		req         *communitypb.DeleteFlairTemplateRequest
+		roles       string
+		wantDeleted bool
+		wantErr     error
+	}{
+		{
+			name:    "missing community id",
+			req:     &communitypb.DeleteFlairTemplateRequest{Id: "456"},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Community id is required"),
+		},
+		{
+			name:    "missing id",
+			req:     &communitypb.DeleteFlairTemplateRequest{CommunityId: "123"},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Flair template id is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &communitypb.DeleteFlairTemplateRequest{CommunityId: "123", Id: "456"},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can delete flair templates"),
+		},
+		{
+			name:        "moderator",
+			req:         &communitypb.DeleteFlairTemplateRequest{CommunityId: "123", Id: "456"},
+			roles:       "moderator",
+			wantDeleted: true,
+		},
+		{
+			name:        "admin",
+			req:         &communitypb.DeleteFlairTemplateRequest{CommunityId: "123", Id: "456"},
+			roles:       "admin",
+			wantDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deleted := false
+			server := &src.CommunityServer{
+				DBClient: &MockDBClient{
+					RemoveFlairTemplateFunc: func(ctx context.Context, req *dbpb.RemoveFlairTemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						deleted = true
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.DeleteFlairTemplate(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantDeleted, deleted)
+		})
+	}
+}
+
+func TestUpdateCommunity_PostingRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   *models.PostingRules
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "negative limit",
+			roles:   "moderator",
+			rules:   &models.PostingRules{MaxTitleLength: -1},
+			wantErr: status.Error(codes.InvalidArgument, "Posting rule limits must be non-negative integers"),
+		},
+		{
+			name:    "title limit above cap",
+			roles:   "moderator",
+			rules:   &models.PostingRules{MaxTitleLength: src.MaxTitleLengthCap + 1},
+			wantErr: status.Errorf(codes.InvalidArgument, "Maximum title length cannot exceed %d characters", src.MaxTitleLengthCap),
+		},
+		{
+			name:    "comment limit above cap",
+			roles:   "moderator",
+			rules:   &models.PostingRules{MaxCommentLength: src.MaxCommentLengthCap + 1},
+			wantErr: status.Errorf(codes.InvalidArgument, "Maximum comment length cannot exceed %d characters", src.MaxCommentLengthCap),
+		},
+		{
+			name:    "minimum above inherited maximum",
+			roles:   "moderator",
+			rules:   &models.PostingRules{MinTitleLength: src.DefaultMaxTitleLength + 1},
+			wantErr: status.Error(codes.InvalidArgument, "Minimum title length cannot exceed the maximum title length"),
+		},
+		{
+			name:    "minimum content above maximum",
+			roles:   "moderator",
+			rules:   &models.PostingRules{MinContentLength: 20, MaxContentLength: 10},
+			wantErr: status.Error(codes.InvalidArgument, "Minimum content length cannot exceed the maximum content length"),
+		},
+		{
+			name:    "valid request",
+			roles:   "moderator",
+			rules:   &models.PostingRules{MaxTitleLength: 120, MaxContentLength: 10000},
+			wantErr: nil,
+		},
+		{
+			name:    "not a moderator",
+			rules:   &models.PostingRules{MaxTitleLength: 120, MaxContentLength: 10000},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can change posting rules"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.CommunityServer{
+				DBClient: &MockDBClient{
+					UpdateCommunityFunc: func(ctx context.Context, req *dbpb.UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.UpdateCommunity(ctx, &communitypb.UpdateCommunityRequest{
+				Id:           "123",
+				PostingRules: tt.rules,
+			})
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		server  *src.CommunityServer
+		wantErr string
+	}{
+		{
+			name:   "built-in defaults",
+			server: &src.CommunityServer{},
+		},
+		{
+			name:    "content limit above cap",
+			server:  &src.CommunityServer{DefaultPostingRules: &models.PostingRules{MaxContentLength: src.MaxContentLengthCap + 1}},
+			wantErr: fmt.Sprintf("maximum content length cannot exceed %d characters", src.MaxContentLengthCap),
+		},
+		{
+			name:    "minimum title above maximum",
+			server:  &src.CommunityServer{DefaultPostingRules: &models.PostingRules{MinTitleLength: 40, MaxTitleLength: 20}},
+			wantErr: "minimum title length cannot exceed the maximum title length",
+		},
+		{
+			name:    "minimum name above inherited maximum",
+			server:  &src.CommunityServer{MinNameLength: src.DefaultMaxNameLength + 1},
+			wantErr: "minimum community name length cannot exceed the maximum community name length",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.server.ValidateLimits()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetCommunityPostingRules(t *testing.T) {
+	server := &src.CommunityServer{
+		DBClient: &MockDBClient{
+			GetCommunityFunc: func(ctx context.Context, req *dbpb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
+				return &models.Community{
+					Id:           "123",
+					PostingRules: &models.PostingRules{MaxTitleLength: 120},
+				}, nil
+			},
+		},
+		DefaultPostingRules: &models.PostingRules{MaxContentLength: 2000},
+	}
+
+	_, err := server.GetCommunityPostingRules(context.Background(), &communitypb.GetCommunityPostingRulesRequest{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Community id is required").Error(), err.Error())
+
+	// community overrides win over site-wide defaults which win over built-in defaults
+	rules, err := server.GetCommunityPostingRules(context.Background(), &communitypb.GetCommunityPostingRulesRequest{Id: "123"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(src.DefaultMinTitleLength), rules.MinTitleLength)
+	assert.Equal(t, int32(120), rules.MaxTitleLength)
+	assert.Equal(t, int32(2000), rules.MaxContentLength)
+	assert.Equal(t, int32(src.DefaultMaxCommentLength), rules.MaxCommentLength)
+}
@@ -3,34 +3,134 @@ package main
 import (
 	"context"
 	server "db-service/src"
+	"db-service/src/storage"
+	"db-service/src/storage/bolt"
+	"db-service/src/storage/memory"
+	"db-service/src/storage/mongodb"
+	"db-service/src/storage/postgres"
 	"fmt"
 	dbpd "gen/db-service/pb"
 	"log"
 	"net"
 	"os"
 	"runtime"
+	"strconv"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgxpool"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
 )
 
+const mongoDatabaseName = "threadit"
+
 func main() {
 	// Set maximum number of CPUs to use
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
-	mongoURI := os.Getenv("MONGO_URI")
-	if mongoURI == "" {
-		log.Fatalf("missing MONGO_URI env var")
-	}
-
 	// get env port
 	port := os.Getenv("SERVICE_PORT")
 	if port == "" {
 		log.Fatalf("missing SERVICE_PORT env var")
 	}
 
+	// select the storage backend, MongoDB unless configured otherwise
+	var db storage.Storage
+	switch backend := os.Getenv("STORAGE_BACKEND"); backend {
+	case "", "mongo":
+		client := connectMongo()
+		defer func() {
+			if err := client.Disconnect(context.Background()); err != nil {
+				log.Fatalf("Failed to disconnect from MongoDB: %v", err)
+			}
+		}()
+		db = mongodb.New(client.Database(mongoDatabaseName))
+	case "postgres":
+		pool := connectPostgres()
+		defer pool.Close()
+		db = postgres.New(pool)
+	case "bolt":
+		boltPath := os.Getenv("BOLT_PATH")
+		if boltPath == "" {
+			log.Fatalf("missing BOLT_PATH env var")
+		}
+		boltStorage, err := bolt.Open(boltPath)
+		if err != nil {
+			log.Fatalf("Failed to open %s: %v", boltPath, err)
+		}
+		defer boltStorage.Close()
+		db = boltStorage
+	case "memory":
+		log.Println("Using the in-memory storage, data is lost on restart")
+		db = memory.New()
+	default:
+		log.Fatalf("unknown STORAGE_BACKEND %q, expected mongo, postgres, bolt or memory", backend)
+	}
+
+	dbService := &server.DBServer{
+		Storage: db,
+	}
+
+	// counters are reconciled with the documents they count by a job scheduled every interval
+	reconcileInterval := server.DefaultReconcileInterval
+	if interval := os.Getenv("COUNTER_RECONCILE_INTERVAL"); interval != "" {
+		parsed, err := time.ParseDuration(interval)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("invalid COUNTER_RECONCILE_INTERVAL env var: must be a positive duration")
+		}
+		reconcileInterval = parsed
+	}
+	reconcileFix := false
+	if fix := os.Getenv("COUNTER_RECONCILE_FIX"); fix != "" {
+		parsed, err := strconv.ParseBool(fix)
+		if err != nil {
+			log.Fatalf("invalid COUNTER_RECONCILE_FIX env var: must be true or false")
+		}
+		reconcileFix = parsed
+	}
+	dbService.ReconcileFix = reconcileFix
+	go dbService.RunReconciler(context.Background(), reconcileInterval)
+
+	// the outbox keeps the events subscribers may resume from for the retention
+	eventRetention := server.DefaultEventRetention
+	if retention := os.Getenv("EVENT_RETENTION"); retention != "" {
+		parsed, err := time.ParseDuration(retention)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("invalid EVENT_RETENTION env var: must be a positive duration")
+		}
+		eventRetention = parsed
+	}
+	go dbService.RunEventPruner(context.Background(), eventRetention)
+
+	// cascading deletes run as jobs, which resume on any replica after a crash
+	go dbService.RunJobs(context.Background(), server.DefaultJobPollInterval)
+
+	// start gRPC server
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		log.Fatalf("failed to listen: %v", err)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.MaxRecvMsgSize(1024*1024*500), // 500MB
+		grpc.MaxSendMsgSize(1024*1024*500), // 500MB
+	)
+	dbpd.RegisterDBServiceServer(grpcServer, dbService)
+
+	log.Printf("gRPC server is listening on :%s", port)
+	if err := grpcServer.Serve(lis); err != nil {
+		log.Fatalf("failed to serve: %v", err)
+	}
+}
+
+// connectMongo connects to MongoDB, loads the dataset into it and migrates its documents
+func connectMongo() *mongo.Client {
+	mongoURI := os.Getenv("MONGO_URI")
+	if mongoURI == "" {
+		log.Fatalf("missing MONGO_URI env var")
+	}
+
 	clientOptions := options.Client().ApplyURI(mongoURI)
 	client, err := mongo.Connect(context.Background(), clientOptions)
 
@@ -52,14 +152,7 @@ func main() {
 		time.Sleep(5 * time.Second)
 	}
 
-	defer func() {
-		if err := client.Disconnect(context.Background()); err != nil {
-			log.Fatalf("Failed to disconnect from MongoDB: %v", err)
-		}
-	}()
-
 	// Load data into MongoDB
-	mongoDatabaseName := "threadit"
 	serviceAccountJsonPath := "/var/secret/gcp/gcs-key.json"
 	var basePath string
 	if _, err := os.Stat(serviceAccountJsonPath); err == nil {
@@ -75,24 +168,37 @@ func main() {
 	if err := loadCommunitiesDataset(client, mongoDatabaseName, basePath); err != nil {
 		log.Fatalf("Error loading communities: %v", err)
 	}
+	if err := mongodb.Migrate(context.Background(), client.Database(mongoDatabaseName)); err != nil {
+		log.Fatalf("Error migrating MongoDB: %v", err)
+	}
+	return client
+}
 
-	// start gRPC server
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+// connectPostgres connects to PostgreSQL and migrates its schema
+func connectPostgres() *pgxpool.Pool {
+	postgresURI := os.Getenv("POSTGRES_URI")
+	if postgresURI == "" {
+		log.Fatalf("missing POSTGRES_URI env var")
+	}
+
+	pool, err := pgxpool.New(context.Background(), postgresURI)
 	if err != nil {
-		log.Fatalf("failed to listen: %v", err)
+		log.Fatalf("invalid POSTGRES_URI: %v", err)
 	}
 
-	grpcServer := grpc.NewServer(
-		grpc.MaxRecvMsgSize(1024*1024*500), // 500MB
-		grpc.MaxSendMsgSize(1024*1024*500), // 500MB
-	)
-	mongoDatabase := client.Database(mongoDatabaseName)
-	dbpd.RegisterDBServiceServer(grpcServer, &server.DBServer{
-		Mongo: mongoDatabase,
-	})
+	// Explicit ping check to ensure PostgreSQL is ready.
+	for {
+		err = pool.Ping(context.Background())
+		if err == nil {
+			log.Println("Successfully connected to PostgreSQL!")
+			break
+		}
+		log.Println("PostgreSQL is not available yet, ping failed with error:", err)
+		time.Sleep(5 * time.Second)
+	}
 
-	log.Printf("gRPC server is listening on :%s", port)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Fatalf("failed to serve: %v", err)
+	if err := postgres.Migrate(context.Background(), pool); err != nil {
+		log.Fatalf("Error migrating PostgreSQL: %v", err)
 	}
+	return pool
 }
@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *DBServer) CreateAttachment(ctx context.Context, req *dbpb.CreateAttachmentRequest) (*dbpb.CreateAttachmentResponse, error) {
+	attachment := &models.Attachment{
+		Id:          generateUniqueId(),
+		Filename:    req.GetFilename(),
+		ContentType: req.GetContentType(),
+		Size:        req.GetSize(),
+		Sha256:      req.GetSha256(),
+		AuthorId:    req.GetAuthorId(),
+		CreatedAt:   timestamppb.Now(),
+	}
+	if req.GetThumbnailSha256() != "" {
+		attachment.Width = req.GetWidth()
+		attachment.Height = req.GetHeight()
+		attachment.ThumbnailSha256 = req.GetThumbnailSha256()
+		attachment.ThumbnailContentType = req.GetThumbnailContentType()
+		attachment.ThumbnailSize = req.GetThumbnailSize()
+	}
+	if err := s.Storage.Attachments().Create(ctx, attachment); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create attachment")
+	}
+	return &dbpb.CreateAttachmentResponse{
+		Id: attachment.Id,
+	}, nil
+}
+
+func (s *DBServer) GetAttachment(ctx context.Context, req *dbpb.GetAttachmentRequest) (*models.Attachment, error) {
+	attachment, err := s.Storage.Attachments().Get(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Attachment not found")
+	}
+	if errors.Is(err, storage.ErrDataLoss) {
+		return nil, status.Errorf(codes.DataLoss, "Stored attachment is malformed")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get attachment")
+	}
+	return attachment, nil
+}
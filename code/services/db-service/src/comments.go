@@ -2,144 +2,232 @@ package server
 
 import (
 	"context"
+	"db-service/src/storage"
 	"errors"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *DBServer) ListComments(ctx context.Context, req *dbpb.ListCommentsRequest) (*dbpb.ListCommentsResponse, error) {
-	collection := s.Mongo.Collection("comments")
-	filter := bson.M{}
-	if req.GetThreadId() != "" {
-		filter["parent_id"] = req.GetThreadId()
-	}
-	cursor, err := collection.Find(ctx, filter, getFindOptions(req.Offset, req.Limit, req.GetSortBy()))
+	list := listing{name: "comments", sortBy: req.GetSortBy(), filters: filterHash(req.ThreadId)}
+	page, err := list.page(req.Offset, req.Limit, req.PageToken)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to find comments")
+		return nil, err
 	}
-	defer cursor.Close(ctx)
 
-	var results []*models.Comment
-	for cursor.Next(ctx) {
-		comment := bson.M{}
-		if err := cursor.Decode(&comment); err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to decode comment")
-		}
-		enumInt, _ := models.CommentParentType_value[comment["parent_type"].(string)]
-		results = append(results, &models.Comment{
-			Id:          comment["_id"].(string),
-			Content:     comment["content"].(string),
-			Ups:         comment["ups"].(int32),
-			Downs:       comment["downs"].(int32),
-			ParentId:    comment["parent_id"].(string),
-			ParentType:  models.CommentParentType(enumInt),
-			NumComments: comment["num_comments"].(int32),
-		})
-	}
-	if err := cursor.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "Unexpected cursor error")
+	results, err := s.Storage.Comments().List(ctx, storage.CommentFilter{
+		ParentId: req.GetThreadId(),
+		SortBy:   req.GetSortBy(),
+		Page:     page,
+	})
+	if errors.Is(err, storage.ErrDataLoss) {
+		return nil, status.Errorf(codes.DataLoss, "Stored comment is malformed")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list comments")
 	}
+	token, err := nextPageToken(list, page, results)
+	if err != nil {
+		return nil, err
+	}
+	for _, comment := range results {
+		renderComment(comment)
+	}
+
 	return &dbpb.ListCommentsResponse{
-		Comments: results,
+		Comments:      results,
+		NextPageToken: token,
 	}, nil
 }
 
 func (s *DBServer) CreateComment(ctx context.Context, req *dbpb.CreateCommentRequest) (*dbpb.CreateCommentResponse, error) {
-	collection := s.Mongo.Collection("comments")
-	comment := bson.M{
-		"_id":          generateUniqueId(),
-		"content":      req.GetContent(),
-		"ups":          0,
-		"downs":        0,
-		"parent_id":    req.GetParentId(),
-		"parent_type":  req.GetParentType().String(),
-		"num_comments": 0,
-	}
-
-	if _, err := collection.InsertOne(ctx, comment); err != nil {
+	comment := newComment(req)
+	if err := s.setAncestors(ctx, comment); err != nil {
+		return nil, err
+	}
+	if err := s.Storage.Comments().Create(ctx, comment); err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to create comment")
 	}
 	return &dbpb.CreateCommentResponse{
-		Id: comment["_id"].(string),
+		Id: comment.Id,
 	}, nil
 }
 
-func (s *DBServer) GetComment(ctx context.Context, req *dbpb.GetCommentRequest) (*models.Comment, error) {
-	collection := s.Mongo.Collection("comments")
-	filter := bson.M{"_id": req.GetId()}
-
-	var comment bson.M
-	err := collection.FindOne(ctx, filter).Decode(&comment)
+func (s *DBServer) CreateCommentAndIncrement(ctx context.Context, req *dbpb.CreateCommentRequest) (*dbpb.CreateCommentResponse, error) {
+	// the comment is counted in its parent in the same transaction
+	comment := newComment(req)
+	if err := s.setAncestors(ctx, comment); err != nil {
+		return nil, err
+	}
+	err := s.Storage.Comments().CreateAndIncrement(ctx, comment)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Parent not found")
+	}
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, status.Errorf(codes.NotFound, "Comment not found")
-		}
-		return nil, status.Errorf(codes.Internal, "Failed to get comment")
+		return nil, status.Errorf(codes.Internal, "Failed to create comment")
 	}
+	return &dbpb.CreateCommentResponse{
+		Id: comment.Id,
+	}, nil
+}
 
-	enumInt, _ := models.CommentParentType_value[comment["parent_type"].(string)]
+// newComment builds the comment of a create request
+func newComment(req *dbpb.CreateCommentRequest) *models.Comment {
 	return &models.Comment{
-		Id:          comment["_id"].(string),
-		Content:     comment["content"].(string),
-		Ups:         comment["ups"].(int32),
-		Downs:       comment["downs"].(int32),
-		ParentId:    comment["parent_id"].(string),
-		ParentType:  models.CommentParentType(enumInt),
-		NumComments: comment["num_comments"].(int32),
-	}, nil
+		Id:            generateUniqueId(),
+		Content:       req.GetContent(),
+		ContentHtml:   RenderMarkdown(req.GetContent()),
+		ParentId:      req.GetParentId(),
+		ParentType:    req.GetParentType(),
+		AuthorId:      req.GetAuthorId(),
+		CreatedAt:     timestamppb.Now(),
+		AttachmentIds: req.GetAttachmentIds(),
+	}
 }
 
-func (s *DBServer) UpdateComment(ctx context.Context, req *dbpb.UpdateCommentRequest) (*emptypb.Empty, error) {
-	collection := s.Mongo.Collection("comments")
-	setValues, incValues := bson.M{}, bson.M{}
+// setAncestors stores the path of a reply, the path of its parent followed by the parent. Comments never move so
+// the path of the parent cannot change in the meantime
+func (s *DBServer) setAncestors(ctx context.Context, comment *models.Comment) error {
+	if comment.ParentType != models.CommentParentType_COMMENT {
+		return nil
+	}
+	parent, err := s.Storage.Comments().Get(ctx, comment.ParentId)
+	if errors.Is(err, storage.ErrNotFound) {
+		return status.Errorf(codes.NotFound, "Parent not found")
+	}
+	if errors.Is(err, storage.ErrDataLoss) {
+		return status.Errorf(codes.DataLoss, "Stored comment is malformed")
+	}
+	if err != nil {
+		return status.Errorf(codes.Internal, "Failed to get parent comment")
+	}
+	comment.AncestorIds = append(parent.AncestorIds, parent.Id)
+	return nil
+}
 
-	if req.Content != nil {
-		setValues["content"] = req.GetContent()
+func (s *DBServer) GetComment(ctx context.Context, req *dbpb.GetCommentRequest) (*models.Comment, error) {
+	comment, err := s.Storage.Comments().Get(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Comment not found")
 	}
-	if req.NumCommentsOffset != nil {
-		if offset := req.GetNumCommentsOffset(); offset == 1 {
-			incValues["num_comments"] = 1
-		} else {
-			incValues["num_comments"] = -1
-		}
+	if errors.Is(err, storage.ErrDataLoss) {
+		return nil, status.Errorf(codes.DataLoss, "Stored comment is malformed")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get comment")
+	}
+	return renderComment(comment), nil
+}
+
+func (s *DBServer) UpdateComment(ctx context.Context, req *dbpb.UpdateCommentRequest) (*emptypb.Empty, error) {
+	update := storage.CommentUpdate{
+		Content:           req.Content,
+		NumCommentsOffset: counterOffset(req.NumCommentsOffset),
+		NumReportsOffset:  counterOffset(req.NumReportsOffset),
+		SpamScore:         req.SpamScore,
 	}
 	if req.VoteOffset != nil {
-		if offset := req.GetVoteOffset(); offset == 1 {
-			incValues["ups"] = 1
+		if req.GetVoteOffset() == 1 {
+			update.UpsOffset = 1
 		} else {
-			incValues["downs"] = 1
+			update.DownsOffset = 1
 		}
 	}
-	if len(setValues) == 0 && len(incValues) == 0 {
+	if update == (storage.CommentUpdate{}) {
 		return nil, status.Errorf(codes.InvalidArgument, "No update fields provided")
 	}
 
-	update := bson.M{"$set": setValues, "$inc": incValues}
-	result, err := collection.UpdateOne(ctx, bson.M{"_id": req.GetId()}, update)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update comment")
+	// the html is rendered once with the content, and edits of the text are kept as revisions
+	if req.Content != nil {
+		html := RenderMarkdown(req.GetContent())
+		update.ContentHtml = &html
+		update.Edit = &storage.Edit{EditorId: req.GetEditorId(), EditedAt: time.Now()}
 	}
-	if result.MatchedCount == 0 {
+	err := s.Storage.Comments().Update(ctx, req.GetId(), update)
+	if errors.Is(err, storage.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, "Comment not found")
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update comment")
+	}
 	return &emptypb.Empty{}, nil
 }
 
 func (s *DBServer) DeleteComment(ctx context.Context, req *dbpb.DeleteCommentRequest) (*emptypb.Empty, error) {
-	collection := s.Mongo.Collection("comments")
+	return s.deleteComment(ctx, req.GetId(), s.Storage.Comments().Delete)
+}
+
+func (s *DBServer) DeleteCommentAndDecrement(ctx context.Context, req *dbpb.DeleteCommentRequest) (*emptypb.Empty, error) {
+	return s.deleteComment(ctx, req.GetId(), s.Storage.Comments().DeleteAndDecrement)
+}
 
-	result, err := collection.DeleteOne(ctx, bson.M{"_id": req.GetId()})
+// deleteComment deletes a comment with one of the delete operations of the storage
+func (s *DBServer) deleteComment(ctx context.Context, id string, delete func(ctx context.Context, id string) error) (*emptypb.Empty, error) {
+	err := delete(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Comment not found")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to delete comment")
 	}
-	if result.DeletedCount == 0 {
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) DeleteCommentTree(ctx context.Context, req *dbpb.DeleteCommentRequest) (*dbpb.DeleteCommentTreeResponse, error) {
+	numDeleted, err := s.Storage.Comments().DeleteTree(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, "Comment not found")
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete comment")
+	}
+
+	return &dbpb.DeleteCommentTreeResponse{
+		NumDeleted: numDeleted,
+	}, nil
+}
+
+func (s *DBServer) RemoveComment(ctx context.Context, req *dbpb.RemoveCommentRequest) (*emptypb.Empty, error) {
+	if req.GetRemovalType() == models.RemovalType_NOT_REMOVED {
+		return nil, status.Errorf(codes.InvalidArgument, "Removal type is required")
+	}
+
+	// only comments that are not already removed can be removed
+	err := s.Storage.Comments().Remove(ctx, req.GetId(), req.GetRemovalType(), req.GetReason(), time.Now())
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "Comment not found")
+	case errors.Is(err, storage.ErrFailedPrecondition):
+		return nil, status.Errorf(codes.FailedPrecondition, "Comment is already removed")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to remove comment")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) RestoreComment(ctx context.Context, req *dbpb.RestoreCommentRequest) (*emptypb.Empty, error) {
+	// only comments removed within the grace period can be restored
+	err := s.Storage.Comments().Restore(ctx, req.GetId(), time.Now().Add(-RestoreGracePeriod))
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "Comment not found")
+	case errors.Is(err, storage.ErrFailedPrecondition):
+		comment, getErr := s.Storage.Comments().Get(ctx, req.GetId())
+		if getErr == nil && comment.GetRemovalType() == models.RemovalType_NOT_REMOVED {
+			return nil, status.Errorf(codes.FailedPrecondition, "Comment is not removed")
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "Comment can no longer be restored")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to restore comment")
+	}
 
 	return &emptypb.Empty{}, nil
 }
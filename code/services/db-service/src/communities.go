@@ -2,141 +2,142 @@ package server
 
 import (
 	"context"
+	"db-service/src/storage"
 	"errors"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func (s *DBServer) ListCommunities(ctx context.Context, req *dbpb.ListCommunitiesRequest) (*dbpb.ListCommunitiesResponse, error) {
-	collection := s.Mongo.Collection("communities")
-	filter := bson.M{}
-	if req.GetName() != "" {
-		filter["name"] = bson.M{"$regex": req.GetName(), "$options": "i"} // case-insensitive name match
-	}
-	cursor, err := collection.Find(ctx, filter, getFindOptions(req.Offset, req.Limit, ""))
+	list := listing{name: "communities", filters: filterHash(req.Name)}
+	page, err := list.page(req.Offset, req.Limit, req.PageToken)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to find communities")
+		return nil, err
 	}
-	defer cursor.Close(ctx)
 
-	var results []*models.Community
-	for cursor.Next(ctx) {
-		community := bson.M{}
-		if err := cursor.Decode(&community); err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to list communities")
-		}
-		results = append(results, &models.Community{
-			Id:         community["_id"].(string),
-			Name:       community["name"].(string),
-			NumThreads: community["num_threads"].(int32),
-		})
+	results, err := s.Storage.Communities().List(ctx, storage.CommunityFilter{
+		Name: req.GetName(),
+		Page: page,
+	})
+	if errors.Is(err, storage.ErrDataLoss) {
+		return nil, status.Errorf(codes.DataLoss, "Stored community is malformed")
 	}
-	if err := cursor.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "Unexpected cursor error")
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list communities")
+	}
+	token, err := nextPageToken(list, page, results)
+	if err != nil {
+		return nil, err
 	}
 
 	return &dbpb.ListCommunitiesResponse{
-		Communities: results,
+		Communities:   results,
+		NextPageToken: token,
 	}, nil
 }
 
 func (s *DBServer) CreateCommunity(ctx context.Context, req *dbpb.CreateCommunityRequest) (*dbpb.CreateCommunityResponse, error) {
-	collection := s.Mongo.Collection("communities")
-	community := bson.M{
-		"_id":         generateUniqueId(),
-		"name":        req.GetName(),
-		"num_threads": 0,
+	community := &models.Community{
+		Id:   generateUniqueId(),
+		Name: req.GetName(),
 	}
 
-	count, err := collection.CountDocuments(ctx, bson.M{"name": req.GetName()})
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to check for name uniqueness")
-	}
-	if count > 0 {
+	err := s.Storage.Communities().Create(ctx, community)
+	if errors.Is(err, storage.ErrAlreadyExists) {
 		return nil, status.Errorf(codes.AlreadyExists, "Community name already in use")
 	}
-	_, err = collection.InsertOne(ctx, community)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to create community")
 	}
 
 	return &dbpb.CreateCommunityResponse{
-		Id: community["_id"].(string),
+		Id: community.Id,
 	}, nil
 }
 
 func (s *DBServer) GetCommunity(ctx context.Context, req *dbpb.GetCommunityRequest) (*models.Community, error) {
-	collection := s.Mongo.Collection("communities")
-	filter := bson.M{"_id": req.GetId()}
-
-	var community bson.M
-	err := collection.FindOne(ctx, filter).Decode(&community)
+	community, err := s.Storage.Communities().Get(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Community not found")
+	}
+	if errors.Is(err, storage.ErrDataLoss) {
+		return nil, status.Errorf(codes.DataLoss, "Stored community is malformed")
+	}
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, status.Errorf(codes.NotFound, "Community not found")
-		}
 		return nil, status.Errorf(codes.Internal, "Failed to get community")
 	}
-
-	return &models.Community{
-		Id:         community["_id"].(string),
-		Name:       community["name"].(string),
-		NumThreads: community["num_threads"].(int32),
-	}, nil
+	return community, nil
 }
 
 func (s *DBServer) UpdateCommunity(ctx context.Context, req *dbpb.UpdateCommunityRequest) (*emptypb.Empty, error) {
-	collection := s.Mongo.Collection("communities")
-	setValues, incValues := bson.M{}, bson.M{}
-
-	if req.Name != nil {
-		filter := bson.M{"name": req.GetName(), "_id": bson.M{"$ne": req.GetId()}}
-		count, err := collection.CountDocuments(ctx, filter)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to check for name uniqueness")
-		}
-		if count > 0 {
-			return nil, status.Errorf(codes.AlreadyExists, "Community name already in use")
-		}
-		setValues["name"] = req.GetName()
-	}
-	if req.NumThreadsOffset != nil {
-		if offset := req.GetNumThreadsOffset(); offset == 1 {
-			incValues["num_threads"] = 1
-		} else {
-			incValues["num_threads"] = -1
-		}
-	}
-	if len(setValues) == 0 && len(incValues) == 0 {
+	update := storage.CommunityUpdate{
+		Name:             req.Name,
+		PostingRules:     req.GetPostingRules(),
+		NumThreadsOffset: counterOffset(req.NumThreadsOffset),
+	}
+	if update.Name == nil && update.PostingRules == nil && update.NumThreadsOffset == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "No update fields provided")
 	}
 
-	update := bson.M{"$set": setValues, "$inc": incValues}
-	result, err := collection.UpdateOne(ctx, bson.M{"_id": req.GetId()}, update)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update community")
-	}
-	if result.MatchedCount == 0 {
+	err := s.Storage.Communities().Update(ctx, req.GetId(), update)
+	switch {
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return nil, status.Errorf(codes.AlreadyExists, "Community name already in use")
+	case errors.Is(err, storage.ErrNotFound):
 		return nil, status.Errorf(codes.NotFound, "Community not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to update community")
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
 func (s *DBServer) DeleteCommunity(ctx context.Context, req *dbpb.DeleteCommunityRequest) (*emptypb.Empty, error) {
-	collection := s.Mongo.Collection("communities")
-
-	result, err := collection.DeleteOne(ctx, bson.M{"_id": req.GetId()})
+	err := s.Storage.Communities().Delete(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Community not found")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to delete community")
 	}
-	if result.DeletedCount == 0 {
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) AddFlairTemplate(ctx context.Context, req *dbpb.AddFlairTemplateRequest) (*dbpb.AddFlairTemplateResponse, error) {
+	template := &models.FlairTemplate{
+		Id:    generateUniqueId(),
+		Text:  req.GetText(),
+		Color: req.GetColor(),
+	}
+
+	// flair texts are unique within a community
+	err := s.Storage.Communities().AddFlairTemplate(ctx, req.GetCommunityId(), template)
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
 		return nil, status.Errorf(codes.NotFound, "Community not found")
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return nil, status.Errorf(codes.AlreadyExists, "Flair text already in use")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to add flair template")
+	}
+
+	return &dbpb.AddFlairTemplateResponse{
+		Id: template.Id,
+	}, nil
+}
+
+func (s *DBServer) RemoveFlairTemplate(ctx context.Context, req *dbpb.RemoveFlairTemplateRequest) (*emptypb.Empty, error) {
+	err := s.Storage.Communities().RemoveFlairTemplate(ctx, req.GetCommunityId(), req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Flair template not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to remove flair template")
 	}
 
 	return &emptypb.Empty{}, nil
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	"fmt"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const DefaultReconcileInterval = time.Hour
+
+func (s *DBServer) ReconcileCounters(ctx context.Context, req *dbpb.ReconcileCountersRequest) (*dbpb.ReconcileCountersResponse, error) {
+	res, err := s.reconcileCounters(ctx, req.GetFix(), func() error { return nil })
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to reconcile counters")
+	}
+	return res, nil
+}
+
+// RunReconciler schedules a reconciliation job every interval until ctx is done. The job id is derived from the
+// interval, so when replicas all run it the job is created once and run by a single job runner
+func (s *DBServer) RunReconciler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		job := &models.Job{
+			Id:        fmt.Sprintf("reconcile-counters-%d", now.Truncate(interval).Unix()),
+			Type:      models.JobType_RECONCILE_COUNTERS,
+			State:     models.JobState_JOB_PENDING,
+			CreatedAt: timestamppb.New(now),
+			UpdatedAt: timestamppb.New(now),
+		}
+		err := s.Storage.Jobs().Create(ctx, job)
+		if err != nil && !errors.Is(err, storage.ErrAlreadyExists) {
+			log.Printf("failed to schedule counter reconciliation: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runReconcileJob reconciles the counters for a job, discrepancies are logged and fixed when ReconcileFix is set
+func (s *DBServer) runReconcileJob(ctx context.Context, job *models.Job) error {
+	res, err := s.reconcileCounters(ctx, s.ReconcileFix, func() error { return s.saveProgress(ctx, job) })
+	if err != nil {
+		return err
+	}
+	for _, discrepancy := range res.GetDiscrepancies() {
+		log.Printf("counter discrepancy (fixed: %t): %v", s.ReconcileFix, discrepancy)
+	}
+	return nil
+}
+
+// reconcileCounters compares num_threads of every community and num_comments of every thread and comment with the
+// documents they count. The storage compares without a transaction, which races with concurrent writes, so the
+// counters that look wrong are recounted atomically before they are reported and fixed. progress is called after the
+// comparison and every batch of recounts
+func (s *DBServer) reconcileCounters(ctx context.Context, fix bool, progress func() error) (*dbpb.ReconcileCountersResponse, error) {
+	numChecked, suspects, err := s.Storage.Counters().Compare(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := progress(); err != nil {
+		return nil, err
+	}
+
+	res := &dbpb.ReconcileCountersResponse{NumChecked: numChecked}
+	for i, suspect := range suspects {
+		if i > 0 && i%jobBatchSize == 0 {
+			if err := progress(); err != nil {
+				return nil, err
+			}
+		}
+		var recount storage.Recount
+		var err error
+		discrepancy := &dbpb.CounterDiscrepancy{CommunityId: suspect.CommunityId, ThreadId: suspect.ThreadId, CommentId: suspect.CommentId}
+		switch {
+		case suspect.CommunityId != "":
+			recount, err = s.Storage.Counters().RecountThreads(ctx, suspect.CommunityId, fix)
+		case suspect.ThreadId != "":
+			recount, err = s.Storage.Counters().RecountComments(ctx, suspect.ThreadId, models.CommentParentType_THREAD, fix)
+		default:
+			recount, err = s.Storage.Counters().RecountComments(ctx, suspect.CommentId, models.CommentParentType_COMMENT, fix)
+		}
+		if errors.Is(err, storage.ErrNotFound) {
+			// deleted in the meantime
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if recount.Stored != recount.Counted {
+			discrepancy.Stored, discrepancy.Counted = recount.Stored, recount.Counted
+			res.Discrepancies = append(res.Discrepancies, discrepancy)
+		}
+	}
+	return res, nil
+}
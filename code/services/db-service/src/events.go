@@ -0,0 +1,78 @@
+package server
+
+import (
+	"context"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultEventRetention = 7 * 24 * time.Hour
+
+	// events sent per read of the outbox
+	eventBatchSize = 500
+	// how often a subscription reads the outbox once it sent every event, writes of other replicas are only seen
+	// in the outbox
+	eventPollInterval  = time.Second
+	eventPruneInterval = time.Hour
+)
+
+// SubscribeEvents streams the events of the outbox after the offset of the request, then the new events as they
+// are written, until the subscriber cancels. Subscribers resume after the offset of the last event they handled
+func (s *DBServer) SubscribeEvents(req *dbpb.SubscribeEventsRequest, stream grpc.ServerStreamingServer[models.Event]) error {
+	after := req.GetAfterOffset()
+	if after < 0 {
+		return status.Errorf(codes.InvalidArgument, "Offset cannot be negative")
+	}
+
+	ctx := stream.Context()
+	ticker := time.NewTicker(eventPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := s.Storage.Events().List(ctx, after, eventBatchSize)
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "Failed to list events")
+		}
+		for _, event := range events {
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+			after = event.Offset
+		}
+		if len(events) == eventBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunEventPruner deletes the events older than retention every hour until ctx is done, subscribers that fall further
+// behind miss them
+func (s *DBServer) RunEventPruner(ctx context.Context, retention time.Duration) {
+	ticker := time.NewTicker(eventPruneInterval)
+	defer ticker.Stop()
+	for {
+		if err := s.Storage.Events().Prune(ctx, time.Now().Add(-retention)); err != nil {
+			log.Printf("failed to prune events: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
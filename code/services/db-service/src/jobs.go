@@ -0,0 +1,278 @@
+package server
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	"fmt"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DefaultJobPollInterval = time.Second
+
+	// documents deleted per page, progress is saved after every page
+	jobBatchSize = 100
+	// how long a runner owns a job, the lease is renewed after every page so only crashed runners lose their jobs
+	jobLeaseDuration = time.Minute
+	// failed runs are retried after attempts times the delay, until the job fails for good
+	jobRetryDelay  = 10 * time.Second
+	maxJobAttempts = 5
+)
+
+func (s *DBServer) CreateJob(ctx context.Context, req *dbpb.CreateJobRequest) (*dbpb.CreateJobResponse, error) {
+	if req.GetTargetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Target id is required")
+	}
+
+	// the target has to exist when the job is created, the job itself tolerates it being gone when it resumes
+	var err error
+	switch req.GetType() {
+	case models.JobType_DELETE_COMMUNITY:
+		_, err = s.Storage.Communities().Get(ctx, req.GetTargetId())
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Community not found")
+		}
+	case models.JobType_DELETE_THREAD:
+		_, err = s.Storage.Threads().Get(ctx, req.GetTargetId())
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "Thread not found")
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid job type")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get job target")
+	}
+
+	now := timestamppb.Now()
+	job := &models.Job{
+		Id:        generateUniqueId(),
+		Type:      req.GetType(),
+		TargetId:  req.GetTargetId(),
+		State:     models.JobState_JOB_PENDING,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.Storage.Jobs().Create(ctx, job); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create job")
+	}
+
+	return &dbpb.CreateJobResponse{
+		Id: job.Id,
+	}, nil
+}
+
+func (s *DBServer) GetJobStatus(ctx context.Context, req *dbpb.GetJobStatusRequest) (*models.Job, error) {
+	job, err := s.Storage.Jobs().Get(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Job not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get job")
+	}
+	return job, nil
+}
+
+// RunJobs runs the jobs one after the other, and looks for new ones every interval once there are none left, until
+// ctx is done. Jobs are leased so replicas can all run it
+func (s *DBServer) RunJobs(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		for {
+			ran, err := s.RunNextJob(ctx)
+			if err != nil {
+				log.Printf("failed to run job: %v", err)
+			}
+			if !ran || err != nil {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunNextJob claims the oldest job that is not running elsewhere and runs it, and reports whether there was one. A
+// failed run is recorded on the job, which is retried later, so the error is about the storage of the job. A run
+// that outlived its lease stops at its next update, and leaves the job to the runner that claimed it since
+func (s *DBServer) RunNextJob(ctx context.Context) (bool, error) {
+	now := time.Now()
+	job, err := s.Storage.Jobs().Claim(ctx, generateUniqueId(), now, now.Add(jobLeaseDuration))
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	runErr := s.runJob(ctx, job)
+	if errors.Is(runErr, storage.ErrFailedPrecondition) {
+		return true, fmt.Errorf("lost the lease of job %s", job.Id)
+	}
+	now = time.Now()
+	job.UpdatedAt = timestamppb.New(now)
+	switch {
+	case runErr == nil:
+		job.State = models.JobState_JOB_SUCCEEDED
+		job.Error = ""
+		job.LeaseExpiresAt = nil
+	case job.Attempts+1 >= maxJobAttempts:
+		job.Attempts++
+		job.State = models.JobState_JOB_FAILED
+		job.Error = runErr.Error()
+		job.LeaseExpiresAt = nil
+	default:
+		// the lease keeps other runners away until the retry
+		job.Attempts++
+		job.State = models.JobState_JOB_PENDING
+		job.Error = runErr.Error()
+		job.LeaseExpiresAt = timestamppb.New(now.Add(time.Duration(job.Attempts) * jobRetryDelay))
+	}
+	err = s.Storage.Jobs().Update(ctx, job)
+	if errors.Is(err, storage.ErrFailedPrecondition) {
+		return true, fmt.Errorf("lost the lease of job %s", job.Id)
+	}
+	return true, err
+}
+
+// runJob reconciles the counters, or deletes the target of a job and everything under it. Every step can run again, so a job resumed after a
+// crash starts over and skips what is already deleted. What is listed is deleted leaf first, so a crash never
+// leaves a document that is no longer found from the target
+func (s *DBServer) runJob(ctx context.Context, job *models.Job) error {
+	switch job.GetType() {
+	case models.JobType_DELETE_COMMUNITY:
+		return s.deleteCommunityTree(ctx, job)
+	case models.JobType_DELETE_THREAD:
+		// threads are found by id, so the thread goes first and takes no new replies
+		deleted, err := s.purgeThread(ctx, job.TargetId, s.Storage.Threads().DeleteAndDecrement)
+		if err != nil {
+			return err
+		}
+		if deleted {
+			job.NumThreadsDeleted++
+		}
+		return s.deleteReplies(ctx, job, job.TargetId)
+	case models.JobType_RECONCILE_COUNTERS:
+		return s.runReconcileJob(ctx, job)
+	default:
+		return errors.New("invalid job type")
+	}
+}
+
+// deleteCommunityTree deletes a community first, so no thread is created in it anymore, then its threads, drafts and
+// mod queue items page by page
+func (s *DBServer) deleteCommunityTree(ctx context.Context, job *models.Job) error {
+	if err := s.Storage.Communities().Delete(ctx, job.TargetId); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	for _, drafts := range []bool{false, true} {
+		for {
+			threads, err := s.Storage.Threads().List(ctx, storage.ThreadFilter{
+				CommunityId: job.TargetId,
+				Drafts:      drafts,
+				Page:        storage.Page{Limit: jobBatchSize},
+			})
+			if err != nil {
+				return err
+			}
+			if len(threads) == 0 {
+				break
+			}
+			for _, thread := range threads {
+				if err := s.deleteReplies(ctx, job, thread.Id); err != nil {
+					return err
+				}
+				// the community is gone, so there is no counter to decrement
+				deleted, err := s.purgeThread(ctx, thread.Id, s.Storage.Threads().Delete)
+				if err != nil {
+					return err
+				}
+				if deleted {
+					job.NumThreadsDeleted++
+				}
+			}
+			if err := s.saveProgress(ctx, job); err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		items, err := s.Storage.Moderation().ListModQueue(ctx, job.TargetId, storage.Page{Limit: jobBatchSize})
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		for _, item := range items {
+			if err := s.Storage.Moderation().DeleteModQueueItem(ctx, item.Id); err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return err
+			}
+		}
+	}
+}
+
+// deleteReplies deletes the comments of a thread page by page, each top-level comment with its subtree, so the
+// replies at any depth are found by their ancestor path instead of a listing per comment
+func (s *DBServer) deleteReplies(ctx context.Context, job *models.Job, threadId string) error {
+	for {
+		comments, err := s.Storage.Comments().List(ctx, storage.CommentFilter{
+			ParentId: threadId,
+			Page:     storage.Page{Limit: jobBatchSize},
+		})
+		if err != nil {
+			return err
+		}
+		if len(comments) == 0 {
+			return nil
+		}
+		for _, comment := range comments {
+			numDeleted, err := s.Storage.Comments().DeleteTree(ctx, comment.Id)
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			job.NumCommentsDeleted += numDeleted
+		}
+		if err := s.saveProgress(ctx, job); err != nil {
+			return err
+		}
+	}
+}
+
+// purgeThread deletes a thread with one of the delete operations of the storage, and reports whether it was still
+// there
+func (s *DBServer) purgeThread(ctx context.Context, id string, delete func(ctx context.Context, id string) error) (bool, error) {
+	err := delete(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// saveProgress stores the counts of a running job and renews its lease
+func (s *DBServer) saveProgress(ctx context.Context, job *models.Job) error {
+	now := time.Now()
+	job.UpdatedAt = timestamppb.New(now)
+	job.LeaseExpiresAt = timestamppb.New(now.Add(jobLeaseDuration))
+	return s.Storage.Jobs().Update(ctx, job)
+}
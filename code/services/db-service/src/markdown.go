@@ -0,0 +1,190 @@
+package server
+
+import (
+	"bytes"
+	"html"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// supported subset: paragraphs, links, emphasis, strikethrough, code, quotes, lists and >!spoilers!<.
+// headings, thematic breaks and html are not parsed and stay text, images are rendered as links
+var markdown = goldmark.New(
+	goldmark.WithParser(parser.NewParser(
+		parser.WithBlockParsers(
+			util.Prioritized(parser.NewListParser(), 300),
+			util.Prioritized(parser.NewListItemParser(), 400),
+			util.Prioritized(parser.NewCodeBlockParser(), 500),
+			util.Prioritized(parser.NewFencedCodeBlockParser(), 700),
+			util.Prioritized(blockquoteParser{parser.NewBlockquoteParser()}, 800),
+			util.Prioritized(parser.NewParagraphParser(), 1000),
+		),
+		parser.WithInlineParsers(
+			util.Prioritized(parser.NewCodeSpanParser(), 100),
+			util.Prioritized(parser.NewLinkParser(), 200),
+			util.Prioritized(parser.NewAutoLinkParser(), 300),
+			util.Prioritized(spoilerParser{}, 400),
+			util.Prioritized(parser.NewEmphasisParser(), 500),
+			util.Prioritized(extension.NewStrikethroughParser(), 500),
+			util.Prioritized(mentionParser{}, 998),
+			util.Prioritized(extension.NewLinkifyParser(), 999),
+		),
+		parser.WithParagraphTransformers(parser.DefaultParagraphTransformers()...),
+		parser.WithASTTransformers(util.Prioritized(imageTransformer{}, 100)),
+	)),
+	goldmark.WithRendererOptions(renderer.WithNodeRenderers(
+		util.Prioritized(spoilerRenderer{}, 500),
+		util.Prioritized(extension.NewStrikethroughHTMLRenderer(), 500),
+	)),
+)
+
+// the renderer escapes text and drops unsafe links already, the policy guards against anything it let through
+var markdownPolicy = func() *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+	policy.AllowElements("p", "br", "em", "strong", "del", "code", "pre", "blockquote", "ul", "ol", "li")
+	policy.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^language-[\w+-]+$`)).OnElements("code")
+	policy.AllowAttrs("class").Matching(regexp.MustCompile(`^spoiler$`)).OnElements("span")
+	policy.AllowAttrs("href", "title").OnElements("a")
+	policy.AllowURLSchemes("http", "https", "mailto")
+	policy.AllowRelativeURLs(true)
+	policy.RequireParseableURLs(true)
+	policy.RequireNoFollowOnLinks(true)
+	policy.AddTargetBlankToFullyQualifiedLinks(true)
+	return policy
+}()
+
+// RenderMarkdown renders markdown content to sanitized html
+func RenderMarkdown(content string) string {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(content), &buf); err != nil {
+		return "<p>" + html.EscapeString(content) + "</p>"
+	}
+	return markdownPolicy.Sanitize(buf.String())
+}
+
+var kindSpoiler = ast.NewNodeKind("Spoiler")
+
+type spoiler struct {
+	ast.BaseInline
+}
+
+func (n *spoiler) Kind() ast.NodeKind {
+	return kindSpoiler
+}
+
+func (n *spoiler) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// parses >!spoilers!< within a line, their content is kept as text
+type spoilerParser struct{}
+
+func (spoilerParser) Trigger() []byte {
+	return []byte{'>'}
+}
+
+func (spoilerParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	if !bytes.HasPrefix(line, []byte(">!")) {
+		return nil
+	}
+	end := bytes.Index(line[2:], []byte("!<"))
+	if end < 0 {
+		return nil
+	}
+	node := &spoiler{}
+	node.AppendChild(node, ast.NewTextSegment(text.NewSegment(segment.Start+2, segment.Start+2+end)))
+	block.Advance(end + 4)
+	return node
+}
+
+type spoilerRenderer struct{}
+
+func (spoilerRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindSpoiler, func(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			_, _ = w.WriteString(`<span class="spoiler">`)
+		} else {
+			_, _ = w.WriteString(`</span>`)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// a line starting with a spoiler is a paragraph rather than a blockquote
+type blockquoteParser struct {
+	parser.BlockParser
+}
+
+func (p blockquoteParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, _ := reader.PeekLine()
+	line = bytes.TrimLeft(line, " ")
+	if bytes.HasPrefix(line, []byte(">!")) && bytes.Contains(line[2:], []byte("!<")) {
+		return nil, parser.NoChildren
+	}
+	return p.BlockParser.Open(parent, reader, pc)
+}
+
+// c/community and u/user references
+var mentionRegex = regexp.MustCompile(`^([cu])/([\w-]+)`)
+
+// links references at the start of a line, after a space or after an opening parenthesis
+type mentionParser struct{}
+
+func (mentionParser) Trigger() []byte {
+	// ' ' stands for any space and the start of a line
+	return []byte{' ', '('}
+}
+
+func (mentionParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	if pc.IsInLinkLabel() {
+		return nil
+	}
+	line, segment := block.PeekLine()
+	consumes := 0
+	if util.IsSpace(line[0]) || line[0] == '(' {
+		consumes = 1
+	}
+	match := mentionRegex.Find(line[consumes:])
+	if match == nil {
+		return nil
+	}
+	if consumes > 0 {
+		ast.MergeOrAppendTextSegment(parent, segment.WithStop(segment.Start+consumes))
+	}
+	link := ast.NewLink()
+	link.Destination = append([]byte("/"), match...)
+	link.AppendChild(link, ast.NewTextSegment(text.NewSegment(segment.Start+consumes, segment.Start+consumes+len(match))))
+	block.Advance(consumes + len(match))
+	return link
+}
+
+// renders images as links
+type imageTransformer struct{}
+
+func (imageTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	var images []*ast.Image
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if image, ok := node.(*ast.Image); ok && entering {
+			images = append(images, image)
+		}
+		return ast.WalkContinue, nil
+	})
+	for _, image := range images {
+		link := ast.NewLink()
+		link.Destination, link.Title = image.Destination, image.Title
+		for child := image.FirstChild(); child != nil; child = image.FirstChild() {
+			link.AppendChild(link, child)
+		}
+		image.Parent().ReplaceChild(image.Parent(), image, link)
+	}
+}
@@ -0,0 +1,206 @@
+package server
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *DBServer) GetAuthorStats(ctx context.Context, req *dbpb.GetAuthorStatsRequest) (*dbpb.GetAuthorStatsResponse, error) {
+	if req.GetAuthorId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Author id is required")
+	}
+
+	karma, firstSeen, err := s.Storage.Moderation().AuthorStats(ctx, req.GetAuthorId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to aggregate author stats")
+	}
+
+	res := &dbpb.GetAuthorStatsResponse{
+		Karma: karma,
+	}
+	if firstSeen != nil {
+		res.FirstSeenAt = timestamppb.New(*firstSeen)
+	}
+	return res, nil
+}
+
+func (s *DBServer) GetAutomodConfig(ctx context.Context, req *dbpb.GetAutomodConfigRequest) (*dbpb.GetAutomodConfigResponse, error) {
+	// communities without rules have an empty config
+	config, err := s.Storage.Moderation().GetAutomodConfig(ctx, req.GetCommunityId())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get automod config")
+	}
+
+	return &dbpb.GetAutomodConfigResponse{
+		Config: config,
+	}, nil
+}
+
+func (s *DBServer) SetAutomodConfig(ctx context.Context, req *dbpb.SetAutomodConfigRequest) (*emptypb.Empty, error) {
+	if err := s.Storage.Moderation().SetAutomodConfig(ctx, req.GetCommunityId(), req.GetConfig()); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to set automod config")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) ListModQueue(ctx context.Context, req *dbpb.ListModQueueRequest) (*dbpb.ListModQueueResponse, error) {
+	results, err := s.Storage.Moderation().ListModQueue(ctx, req.GetCommunityId(), page(req.Offset, req.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list mod queue items")
+	}
+
+	return &dbpb.ListModQueueResponse{
+		Items: results,
+	}, nil
+}
+
+func (s *DBServer) CreateModQueueItem(ctx context.Context, req *dbpb.CreateModQueueItemRequest) (*dbpb.CreateModQueueItemResponse, error) {
+	item := &models.ModQueueItem{
+		Id:          generateUniqueId(),
+		CommunityId: req.GetCommunityId(),
+		ThreadId:    req.GetThreadId(),
+		CommentId:   req.GetCommentId(),
+		Rule:        req.GetRule(),
+		Reason:      req.GetReason(),
+		DryRun:      req.GetDryRun(),
+		CreatedAt:   timestamppb.Now(),
+	}
+
+	if err := s.Storage.Moderation().CreateModQueueItem(ctx, item); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to create mod queue item")
+	}
+	return &dbpb.CreateModQueueItemResponse{
+		Id: item.Id,
+	}, nil
+}
+
+func (s *DBServer) DeleteModQueueItem(ctx context.Context, req *dbpb.DeleteModQueueItemRequest) (*emptypb.Empty, error) {
+	err := s.Storage.Moderation().DeleteModQueueItem(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Mod queue item not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to delete mod queue item")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) RecordAutomodAction(ctx context.Context, req *dbpb.RecordAutomodActionRequest) (*emptypb.Empty, error) {
+	if req.GetThreadId() == "" || req.GetRule() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Thread id and rule are required")
+	}
+
+	// a rule acts once per thread or comment
+	err := s.Storage.Moderation().RecordAutomodAction(ctx, storage.AutomodAction{
+		ThreadId:  req.GetThreadId(),
+		CommentId: req.GetCommentId(),
+		Rule:      req.GetRule(),
+		DryRun:    req.GetDryRun(),
+	})
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "Rule already acted on this target")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to record automod action")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) CreateReport(ctx context.Context, req *dbpb.CreateReportRequest) (*emptypb.Empty, error) {
+	if (req.GetThreadId() == "") == (req.GetCommentId() == "") || req.GetReporterId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Exactly one of thread id and comment id, and reporter id are required")
+	}
+
+	// a user reports a thread or comment once
+	err := s.Storage.Moderation().CreateReport(ctx, storage.Report{
+		ThreadId:   req.GetThreadId(),
+		CommentId:  req.GetCommentId(),
+		ReporterId: req.GetReporterId(),
+	})
+	switch {
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return nil, status.Errorf(codes.AlreadyExists, "User already reported this target")
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "Report target not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to create report")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) ListSpamSamples(ctx context.Context, req *dbpb.ListSpamSamplesRequest) (*dbpb.ListSpamSamplesResponse, error) {
+	var results []*dbpb.SpamSample
+	if req.GetSource() == dbpb.SpamSampleSource_COMMENTS {
+		comments, err := s.Storage.Moderation().ListSpamComments(ctx, page(req.Offset, req.Limit))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to list spam samples")
+		}
+		for _, comment := range comments {
+			results = append(results, spamSample("", comment.Content, "", comment.RemovalType))
+		}
+	} else {
+		threads, err := s.Storage.Moderation().ListSpamThreads(ctx, page(req.Offset, req.Limit))
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "Failed to list spam samples")
+		}
+		for _, thread := range threads {
+			results = append(results, spamSample(thread.Title, thread.Content, thread.Url, thread.RemovalType))
+		}
+	}
+
+	return &dbpb.ListSpamSamplesResponse{
+		Samples: results,
+	}, nil
+}
+
+// spamSample joins the text of a thread or comment, what moderators removed is spam
+func spamSample(title string, content string, url string, removalType models.RemovalType) *dbpb.SpamSample {
+	text := content
+	if title != "" {
+		text = title + "\n" + text
+	}
+	if url != "" {
+		text += "\n" + url
+	}
+	return &dbpb.SpamSample{
+		Text: text,
+		Spam: removalType == models.RemovalType_REMOVED,
+	}
+}
+
+func (s *DBServer) GetSpamModel(ctx context.Context, req *emptypb.Empty) (*dbpb.SpamModel, error) {
+	// no model has been trained yet without a training time
+	model, trainedAt, err := s.Storage.Moderation().GetSpamModel(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get spam model")
+	}
+
+	res := &dbpb.SpamModel{
+		Model: model,
+	}
+	if trainedAt != nil {
+		res.TrainedAt = timestamppb.New(*trainedAt)
+	}
+	return res, nil
+}
+
+func (s *DBServer) SetSpamModel(ctx context.Context, req *dbpb.SpamModel) (*emptypb.Empty, error) {
+	if err := s.Storage.Moderation().SetSpamModel(ctx, req.GetModel(), time.Now()); err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to set spam model")
+	}
+
+	return &emptypb.Empty{}, nil
+}
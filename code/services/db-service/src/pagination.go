@@ -0,0 +1,132 @@
+package server
+
+import (
+	"crypto/sha256"
+	"db-service/src/storage"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var errTokenMismatch = errors.New("page token of another list")
+
+// listing is a sorted list clients page through, the page token of a list is refused by other lists, sortings and
+// filters
+type listing struct {
+	name        string // threads, comments or communities
+	sortBy      string
+	pinnedFirst bool
+	filters     string // hash of the filter values, see filterHash
+}
+
+// filterHash identifies the values of the filters of a list, unset optional filters differ from empty ones
+func filterHash(filters ...any) string {
+	// strings, bools and pointers to them always marshal
+	data, _ := json.Marshal(filters)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:8])
+}
+
+// pageToken is the position of the last document of a page, clients get it base64 encoded. The sort value is
+// kept in the field of its type
+type pageToken struct {
+	List    string     `json:"l"`
+	SortBy  string     `json:"s,omitempty"`
+	Filters string     `json:"h,omitempty"`
+	Pinned  *bool      `json:"p,omitempty"`
+	Int     *int64     `json:"n,omitempty"`
+	Uint    *uint64    `json:"u,omitempty"`
+	Float   *float64   `json:"f,omitempty"`
+	String  *string    `json:"t,omitempty"`
+	Bool    *bool      `json:"b,omitempty"`
+	Time    *time.Time `json:"d,omitempty"`
+	Id      string     `json:"i"`
+}
+
+// page selects the requested range of the list, a page token continues it after the previous page and replaces the
+// offset
+func (l listing) page(offset *int32, limit *int32, token *string) (storage.Page, error) {
+	p := page(offset, limit)
+	if token == nil || *token == "" {
+		return p, nil
+	}
+	cursor, err := l.decode(*token)
+	if err != nil {
+		return storage.Page{}, status.Errorf(codes.InvalidArgument, "Invalid page token")
+	}
+	p.Offset = 0
+	p.After = cursor
+	return p, nil
+}
+
+// nextPageToken returns the token of the page after a full page of a list, it is empty after the last page
+func nextPageToken[T proto.Message](l listing, p storage.Page, rows []T) (string, error) {
+	if p.Limit <= 0 || len(rows) < int(p.Limit) {
+		return "", nil
+	}
+	token, err := l.encode(storage.NewCursor(rows[len(rows)-1], l.sortBy, l.pinnedFirst))
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "Failed to create page token")
+	}
+	return token, nil
+}
+
+func (l listing) encode(cursor *storage.Cursor) (string, error) {
+	token := pageToken{List: l.name, SortBy: l.sortBy, Filters: l.filters, Pinned: cursor.Pinned, Id: cursor.Id}
+	switch value := cursor.Value.(type) {
+	case int64:
+		token.Int = &value
+	case uint64:
+		token.Uint = &value
+	case float64:
+		token.Float = &value
+	case string:
+		token.String = &value
+	case bool:
+		token.Bool = &value
+	case time.Time:
+		token.Time = &value
+	}
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decode reads a token of the list, it fails for tokens of other lists, sortings or filters
+func (l listing) decode(encoded string) (*storage.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var token pageToken
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, err
+	}
+	if token.List != l.name || token.SortBy != l.sortBy || token.Filters != l.filters || (token.Pinned != nil) != l.pinnedFirst {
+		return nil, errTokenMismatch
+	}
+
+	cursor := &storage.Cursor{Pinned: token.Pinned, Id: token.Id}
+	switch {
+	case token.Int != nil:
+		cursor.Value = *token.Int
+	case token.Uint != nil:
+		cursor.Value = *token.Uint
+	case token.Float != nil:
+		cursor.Value = *token.Float
+	case token.String != nil:
+		cursor.Value = *token.String
+	case token.Bool != nil:
+		cursor.Value = *token.Bool
+	case token.Time != nil:
+		cursor.Value = *token.Time
+	}
+	return cursor, nil
+}
@@ -0,0 +1,60 @@
+package server
+
+import (
+	"context"
+	"db-service/src/storage"
+	"fmt"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *DBServer) ListRevisions(ctx context.Context, req *dbpb.ListRevisionsRequest) (*dbpb.ListRevisionsResponse, error) {
+	var target storage.RevisionTarget
+	switch {
+	case req.GetThreadId() != "" && req.GetCommentId() == "":
+		target.ThreadId = req.GetThreadId()
+	case req.GetCommentId() != "" && req.GetThreadId() == "":
+		target.CommentId = req.GetCommentId()
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Exactly one of thread id and comment id is required")
+	}
+
+	results, err := s.Storage.Revisions().List(ctx, target)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list revisions")
+	}
+	for i := 1; i < len(results); i++ {
+		results[i].Diff = revisionDiff(results[i-1], results[i])
+	}
+
+	return &dbpb.ListRevisionsResponse{
+		Revisions: results,
+	}, nil
+}
+
+// unified diff of the text of two revisions
+func revisionDiff(from *models.Revision, to *models.Revision) string {
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(revisionText(from)),
+		B:        difflib.SplitLines(revisionText(to)),
+		FromFile: fmt.Sprintf("version %d", from.Version),
+		ToFile:   fmt.Sprintf("version %d", to.Version),
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// revisionText is the text of a revision that is diffed, the title of a thread revision is its first line
+func revisionText(revision *models.Revision) string {
+	if revision.GetTitle() == "" {
+		return revision.GetContent()
+	}
+	return revision.GetTitle() + "\n\n" + revision.GetContent()
+}
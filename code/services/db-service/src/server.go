@@ -1,11 +1,13 @@
 package server
 
 import (
+	"db-service/src/storage"
 	dbpb "gen/db-service/pb"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type DBServer struct {
 	dbpb.UnimplementedDBServiceServer
-	Mongo *mongo.Database
+	Storage storage.Storage
+	// ReconcileFix makes the reconciliation jobs fix the counters they find wrong instead of only logging them
+	ReconcileFix bool
 }
@@ -0,0 +1,28 @@
+package bolt
+
+import (
+	"context"
+	models "gen/models/pb"
+
+	"go.etcd.io/bbolt"
+)
+
+type attachments struct {
+	*Storage
+}
+
+func (s *attachments) Create(ctx context.Context, attachment *models.Attachment) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return attachmentsTable.put(tx, attachment.GetId(), attachment)
+	})
+}
+
+func (s *attachments) Get(ctx context.Context, id string) (*models.Attachment, error) {
+	var attachment *models.Attachment
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		attachment, err = attachmentsTable.get(tx, id)
+		return err
+	})
+	return attachment, err
+}
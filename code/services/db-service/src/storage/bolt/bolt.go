@@ -0,0 +1,439 @@
+// Package bolt stores the documents of db-service in a single bbolt file, for demos and CI.
+package bolt
+
+import (
+	"bytes"
+	"db-service/src/storage"
+	"encoding/binary"
+	models "gen/models/pb"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// buckets that are not the rows or indexes of a table
+var (
+	numEditsBucket       = []byte("num_edits")       // id → edits of a thread or comment
+	pollVotesBucket      = []byte("poll_votes")      // thread id, user id → nothing
+	revisionsBucket      = []byte("revisions")       // target, sequence → revision
+	automodConfigsBucket = []byte("automod_configs") // community id → config
+	automodActionsBucket = []byte("automod_actions") // thread id, comment id, rule, dry run → nothing
+	reportsBucket        = []byte("reports")         // thread id, comment id, reporter id → nothing
+	spamModelBucket      = []byte("spam_model")      // model and trained_at keys
+	eventsBucket         = []byte("events")          // offset → event, the outbox
+)
+
+// tables and their secondary indexes, index keys are the value of the index followed by the sequence of the row
+var (
+	communitiesTable = &table[*models.Community]{
+		name: "communities",
+		indexes: []index[*models.Community]{
+			{name: "communities_by_name", key: func(c *models.Community) []byte { return stringKey(c.Name) }},
+		},
+	}
+	threadsTable = &table[*models.Thread]{
+		name: "threads",
+		indexes: []index[*models.Thread]{
+			{name: "threads_by_community", key: func(t *models.Thread) []byte { return stringKey(t.CommunityId) }},
+			{name: "threads_by_ups", key: func(t *models.Thread) []byte { return scoreKey(t.Ups) }},
+		},
+	}
+	commentsTable = &table[*models.Comment]{
+		name: "comments",
+		indexes: []index[*models.Comment]{
+			{name: "comments_by_parent", key: func(c *models.Comment) []byte { return stringKey(c.ParentId) }},
+			{name: "comments_by_ups", key: func(c *models.Comment) []byte { return scoreKey(c.Ups) }},
+			{name: "comments_by_ancestor", keys: func(c *models.Comment) [][]byte {
+				keys := make([][]byte, 0, len(c.AncestorIds))
+				for _, id := range c.AncestorIds {
+					keys = append(keys, stringKey(id))
+				}
+				return keys
+			}},
+		},
+	}
+	attachmentsTable = &table[*models.Attachment]{name: "attachments"}
+	modQueueTable    = &table[*models.ModQueueItem]{
+		name: "mod_queue",
+		indexes: []index[*models.ModQueueItem]{
+			{name: "mod_queue_by_community", key: func(i *models.ModQueueItem) []byte { return stringKey(i.CommunityId) }},
+		},
+	}
+	jobsTable = &table[*models.Job]{
+		name: "jobs",
+		indexes: []index[*models.Job]{
+			{name: "jobs_by_state", key: func(j *models.Job) []byte { return stringKey(j.State.String()) }},
+		},
+	}
+)
+
+// Storage keeps every kind of document in its own buckets of a bbolt file, bbolt serializes writes so every
+// operation is atomic
+type Storage struct {
+	db *bbolt.DB
+}
+
+// Open opens the data file at path, creating it and its buckets when missing
+func Open(path string) (*Storage, error) {
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	// the ancestor index is missing in files written before it, where replies may have no ancestor path either
+	indexAncestors := false
+	err = db.View(func(tx *bbolt.Tx) error {
+		indexAncestors = tx.Bucket([]byte("comments")) != nil && tx.Bucket([]byte("comments_by_ancestor")) == nil
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	names := [][]byte{numEditsBucket, pollVotesBucket, revisionsBucket, automodConfigsBucket, automodActionsBucket, reportsBucket, spamModelBucket, eventsBucket}
+	names = append(names, communitiesTable.buckets()...)
+	names = append(names, threadsTable.buckets()...)
+	names = append(names, commentsTable.buckets()...)
+	names = append(names, attachmentsTable.buckets()...)
+	names = append(names, modQueueTable.buckets()...)
+	names = append(names, jobsTable.buckets()...)
+	err = db.Update(func(tx *bbolt.Tx) error {
+		for _, name := range names {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		if indexAncestors {
+			return migrateAncestors(tx)
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &Storage{db: db}, nil
+}
+
+func (s *Storage) Close() error {
+	return s.db.Close()
+}
+
+func (s *Storage) Communities() storage.CommunityRepository {
+	return &communities{s}
+}
+
+func (s *Storage) Threads() storage.ThreadRepository {
+	return &threads{s}
+}
+
+func (s *Storage) Comments() storage.CommentRepository {
+	return &comments{s}
+}
+
+func (s *Storage) Revisions() storage.RevisionRepository {
+	return &revisions{s}
+}
+
+func (s *Storage) Attachments() storage.AttachmentRepository {
+	return &attachments{s}
+}
+
+func (s *Storage) Moderation() storage.ModerationRepository {
+	return &moderation{s}
+}
+
+func (s *Storage) Counters() storage.CounterRepository {
+	return &counters{s}
+}
+
+func (s *Storage) Events() storage.EventRepository {
+	return &events{s}
+}
+
+func (s *Storage) Jobs() storage.JobRepository {
+	return &jobs{s}
+}
+
+// emit appends events to the outbox in the transaction of the change they describe
+func emit(tx *bbolt.Tx, events ...*models.Event) error {
+	bucket := tx.Bucket(eventsBucket)
+	for _, event := range events {
+		offset, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		event.Offset = int64(offset)
+		data, err := proto.Marshal(event)
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put(binary.BigEndian.AppendUint64(nil, offset), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// index is a secondary index of a table, key is the value rows are found by. Indexes of several values per row set
+// keys instead
+type index[T proto.Message] struct {
+	name string
+	key  func(row T) []byte
+	keys func(row T) [][]byte
+}
+
+// values returns the keys a row is indexed by
+func (i index[T]) values(row T) [][]byte {
+	if i.keys != nil {
+		return i.keys(row)
+	}
+	return [][]byte{i.key(row)}
+}
+
+// table keeps rows by sequence, which is the insertion order. Rows are found by id through the ids bucket and by
+// their indexed values through the index buckets
+type table[T proto.Message] struct {
+	name    string
+	indexes []index[T]
+}
+
+func (t *table[T]) buckets() [][]byte {
+	names := [][]byte{[]byte(t.name), []byte(t.name + "_ids")}
+	for _, index := range t.indexes {
+		names = append(names, []byte(index.name))
+	}
+	return names
+}
+
+func (t *table[T]) rows(tx *bbolt.Tx) *bbolt.Bucket {
+	return tx.Bucket([]byte(t.name))
+}
+
+func (t *table[T]) ids(tx *bbolt.Tx) *bbolt.Bucket {
+	return tx.Bucket([]byte(t.name + "_ids"))
+}
+
+// decode unmarshals a row, the message does not share memory with the transaction
+func (t *table[T]) decode(data []byte) (T, error) {
+	var zero T
+	row := zero.ProtoReflect().New().Interface().(T)
+	if err := proto.Unmarshal(data, row); err != nil {
+		return zero, err
+	}
+	return row, nil
+}
+
+// get finds a row by id, it fails with ErrNotFound without it
+func (t *table[T]) get(tx *bbolt.Tx, id string) (T, error) {
+	var zero T
+	seq := t.ids(tx).Get([]byte(id))
+	if seq == nil {
+		return zero, storage.ErrNotFound
+	}
+	return t.decode(t.rows(tx).Get(seq))
+}
+
+// put inserts a row or replaces the row with the same id, which keeps its place in the insertion order
+func (t *table[T]) put(tx *bbolt.Tx, id string, row T) error {
+	seq := bytes.Clone(t.ids(tx).Get([]byte(id)))
+	if seq != nil {
+		previous, err := t.decode(t.rows(tx).Get(seq))
+		if err != nil {
+			return err
+		}
+		if err := t.unindex(tx, previous, seq); err != nil {
+			return err
+		}
+	} else {
+		next, err := t.rows(tx).NextSequence()
+		if err != nil {
+			return err
+		}
+		seq = binary.BigEndian.AppendUint64(nil, next)
+		if err := t.ids(tx).Put([]byte(id), seq); err != nil {
+			return err
+		}
+	}
+
+	data, err := proto.Marshal(row)
+	if err != nil {
+		return err
+	}
+	if err := t.rows(tx).Put(seq, data); err != nil {
+		return err
+	}
+	for _, index := range t.indexes {
+		for _, key := range index.values(row) {
+			if err := tx.Bucket([]byte(index.name)).Put(append(key, seq...), []byte{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// update changes a row found by id with fn, it fails with ErrNotFound without it
+func (t *table[T]) update(tx *bbolt.Tx, id string, fn func(row T) error) error {
+	row, err := t.get(tx, id)
+	if err != nil {
+		return err
+	}
+	if err := fn(row); err != nil {
+		return err
+	}
+	return t.put(tx, id, row)
+}
+
+// delete deletes a row by id, it fails with ErrNotFound without it
+func (t *table[T]) delete(tx *bbolt.Tx, id string) error {
+	seq := t.ids(tx).Get([]byte(id))
+	if seq == nil {
+		return storage.ErrNotFound
+	}
+	seq = bytes.Clone(seq)
+	row, err := t.decode(t.rows(tx).Get(seq))
+	if err != nil {
+		return err
+	}
+	if err := t.unindex(tx, row, seq); err != nil {
+		return err
+	}
+	if err := t.rows(tx).Delete(seq); err != nil {
+		return err
+	}
+	return t.ids(tx).Delete([]byte(id))
+}
+
+func (t *table[T]) unindex(tx *bbolt.Tx, row T, seq []byte) error {
+	for _, index := range t.indexes {
+		for _, key := range index.values(row) {
+			if err := tx.Bucket([]byte(index.name)).Delete(append(key, seq...)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// scan calls fn with every row in insertion order until fn returns false
+func (t *table[T]) scan(tx *bbolt.Tx, fn func(row T) bool) error {
+	c := t.rows(tx).Cursor()
+	for key, data := c.First(); key != nil; key, data = c.Next() {
+		row, err := t.decode(data)
+		if err != nil {
+			return err
+		}
+		if !fn(row) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// all returns the rows in insertion order
+func (t *table[T]) all(tx *bbolt.Tx) ([]T, error) {
+	var rows []T
+	err := t.scan(tx, func(row T) bool {
+		rows = append(rows, row)
+		return true
+	})
+	return rows, err
+}
+
+// scanIndex calls fn with the rows whose index key starts with prefix, in the order of the index from the first key
+// at or after seek, until fn returns false. A nil seek reads every row of the prefix
+func (t *table[T]) scanIndex(tx *bbolt.Tx, name string, prefix []byte, seek []byte, fn func(row T) bool) error {
+	if seek == nil {
+		seek = prefix
+	}
+	rows := t.rows(tx)
+	c := tx.Bucket([]byte(name)).Cursor()
+	for key, _ := c.Seek(seek); key != nil && bytes.HasPrefix(key, prefix); key, _ = c.Next() {
+		row, err := t.decode(rows.Get(key[len(key)-8:]))
+		if err != nil {
+			return err
+		}
+		if !fn(row) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// stringKey indexes a string, the separator keeps values that are prefixes of others apart
+func stringKey(value string) []byte {
+	return append([]byte(value), 0)
+}
+
+// scoreKey indexes a score in descending order
+func scoreKey(score int32) []byte {
+	return binary.BigEndian.AppendUint32(nil, ^(uint32(score) ^ 1<<31))
+}
+
+// cursorScoreKey returns where a score index is read from to continue a list sorted by ups after a cursor, nil
+// without one
+func cursorScoreKey(after *storage.Cursor) []byte {
+	if after == nil {
+		return nil
+	}
+	ups, ok := after.Value.(int64)
+	if !ok {
+		return nil
+	}
+	return scoreKey(int32(ups))
+}
+
+// paginate returns the rows of a page of a list
+func paginate[T any](rows []T, page storage.Page) []T {
+	start, end := page.Bounds(len(rows))
+	if start == end {
+		return nil
+	}
+	return rows[start:end]
+}
+
+// pageFilled reports whether n rows of a list fill a page, so reading more rows is not needed
+func pageFilled(n int, page storage.Page) bool {
+	return page.Limit > 0 && n >= int(page.Offset+page.Limit)
+}
+
+// counters of edits of threads and comments, which are not part of their messages
+
+func numEdits(tx *bbolt.Tx, id string) int32 {
+	value := tx.Bucket(numEditsBucket).Get([]byte(id))
+	if value == nil {
+		return 0
+	}
+	return int32(binary.BigEndian.Uint32(value))
+}
+
+func countEdit(tx *bbolt.Tx, id string) error {
+	return tx.Bucket(numEditsBucket).Put([]byte(id), binary.BigEndian.AppendUint32(nil, uint32(numEdits(tx, id)+1)))
+}
+
+// removal of threads and comments, which share the removal fields
+
+func remove(removalType *models.RemovalType, removalReason *string, removedAt **timestamppb.Timestamp, newType models.RemovalType, reason string, at time.Time) error {
+	if *removalType != models.RemovalType_NOT_REMOVED {
+		return storage.ErrFailedPrecondition
+	}
+	*removalType = newType
+	*removalReason = reason
+	*removedAt = timestamppb.New(at)
+	return nil
+}
+
+func restore(removalType *models.RemovalType, removalReason *string, removedAt **timestamppb.Timestamp, removedAfter time.Time) error {
+	// only what is removed has a removal time
+	if *removedAt == nil || (*removedAt).AsTime().Before(removedAfter) {
+		return storage.ErrFailedPrecondition
+	}
+	*removalType = models.RemovalType_NOT_REMOVED
+	*removalReason = ""
+	*removedAt = nil
+	return nil
+}
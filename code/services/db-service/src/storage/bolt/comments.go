@@ -0,0 +1,262 @@
+package bolt
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type comments struct {
+	*Storage
+}
+
+func (s *comments) List(ctx context.Context, filter storage.CommentFilter) ([]*models.Comment, error) {
+	var results []*models.Comment
+	collect := func(comment *models.Comment) bool {
+		if filter.Page.After == nil || filter.Page.After.Precedes(comment, filter.SortBy) {
+			results = append(results, comment)
+		}
+		return true
+	}
+
+	// the comments of a parent are read through its index. The score index is sorted already, it is read from the
+	// score of the cursor up to the end of the page and the ties of its last comment, which are sorted by id below
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		switch {
+		case filter.ParentId != "":
+			return commentsTable.scanIndex(tx, "comments_by_parent", stringKey(filter.ParentId), nil, collect)
+		case filter.SortBy == "ups":
+			return commentsTable.scanIndex(tx, "comments_by_ups", nil, cursorScoreKey(filter.Page.After), func(comment *models.Comment) bool {
+				if pageFilled(len(results), filter.Page) && comment.Ups != results[len(results)-1].Ups {
+					return false
+				}
+				return collect(comment)
+			})
+		default:
+			return commentsTable.scan(tx, collect)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	storage.Sort(results, filter.SortBy, false)
+	return paginate(results, filter.Page), nil
+}
+
+func (s *comments) Create(ctx context.Context, comment *models.Comment) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := commentsTable.put(tx, comment.GetId(), comment); err != nil {
+			return err
+		}
+		return emit(tx, storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	})
+}
+
+func (s *comments) Get(ctx context.Context, id string) (*models.Comment, error) {
+	var comment *models.Comment
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		comment, err = commentsTable.get(tx, id)
+		return err
+	})
+	return comment, err
+}
+
+func (s *comments) Update(ctx context.Context, id string, update storage.CommentUpdate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return commentsTable.update(tx, id, func(comment *models.Comment) error {
+			if err := createRevisions(tx, storage.RevisionTarget{CommentId: id}, update.Revisions(comment, numEdits(tx, id))); err != nil {
+				return err
+			}
+			if update.Content != nil {
+				comment.Content = *update.Content
+			}
+			if update.ContentHtml != nil {
+				comment.ContentHtml = *update.ContentHtml
+			}
+			comment.Ups += update.UpsOffset
+			comment.Downs += update.DownsOffset
+			comment.NumComments += update.NumCommentsOffset
+			comment.NumReports += update.NumReportsOffset
+			if update.SpamScore != nil {
+				comment.SpamScore = *update.SpamScore
+			}
+			if update.Edit != nil {
+				comment.EditedAt = timestamppb.New(update.Edit.EditedAt)
+				if err := countEdit(tx, id); err != nil {
+					return err
+				}
+			}
+			return emit(tx, update.Events(id)...)
+		})
+	})
+}
+
+func (s *comments) Delete(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return deleteComment(tx, id)
+	})
+}
+
+func deleteComment(tx *bbolt.Tx, id string) error {
+	if err := commentsTable.delete(tx, id); err != nil {
+		return err
+	}
+	if err := tx.Bucket(numEditsBucket).Delete([]byte(id)); err != nil {
+		return err
+	}
+	if err := deletePrefix(tx.Bucket(revisionsBucket), revisionPrefix(storage.RevisionTarget{CommentId: id})); err != nil {
+		return err
+	}
+	return emit(tx, storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+}
+
+func (s *comments) CreateAndIncrement(ctx context.Context, comment *models.Comment) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := countComment(tx, comment, 1); err != nil {
+			return err
+		}
+		if err := commentsTable.put(tx, comment.GetId(), comment); err != nil {
+			return err
+		}
+		return emit(tx, storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	})
+}
+
+func (s *comments) DeleteAndDecrement(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		comment, err := commentsTable.get(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := deleteComment(tx, id); err != nil {
+			return err
+		}
+		if err := countComment(tx, comment, -1); !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+}
+
+func (s *comments) DeleteTree(ctx context.Context, id string) (int32, error) {
+	var ids []string
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		comment, err := commentsTable.get(tx, id)
+		if err != nil {
+			return err
+		}
+		// the replies at any depth are found by the ancestor path, without walking the tree
+		ids = []string{id}
+		err = commentsTable.scanIndex(tx, "comments_by_ancestor", stringKey(id), nil, func(reply *models.Comment) bool {
+			ids = append(ids, reply.Id)
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if err := deleteComment(tx, id); err != nil {
+				return err
+			}
+		}
+		if err := countComment(tx, comment, -1); !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(len(ids)), nil
+}
+
+// countComment adds an offset to num_comments of the parent of a comment, it fails with ErrNotFound when the parent
+// does not exist
+func countComment(tx *bbolt.Tx, comment *models.Comment, offset int32) error {
+	if comment.GetParentType() == models.CommentParentType_COMMENT {
+		return commentsTable.update(tx, comment.GetParentId(), func(parent *models.Comment) error {
+			parent.NumComments += offset
+			return nil
+		})
+	}
+	return threadsTable.update(tx, comment.GetParentId(), func(parent *models.Thread) error {
+		parent.NumComments += offset
+		return nil
+	})
+}
+
+func (s *comments) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		err := commentsTable.update(tx, id, func(comment *models.Comment) error {
+			return remove(&comment.RemovalType, &comment.RemovalReason, &comment.RemovedAt, removalType, reason, removedAt)
+		})
+		if err != nil {
+			return err
+		}
+		return emit(tx, storage.CommentEvent(models.EventType_COMMENT_REMOVED, id))
+	})
+}
+
+func (s *comments) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		err := commentsTable.update(tx, id, func(comment *models.Comment) error {
+			return restore(&comment.RemovalType, &comment.RemovalReason, &comment.RemovedAt, removedAfter)
+		})
+		if err != nil {
+			return err
+		}
+		return emit(tx, storage.CommentEvent(models.EventType_COMMENT_RESTORED, id))
+	})
+}
+
+// migrateAncestors gives the replies of files written before comments had an ancestor path the path of their parent,
+// and indexes every comment by its ancestors. Replies whose parent is gone keep no path
+func migrateAncestors(tx *bbolt.Tx) error {
+	var ids []string
+	err := commentsTable.scan(tx, func(comment *models.Comment) bool {
+		ids = append(ids, comment.Id)
+		return true
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		comment, err := commentsTable.get(tx, id)
+		if err != nil {
+			return err
+		}
+		if comment.ParentType == models.CommentParentType_COMMENT && len(comment.AncestorIds) == 0 {
+			path, err := ancestorPath(tx, comment.ParentId)
+			if err != nil && !errors.Is(err, storage.ErrNotFound) {
+				return err
+			}
+			comment.AncestorIds = path
+		}
+		if err := commentsTable.put(tx, id, comment); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ancestorPath returns the ancestor path of the replies of a comment, it fails with ErrNotFound when an ancestor is
+// gone
+func ancestorPath(tx *bbolt.Tx, parentId string) ([]string, error) {
+	parent, err := commentsTable.get(tx, parentId)
+	if err != nil {
+		return nil, err
+	}
+	path := parent.AncestorIds
+	if parent.ParentType == models.CommentParentType_COMMENT && len(path) == 0 {
+		if path, err = ancestorPath(tx, parent.ParentId); err != nil {
+			return nil, err
+		}
+	}
+	return append(path, parent.Id), nil
+}
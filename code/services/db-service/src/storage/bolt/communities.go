@@ -0,0 +1,135 @@
+package bolt
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"regexp"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+type communities struct {
+	*Storage
+}
+
+func (s *communities) List(ctx context.Context, filter storage.CommunityFilter) ([]*models.Community, error) {
+	var name *regexp.Regexp
+	if filter.Name != "" {
+		var err error
+		if name, err = regexp.Compile("(?i)" + filter.Name); err != nil { // case-insensitive name match
+			return nil, err
+		}
+	}
+	var results []*models.Community
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return communitiesTable.scan(tx, func(community *models.Community) bool {
+			if (name == nil || name.MatchString(community.Name)) && (filter.Page.After == nil || filter.Page.After.Precedes(community, "")) {
+				results = append(results, community)
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	storage.Sort(results, "", false)
+	return paginate(results, filter.Page), nil
+}
+
+// nameInUse reports whether a community other than id has the name
+func nameInUse(tx *bbolt.Tx, name string, id string) (bool, error) {
+	inUse := false
+	err := communitiesTable.scanIndex(tx, "communities_by_name", stringKey(name), nil, func(community *models.Community) bool {
+		inUse = community.Id != id
+		return !inUse
+	})
+	return inUse, err
+}
+
+func (s *communities) Create(ctx context.Context, community *models.Community) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		inUse, err := nameInUse(tx, community.GetName(), "")
+		if err != nil {
+			return err
+		}
+		if inUse {
+			return storage.ErrAlreadyExists
+		}
+		if err := communitiesTable.put(tx, community.GetId(), community); err != nil {
+			return err
+		}
+		return emit(tx, storage.CommunityEvent(models.EventType_COMMUNITY_CREATED, community.GetId()))
+	})
+}
+
+func (s *communities) Get(ctx context.Context, id string) (*models.Community, error) {
+	var community *models.Community
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		community, err = communitiesTable.get(tx, id)
+		return err
+	})
+	return community, err
+}
+
+func (s *communities) Update(ctx context.Context, id string, update storage.CommunityUpdate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return communitiesTable.update(tx, id, func(community *models.Community) error {
+			if update.Name != nil {
+				inUse, err := nameInUse(tx, *update.Name, id)
+				if err != nil {
+					return err
+				}
+				if inUse {
+					return storage.ErrAlreadyExists
+				}
+				community.Name = *update.Name
+			}
+			if update.PostingRules != nil {
+				community.PostingRules = proto.Clone(update.PostingRules).(*models.PostingRules)
+			}
+			community.NumThreads += update.NumThreadsOffset
+			return nil
+		})
+	})
+}
+
+func (s *communities) Delete(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := communitiesTable.delete(tx, id); err != nil {
+			return err
+		}
+		return emit(tx, storage.CommunityEvent(models.EventType_COMMUNITY_DELETED, id))
+	})
+}
+
+func (s *communities) AddFlairTemplate(ctx context.Context, communityId string, template *models.FlairTemplate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return communitiesTable.update(tx, communityId, func(community *models.Community) error {
+			// flair texts are unique within a community
+			for _, existing := range community.FlairTemplates {
+				if existing.Text == template.GetText() {
+					return storage.ErrAlreadyExists
+				}
+			}
+			community.FlairTemplates = append(community.FlairTemplates, template)
+			return nil
+		})
+	})
+}
+
+func (s *communities) RemoveFlairTemplate(ctx context.Context, communityId string, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return communitiesTable.update(tx, communityId, func(community *models.Community) error {
+			for i, template := range community.FlairTemplates {
+				if template.Id == id {
+					community.FlairTemplates = append(community.FlairTemplates[:i], community.FlairTemplates[i+1:]...)
+					return nil
+				}
+			}
+			return storage.ErrNotFound
+		})
+	})
+}
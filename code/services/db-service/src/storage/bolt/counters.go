@@ -0,0 +1,132 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+
+	"go.etcd.io/bbolt"
+)
+
+type counters struct {
+	*Storage
+}
+
+func (s *counters) RecountThreads(ctx context.Context, communityId string, fix bool) (storage.Recount, error) {
+	var recount storage.Recount
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		community, err := communitiesTable.get(tx, communityId)
+		if err != nil {
+			return err
+		}
+		recount.Stored = community.NumThreads
+		err = threadsTable.scanIndex(tx, "threads_by_community", stringKey(communityId), nil, func(thread *models.Thread) bool {
+			if storage.Counted(thread) {
+				recount.Counted++
+			}
+			return true
+		})
+		if err != nil || !fix || recount.Stored == recount.Counted {
+			return err
+		}
+		community.NumThreads = recount.Counted
+		return communitiesTable.put(tx, communityId, community)
+	})
+	return recount, err
+}
+
+func (s *counters) RecountComments(ctx context.Context, parentId string, parentType models.CommentParentType, fix bool) (storage.Recount, error) {
+	var recount storage.Recount
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		err := commentsTable.scanIndex(tx, "comments_by_parent", stringKey(parentId), nil, func(comment *models.Comment) bool {
+			if comment.ParentType == parentType {
+				recount.Counted++
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+
+		// the parent is read and fixed in the same transaction as the count
+		if parentType == models.CommentParentType_COMMENT {
+			return commentsTable.update(tx, parentId, func(parent *models.Comment) error {
+				recount.Stored = parent.NumComments
+				if fix {
+					parent.NumComments = recount.Counted
+				}
+				return nil
+			})
+		}
+		return threadsTable.update(tx, parentId, func(parent *models.Thread) error {
+			recount.Stored = parent.NumComments
+			if fix {
+				parent.NumComments = recount.Counted
+			}
+			return nil
+		})
+	})
+	return recount, err
+}
+
+// Compare counts the replies of a thread or comment from the keys of the parent index, so no reply is decoded and no
+// count is kept once its parent is compared
+func (s *counters) Compare(ctx context.Context) (int32, []storage.Suspect, error) {
+	var numChecked int32
+	var suspects []storage.Suspect
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		replies := tx.Bucket([]byte("comments_by_parent"))
+		err := threadsTable.scan(tx, func(thread *models.Thread) bool {
+			numChecked++
+			if thread.NumComments != countPrefix(replies, stringKey(thread.Id)) {
+				suspects = append(suspects, storage.Suspect{ThreadId: thread.Id})
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		err = commentsTable.scan(tx, func(comment *models.Comment) bool {
+			numChecked++
+			if comment.NumComments != countPrefix(replies, stringKey(comment.Id)) {
+				suspects = append(suspects, storage.Suspect{CommentId: comment.Id})
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return communitiesTable.scan(tx, func(community *models.Community) bool {
+			numChecked++
+			var counted int32
+			err = threadsTable.scanIndex(tx, "threads_by_community", stringKey(community.Id), nil, func(thread *models.Thread) bool {
+				if storage.Counted(thread) {
+					counted++
+				}
+				return true
+			})
+			if err != nil {
+				return false
+			}
+			if community.NumThreads != counted {
+				suspects = append(suspects, storage.Suspect{CommunityId: community.Id})
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return numChecked, suspects, nil
+}
+
+// countPrefix counts the keys of a bucket that start with prefix
+func countPrefix(bucket *bbolt.Bucket, prefix []byte) int32 {
+	var n int32
+	c := bucket.Cursor()
+	for key, _ := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = c.Next() {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,54 @@
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	models "gen/models/pb"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+type events struct {
+	*Storage
+}
+
+func (s *events) List(ctx context.Context, after int64, limit int32) ([]*models.Event, error) {
+	var results []*models.Event
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		c := tx.Bucket(eventsBucket).Cursor()
+		for key, data := c.Seek(binary.BigEndian.AppendUint64(nil, uint64(after)+1)); key != nil; key, data = c.Next() {
+			if limit > 0 && len(results) >= int(limit) {
+				return nil
+			}
+			event := &models.Event{}
+			if err := proto.Unmarshal(data, event); err != nil {
+				return err
+			}
+			results = append(results, event)
+		}
+		return nil
+	})
+	return results, err
+}
+
+func (s *events) Prune(ctx context.Context, before time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		// events are appended in time order, so the pruned ones are at the start of the bucket
+		c := tx.Bucket(eventsBucket).Cursor()
+		for key, data := c.First(); key != nil; key, data = c.First() {
+			event := &models.Event{}
+			if err := proto.Unmarshal(data, event); err != nil {
+				return err
+			}
+			if !event.CreatedAt.AsTime().Before(before) {
+				return nil
+			}
+			if err := c.Delete(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
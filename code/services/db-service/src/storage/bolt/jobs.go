@@ -0,0 +1,82 @@
+package bolt
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type jobs struct {
+	*Storage
+}
+
+func (s *jobs) Create(ctx context.Context, job *models.Job) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		_, err := jobsTable.get(tx, job.GetId())
+		if err == nil {
+			return storage.ErrAlreadyExists
+		}
+		if !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+		return jobsTable.put(tx, job.GetId(), job)
+	})
+}
+
+func (s *jobs) Get(ctx context.Context, id string) (*models.Job, error) {
+	var job *models.Job
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		job, err = jobsTable.get(tx, id)
+		return err
+	})
+	return job, err
+}
+
+func (s *jobs) Claim(ctx context.Context, leaseId string, now time.Time, leaseExpiresAt time.Time) (*models.Job, error) {
+	var claimed *models.Job
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		// only pending and running jobs are read, finished ones pile up
+		for _, state := range []models.JobState{models.JobState_JOB_PENDING, models.JobState_JOB_RUNNING} {
+			err := jobsTable.scanIndex(tx, "jobs_by_state", stringKey(state.String()), nil, func(job *models.Job) bool {
+				if storage.Claimable(job, now) && (claimed == nil || job.CreatedAt.AsTime().Before(claimed.CreatedAt.AsTime())) {
+					claimed = job
+				}
+				return true
+			})
+			if err != nil {
+				return err
+			}
+		}
+		if claimed == nil {
+			return storage.ErrNotFound
+		}
+		claimed.State = models.JobState_JOB_RUNNING
+		claimed.LeaseId = leaseId
+		claimed.LeaseExpiresAt = timestamppb.New(leaseExpiresAt)
+		claimed.UpdatedAt = timestamppb.New(now)
+		return jobsTable.put(tx, claimed.GetId(), claimed)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claimed, nil
+}
+
+func (s *jobs) Update(ctx context.Context, job *models.Job) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		stored, err := jobsTable.get(tx, job.GetId())
+		if err != nil {
+			return err
+		}
+		if stored.LeaseId != job.GetLeaseId() {
+			return storage.ErrFailedPrecondition
+		}
+		return jobsTable.put(tx, job.GetId(), job)
+	})
+}
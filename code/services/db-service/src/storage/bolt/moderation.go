@@ -0,0 +1,215 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"slices"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+// keys of the spam model bucket
+var (
+	spamModelKey     = []byte("model")
+	spamTrainedAtKey = []byte("trained_at")
+)
+
+type moderation struct {
+	*Storage
+}
+
+func (s *moderation) AuthorStats(ctx context.Context, authorId string) (int32, *time.Time, error) {
+	// karma and first activity are aggregated over both threads and comments
+	var karma int32
+	var firstSeen *time.Time
+	count := func(ups int32, downs int32, createdAt time.Time) {
+		karma += ups - downs
+		if firstSeen == nil || createdAt.Before(*firstSeen) {
+			firstSeen = &createdAt
+		}
+	}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		err := threadsTable.scan(tx, func(thread *models.Thread) bool {
+			if thread.AuthorId == authorId {
+				count(thread.Ups, thread.Downs, thread.CreatedAt.AsTime())
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		return commentsTable.scan(tx, func(comment *models.Comment) bool {
+			if comment.AuthorId == authorId {
+				count(comment.Ups, comment.Downs, comment.CreatedAt.AsTime())
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return karma, firstSeen, nil
+}
+
+func (s *moderation) GetAutomodConfig(ctx context.Context, communityId string) (string, error) {
+	var config string
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		config = string(tx.Bucket(automodConfigsBucket).Get([]byte(communityId)))
+		return nil
+	})
+	return config, err
+}
+
+func (s *moderation) SetAutomodConfig(ctx context.Context, communityId string, config string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(automodConfigsBucket).Put([]byte(communityId), []byte(config))
+	})
+}
+
+func (s *moderation) ListModQueue(ctx context.Context, communityId string, page storage.Page) ([]*models.ModQueueItem, error) {
+	var results []*models.ModQueueItem
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		if communityId == "" {
+			results, err = modQueueTable.all(tx)
+			return err
+		}
+		return modQueueTable.scanIndex(tx, "mod_queue_by_community", stringKey(communityId), nil, func(item *models.ModQueueItem) bool {
+			results = append(results, item)
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	storage.Sort(results, "created_at", false)
+	return paginate(results, page), nil
+}
+
+func (s *moderation) CreateModQueueItem(ctx context.Context, item *models.ModQueueItem) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return modQueueTable.put(tx, item.GetId(), item)
+	})
+}
+
+func (s *moderation) DeleteModQueueItem(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return modQueueTable.delete(tx, id)
+	})
+}
+
+func (s *moderation) RecordAutomodAction(ctx context.Context, action storage.AutomodAction) error {
+	key := append(stringKey(action.ThreadId), stringKey(action.CommentId)...)
+	key = append(key, stringKey(action.Rule)...)
+	if action.DryRun {
+		key = append(key, 1)
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		actions := tx.Bucket(automodActionsBucket)
+		if hasKey(actions, key) {
+			return storage.ErrAlreadyExists
+		}
+		return actions.Put(key, []byte{})
+	})
+}
+
+func (s *moderation) CreateReport(ctx context.Context, report storage.Report) error {
+	key := append(stringKey(report.ThreadId), stringKey(report.CommentId)...)
+	key = append(key, report.ReporterId...)
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		reports := tx.Bucket(reportsBucket)
+		if hasKey(reports, key) {
+			return storage.ErrAlreadyExists
+		}
+		var err error
+		if report.CommentId != "" {
+			err = commentsTable.update(tx, report.CommentId, func(comment *models.Comment) error {
+				comment.NumReports++
+				return nil
+			})
+		} else {
+			err = threadsTable.update(tx, report.ThreadId, func(thread *models.Thread) error {
+				thread.NumReports++
+				return nil
+			})
+		}
+		if err != nil {
+			return err
+		}
+		return reports.Put(key, []byte{})
+	})
+}
+
+func (s *moderation) ListSpamThreads(ctx context.Context, page storage.Page) ([]*models.Thread, error) {
+	var results []*models.Thread
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		results, err = threadsTable.all(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	// content deleted by its author says nothing about spam
+	results = slices.DeleteFunc(results, func(thread *models.Thread) bool {
+		return thread.RemovalType == models.RemovalType_DELETED
+	})
+	storage.Sort(results, "_id", false)
+	return paginate(results, page), nil
+}
+
+func (s *moderation) ListSpamComments(ctx context.Context, page storage.Page) ([]*models.Comment, error) {
+	var results []*models.Comment
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		results, err = commentsTable.all(tx)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	results = slices.DeleteFunc(results, func(comment *models.Comment) bool {
+		return comment.RemovalType == models.RemovalType_DELETED
+	})
+	storage.Sort(results, "_id", false)
+	return paginate(results, page), nil
+}
+
+func (s *moderation) GetSpamModel(ctx context.Context) ([]byte, *time.Time, error) {
+	var model []byte
+	var trainedAt *time.Time
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(spamModelBucket)
+		value := bucket.Get(spamTrainedAtKey)
+		if value == nil {
+			return nil
+		}
+		trainedAt = &time.Time{}
+		if err := trainedAt.UnmarshalBinary(value); err != nil {
+			return err
+		}
+		model = bytes.Clone(bucket.Get(spamModelKey))
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return model, trainedAt, nil
+}
+
+func (s *moderation) SetSpamModel(ctx context.Context, model []byte, trainedAt time.Time) error {
+	value, err := trainedAt.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(spamModelBucket)
+		if err := bucket.Put(spamModelKey, model); err != nil {
+			return err
+		}
+		return bucket.Put(spamTrainedAtKey, value)
+	})
+}
@@ -0,0 +1,66 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"db-service/src/storage"
+	"encoding/binary"
+	models "gen/models/pb"
+	"slices"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+type revisions struct {
+	*Storage
+}
+
+// revisionPrefix starts the keys of the revisions of a thread or comment
+func revisionPrefix(target storage.RevisionTarget) []byte {
+	if target.ThreadId != "" {
+		return append([]byte("thread\x00"), stringKey(target.ThreadId)...)
+	}
+	return append([]byte("comment\x00"), stringKey(target.CommentId)...)
+}
+
+func createRevisions(tx *bbolt.Tx, target storage.RevisionTarget, revisions []*models.Revision) error {
+	bucket := tx.Bucket(revisionsBucket)
+	for _, revision := range revisions {
+		data, err := proto.Marshal(revision)
+		if err != nil {
+			return err
+		}
+		seq, err := bucket.NextSequence()
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put(binary.BigEndian.AppendUint64(revisionPrefix(target), seq), data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *revisions) List(ctx context.Context, target storage.RevisionTarget) ([]*models.Revision, error) {
+	var results []*models.Revision
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		prefix := revisionPrefix(target)
+		c := tx.Bucket(revisionsBucket).Cursor()
+		for key, data := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, data = c.Next() {
+			revision := &models.Revision{}
+			if err := proto.Unmarshal(data, revision); err != nil {
+				return err
+			}
+			results = append(results, revision)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(results, func(a *models.Revision, b *models.Revision) int {
+		return int(a.Version - b.Version)
+	})
+	return results, nil
+}
@@ -0,0 +1,456 @@
+package bolt
+
+import (
+	"bytes"
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"regexp"
+	"slices"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type threads struct {
+	*Storage
+}
+
+func (s *threads) List(ctx context.Context, filter storage.ThreadFilter) ([]*models.Thread, error) {
+	var title *regexp.Regexp
+	if filter.Title != "" {
+		var err error
+		if title, err = regexp.Compile("(?i)" + filter.Title); err != nil { // case-insensitive title match
+			return nil, err
+		}
+	}
+	var results []*models.Thread
+	collect := func(thread *models.Thread) bool {
+		switch {
+		case title != nil && !title.MatchString(thread.Title),
+			filter.Pinned != nil && thread.Pinned != *filter.Pinned,
+			filter.Flair != nil && thread.Flair != *filter.Flair,
+			filter.Tag != nil && !slices.Contains(thread.Tags, *filter.Tag),
+			filter.AuthorId != nil && thread.AuthorId != *filter.AuthorId,
+			filter.CrosspostParentId != nil && thread.CrosspostParentId != *filter.CrosspostParentId,
+			thread.Draft != filter.Drafts,
+			filter.Page.After != nil && !filter.Page.After.Precedes(thread, filter.SortBy):
+			return true
+		}
+		results = append(results, thread)
+		return true
+	}
+
+	// the threads of a community are read through its index. The score index is sorted already, it is read from the
+	// score of the cursor up to the end of the page and the ties of its last thread, which are sorted by id below
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		switch {
+		case filter.CommunityId != "":
+			return threadsTable.scanIndex(tx, "threads_by_community", stringKey(filter.CommunityId), nil, collect)
+		case filter.SortBy == "ups":
+			return threadsTable.scanIndex(tx, "threads_by_ups", nil, cursorScoreKey(filter.Page.After), func(thread *models.Thread) bool {
+				if pageFilled(len(results), filter.Page) && thread.Ups != results[len(results)-1].Ups {
+					return false
+				}
+				return collect(thread)
+			})
+		default:
+			return threadsTable.scan(tx, collect)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// pinned threads are listed first within a community
+	storage.Sort(results, filter.SortBy, filter.CommunityId != "")
+	return paginate(results, filter.Page), nil
+}
+
+func (s *threads) Create(ctx context.Context, thread *models.Thread) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if err := threadsTable.put(tx, thread.GetId(), thread); err != nil {
+			return err
+		}
+		return emit(tx, storage.ThreadCreated(thread)...)
+	})
+}
+
+func (s *threads) Get(ctx context.Context, id string) (*models.Thread, error) {
+	var thread *models.Thread
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		var err error
+		thread, err = threadsTable.get(tx, id)
+		return err
+	})
+	return thread, err
+}
+
+func (s *threads) Update(ctx context.Context, id string, update storage.ThreadUpdate) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return threadsTable.update(tx, id, func(thread *models.Thread) error {
+			if err := createRevisions(tx, storage.RevisionTarget{ThreadId: id}, update.Revisions(thread, numEdits(tx, id))); err != nil {
+				return err
+			}
+			if update.Title != nil {
+				thread.Title = *update.Title
+			}
+			if update.Content != nil {
+				thread.Content = *update.Content
+			}
+			if update.ContentHtml != nil {
+				thread.ContentHtml = *update.ContentHtml
+			}
+			thread.Ups += update.UpsOffset
+			thread.Downs += update.DownsOffset
+			thread.NumComments += update.NumCommentsOffset
+			thread.NumReports += update.NumReportsOffset
+			if update.Locked != nil {
+				thread.Locked = *update.Locked
+			}
+			if update.Flair != nil {
+				thread.Flair = *update.Flair
+			}
+			if update.SpamScore != nil {
+				thread.SpamScore = *update.SpamScore
+			}
+			if update.Pinned != nil {
+				thread.Pinned = *update.Pinned
+			}
+			if update.FlairColor != nil {
+				thread.FlairColor = *update.FlairColor
+			}
+			if update.Tags != nil {
+				thread.Tags = slices.Clone(*update.Tags)
+			}
+			if update.PublishAt != nil {
+				thread.PublishAt = timestamppb.New(*update.PublishAt)
+			}
+			if update.Edit != nil {
+				thread.EditedAt = timestamppb.New(update.Edit.EditedAt)
+				if err := countEdit(tx, id); err != nil {
+					return err
+				}
+			}
+			return emit(tx, update.Events(id)...)
+		})
+	})
+}
+
+func (s *threads) Delete(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		return deleteThread(tx, id)
+	})
+}
+
+func deleteThread(tx *bbolt.Tx, id string) error {
+	if err := threadsTable.delete(tx, id); err != nil {
+		return err
+	}
+	if err := tx.Bucket(numEditsBucket).Delete([]byte(id)); err != nil {
+		return err
+	}
+	if err := deletePrefix(tx.Bucket(revisionsBucket), revisionPrefix(storage.RevisionTarget{ThreadId: id})); err != nil {
+		return err
+	}
+	if err := deletePrefix(tx.Bucket(pollVotesBucket), stringKey(id)); err != nil {
+		return err
+	}
+	return emit(tx, storage.ThreadEvent(models.EventType_THREAD_DELETED, id))
+}
+
+func (s *threads) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		err := threadsTable.update(tx, id, func(thread *models.Thread) error {
+			return remove(&thread.RemovalType, &thread.RemovalReason, &thread.RemovedAt, removalType, reason, removedAt)
+		})
+		if err != nil {
+			return err
+		}
+		return emit(tx, storage.ThreadEvent(models.EventType_THREAD_REMOVED, id))
+	})
+}
+
+func (s *threads) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		err := threadsTable.update(tx, id, func(thread *models.Thread) error {
+			return restore(&thread.RemovalType, &thread.RemovalReason, &thread.RemovedAt, removedAfter)
+		})
+		if err != nil {
+			return err
+		}
+		return emit(tx, storage.ThreadEvent(models.EventType_THREAD_RESTORED, id))
+	})
+}
+
+func (s *threads) Publish(ctx context.Context, id string, publishedAt time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		_, err := publishThread(tx, id, publishedAt)
+		return err
+	})
+}
+
+func publishThread(tx *bbolt.Tx, id string, publishedAt time.Time) (*models.Thread, error) {
+	var published *models.Thread
+	err := threadsTable.update(tx, id, func(thread *models.Thread) error {
+		if !thread.Draft {
+			return storage.ErrFailedPrecondition
+		}
+		thread.Draft = false
+		thread.PublishAt = nil
+		thread.CreatedAt = timestamppb.New(publishedAt)
+		published = thread
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return published, emit(tx, storage.ThreadEvent(models.EventType_THREAD_PUBLISHED, id))
+}
+
+func (s *threads) CreateAndIncrement(ctx context.Context, thread *models.Thread) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if _, err := communitiesTable.get(tx, thread.GetCommunityId()); err != nil {
+			return err
+		}
+		if parentId := thread.GetCrosspostParentId(); parentId != "" {
+			crossposted := false
+			err := threadsTable.scanIndex(tx, "threads_by_community", stringKey(thread.GetCommunityId()), nil, func(other *models.Thread) bool {
+				crossposted = other.CrosspostParentId == parentId
+				return !crossposted
+			})
+			if err != nil {
+				return err
+			}
+			if crossposted {
+				return storage.ErrAlreadyExists
+			}
+		}
+		if err := countThread(tx, thread, 1); err != nil {
+			return err
+		}
+		if err := threadsTable.put(tx, thread.GetId(), thread); err != nil {
+			return err
+		}
+		return emit(tx, storage.ThreadCreated(thread)...)
+	})
+}
+
+func (s *threads) DeleteAndDecrement(ctx context.Context, id string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		thread, err := threadsTable.get(tx, id)
+		if err != nil {
+			return err
+		}
+		if err := deleteThread(tx, id); err != nil {
+			return err
+		}
+		return countThread(tx, thread, -1)
+	})
+}
+
+func (s *threads) PublishAndIncrement(ctx context.Context, id string, publishedAt time.Time) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		thread, err := publishThread(tx, id, publishedAt)
+		if err != nil {
+			return err
+		}
+		return countThread(tx, thread, 1)
+	})
+}
+
+// countThread adds an offset to num_threads of the community of a counted thread, when the community still exists
+func countThread(tx *bbolt.Tx, thread *models.Thread, offset int32) error {
+	if !storage.Counted(thread) {
+		return nil
+	}
+	err := communitiesTable.update(tx, thread.GetCommunityId(), func(community *models.Community) error {
+		community.NumThreads += offset
+		return nil
+	})
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (s *threads) ListScheduled(ctx context.Context, before time.Time, limit int32) ([]*models.Thread, error) {
+	var results []*models.Thread
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		return threadsTable.scan(tx, func(thread *models.Thread) bool {
+			if thread.Draft && thread.PublishAt != nil && !thread.PublishAt.AsTime().After(before) {
+				results = append(results, thread)
+			}
+			return true
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.SortStableFunc(results, func(a *models.Thread, b *models.Thread) int {
+		return a.PublishAt.AsTime().Compare(b.PublishAt.AsTime())
+	})
+	return paginate(results, storage.Page{Limit: max(limit, 0)}), nil
+}
+
+func (s *threads) Pin(ctx context.Context, id string, max int32) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		thread, err := threadsTable.get(tx, id)
+		if err != nil {
+			return err
+		}
+		if thread.Pinned {
+			return nil
+		}
+		var pinned int32
+		err = threadsTable.scanIndex(tx, "threads_by_community", stringKey(thread.CommunityId), nil, func(other *models.Thread) bool {
+			if other.Pinned {
+				pinned++
+			}
+			return true
+		})
+		if err != nil {
+			return err
+		}
+		if pinned >= max {
+			return storage.ErrFailedPrecondition
+		}
+		err = threadsTable.update(tx, id, func(thread *models.Thread) error {
+			thread.Pinned = true
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return emit(tx, storage.ThreadEvent(models.EventType_THREAD_UPDATED, id))
+	})
+}
+
+func (s *threads) CastPollVote(ctx context.Context, threadId string, userId string, optionIds []string) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		votes := tx.Bucket(pollVotesBucket)
+		vote := append(stringKey(threadId), userId...)
+		if hasKey(votes, vote) {
+			return storage.ErrAlreadyExists
+		}
+		err := threadsTable.update(tx, threadId, func(thread *models.Thread) error {
+			// count the vote on the chosen options
+			if thread.Poll == nil {
+				return nil
+			}
+			thread.Poll.NumVoters++
+			for _, option := range thread.Poll.Options {
+				if slices.Contains(optionIds, option.Id) {
+					option.NumVotes++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := votes.Put(vote, []byte{}); err != nil {
+			return err
+		}
+		return emit(tx, storage.ThreadEvent(models.EventType_POLL_VOTE_CAST, threadId))
+	})
+}
+
+func (s *threads) Move(ctx context.Context, id string, from string, to string, redirect *models.Thread) error {
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		if _, err := communitiesTable.get(tx, to); err != nil {
+			return err
+		}
+		thread, err := threadsTable.get(tx, id)
+		if err != nil {
+			return err
+		}
+		if thread.CommunityId != from {
+			return storage.ErrNotFound
+		}
+
+		err = communitiesTable.update(tx, to, func(destination *models.Community) error {
+			destination.NumThreads++
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		err = communitiesTable.update(tx, from, func(source *models.Community) error {
+			source.NumThreads--
+			return nil
+		})
+		if err != nil && !errors.Is(err, storage.ErrNotFound) {
+			return err
+		}
+
+		// flairs and pins belong to the previous community
+		thread.CommunityId = to
+		thread.Pinned = false
+		thread.Flair = ""
+		thread.FlairColor = ""
+		if err := threadsTable.put(tx, id, thread); err != nil {
+			return err
+		}
+		items, err := modQueueTable.all(tx)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if item.ThreadId != id {
+				continue
+			}
+			item.CommunityId = to
+			if err := modQueueTable.put(tx, item.Id, item); err != nil {
+				return err
+			}
+		}
+
+		// redirects of earlier moves follow the thread, a redirect in the community it returns to is dropped
+		existing, err := threadsTable.all(tx)
+		if err != nil {
+			return err
+		}
+		for _, other := range existing {
+			if other.RedirectThreadId != id {
+				continue
+			}
+			if other.CommunityId == to {
+				err = threadsTable.delete(tx, other.Id)
+			} else {
+				other.RedirectCommunityId = to
+				err = threadsTable.put(tx, other.Id, other)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := threadsTable.put(tx, redirect.GetId(), redirect); err != nil {
+			return err
+		}
+		return emit(tx, storage.ThreadMoved(id, to))
+	})
+}
+
+// hasKey reports whether a bucket has a key, whatever its value
+func hasKey(bucket *bbolt.Bucket, key []byte) bool {
+	found, _ := bucket.Cursor().Seek(key)
+	return bytes.Equal(found, key)
+}
+
+// deletePrefix deletes the keys of a bucket that start with prefix
+func deletePrefix(bucket *bbolt.Bucket, prefix []byte) error {
+	var keys [][]byte
+	c := bucket.Cursor()
+	for key, _ := c.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = c.Next() {
+		keys = append(keys, bytes.Clone(key))
+	}
+	for _, key := range keys {
+		if err := bucket.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	models "gen/models/pb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// EventRepository reads the outbox the write operations of the other repositories append their events to, in the
+// same transaction as the change. Offsets are assigned when the events are appended and increase in commit order, so
+// a reader that saw an offset has seen every event before it
+type EventRepository interface {
+	// List lists at most limit events after an offset, by offset
+	List(ctx context.Context, after int64, limit int32) ([]*models.Event, error)
+	// Prune deletes the events created before a time
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// NewEvent returns an event created now, the backend assigns its offset
+func NewEvent(eventType models.EventType) *models.Event {
+	return &models.Event{Type: eventType, CreatedAt: timestamppb.Now()}
+}
+
+func CommunityEvent(eventType models.EventType, communityId string) *models.Event {
+	event := NewEvent(eventType)
+	event.CommunityId = communityId
+	return event
+}
+
+func ThreadEvent(eventType models.EventType, threadId string) *models.Event {
+	event := NewEvent(eventType)
+	event.ThreadId = threadId
+	return event
+}
+
+func CommentEvent(eventType models.EventType, commentId string) *models.Event {
+	event := NewEvent(eventType)
+	event.CommentId = commentId
+	return event
+}
+
+// ThreadCreated returns the event of a created thread, none for drafts and redirects
+func ThreadCreated(thread *models.Thread) []*models.Event {
+	if !Counted(thread) {
+		return nil
+	}
+	return []*models.Event{ThreadEvent(models.EventType_THREAD_CREATED, thread.GetId())}
+}
+
+// ThreadMoved returns the event of a thread moved to a community
+func ThreadMoved(threadId string, communityId string) *models.Event {
+	event := ThreadEvent(models.EventType_THREAD_MOVED, threadId)
+	event.CommunityId = communityId
+	return event
+}
+
+// Events returns the events of an update of a thread, changes of counters and of the spam score have none
+func (u ThreadUpdate) Events(threadId string) []*models.Event {
+	events := votes(u.UpsOffset, u.DownsOffset, func(event *models.Event) { event.ThreadId = threadId })
+	if u.Title != nil || u.Content != nil || u.Locked != nil || u.Flair != nil || u.FlairColor != nil ||
+		u.Pinned != nil || u.Tags != nil || u.PublishAt != nil || u.Edit != nil {
+		events = append(events, ThreadEvent(models.EventType_THREAD_UPDATED, threadId))
+	}
+	return events
+}
+
+// Events returns the events of an update of a comment, changes of counters and of the spam score have none
+func (u CommentUpdate) Events(commentId string) []*models.Event {
+	events := votes(u.UpsOffset, u.DownsOffset, func(event *models.Event) { event.CommentId = commentId })
+	if u.Content != nil || u.Edit != nil {
+		events = append(events, CommentEvent(models.EventType_COMMENT_UPDATED, commentId))
+	}
+	return events
+}
+
+// votes returns the VOTE_CAST events of an upvote or downvote added by the offsets
+func votes(upsOffset int32, downsOffset int32, target func(event *models.Event)) []*models.Event {
+	var events []*models.Event
+	for _, vote := range []struct{ value, offset int32 }{{1, upsOffset}, {-1, downsOffset}} {
+		if vote.offset > 0 {
+			event := NewEvent(models.EventType_VOTE_CAST)
+			event.Vote = vote.value
+			target(event)
+			events = append(events, event)
+		}
+	}
+	return events
+}
@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"context"
+	models "gen/models/pb"
+	"time"
+)
+
+// JobRepository keeps the background jobs of db-service. A runner leases the job it runs and a job whose lease
+// expired can be claimed again, so a job resumes when its runner crashed
+type JobRepository interface {
+	// Create stores a new job, it fails with ErrAlreadyExists when a job with the same id exists
+	Create(ctx context.Context, job *models.Job) error
+	Get(ctx context.Context, id string) (*models.Job, error)
+	// Claim marks the oldest claimable job at now as running and leases it to leaseId until leaseExpiresAt. It fails
+	// with ErrNotFound when no job can be claimed
+	Claim(ctx context.Context, leaseId string, now time.Time, leaseExpiresAt time.Time) (*models.Job, error)
+	// Update replaces a job while it is leased to the lease id of the job, it fails with ErrNotFound when the job does
+	// not exist and with ErrFailedPrecondition when another runner claimed it since
+	Update(ctx context.Context, job *models.Job) error
+}
+
+// Finished reports whether a job succeeded or failed for good
+func Finished(job *models.Job) bool {
+	return job.GetState() == models.JobState_JOB_SUCCEEDED || job.GetState() == models.JobState_JOB_FAILED
+}
+
+// Claimable reports whether a job can be claimed at now, when it is not finished and not leased to a runner
+func Claimable(job *models.Job, now time.Time) bool {
+	return !Finished(job) && (job.GetLeaseExpiresAt() == nil || !job.GetLeaseExpiresAt().AsTime().After(now))
+}
@@ -0,0 +1,30 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+)
+
+type attachments struct {
+	*Storage
+}
+
+func (s *attachments) Create(ctx context.Context, attachment *models.Attachment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.attachments.put(attachment.GetId(), clone(attachment))
+	return nil
+}
+
+func (s *attachments) Get(ctx context.Context, id string) (*models.Attachment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	attachment, ok := s.attachments.get(id)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return clone(attachment), nil
+}
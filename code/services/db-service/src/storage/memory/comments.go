@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type comments struct {
+	*Storage
+}
+
+func (s *comments) List(ctx context.Context, filter storage.CommentFilter) ([]*models.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var results []*models.Comment
+	for _, comment := range s.comments.all() {
+		if (filter.ParentId == "" || comment.ParentId == filter.ParentId) &&
+			(filter.Page.After == nil || filter.Page.After.Precedes(comment, filter.SortBy)) {
+			results = append(results, comment)
+		}
+	}
+	storage.Sort(results, filter.SortBy, false)
+	return paginate(results, filter.Page), nil
+}
+
+func (s *comments) Create(ctx context.Context, comment *models.Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.comments.put(comment.GetId(), clone(comment))
+	s.emit(storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	return nil
+}
+
+func (s *comments) Get(ctx context.Context, id string) (*models.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments.get(id)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return clone(comment), nil
+}
+
+func (s *comments) Update(ctx context.Context, id string, update storage.CommentUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	target := storage.RevisionTarget{CommentId: id}
+	s.revisions[target] = append(s.revisions[target], update.Revisions(comment, s.numEdits[id])...)
+
+	if update.Content != nil {
+		comment.Content = *update.Content
+	}
+	if update.ContentHtml != nil {
+		comment.ContentHtml = *update.ContentHtml
+	}
+	comment.Ups += update.UpsOffset
+	comment.Downs += update.DownsOffset
+	comment.NumComments += update.NumCommentsOffset
+	comment.NumReports += update.NumReportsOffset
+	if update.SpamScore != nil {
+		comment.SpamScore = *update.SpamScore
+	}
+	if update.Edit != nil {
+		comment.EditedAt = timestamppb.New(update.Edit.EditedAt)
+		s.numEdits[id]++
+	}
+	s.emit(update.Events(id)...)
+	return nil
+}
+
+func (s *comments) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.comments.delete(id) {
+		return storage.ErrNotFound
+	}
+	delete(s.numEdits, id)
+	delete(s.revisions, storage.RevisionTarget{CommentId: id})
+	s.emit(storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+	return nil
+}
+
+func (s *comments) CreateAndIncrement(ctx context.Context, comment *models.Comment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.countComment(comment, 1) {
+		return storage.ErrNotFound
+	}
+	s.comments.put(comment.GetId(), clone(comment))
+	s.emit(storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	return nil
+}
+
+func (s *comments) DeleteAndDecrement(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	s.comments.delete(id)
+	delete(s.numEdits, id)
+	delete(s.revisions, storage.RevisionTarget{CommentId: id})
+	s.countComment(comment, -1)
+	s.emit(storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+	return nil
+}
+
+func (s *comments) DeleteTree(ctx context.Context, id string) (int32, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments.get(id)
+	if !ok {
+		return 0, storage.ErrNotFound
+	}
+	ids := []string{id}
+	for _, reply := range s.comments.all() {
+		if slices.Contains(reply.AncestorIds, id) {
+			ids = append(ids, reply.Id)
+		}
+	}
+	for _, id := range ids {
+		s.comments.delete(id)
+		delete(s.numEdits, id)
+		delete(s.revisions, storage.RevisionTarget{CommentId: id})
+		s.emit(storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+	}
+	s.countComment(comment, -1)
+	return int32(len(ids)), nil
+}
+
+// countComment adds an offset to num_comments of the parent of a comment, it reports whether the parent exists
+func (s *comments) countComment(comment *models.Comment, offset int32) bool {
+	if comment.GetParentType() == models.CommentParentType_COMMENT {
+		parent, ok := s.comments.get(comment.GetParentId())
+		if ok {
+			parent.NumComments += offset
+		}
+		return ok
+	}
+	parent, ok := s.threads.get(comment.GetParentId())
+	if ok {
+		parent.NumComments += offset
+	}
+	return ok
+}
+
+func (s *comments) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if err := remove(&comment.RemovalType, &comment.RemovalReason, &comment.RemovedAt, removalType, reason, removedAt); err != nil {
+		return err
+	}
+	s.emit(storage.CommentEvent(models.EventType_COMMENT_REMOVED, id))
+	return nil
+}
+
+func (s *comments) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	comment, ok := s.comments.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if err := restore(&comment.RemovalType, &comment.RemovalReason, &comment.RemovedAt, removedAfter); err != nil {
+		return err
+	}
+	s.emit(storage.CommentEvent(models.EventType_COMMENT_RESTORED, id))
+	return nil
+}
@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"regexp"
+)
+
+type communities struct {
+	*Storage
+}
+
+func (s *communities) List(ctx context.Context, filter storage.CommunityFilter) ([]*models.Community, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var name *regexp.Regexp
+	if filter.Name != "" {
+		var err error
+		if name, err = regexp.Compile("(?i)" + filter.Name); err != nil { // case-insensitive name match
+			return nil, err
+		}
+	}
+	var results []*models.Community
+	for _, community := range s.communities.all() {
+		if (name == nil || name.MatchString(community.Name)) && (filter.Page.After == nil || filter.Page.After.Precedes(community, "")) {
+			results = append(results, community)
+		}
+	}
+	storage.Sort(results, "", false)
+	return paginate(results, filter.Page), nil
+}
+
+// nameInUse reports whether a community other than id has the name
+func (s *communities) nameInUse(name string, id string) bool {
+	for _, community := range s.communities.all() {
+		if community.Name == name && community.Id != id {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *communities) Create(ctx context.Context, community *models.Community) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.nameInUse(community.GetName(), "") {
+		return storage.ErrAlreadyExists
+	}
+	s.communities.put(community.GetId(), clone(community))
+	s.emit(storage.CommunityEvent(models.EventType_COMMUNITY_CREATED, community.GetId()))
+	return nil
+}
+
+func (s *communities) Get(ctx context.Context, id string) (*models.Community, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	community, ok := s.communities.get(id)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return clone(community), nil
+}
+
+func (s *communities) Update(ctx context.Context, id string, update storage.CommunityUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	community, ok := s.communities.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if update.Name != nil {
+		if s.nameInUse(*update.Name, id) {
+			return storage.ErrAlreadyExists
+		}
+		community.Name = *update.Name
+	}
+	if update.PostingRules != nil {
+		community.PostingRules = clone(update.PostingRules)
+	}
+	community.NumThreads += update.NumThreadsOffset
+	return nil
+}
+
+func (s *communities) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.communities.delete(id) {
+		return storage.ErrNotFound
+	}
+	s.emit(storage.CommunityEvent(models.EventType_COMMUNITY_DELETED, id))
+	return nil
+}
+
+func (s *communities) AddFlairTemplate(ctx context.Context, communityId string, template *models.FlairTemplate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	community, ok := s.communities.get(communityId)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	// flair texts are unique within a community
+	for _, existing := range community.FlairTemplates {
+		if existing.Text == template.GetText() {
+			return storage.ErrAlreadyExists
+		}
+	}
+	community.FlairTemplates = append(community.FlairTemplates, clone(template))
+	return nil
+}
+
+func (s *communities) RemoveFlairTemplate(ctx context.Context, communityId string, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	community, ok := s.communities.get(communityId)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	for i, template := range community.FlairTemplates {
+		if template.Id == id {
+			community.FlairTemplates = append(community.FlairTemplates[:i], community.FlairTemplates[i+1:]...)
+			return nil
+		}
+	}
+	return storage.ErrNotFound
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+)
+
+type counters struct {
+	*Storage
+}
+
+func (s *counters) RecountThreads(ctx context.Context, communityId string, fix bool) (storage.Recount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	community, ok := s.communities.get(communityId)
+	if !ok {
+		return storage.Recount{}, storage.ErrNotFound
+	}
+	recount := storage.Recount{Stored: community.NumThreads}
+	for _, thread := range s.threads.all() {
+		if thread.CommunityId == communityId && storage.Counted(thread) {
+			recount.Counted++
+		}
+	}
+	if fix {
+		community.NumThreads = recount.Counted
+	}
+	return recount, nil
+}
+
+func (s *counters) RecountComments(ctx context.Context, parentId string, parentType models.CommentParentType, fix bool) (storage.Recount, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var numComments *int32
+	if parentType == models.CommentParentType_COMMENT {
+		if parent, ok := s.comments.get(parentId); ok {
+			numComments = &parent.NumComments
+		}
+	} else if parent, ok := s.threads.get(parentId); ok {
+		numComments = &parent.NumComments
+	}
+	if numComments == nil {
+		return storage.Recount{}, storage.ErrNotFound
+	}
+
+	recount := storage.Recount{Stored: *numComments}
+	for _, comment := range s.comments.all() {
+		if comment.ParentId == parentId && comment.ParentType == parentType {
+			recount.Counted++
+		}
+	}
+	if fix {
+		*numComments = recount.Counted
+	}
+	return recount, nil
+}
+
+func (s *counters) Compare(ctx context.Context) (int32, []storage.Suspect, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	numThreads := map[string]int32{}  // counted threads by community
+	numComments := map[string]int32{} // replies by parent thread or comment
+	for _, thread := range s.threads.all() {
+		if storage.Counted(thread) {
+			numThreads[thread.CommunityId]++
+		}
+	}
+	for _, comment := range s.comments.all() {
+		numComments[comment.ParentId]++
+	}
+
+	var suspects []storage.Suspect
+	threads, comments, communities := s.threads.all(), s.comments.all(), s.communities.all()
+	for _, thread := range threads {
+		if thread.NumComments != numComments[thread.Id] {
+			suspects = append(suspects, storage.Suspect{ThreadId: thread.Id})
+		}
+	}
+	for _, comment := range comments {
+		if comment.NumComments != numComments[comment.Id] {
+			suspects = append(suspects, storage.Suspect{CommentId: comment.Id})
+		}
+	}
+	for _, community := range communities {
+		if community.NumThreads != numThreads[community.Id] {
+			suspects = append(suspects, storage.Suspect{CommunityId: community.Id})
+		}
+	}
+	return int32(len(threads) + len(comments) + len(communities)), suspects, nil
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"slices"
+	"time"
+)
+
+type events struct {
+	*Storage
+}
+
+func (s *events) List(ctx context.Context, after int64, limit int32) ([]*models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	start, _ := slices.BinarySearchFunc(s.events, after, func(event *models.Event, offset int64) int {
+		if event.Offset <= offset {
+			return -1
+		}
+		return 1
+	})
+	return paginate(s.events[start:], storage.Page{Limit: max(limit, 0)}), nil
+}
+
+func (s *events) Prune(ctx context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = slices.DeleteFunc(s.events, func(event *models.Event) bool {
+		return event.CreatedAt.AsTime().Before(before)
+	})
+	return nil
+}
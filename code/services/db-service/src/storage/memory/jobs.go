@@ -0,0 +1,68 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type jobs struct {
+	*Storage
+}
+
+func (s *jobs) Create(ctx context.Context, job *models.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.jobs.get(job.GetId()); ok {
+		return storage.ErrAlreadyExists
+	}
+	s.jobs.put(job.GetId(), clone(job))
+	return nil
+}
+
+func (s *jobs) Get(ctx context.Context, id string) (*models.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs.get(id)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return clone(job), nil
+}
+
+func (s *jobs) Claim(ctx context.Context, leaseId string, now time.Time, leaseExpiresAt time.Time) (*models.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// jobs are created in time order
+	for _, job := range s.jobs.all() {
+		if storage.Claimable(job, now) {
+			job.State = models.JobState_JOB_RUNNING
+			job.LeaseId = leaseId
+			job.LeaseExpiresAt = timestamppb.New(leaseExpiresAt)
+			job.UpdatedAt = timestamppb.New(now)
+			return clone(job), nil
+		}
+	}
+	return nil, storage.ErrNotFound
+}
+
+func (s *jobs) Update(ctx context.Context, job *models.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.jobs.get(job.GetId())
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if stored.LeaseId != job.GetLeaseId() {
+		return storage.ErrFailedPrecondition
+	}
+	s.jobs.put(job.GetId(), clone(job))
+	return nil
+}
@@ -0,0 +1,180 @@
+// Package memory stores the documents of db-service in memory, for development and tests.
+package memory
+
+import (
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"slices"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Storage keeps every kind of document in its own table, a single lock makes every operation atomic
+type Storage struct {
+	mu             sync.Mutex
+	communities    *table[*models.Community]
+	threads        *table[*models.Thread]
+	comments       *table[*models.Comment]
+	numEdits       map[string]int32 // edits of threads and comments by id
+	pollVotes      map[pollVote]bool
+	revisions      map[storage.RevisionTarget][]*models.Revision
+	attachments    *table[*models.Attachment]
+	automodConfigs map[string]string
+	modQueue       *table[*models.ModQueueItem]
+	automodActions map[storage.AutomodAction]bool
+	reports        map[storage.Report]bool
+	spamModel      []byte
+	spamTrainedAt  *time.Time
+	events         []*models.Event // the outbox, by offset
+	lastOffset     int64
+	jobs           *table[*models.Job]
+}
+
+func New() *Storage {
+	return &Storage{
+		communities:    newTable[*models.Community](),
+		threads:        newTable[*models.Thread](),
+		comments:       newTable[*models.Comment](),
+		numEdits:       map[string]int32{},
+		pollVotes:      map[pollVote]bool{},
+		revisions:      map[storage.RevisionTarget][]*models.Revision{},
+		attachments:    newTable[*models.Attachment](),
+		automodConfigs: map[string]string{},
+		modQueue:       newTable[*models.ModQueueItem](),
+		automodActions: map[storage.AutomodAction]bool{},
+		reports:        map[storage.Report]bool{},
+		jobs:           newTable[*models.Job](),
+	}
+}
+
+func (s *Storage) Communities() storage.CommunityRepository {
+	return &communities{s}
+}
+
+func (s *Storage) Threads() storage.ThreadRepository {
+	return &threads{s}
+}
+
+func (s *Storage) Comments() storage.CommentRepository {
+	return &comments{s}
+}
+
+func (s *Storage) Revisions() storage.RevisionRepository {
+	return &revisions{s}
+}
+
+func (s *Storage) Attachments() storage.AttachmentRepository {
+	return &attachments{s}
+}
+
+func (s *Storage) Moderation() storage.ModerationRepository {
+	return &moderation{s}
+}
+
+func (s *Storage) Counters() storage.CounterRepository {
+	return &counters{s}
+}
+
+func (s *Storage) Events() storage.EventRepository {
+	return &events{s}
+}
+
+func (s *Storage) Jobs() storage.JobRepository {
+	return &jobs{s}
+}
+
+// emit appends events to the outbox, the caller holds the lock of the change they describe
+func (s *Storage) emit(events ...*models.Event) {
+	for _, event := range events {
+		s.lastOffset++
+		event.Offset = s.lastOffset
+		s.events = append(s.events, event)
+	}
+}
+
+// pollVote is the vote of a user in a poll, users vote once per poll
+type pollVote struct {
+	threadId string
+	userId   string
+}
+
+// table keeps documents by id in insertion order
+type table[T proto.Message] struct {
+	rows  map[string]T
+	order []string
+}
+
+func newTable[T proto.Message]() *table[T] {
+	return &table[T]{rows: map[string]T{}}
+}
+
+func (t *table[T]) get(id string) (T, bool) {
+	row, ok := t.rows[id]
+	return row, ok
+}
+
+func (t *table[T]) put(id string, row T) {
+	if _, ok := t.rows[id]; !ok {
+		t.order = append(t.order, id)
+	}
+	t.rows[id] = row
+}
+
+func (t *table[T]) delete(id string) bool {
+	if _, ok := t.rows[id]; !ok {
+		return false
+	}
+	delete(t.rows, id)
+	t.order = slices.DeleteFunc(t.order, func(rowId string) bool { return rowId == id })
+	return true
+}
+
+// all returns the documents in insertion order
+func (t *table[T]) all() []T {
+	res := make([]T, 0, len(t.order))
+	for _, id := range t.order {
+		res = append(res, t.rows[id])
+	}
+	return res
+}
+
+// clone copies what is stored or returned, so callers never share documents with the storage
+func clone[T proto.Message](message T) T {
+	return proto.Clone(message).(T)
+}
+
+// paginate clones the documents of a page of a list
+func paginate[T proto.Message](rows []T, page storage.Page) []T {
+	start, end := page.Bounds(len(rows))
+	var res []T
+	for _, row := range rows[start:end] {
+		res = append(res, clone(row))
+	}
+	return res
+}
+
+// removal of threads and comments, which share the removal fields
+
+func remove(removalType *models.RemovalType, removalReason *string, removedAt **timestamppb.Timestamp, newType models.RemovalType, reason string, at time.Time) error {
+	if *removalType != models.RemovalType_NOT_REMOVED {
+		return storage.ErrFailedPrecondition
+	}
+	*removalType = newType
+	*removalReason = reason
+	*removedAt = timestamppb.New(at)
+	return nil
+}
+
+func restore(removalType *models.RemovalType, removalReason *string, removedAt **timestamppb.Timestamp, removedAfter time.Time) error {
+	// only what is removed has a removal time
+	if *removedAt == nil || (*removedAt).AsTime().Before(removedAfter) {
+		return storage.ErrFailedPrecondition
+	}
+	*removalType = models.RemovalType_NOT_REMOVED
+	*removalReason = ""
+	*removedAt = nil
+	return nil
+}
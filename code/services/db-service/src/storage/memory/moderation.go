@@ -0,0 +1,160 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"slices"
+	"time"
+)
+
+type moderation struct {
+	*Storage
+}
+
+func (s *moderation) AuthorStats(ctx context.Context, authorId string) (int32, *time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// karma and first activity are aggregated over both threads and comments
+	var karma int32
+	var firstSeen *time.Time
+	count := func(ups int32, downs int32, createdAt time.Time) {
+		karma += ups - downs
+		if firstSeen == nil || createdAt.Before(*firstSeen) {
+			firstSeen = &createdAt
+		}
+	}
+	for _, thread := range s.threads.all() {
+		if thread.AuthorId == authorId {
+			count(thread.Ups, thread.Downs, thread.CreatedAt.AsTime())
+		}
+	}
+	for _, comment := range s.comments.all() {
+		if comment.AuthorId == authorId {
+			count(comment.Ups, comment.Downs, comment.CreatedAt.AsTime())
+		}
+	}
+	return karma, firstSeen, nil
+}
+
+func (s *moderation) GetAutomodConfig(ctx context.Context, communityId string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.automodConfigs[communityId], nil
+}
+
+func (s *moderation) SetAutomodConfig(ctx context.Context, communityId string, config string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.automodConfigs[communityId] = config
+	return nil
+}
+
+func (s *moderation) ListModQueue(ctx context.Context, communityId string, page storage.Page) ([]*models.ModQueueItem, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var results []*models.ModQueueItem
+	for _, item := range s.modQueue.all() {
+		if communityId == "" || item.CommunityId == communityId {
+			results = append(results, item)
+		}
+	}
+	storage.Sort(results, "created_at", false)
+	return paginate(results, page), nil
+}
+
+func (s *moderation) CreateModQueueItem(ctx context.Context, item *models.ModQueueItem) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.modQueue.put(item.GetId(), clone(item))
+	return nil
+}
+
+func (s *moderation) DeleteModQueueItem(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.modQueue.delete(id) {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (s *moderation) RecordAutomodAction(ctx context.Context, action storage.AutomodAction) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.automodActions[action] {
+		return storage.ErrAlreadyExists
+	}
+	s.automodActions[action] = true
+	return nil
+}
+
+func (s *moderation) CreateReport(ctx context.Context, report storage.Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.reports[report] {
+		return storage.ErrAlreadyExists
+	}
+	if report.CommentId != "" {
+		comment, ok := s.comments.get(report.CommentId)
+		if !ok {
+			return storage.ErrNotFound
+		}
+		comment.NumReports++
+	} else {
+		thread, ok := s.threads.get(report.ThreadId)
+		if !ok {
+			return storage.ErrNotFound
+		}
+		thread.NumReports++
+	}
+	s.reports[report] = true
+	return nil
+}
+
+func (s *moderation) ListSpamThreads(ctx context.Context, page storage.Page) ([]*models.Thread, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// content deleted by its author says nothing about spam
+	results := slices.DeleteFunc(s.threads.all(), func(thread *models.Thread) bool {
+		return thread.RemovalType == models.RemovalType_DELETED
+	})
+	storage.Sort(results, "_id", false)
+	return paginate(results, page), nil
+}
+
+func (s *moderation) ListSpamComments(ctx context.Context, page storage.Page) ([]*models.Comment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := slices.DeleteFunc(s.comments.all(), func(comment *models.Comment) bool {
+		return comment.RemovalType == models.RemovalType_DELETED
+	})
+	storage.Sort(results, "_id", false)
+	return paginate(results, page), nil
+}
+
+func (s *moderation) GetSpamModel(ctx context.Context) ([]byte, *time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.spamModel), s.spamTrainedAt, nil
+}
+
+func (s *moderation) SetSpamModel(ctx context.Context, model []byte, trainedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.spamModel = slices.Clone(model)
+	s.spamTrainedAt = &trainedAt
+	return nil
+}
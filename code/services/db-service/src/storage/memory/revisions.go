@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"slices"
+)
+
+type revisions struct {
+	*Storage
+}
+
+func (s *revisions) List(ctx context.Context, target storage.RevisionTarget) ([]*models.Revision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results := paginate(s.revisions[target], storage.Page{})
+	slices.SortStableFunc(results, func(a *models.Revision, b *models.Revision) int {
+		return int(a.Version - b.Version)
+	})
+	return results, nil
+}
@@ -0,0 +1,368 @@
+package memory
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"regexp"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type threads struct {
+	*Storage
+}
+
+func (s *threads) List(ctx context.Context, filter storage.ThreadFilter) ([]*models.Thread, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var title *regexp.Regexp
+	if filter.Title != "" {
+		var err error
+		if title, err = regexp.Compile("(?i)" + filter.Title); err != nil { // case-insensitive title match
+			return nil, err
+		}
+	}
+	var results []*models.Thread
+	for _, thread := range s.threads.all() {
+		switch {
+		case filter.CommunityId != "" && thread.CommunityId != filter.CommunityId,
+			title != nil && !title.MatchString(thread.Title),
+			filter.Pinned != nil && thread.Pinned != *filter.Pinned,
+			filter.Flair != nil && thread.Flair != *filter.Flair,
+			filter.Tag != nil && !slices.Contains(thread.Tags, *filter.Tag),
+			filter.AuthorId != nil && thread.AuthorId != *filter.AuthorId,
+			filter.CrosspostParentId != nil && thread.CrosspostParentId != *filter.CrosspostParentId,
+			thread.Draft != filter.Drafts,
+			filter.Page.After != nil && !filter.Page.After.Precedes(thread, filter.SortBy):
+			continue
+		}
+		results = append(results, thread)
+	}
+
+	// pinned threads are listed first within a community
+	storage.Sort(results, filter.SortBy, filter.CommunityId != "")
+	return paginate(results, filter.Page), nil
+}
+
+func (s *threads) Create(ctx context.Context, thread *models.Thread) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.threads.put(thread.GetId(), clone(thread))
+	s.emit(storage.ThreadCreated(thread)...)
+	return nil
+}
+
+func (s *threads) Get(ctx context.Context, id string) (*models.Thread, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, ok := s.threads.get(id)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	return clone(thread), nil
+}
+
+func (s *threads) Update(ctx context.Context, id string, update storage.ThreadUpdate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, ok := s.threads.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	target := storage.RevisionTarget{ThreadId: id}
+	s.revisions[target] = append(s.revisions[target], update.Revisions(thread, s.numEdits[id])...)
+
+	if update.Title != nil {
+		thread.Title = *update.Title
+	}
+	if update.Content != nil {
+		thread.Content = *update.Content
+	}
+	if update.ContentHtml != nil {
+		thread.ContentHtml = *update.ContentHtml
+	}
+	thread.Ups += update.UpsOffset
+	thread.Downs += update.DownsOffset
+	thread.NumComments += update.NumCommentsOffset
+	thread.NumReports += update.NumReportsOffset
+	if update.Locked != nil {
+		thread.Locked = *update.Locked
+	}
+	if update.Flair != nil {
+		thread.Flair = *update.Flair
+	}
+	if update.SpamScore != nil {
+		thread.SpamScore = *update.SpamScore
+	}
+	if update.Pinned != nil {
+		thread.Pinned = *update.Pinned
+	}
+	if update.FlairColor != nil {
+		thread.FlairColor = *update.FlairColor
+	}
+	if update.Tags != nil {
+		thread.Tags = slices.Clone(*update.Tags)
+	}
+	if update.PublishAt != nil {
+		thread.PublishAt = timestamppb.New(*update.PublishAt)
+	}
+	if update.Edit != nil {
+		thread.EditedAt = timestamppb.New(update.Edit.EditedAt)
+		s.numEdits[id]++
+	}
+	s.emit(update.Events(id)...)
+	return nil
+}
+
+func (s *threads) Delete(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.delete(id)
+}
+
+func (s *threads) delete(id string) error {
+	if !s.threads.delete(id) {
+		return storage.ErrNotFound
+	}
+	delete(s.numEdits, id)
+	delete(s.revisions, storage.RevisionTarget{ThreadId: id})
+	for vote := range s.pollVotes {
+		if vote.threadId == id {
+			delete(s.pollVotes, vote)
+		}
+	}
+	s.emit(storage.ThreadEvent(models.EventType_THREAD_DELETED, id))
+	return nil
+}
+
+func (s *threads) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, ok := s.threads.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if err := remove(&thread.RemovalType, &thread.RemovalReason, &thread.RemovedAt, removalType, reason, removedAt); err != nil {
+		return err
+	}
+	s.emit(storage.ThreadEvent(models.EventType_THREAD_REMOVED, id))
+	return nil
+}
+
+func (s *threads) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, ok := s.threads.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if err := restore(&thread.RemovalType, &thread.RemovalReason, &thread.RemovedAt, removedAfter); err != nil {
+		return err
+	}
+	s.emit(storage.ThreadEvent(models.EventType_THREAD_RESTORED, id))
+	return nil
+}
+
+func (s *threads) Publish(ctx context.Context, id string, publishedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err := s.publish(id, publishedAt)
+	return err
+}
+
+func (s *threads) publish(id string, publishedAt time.Time) (*models.Thread, error) {
+	thread, ok := s.threads.get(id)
+	if !ok {
+		return nil, storage.ErrNotFound
+	}
+	if !thread.Draft {
+		return nil, storage.ErrFailedPrecondition
+	}
+	thread.Draft = false
+	thread.PublishAt = nil
+	thread.CreatedAt = timestamppb.New(publishedAt)
+	s.emit(storage.ThreadEvent(models.EventType_THREAD_PUBLISHED, id))
+	return thread, nil
+}
+
+func (s *threads) CreateAndIncrement(ctx context.Context, thread *models.Thread) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	community, ok := s.communities.get(thread.GetCommunityId())
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if parentId := thread.GetCrosspostParentId(); parentId != "" {
+		for _, other := range s.threads.all() {
+			if other.CommunityId == thread.GetCommunityId() && other.CrosspostParentId == parentId {
+				return storage.ErrAlreadyExists
+			}
+		}
+	}
+	if storage.Counted(thread) {
+		community.NumThreads++
+	}
+	s.threads.put(thread.GetId(), clone(thread))
+	s.emit(storage.ThreadCreated(thread)...)
+	return nil
+}
+
+func (s *threads) DeleteAndDecrement(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, ok := s.threads.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if err := s.delete(id); err != nil {
+		return err
+	}
+	s.countThread(thread, -1)
+	return nil
+}
+
+func (s *threads) PublishAndIncrement(ctx context.Context, id string, publishedAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, err := s.publish(id, publishedAt)
+	if err != nil {
+		return err
+	}
+	s.countThread(thread, 1)
+	return nil
+}
+
+// countThread adds an offset to num_threads of the community of a counted thread, when the community still exists
+func (s *threads) countThread(thread *models.Thread, offset int32) {
+	if community, ok := s.communities.get(thread.CommunityId); ok && storage.Counted(thread) {
+		community.NumThreads += offset
+	}
+}
+
+func (s *threads) ListScheduled(ctx context.Context, before time.Time, limit int32) ([]*models.Thread, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var results []*models.Thread
+	for _, thread := range s.threads.all() {
+		if thread.Draft && thread.PublishAt != nil && !thread.PublishAt.AsTime().After(before) {
+			results = append(results, thread)
+		}
+	}
+	slices.SortStableFunc(results, func(a *models.Thread, b *models.Thread) int {
+		return a.PublishAt.AsTime().Compare(b.PublishAt.AsTime())
+	})
+	return paginate(results, storage.Page{Limit: max(limit, 0)}), nil
+}
+
+func (s *threads) Pin(ctx context.Context, id string, max int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	thread, ok := s.threads.get(id)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	if thread.Pinned {
+		return nil
+	}
+	var pinned int32
+	for _, other := range s.threads.all() {
+		if other.CommunityId == thread.CommunityId && other.Pinned {
+			pinned++
+		}
+	}
+	if pinned >= max {
+		return storage.ErrFailedPrecondition
+	}
+	thread.Pinned = true
+	s.emit(storage.ThreadEvent(models.EventType_THREAD_UPDATED, id))
+	return nil
+}
+
+func (s *threads) CastPollVote(ctx context.Context, threadId string, userId string, optionIds []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vote := pollVote{threadId: threadId, userId: userId}
+	if s.pollVotes[vote] {
+		return storage.ErrAlreadyExists
+	}
+	thread, ok := s.threads.get(threadId)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	s.pollVotes[vote] = true
+	s.emit(storage.ThreadEvent(models.EventType_POLL_VOTE_CAST, threadId))
+
+	// count the vote on the chosen options
+	if thread.Poll == nil {
+		return nil
+	}
+	thread.Poll.NumVoters++
+	for _, option := range thread.Poll.Options {
+		if slices.Contains(optionIds, option.Id) {
+			option.NumVotes++
+		}
+	}
+	return nil
+}
+
+func (s *threads) Move(ctx context.Context, id string, from string, to string, redirect *models.Thread) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	destination, ok := s.communities.get(to)
+	if !ok {
+		return storage.ErrNotFound
+	}
+	thread, ok := s.threads.get(id)
+	if !ok || thread.CommunityId != from {
+		return storage.ErrNotFound
+	}
+
+	destination.NumThreads++
+	if source, ok := s.communities.get(from); ok {
+		source.NumThreads--
+	}
+
+	// flairs and pins belong to the previous community
+	thread.CommunityId = to
+	thread.Pinned = false
+	thread.Flair = ""
+	thread.FlairColor = ""
+	for _, item := range s.modQueue.all() {
+		if item.ThreadId == id {
+			item.CommunityId = to
+		}
+	}
+
+	// redirects of earlier moves follow the thread, a redirect in the community it returns to is dropped
+	for _, existing := range s.threads.all() {
+		if existing.RedirectThreadId != id {
+			continue
+		}
+		if existing.CommunityId == to {
+			s.threads.delete(existing.Id)
+		} else {
+			existing.RedirectCommunityId = to
+		}
+	}
+	s.threads.put(redirect.GetId(), clone(redirect))
+	s.emit(storage.ThreadMoved(id, to))
+	return nil
+}
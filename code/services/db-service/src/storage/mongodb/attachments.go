@@ -0,0 +1,25 @@
+package mongodb
+
+import (
+	"context"
+	models "gen/models/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type attachments struct {
+	*Storage
+}
+
+func (s *attachments) Create(ctx context.Context, attachment *models.Attachment) error {
+	_, err := s.db.Collection("attachments").InsertOne(ctx, newAttachmentDocument(attachment))
+	return err
+}
+
+func (s *attachments) Get(ctx context.Context, id string) (*models.Attachment, error) {
+	doc, err := decodeOne[attachmentDocument](s.db.Collection("attachments").FindOne(ctx, bson.M{"_id": id}))
+	if err != nil {
+		return nil, err
+	}
+	return doc.attachment(), nil
+}
@@ -0,0 +1,215 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type comments struct {
+	*Storage
+}
+
+func (s *comments) collection() *mongo.Collection {
+	return s.db.Collection("comments")
+}
+
+func (s *comments) List(ctx context.Context, filter storage.CommentFilter) ([]*models.Comment, error) {
+	query := bson.M{}
+	if filter.ParentId != "" {
+		query["parent_id"] = filter.ParentId
+	}
+	continueAfter(query, filter.Page, filter.SortBy)
+	cursor, err := s.collection().Find(ctx, query, findOptions(filter.Page, filter.SortBy))
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*commentDocument).comment)
+}
+
+func (s *comments) Create(ctx context.Context, comment *models.Comment) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		if _, err := s.collection().InsertOne(ctx, newCommentDocument(comment)); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	})
+}
+
+func (s *comments) Get(ctx context.Context, id string) (*models.Comment, error) {
+	doc, err := decodeOne[commentDocument](s.collection().FindOne(ctx, bson.M{"_id": id}))
+	if err != nil {
+		return nil, err
+	}
+	return doc.comment(), nil
+}
+
+func (s *comments) Update(ctx context.Context, id string, update storage.CommentUpdate) error {
+	setValues, incValues := bson.M{}, bson.M{}
+	if update.Content != nil {
+		setValues["content"] = *update.Content
+	}
+	if update.ContentHtml != nil {
+		setValues["content_html"] = *update.ContentHtml
+	}
+	addOffset(incValues, "ups", update.UpsOffset)
+	addOffset(incValues, "downs", update.DownsOffset)
+	addOffset(incValues, "num_comments", update.NumCommentsOffset)
+	addOffset(incValues, "num_reports", update.NumReportsOffset)
+	if update.SpamScore != nil {
+		setValues["spam_score"] = *update.SpamScore
+	}
+	if update.Edit != nil {
+		setValues["edited_at"] = update.Edit.EditedAt
+		incValues["num_edits"] = 1
+	}
+
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		// concurrent edits conflict on num_edits and are retried, so they record consecutive versions
+		before, err := findAndUpdate[commentDocument](ctx, s.collection(), id, setValues, incValues, nil)
+		if err != nil {
+			return err
+		}
+		if err := s.createRevisions(ctx, storage.RevisionTarget{CommentId: id}, update.Revisions(before.comment(), before.NumEdits)); err != nil {
+			return err
+		}
+		return s.emit(ctx, update.Events(id)...)
+	})
+}
+
+func (s *comments) Delete(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		result, err := s.collection().DeleteOne(ctx, bson.M{"_id": id})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return storage.ErrNotFound
+		}
+		if err := s.deleteRevisions(ctx, bson.M{"comment_id": id}); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+	})
+}
+
+func (s *comments) CreateAndIncrement(ctx context.Context, comment *models.Comment) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		result, err := s.countComment(ctx, comment, 1)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return storage.ErrNotFound
+		}
+		if _, err := s.collection().InsertOne(ctx, newCommentDocument(comment)); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	})
+}
+
+func (s *comments) DeleteAndDecrement(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		doc, err := decodeOne[commentDocument](s.collection().FindOneAndDelete(ctx, bson.M{"_id": id}))
+		if err != nil {
+			return err
+		}
+		if _, err := s.countComment(ctx, doc.comment(), -1); err != nil {
+			return err
+		}
+		if err := s.deleteRevisions(ctx, bson.M{"comment_id": id}); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+	})
+}
+
+// replies deleted per transaction by DeleteTree
+const deleteTreeBatchSize = 500
+
+// DeleteTree deletes the replies in batches, one transaction each, so a large subtree stays within the transaction
+// limits. The comment itself is deleted in the transaction of the last batch, so a failed call leaves it with the
+// replies that are left, and deleting it again finishes the subtree
+func (s *comments) DeleteTree(ctx context.Context, id string) (int32, error) {
+	var numDeleted int32
+	for done := false; !done; {
+		var n int32
+		err := s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+			// the transaction may be retried, so the results are only kept from the last run
+			n, done = 0, false
+			if _, err := decodeOne[idDocument](s.collection().FindOne(ctx, bson.M{"_id": id})); err != nil {
+				return err
+			}
+			opts := options.Find().SetProjection(bson.M{"_id": 1}).SetLimit(deleteTreeBatchSize)
+			cursor, err := s.collection().Find(ctx, bson.M{"ancestor_ids": id}, opts)
+			if err != nil {
+				return err
+			}
+			replyIds, err := decodeAll(ctx, cursor, func(doc *idDocument) string { return doc.Id })
+			if err != nil {
+				return err
+			}
+			if len(replyIds) < deleteTreeBatchSize {
+				// last batch, the comment goes with it
+				doc, err := decodeOne[commentDocument](s.collection().FindOneAndDelete(ctx, bson.M{"_id": id}))
+				if err != nil {
+					return err
+				}
+				if _, err := s.countComment(ctx, doc.comment(), -1); err != nil {
+					return err
+				}
+				replyIds, done = append(replyIds, id), true
+			}
+			if _, err := s.collection().DeleteMany(ctx, bson.M{"_id": bson.M{"$in": replyIds}}); err != nil {
+				return err
+			}
+			if err := s.deleteRevisions(ctx, bson.M{"comment_id": bson.M{"$in": replyIds}}); err != nil {
+				return err
+			}
+			events := make([]*models.Event, 0, len(replyIds))
+			for _, id := range replyIds {
+				events = append(events, storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+			}
+			n = int32(len(replyIds))
+			return s.emit(ctx, events...)
+		})
+		if err != nil {
+			return 0, err
+		}
+		numDeleted += n
+	}
+	return numDeleted, nil
+}
+
+// countComment adds an offset to num_comments of the parent thread or comment of a comment
+func (s *comments) countComment(ctx context.Context, comment *models.Comment, offset int32) (*mongo.UpdateResult, error) {
+	parents := s.db.Collection("threads")
+	if comment.GetParentType() == models.CommentParentType_COMMENT {
+		parents = s.collection()
+	}
+	return parents.UpdateOne(ctx, bson.M{"_id": comment.GetParentId()}, bson.M{"$inc": bson.M{"num_comments": offset}})
+}
+
+func (s *comments) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		if err := remove(ctx, s.collection(), id, removalType, reason, removedAt); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.CommentEvent(models.EventType_COMMENT_REMOVED, id))
+	})
+}
+
+func (s *comments) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		if err := restore(ctx, s.collection(), id, removedAfter); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.CommentEvent(models.EventType_COMMENT_RESTORED, id))
+	})
+}
@@ -0,0 +1,130 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type communities struct {
+	*Storage
+}
+
+func (s *communities) collection() *mongo.Collection {
+	return s.db.Collection("communities")
+}
+
+func (s *communities) List(ctx context.Context, filter storage.CommunityFilter) ([]*models.Community, error) {
+	query := bson.M{}
+	if filter.Name != "" {
+		query["name"] = bson.M{"$regex": filter.Name, "$options": "i"} // case-insensitive name match
+	}
+	continueAfter(query, filter.Page, "")
+	cursor, err := s.collection().Find(ctx, query, findOptions(filter.Page, ""))
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*communityDocument).community)
+}
+
+func (s *communities) Create(ctx context.Context, community *models.Community) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		count, err := s.collection().CountDocuments(ctx, bson.M{"name": community.GetName()})
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return storage.ErrAlreadyExists
+		}
+		if _, err := s.collection().InsertOne(ctx, newCommunityDocument(community)); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.CommunityEvent(models.EventType_COMMUNITY_CREATED, community.GetId()))
+	})
+}
+
+func (s *communities) Get(ctx context.Context, id string) (*models.Community, error) {
+	doc, err := decodeOne[communityDocument](s.collection().FindOne(ctx, bson.M{"_id": id}))
+	if err != nil {
+		return nil, err
+	}
+	return doc.community(), nil
+}
+
+func (s *communities) Update(ctx context.Context, id string, update storage.CommunityUpdate) error {
+	setValues, incValues := bson.M{}, bson.M{}
+	if update.Name != nil {
+		filter := bson.M{"name": *update.Name, "_id": bson.M{"$ne": id}}
+		count, err := s.collection().CountDocuments(ctx, filter)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return storage.ErrAlreadyExists
+		}
+		setValues["name"] = *update.Name
+	}
+	if update.PostingRules != nil {
+		setValues["posting_rules"] = newPostingRulesDocument(update.PostingRules)
+	}
+	if update.NumThreadsOffset != 0 {
+		incValues["num_threads"] = update.NumThreadsOffset
+	}
+
+	result, err := s.collection().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": setValues, "$inc": incValues})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (s *communities) Delete(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		result, err := s.collection().DeleteOne(ctx, bson.M{"_id": id})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return storage.ErrNotFound
+		}
+		return s.emit(ctx, storage.CommunityEvent(models.EventType_COMMUNITY_DELETED, id))
+	})
+}
+
+func (s *communities) AddFlairTemplate(ctx context.Context, communityId string, template *models.FlairTemplate) error {
+	// flair texts are unique within a community
+	filter := bson.M{"_id": communityId, "flair_templates.text": bson.M{"$ne": template.GetText()}}
+	result, err := s.collection().UpdateOne(ctx, filter, bson.M{"$push": bson.M{"flair_templates": newFlairTemplateDocument(template)}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		count, err := s.collection().CountDocuments(ctx, bson.M{"_id": communityId})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			return storage.ErrNotFound
+		}
+		return storage.ErrAlreadyExists
+	}
+	return nil
+}
+
+func (s *communities) RemoveFlairTemplate(ctx context.Context, communityId string, id string) error {
+	filter := bson.M{"_id": communityId, "flair_templates._id": id}
+	result, err := s.collection().UpdateOne(ctx, filter, bson.M{"$pull": bson.M{"flair_templates": bson.M{"_id": id}}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
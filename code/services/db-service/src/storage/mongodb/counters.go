@@ -0,0 +1,140 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type counters struct {
+	*Storage
+}
+
+func (s *counters) RecountThreads(ctx context.Context, communityId string, fix bool) (storage.Recount, error) {
+	communities := s.db.Collection("communities")
+	var recount storage.Recount
+	err := s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		community, err := decodeOne[communityDocument](communities.FindOne(ctx, bson.M{"_id": communityId}))
+		if err != nil {
+			return err
+		}
+		filter := bson.M{"community_id": communityId, "draft": bson.M{"$ne": true}, "kind": bson.M{"$ne": models.ThreadKind_REDIRECT.String()}}
+		counted, err := s.db.Collection("threads").CountDocuments(ctx, filter)
+		if err != nil {
+			return err
+		}
+		recount = storage.Recount{Stored: community.NumThreads, Counted: int32(counted)}
+		return fixCounter(ctx, communities, communityId, "num_threads", recount, fix)
+	})
+	return recount, err
+}
+
+func (s *counters) RecountComments(ctx context.Context, parentId string, parentType models.CommentParentType, fix bool) (storage.Recount, error) {
+	var recount storage.Recount
+	err := s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		var parents *mongo.Collection
+		var stored int32
+		if parentType == models.CommentParentType_COMMENT {
+			parents = s.db.Collection("comments")
+			parent, err := decodeOne[commentDocument](parents.FindOne(ctx, bson.M{"_id": parentId}))
+			if err != nil {
+				return err
+			}
+			stored = parent.NumComments
+		} else {
+			parents = s.db.Collection("threads")
+			parent, err := decodeOne[threadDocument](parents.FindOne(ctx, bson.M{"_id": parentId}))
+			if err != nil {
+				return err
+			}
+			stored = parent.NumComments
+		}
+		filter := bson.M{"parent_id": parentId, "parent_type": parentType.String()}
+		counted, err := s.db.Collection("comments").CountDocuments(ctx, filter)
+		if err != nil {
+			return err
+		}
+		recount = storage.Recount{Stored: stored, Counted: int32(counted)}
+		return fixCounter(ctx, parents, parentId, "num_comments", recount, fix)
+	})
+	return recount, err
+}
+
+// fixCounter stores the count of a recount when fix is set. Writing the counter makes a concurrent increment conflict
+// with the transaction, which is then retried
+func fixCounter(ctx mongo.SessionContext, collection *mongo.Collection, id string, counter string, recount storage.Recount, fix bool) error {
+	if !fix || recount.Stored == recount.Counted {
+		return nil
+	}
+	_, err := collection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{counter: recount.Counted}})
+	return err
+}
+
+// Compare counts with a $lookup per document that only returns the count, so the counted documents are never read out
+func (s *counters) Compare(ctx context.Context) (int32, []storage.Suspect, error) {
+	checks := []struct {
+		collection string
+		counter    string
+		from       string
+		match      bson.M
+		suspect    func(id string) storage.Suspect
+	}{
+		{"threads", "num_comments", "comments", bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$parent_id", "$$id"}},
+			bson.M{"$eq": bson.A{"$parent_type", models.CommentParentType_THREAD.String()}},
+		}}, func(id string) storage.Suspect { return storage.Suspect{ThreadId: id} }},
+		{"comments", "num_comments", "comments", bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$parent_id", "$$id"}},
+			bson.M{"$eq": bson.A{"$parent_type", models.CommentParentType_COMMENT.String()}},
+		}}, func(id string) storage.Suspect { return storage.Suspect{CommentId: id} }},
+		{"communities", "num_threads", "threads", bson.M{"$and": bson.A{
+			bson.M{"$eq": bson.A{"$community_id", "$$id"}},
+			bson.M{"$ne": bson.A{"$draft", true}},
+			bson.M{"$ne": bson.A{"$kind", models.ThreadKind_REDIRECT.String()}},
+		}}, func(id string) storage.Suspect { return storage.Suspect{CommunityId: id} }},
+	}
+
+	var numChecked int32
+	var suspects []storage.Suspect
+	for _, check := range checks {
+		collection := s.db.Collection(check.collection)
+		n, err := collection.EstimatedDocumentCount(ctx)
+		if err != nil {
+			return 0, nil, err
+		}
+		numChecked += int32(n)
+
+		pipeline := mongo.Pipeline{
+			{{Key: "$lookup", Value: bson.M{
+				"from":     check.from,
+				"let":      bson.M{"id": "$_id"},
+				"pipeline": bson.A{bson.M{"$match": bson.M{"$expr": check.match}}, bson.M{"$count": "n"}},
+				"as":       "counted",
+			}}},
+			{{Key: "$project", Value: bson.M{
+				"differs": bson.M{"$ne": bson.A{
+					bson.M{"$ifNull": bson.A{"$" + check.counter, 0}},
+					bson.M{"$ifNull": bson.A{bson.M{"$arrayElemAt": bson.A{"$counted.n", 0}}, 0}},
+				}},
+			}}},
+			{{Key: "$match", Value: bson.M{"differs": true}}},
+			{{Key: "$sort", Value: bson.M{"_id": 1}}},
+		}
+		cursor, err := collection.Aggregate(ctx, pipeline)
+		if err != nil {
+			return 0, nil, err
+		}
+		type differing struct {
+			Id string `bson:"_id"`
+		}
+		found, err := decodeAll(ctx, cursor, func(doc *differing) storage.Suspect { return check.suspect(doc.Id) })
+		if err != nil {
+			return 0, nil, err
+		}
+		suspects = append(suspects, found...)
+	}
+	return numChecked, suspects, nil
+}
@@ -0,0 +1,707 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	"fmt"
+	models "gen/models/pb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// documents as they are stored in MongoDB. Fields introduced later are missing in older documents and decode to
+// their zero value, counters decode from any integer type since other tools and the dataset loader store int64
+
+type communityDocument struct {
+	Id             string                  `bson:"_id"`
+	Name           string                  `bson:"name"`
+	NumThreads     int32                   `bson:"num_threads"`
+	FlairTemplates []flairTemplateDocument `bson:"flair_templates,omitempty"`
+	PostingRules   *postingRulesDocument   `bson:"posting_rules,omitempty"`
+}
+
+type flairTemplateDocument struct {
+	Id    string `bson:"_id"`
+	Text  string `bson:"text"`
+	Color string `bson:"color"`
+}
+
+type postingRulesDocument struct {
+	MinTitleLength   int32 `bson:"min_title_length"`
+	MaxTitleLength   int32 `bson:"max_title_length"`
+	MinContentLength int32 `bson:"min_content_length"`
+	MaxContentLength int32 `bson:"max_content_length"`
+	MaxCommentLength int32 `bson:"max_comment_length"`
+}
+
+// removalDocument holds the fields set on threads and comments when they are soft deleted
+type removalDocument struct {
+	RemovalType   string     `bson:"removal_type,omitempty"`
+	RemovalReason string     `bson:"removal_reason,omitempty"`
+	RemovedAt     *time.Time `bson:"removed_at,omitempty"`
+}
+
+// threadDocument stores the fields of the kind of a thread and of its state besides the common ones
+type threadDocument struct {
+	Id                  string          `bson:"_id"`
+	CommunityId         string          `bson:"community_id"`
+	Title               string          `bson:"title"`
+	Content             string          `bson:"content"`
+	ContentHtml         string          `bson:"content_html,omitempty"` // missing on threads written before it was stored
+	AuthorId            string          `bson:"author_id"`
+	CreatedAt           *time.Time      `bson:"created_at"`
+	Ups                 int32           `bson:"ups"`
+	Downs               int32           `bson:"downs"`
+	NumComments         int32           `bson:"num_comments"`
+	NumReports          int32           `bson:"num_reports"`
+	Flair               string          `bson:"flair"`
+	FlairColor          string          `bson:"flair_color"`
+	Tags                []string        `bson:"tags"`
+	Kind                string          `bson:"kind"`
+	Locked              bool            `bson:"locked,omitempty"`
+	SpamScore           float64         `bson:"spam_score,omitempty"`
+	Pinned              bool            `bson:"pinned,omitempty"`
+	Draft               bool            `bson:"draft,omitempty"`
+	PublishAt           *time.Time      `bson:"publish_at,omitempty"`
+	Url                 string          `bson:"url,omitempty"`
+	Domain              string          `bson:"domain,omitempty"`
+	AttachmentId        string          `bson:"attachment_id,omitempty"`
+	ThumbnailUrl        string          `bson:"thumbnail_url,omitempty"`
+	Poll                *pollDocument   `bson:"poll,omitempty"`
+	CrosspostParentId   string          `bson:"crosspost_parent_id,omitempty"`
+	RedirectThreadId    string          `bson:"redirect_thread_id,omitempty"`
+	RedirectCommunityId string          `bson:"redirect_community_id,omitempty"`
+	EditedAt            *time.Time      `bson:"edited_at,omitempty"`
+	NumEdits            int32           `bson:"num_edits,omitempty"`
+	Removal             removalDocument `bson:",inline"`
+}
+
+type pollDocument struct {
+	Options        []pollOptionDocument `bson:"options"`
+	EndsAt         *time.Time           `bson:"ends_at"`
+	MultipleChoice bool                 `bson:"multiple_choice"`
+	HideResults    bool                 `bson:"hide_results"`
+	NumVoters      int32                `bson:"num_voters"`
+}
+
+type pollOptionDocument struct {
+	Id       string `bson:"_id"`
+	Text     string `bson:"text"`
+	NumVotes int32  `bson:"num_votes"`
+}
+
+// pollVoteDocument is unique per user and poll, a second vote fails to insert
+type pollVoteDocument struct {
+	Id        string    `bson:"_id"`
+	ThreadId  string    `bson:"thread_id"`
+	UserId    string    `bson:"user_id"`
+	OptionIds []string  `bson:"option_ids"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+type commentDocument struct {
+	Id            string          `bson:"_id"`
+	Content       string          `bson:"content"`
+	ContentHtml   string          `bson:"content_html,omitempty"` // missing on comments written before it was stored
+	Ups           int32           `bson:"ups"`
+	Downs         int32           `bson:"downs"`
+	ParentId      string          `bson:"parent_id"`
+	ParentType    string          `bson:"parent_type"`
+	AuthorId      string          `bson:"author_id"`
+	CreatedAt     *time.Time      `bson:"created_at"`
+	NumComments   int32           `bson:"num_comments"`
+	NumReports    int32           `bson:"num_reports"`
+	SpamScore     float64         `bson:"spam_score,omitempty"`
+	AttachmentIds []string        `bson:"attachment_ids,omitempty"`
+	EditedAt      *time.Time      `bson:"edited_at,omitempty"`
+	NumEdits      int32           `bson:"num_edits,omitempty"`
+	AncestorIds   []string        `bson:"ancestor_ids,omitempty"` // replies only
+	Removal       removalDocument `bson:",inline"`
+}
+
+// idDocument reads only the id of a document
+type idDocument struct {
+	Id string `bson:"_id"`
+}
+
+// pathDocument is the place of a comment in its tree
+type pathDocument struct {
+	Id          string   `bson:"_id"`
+	ParentId    string   `bson:"parent_id"`
+	ParentType  string   `bson:"parent_type"`
+	AncestorIds []string `bson:"ancestor_ids"`
+}
+
+// revisionDocument belongs to a thread or a comment, only threads have a title
+type revisionDocument struct {
+	Id        string     `bson:"_id"`
+	ThreadId  string     `bson:"thread_id,omitempty"`
+	CommentId string     `bson:"comment_id,omitempty"`
+	Version   int32      `bson:"version"`
+	Title     string     `bson:"title,omitempty"`
+	Content   string     `bson:"content"`
+	EditorId  string     `bson:"editor_id"`
+	CreatedAt *time.Time `bson:"created_at"`
+}
+
+// attachmentDocument has the thumbnail fields for images only
+type attachmentDocument struct {
+	Id                   string     `bson:"_id"`
+	Filename             string     `bson:"filename"`
+	ContentType          string     `bson:"content_type"`
+	Size                 int64      `bson:"size"`
+	Sha256               string     `bson:"sha256"`
+	AuthorId             string     `bson:"author_id"`
+	CreatedAt            *time.Time `bson:"created_at"`
+	Width                int32      `bson:"width,omitempty"`
+	Height               int32      `bson:"height,omitempty"`
+	ThumbnailSha256      string     `bson:"thumbnail_sha256,omitempty"`
+	ThumbnailContentType string     `bson:"thumbnail_content_type,omitempty"`
+	ThumbnailSize        int64      `bson:"thumbnail_size,omitempty"`
+}
+
+type modQueueDocument struct {
+	Id          string     `bson:"_id"`
+	CommunityId string     `bson:"community_id"`
+	ThreadId    string     `bson:"thread_id"`
+	CommentId   string     `bson:"comment_id"`
+	Rule        string     `bson:"rule"`
+	Reason      string     `bson:"reason"`
+	DryRun      bool       `bson:"dry_run"`
+	CreatedAt   *time.Time `bson:"created_at"`
+}
+
+// automodActionDocument is unique per rule and target, a rule acting again fails to insert
+type automodActionDocument struct {
+	Id        string    `bson:"_id"`
+	ThreadId  string    `bson:"thread_id"`
+	CommentId string    `bson:"comment_id"`
+	Rule      string    `bson:"rule"`
+	DryRun    bool      `bson:"dry_run"`
+	CreatedAt time.Time `bson:"created_at"`
+}
+
+// reportDocument is unique per user and target, a second report fails to insert
+type reportDocument struct {
+	Id         string    `bson:"_id"`
+	ThreadId   string    `bson:"thread_id"`
+	CommentId  string    `bson:"comment_id"`
+	ReporterId string    `bson:"reporter_id"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+type eventDocument struct {
+	Offset      int64     `bson:"_id"`
+	Type        string    `bson:"type"`
+	CommunityId string    `bson:"community_id"`
+	ThreadId    string    `bson:"thread_id"`
+	CommentId   string    `bson:"comment_id"`
+	Vote        int32     `bson:"vote"`
+	CreatedAt   time.Time `bson:"created_at"`
+}
+
+// sequenceDocument is the last value drawn from a sequence
+type sequenceDocument struct {
+	Value int64 `bson:"value"`
+}
+
+type jobDocument struct {
+	Id                 string     `bson:"_id"`
+	Type               string     `bson:"type"`
+	TargetId           string     `bson:"target_id"`
+	State              string     `bson:"state"`
+	NumThreadsDeleted  int32      `bson:"num_threads_deleted"`
+	NumCommentsDeleted int32      `bson:"num_comments_deleted"`
+	Attempts           int32      `bson:"attempts"`
+	Error              string     `bson:"error"`
+	CreatedAt          *time.Time `bson:"created_at"`
+	UpdatedAt          *time.Time `bson:"updated_at"`
+	LeaseExpiresAt     *time.Time `bson:"lease_expires_at"`
+	LeaseId            string     `bson:"lease_id"`
+}
+
+type automodConfigDocument struct {
+	Config string `bson:"config"`
+}
+
+type spamModelDocument struct {
+	Model     []byte     `bson:"model"`
+	TrainedAt *time.Time `bson:"trained_at"`
+}
+
+// conversions between messages and documents
+
+func newCommunityDocument(community *models.Community) *communityDocument {
+	doc := &communityDocument{
+		Id:           community.GetId(),
+		Name:         community.GetName(),
+		NumThreads:   community.GetNumThreads(),
+		PostingRules: newPostingRulesDocument(community.GetPostingRules()),
+	}
+	for _, template := range community.GetFlairTemplates() {
+		doc.FlairTemplates = append(doc.FlairTemplates, newFlairTemplateDocument(template))
+	}
+	return doc
+}
+
+func (d *communityDocument) community() *models.Community {
+	res := &models.Community{
+		Id:         d.Id,
+		Name:       d.Name,
+		NumThreads: d.NumThreads,
+	}
+	for _, template := range d.FlairTemplates {
+		res.FlairTemplates = append(res.FlairTemplates, &models.FlairTemplate{
+			Id:    template.Id,
+			Text:  template.Text,
+			Color: template.Color,
+		})
+	}
+	if rules := d.PostingRules; rules != nil {
+		res.PostingRules = &models.PostingRules{
+			MinTitleLength:   rules.MinTitleLength,
+			MaxTitleLength:   rules.MaxTitleLength,
+			MinContentLength: rules.MinContentLength,
+			MaxContentLength: rules.MaxContentLength,
+			MaxCommentLength: rules.MaxCommentLength,
+		}
+	}
+	return res
+}
+
+func newFlairTemplateDocument(template *models.FlairTemplate) flairTemplateDocument {
+	return flairTemplateDocument{
+		Id:    template.GetId(),
+		Text:  template.GetText(),
+		Color: template.GetColor(),
+	}
+}
+
+func newPostingRulesDocument(rules *models.PostingRules) *postingRulesDocument {
+	if rules == nil {
+		return nil
+	}
+	return &postingRulesDocument{
+		MinTitleLength:   rules.GetMinTitleLength(),
+		MaxTitleLength:   rules.GetMaxTitleLength(),
+		MinContentLength: rules.GetMinContentLength(),
+		MaxContentLength: rules.GetMaxContentLength(),
+		MaxCommentLength: rules.GetMaxCommentLength(),
+	}
+}
+
+func newThreadDocument(thread *models.Thread) *threadDocument {
+	doc := &threadDocument{
+		Id:          thread.GetId(),
+		CommunityId: thread.GetCommunityId(),
+		Title:       thread.GetTitle(),
+		Content:     thread.GetContent(),
+		ContentHtml: thread.GetContentHtml(),
+		AuthorId:    thread.GetAuthorId(),
+		CreatedAt:   timeValue(thread.GetCreatedAt()),
+		Ups:         thread.GetUps(),
+		Downs:       thread.GetDowns(),
+		NumComments: thread.GetNumComments(),
+		NumReports:  thread.GetNumReports(),
+		Flair:       thread.GetFlair(),
+		FlairColor:  thread.GetFlairColor(),
+		Tags:        thread.GetTags(),
+		Kind:        thread.GetKind().String(),
+		Locked:      thread.GetLocked(),
+	}
+	if thread.GetDraft() {
+		doc.Draft = true
+		doc.PublishAt = timeValue(thread.GetPublishAt())
+	}
+	switch thread.GetKind() {
+	case models.ThreadKind_LINK:
+		doc.Url = thread.GetUrl()
+		doc.Domain = thread.GetDomain()
+	case models.ThreadKind_IMAGE:
+		doc.AttachmentId = thread.GetAttachmentId()
+		doc.ThumbnailUrl = thread.GetThumbnailUrl()
+	case models.ThreadKind_POLL:
+		doc.Poll = &pollDocument{
+			Options:        []pollOptionDocument{},
+			EndsAt:         timeValue(thread.GetPoll().GetEndsAt()),
+			MultipleChoice: thread.GetPoll().GetMultipleChoice(),
+			HideResults:    thread.GetPoll().GetHideResults(),
+			NumVoters:      thread.GetPoll().GetNumVoters(),
+		}
+		for _, option := range thread.GetPoll().GetOptions() {
+			doc.Poll.Options = append(doc.Poll.Options, pollOptionDocument{
+				Id:       option.GetId(),
+				Text:     option.GetText(),
+				NumVotes: option.GetNumVotes(),
+			})
+		}
+	case models.ThreadKind_CROSSPOST:
+		doc.CrosspostParentId = thread.GetCrosspostParentId()
+	case models.ThreadKind_REDIRECT:
+		doc.RedirectThreadId = thread.GetRedirectThreadId()
+		doc.RedirectCommunityId = thread.GetRedirectCommunityId()
+	}
+	return doc
+}
+
+func (d *threadDocument) thread() *models.Thread {
+	res := &models.Thread{
+		Id:                  d.Id,
+		CommunityId:         d.CommunityId,
+		Title:               d.Title,
+		Content:             d.Content,
+		ContentHtml:         d.ContentHtml,
+		Ups:                 d.Ups,
+		Downs:               d.Downs,
+		NumComments:         d.NumComments,
+		AuthorId:            d.AuthorId,
+		CreatedAt:           timestamp(d.CreatedAt),
+		NumReports:          d.NumReports,
+		Locked:              d.Locked,
+		Flair:               d.Flair,
+		SpamScore:           d.SpamScore,
+		Pinned:              d.Pinned,
+		FlairColor:          d.FlairColor,
+		Tags:                d.Tags,
+		Kind:                models.ThreadKind(models.ThreadKind_value[d.Kind]),
+		Url:                 d.Url,
+		Domain:              d.Domain,
+		AttachmentId:        d.AttachmentId,
+		ThumbnailUrl:        d.ThumbnailUrl,
+		EditedAt:            timestamp(d.EditedAt),
+		Draft:               d.Draft,
+		PublishAt:           timestamp(d.PublishAt),
+		CrosspostParentId:   d.CrosspostParentId,
+		RedirectThreadId:    d.RedirectThreadId,
+		RedirectCommunityId: d.RedirectCommunityId,
+	}
+	if poll := d.Poll; poll != nil {
+		res.Poll = &models.Poll{
+			EndsAt:         timestamp(poll.EndsAt),
+			MultipleChoice: poll.MultipleChoice,
+			HideResults:    poll.HideResults,
+			NumVoters:      poll.NumVoters,
+		}
+		for _, option := range poll.Options {
+			res.Poll.Options = append(res.Poll.Options, &models.PollOption{
+				Id:       option.Id,
+				Text:     option.Text,
+				NumVotes: option.NumVotes,
+			})
+		}
+	}
+	res.RemovalType, res.RemovalReason, res.RemovedAt = d.Removal.removal()
+	return res
+}
+
+func newCommentDocument(comment *models.Comment) *commentDocument {
+	return &commentDocument{
+		Id:            comment.GetId(),
+		Content:       comment.GetContent(),
+		ContentHtml:   comment.GetContentHtml(),
+		Ups:           comment.GetUps(),
+		Downs:         comment.GetDowns(),
+		ParentId:      comment.GetParentId(),
+		ParentType:    comment.GetParentType().String(),
+		AuthorId:      comment.GetAuthorId(),
+		CreatedAt:     timeValue(comment.GetCreatedAt()),
+		NumComments:   comment.GetNumComments(),
+		NumReports:    comment.GetNumReports(),
+		AttachmentIds: comment.GetAttachmentIds(),
+		AncestorIds:   comment.GetAncestorIds(),
+	}
+}
+
+func (d *commentDocument) comment() *models.Comment {
+	res := &models.Comment{
+		Id:            d.Id,
+		Content:       d.Content,
+		ContentHtml:   d.ContentHtml,
+		Ups:           d.Ups,
+		Downs:         d.Downs,
+		ParentId:      d.ParentId,
+		ParentType:    models.CommentParentType(models.CommentParentType_value[d.ParentType]),
+		NumComments:   d.NumComments,
+		AuthorId:      d.AuthorId,
+		CreatedAt:     timestamp(d.CreatedAt),
+		NumReports:    d.NumReports,
+		SpamScore:     d.SpamScore,
+		AttachmentIds: d.AttachmentIds,
+		EditedAt:      timestamp(d.EditedAt),
+		AncestorIds:   d.AncestorIds,
+	}
+	res.RemovalType, res.RemovalReason, res.RemovedAt = d.Removal.removal()
+	return res
+}
+
+func (d *revisionDocument) revision() *models.Revision {
+	return &models.Revision{
+		Version:   d.Version,
+		Title:     d.Title,
+		Content:   d.Content,
+		EditorId:  d.EditorId,
+		CreatedAt: timestamp(d.CreatedAt),
+	}
+}
+
+func newAttachmentDocument(attachment *models.Attachment) *attachmentDocument {
+	doc := &attachmentDocument{
+		Id:          attachment.GetId(),
+		Filename:    attachment.GetFilename(),
+		ContentType: attachment.GetContentType(),
+		Size:        attachment.GetSize(),
+		Sha256:      attachment.GetSha256(),
+		AuthorId:    attachment.GetAuthorId(),
+		CreatedAt:   timeValue(attachment.GetCreatedAt()),
+	}
+	if attachment.GetThumbnailSha256() != "" {
+		doc.Width = attachment.GetWidth()
+		doc.Height = attachment.GetHeight()
+		doc.ThumbnailSha256 = attachment.GetThumbnailSha256()
+		doc.ThumbnailContentType = attachment.GetThumbnailContentType()
+		doc.ThumbnailSize = attachment.GetThumbnailSize()
+	}
+	return doc
+}
+
+func (d *attachmentDocument) attachment() *models.Attachment {
+	return &models.Attachment{
+		Id:                   d.Id,
+		Filename:             d.Filename,
+		ContentType:          d.ContentType,
+		Size:                 d.Size,
+		Sha256:               d.Sha256,
+		AuthorId:             d.AuthorId,
+		CreatedAt:            timestamp(d.CreatedAt),
+		Width:                d.Width,
+		Height:               d.Height,
+		ThumbnailSha256:      d.ThumbnailSha256,
+		ThumbnailContentType: d.ThumbnailContentType,
+		ThumbnailSize:        d.ThumbnailSize,
+	}
+}
+
+func newModQueueDocument(item *models.ModQueueItem) *modQueueDocument {
+	return &modQueueDocument{
+		Id:          item.GetId(),
+		CommunityId: item.GetCommunityId(),
+		ThreadId:    item.GetThreadId(),
+		CommentId:   item.GetCommentId(),
+		Rule:        item.GetRule(),
+		Reason:      item.GetReason(),
+		DryRun:      item.GetDryRun(),
+		CreatedAt:   timeValue(item.GetCreatedAt()),
+	}
+}
+
+func (d *modQueueDocument) item() *models.ModQueueItem {
+	return &models.ModQueueItem{
+		Id:          d.Id,
+		CommunityId: d.CommunityId,
+		ThreadId:    d.ThreadId,
+		CommentId:   d.CommentId,
+		Rule:        d.Rule,
+		Reason:      d.Reason,
+		DryRun:      d.DryRun,
+		CreatedAt:   timestamp(d.CreatedAt),
+	}
+}
+
+// fields set on threads and comments when they are soft deleted
+var removalFields = bson.M{"removal_type": "", "removal_reason": "", "removed_at": ""}
+
+// matches threads and comments that are not removed, documents created before removals have no removal type
+var notRemoved = bson.M{"$in": bson.A{nil, models.RemovalType_NOT_REMOVED.String()}}
+
+func newEventDocument(event *models.Event) *eventDocument {
+	return &eventDocument{
+		Offset:      event.GetOffset(),
+		Type:        event.GetType().String(),
+		CommunityId: event.GetCommunityId(),
+		ThreadId:    event.GetThreadId(),
+		CommentId:   event.GetCommentId(),
+		Vote:        event.GetVote(),
+		CreatedAt:   event.GetCreatedAt().AsTime(),
+	}
+}
+
+func (d *eventDocument) event() *models.Event {
+	return &models.Event{
+		Offset:      d.Offset,
+		Type:        models.EventType(models.EventType_value[d.Type]),
+		CommunityId: d.CommunityId,
+		ThreadId:    d.ThreadId,
+		CommentId:   d.CommentId,
+		Vote:        d.Vote,
+		CreatedAt:   timestamppb.New(d.CreatedAt),
+	}
+}
+
+func newJobDocument(job *models.Job) *jobDocument {
+	return &jobDocument{
+		Id:                 job.GetId(),
+		Type:               job.GetType().String(),
+		TargetId:           job.GetTargetId(),
+		State:              job.GetState().String(),
+		NumThreadsDeleted:  job.GetNumThreadsDeleted(),
+		NumCommentsDeleted: job.GetNumCommentsDeleted(),
+		Attempts:           job.GetAttempts(),
+		Error:              job.GetError(),
+		CreatedAt:          timeValue(job.GetCreatedAt()),
+		UpdatedAt:          timeValue(job.GetUpdatedAt()),
+		LeaseExpiresAt:     timeValue(job.GetLeaseExpiresAt()),
+		LeaseId:            job.GetLeaseId(),
+	}
+}
+
+func (d *jobDocument) job() *models.Job {
+	return &models.Job{
+		Id:                 d.Id,
+		Type:               models.JobType(models.JobType_value[d.Type]),
+		TargetId:           d.TargetId,
+		State:              models.JobState(models.JobState_value[d.State]),
+		NumThreadsDeleted:  d.NumThreadsDeleted,
+		NumCommentsDeleted: d.NumCommentsDeleted,
+		Attempts:           d.Attempts,
+		Error:              d.Error,
+		CreatedAt:          timestamp(d.CreatedAt),
+		UpdatedAt:          timestamp(d.UpdatedAt),
+		LeaseExpiresAt:     timestamp(d.LeaseExpiresAt),
+		LeaseId:            d.LeaseId,
+	}
+}
+
+func newRemovalDocument(removalType models.RemovalType, reason string, removedAt time.Time) removalDocument {
+	return removalDocument{
+		RemovalType:   removalType.String(),
+		RemovalReason: reason,
+		RemovedAt:     &removedAt,
+	}
+}
+
+func (d *removalDocument) removal() (models.RemovalType, string, *timestamppb.Timestamp) {
+	if d.RemovalType == "" {
+		return models.RemovalType_NOT_REMOVED, "", nil
+	}
+	return models.RemovalType(models.RemovalType_value[d.RemovalType]), d.RemovalReason, timestamp(d.RemovedAt)
+}
+
+// timeValue stores missing timestamps as null
+func timeValue(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := t.AsTime()
+	return &value
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// decoding of documents, a document that does not match its type fails with ErrDataLoss instead of being read partially
+
+// malformed describes a document that failed to decode
+func malformed(raw bson.Raw, err error) error {
+	if id, ok := raw.Lookup("_id").StringValueOK(); ok {
+		return fmt.Errorf("%w: document %s: %v", storage.ErrDataLoss, id, err)
+	}
+	return fmt.Errorf("%w: %v", storage.ErrDataLoss, err)
+}
+
+// decodeOne decodes the document of a single result, it fails with ErrNotFound without a document
+func decodeOne[D any](result *mongo.SingleResult) (*D, error) {
+	raw, err := result.Raw()
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc := new(D)
+	if err := bson.Unmarshal(raw, doc); err != nil {
+		return nil, malformed(raw, err)
+	}
+	return doc, nil
+}
+
+// decodeAll decodes every document of a cursor and converts it with fromDocument
+func decodeAll[D any, T any](ctx context.Context, cursor *mongo.Cursor, fromDocument func(*D) T) ([]T, error) {
+	defer cursor.Close(ctx)
+
+	var results []T
+	for cursor.Next(ctx) {
+		doc := new(D)
+		if err := cursor.Decode(doc); err != nil {
+			return nil, malformed(cursor.Current, err)
+		}
+		results = append(results, fromDocument(doc))
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// addOffset increments a counter by a non-zero offset
+func addOffset(incValues bson.M, key string, offset int32) {
+	if offset != 0 {
+		incValues[key] = offset
+	}
+}
+
+// findAndUpdate updates a document and returns it as it was before the update
+func findAndUpdate[D any](ctx context.Context, collection *mongo.Collection, id string, setValues bson.M, incValues bson.M, unsetValues bson.M) (*D, error) {
+	update := bson.M{"$set": setValues, "$inc": incValues}
+	if len(unsetValues) > 0 {
+		update["$unset"] = unsetValues
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.Before)
+	return decodeOne[D](collection.FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts))
+}
+
+// remove soft deletes a thread or comment that is not already removed
+func remove(ctx context.Context, collection *mongo.Collection, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	filter := bson.M{"_id": id, "removal_type": notRemoved}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$set": newRemovalDocument(removalType, reason, removedAt)})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return existsOr(ctx, collection, id, storage.ErrFailedPrecondition)
+	}
+	return nil
+}
+
+func restore(ctx context.Context, collection *mongo.Collection, id string, removedAfter time.Time) error {
+	// only what is removed has a removal time
+	filter := bson.M{"_id": id, "removed_at": bson.M{"$gte": removedAfter}}
+	result, err := collection.UpdateOne(ctx, filter, bson.M{"$unset": removalFields})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return existsOr(ctx, collection, id, storage.ErrFailedPrecondition)
+	}
+	return nil
+}
+
+// existsOr tells why a conditional update matched nothing: err when the document exists, ErrNotFound otherwise
+func existsOr(ctx context.Context, collection *mongo.Collection, id string, err error) error {
+	count, countErr := collection.CountDocuments(ctx, bson.M{"_id": id})
+	if countErr != nil {
+		return countErr
+	}
+	if count == 0 {
+		return storage.ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package mongodb
+
+import (
+	"context"
+	models "gen/models/pb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type events struct {
+	*Storage
+}
+
+func (s *events) List(ctx context.Context, after int64, limit int32) ([]*models.Event, error) {
+	opts := options.Find().SetSort(bson.M{"_id": 1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := s.db.Collection("events").Find(ctx, bson.M{"_id": bson.M{"$gt": after}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*eventDocument).event)
+}
+
+func (s *events) Prune(ctx context.Context, before time.Time) error {
+	_, err := s.db.Collection("events").DeleteMany(ctx, bson.M{"created_at": bson.M{"$lt": before}})
+	return err
+}
+
+// emit appends events to the outbox in the transaction of the change they describe. Offsets come from a counter
+// document, which is incremented once by the number of events. Concurrent transactions conflict on it and retry
+// after the first commits, so offsets become visible in increasing order
+func (s *Storage) emit(ctx mongo.SessionContext, events ...*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	update := bson.M{"$inc": bson.M{"value": int64(len(events))}}
+	sequence, err := decodeOne[sequenceDocument](s.db.Collection("sequences").FindOneAndUpdate(ctx, bson.M{"_id": "events"}, update, opts))
+	if err != nil {
+		return err
+	}
+	docs := make([]any, 0, len(events))
+	for i, event := range events {
+		event.Offset = sequence.Value - int64(len(events)-1-i)
+		docs = append(docs, newEventDocument(event))
+	}
+	_, err = s.db.Collection("events").InsertMany(ctx, docs)
+	return err
+}
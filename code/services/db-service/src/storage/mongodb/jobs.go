@@ -0,0 +1,71 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type jobs struct {
+	*Storage
+}
+
+func (s *jobs) Create(ctx context.Context, job *models.Job) error {
+	_, err := s.db.Collection("jobs").InsertOne(ctx, newJobDocument(job))
+	if mongo.IsDuplicateKeyError(err) {
+		return storage.ErrAlreadyExists
+	}
+	return err
+}
+
+func (s *jobs) Get(ctx context.Context, id string) (*models.Job, error) {
+	doc, err := decodeOne[jobDocument](s.db.Collection("jobs").FindOne(ctx, bson.M{"_id": id}))
+	if err != nil {
+		return nil, err
+	}
+	return doc.job(), nil
+}
+
+func (s *jobs) Claim(ctx context.Context, leaseId string, now time.Time, leaseExpiresAt time.Time) (*models.Job, error) {
+	// the update is atomic, so runners claiming together never claim the same job
+	filter := bson.M{
+		"state": bson.M{"$in": bson.A{models.JobState_JOB_PENDING.String(), models.JobState_JOB_RUNNING.String()}},
+		"$or":   bson.A{bson.M{"lease_expires_at": nil}, bson.M{"lease_expires_at": bson.M{"$lte": now}}},
+	}
+	update := bson.M{"$set": bson.M{
+		"state":            models.JobState_JOB_RUNNING.String(),
+		"lease_id":         leaseId,
+		"lease_expires_at": leaseExpiresAt,
+		"updated_at":       now,
+	}}
+	opts := options.FindOneAndUpdate().SetSort(bson.M{"created_at": 1}).SetReturnDocument(options.After)
+	doc, err := decodeOne[jobDocument](s.db.Collection("jobs").FindOneAndUpdate(ctx, filter, update, opts))
+	if err != nil {
+		return nil, err
+	}
+	return doc.job(), nil
+}
+
+func (s *jobs) Update(ctx context.Context, job *models.Job) error {
+	jobs := s.db.Collection("jobs")
+	result, err := jobs.ReplaceOne(ctx, bson.M{"_id": job.GetId(), "lease_id": job.GetLeaseId()}, newJobDocument(job))
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+	n, err := jobs.CountDocuments(ctx, bson.M{"_id": job.GetId()})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return storage.ErrNotFound
+	}
+	return storage.ErrFailedPrecondition
+}
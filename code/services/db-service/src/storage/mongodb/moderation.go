@@ -0,0 +1,186 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	"fmt"
+	models "gen/models/pb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// the spam classifier is shared by all communities
+const spamModelId = "default"
+
+type moderation struct {
+	*Storage
+}
+
+func (s *moderation) AuthorStats(ctx context.Context, authorId string) (int32, *time.Time, error) {
+	// karma and first activity are aggregated over both threads and comments
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"author_id": authorId}}},
+		{{Key: "$group", Value: bson.M{
+			"_id":        nil,
+			"karma":      bson.M{"$sum": bson.M{"$subtract": bson.A{"$ups", "$downs"}}},
+			"first_seen": bson.M{"$min": "$created_at"},
+		}}},
+	}
+	var karma int32
+	var firstSeen *time.Time
+	for _, collectionName := range []string{"threads", "comments"} {
+		cursor, err := s.db.Collection(collectionName).Aggregate(ctx, pipeline)
+		if err != nil {
+			return 0, nil, err
+		}
+		var results []struct {
+			Karma     int32      `bson:"karma"`
+			FirstSeen *time.Time `bson:"first_seen"`
+		}
+		if err := cursor.All(ctx, &results); err != nil {
+			return 0, nil, fmt.Errorf("%w: author stats: %v", storage.ErrDataLoss, err)
+		}
+		for _, result := range results {
+			karma += result.Karma
+			if result.FirstSeen != nil && (firstSeen == nil || result.FirstSeen.Before(*firstSeen)) {
+				firstSeen = result.FirstSeen
+			}
+		}
+	}
+	return karma, firstSeen, nil
+}
+
+func (s *moderation) GetAutomodConfig(ctx context.Context, communityId string) (string, error) {
+	config, err := decodeOne[automodConfigDocument](s.db.Collection("automod_configs").FindOne(ctx, bson.M{"_id": communityId}))
+	if errors.Is(err, storage.ErrNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return config.Config, nil
+}
+
+func (s *moderation) SetAutomodConfig(ctx context.Context, communityId string, config string) error {
+	update := bson.M{"$set": bson.M{"config": config}}
+	_, err := s.db.Collection("automod_configs").UpdateOne(ctx, bson.M{"_id": communityId}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+func (s *moderation) ListModQueue(ctx context.Context, communityId string, page storage.Page) ([]*models.ModQueueItem, error) {
+	filter := bson.M{}
+	if communityId != "" {
+		filter["community_id"] = communityId
+	}
+	cursor, err := s.db.Collection("mod_queue").Find(ctx, filter, findOptions(page, "created_at"))
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*modQueueDocument).item)
+}
+
+func (s *moderation) CreateModQueueItem(ctx context.Context, item *models.ModQueueItem) error {
+	_, err := s.db.Collection("mod_queue").InsertOne(ctx, newModQueueDocument(item))
+	return err
+}
+
+func (s *moderation) DeleteModQueueItem(ctx context.Context, id string) error {
+	result, err := s.db.Collection("mod_queue").DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (s *moderation) RecordAutomodAction(ctx context.Context, action storage.AutomodAction) error {
+	document := &automodActionDocument{
+		Id:        fmt.Sprintf("%s:%s:%s:%t", action.ThreadId, action.CommentId, action.Rule, action.DryRun),
+		ThreadId:  action.ThreadId,
+		CommentId: action.CommentId,
+		Rule:      action.Rule,
+		DryRun:    action.DryRun,
+		CreatedAt: time.Now(),
+	}
+	_, err := s.db.Collection("automod_actions").InsertOne(ctx, document)
+	if mongo.IsDuplicateKeyError(err) {
+		return storage.ErrAlreadyExists
+	}
+	return err
+}
+
+func (s *moderation) CreateReport(ctx context.Context, report storage.Report) error {
+	document := &reportDocument{
+		Id:         fmt.Sprintf("%s:%s:%s", report.ThreadId, report.CommentId, report.ReporterId),
+		ThreadId:   report.ThreadId,
+		CommentId:  report.CommentId,
+		ReporterId: report.ReporterId,
+		CreatedAt:  time.Now(),
+	}
+	collection, id := "threads", report.ThreadId
+	if report.CommentId != "" {
+		collection, id = "comments", report.CommentId
+	}
+
+	// the report and its count are written together, so a report is never recorded without being counted
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		_, err := s.db.Collection("reports").InsertOne(ctx, document)
+		if mongo.IsDuplicateKeyError(err) {
+			return storage.ErrAlreadyExists
+		}
+		if err != nil {
+			return err
+		}
+
+		result, err := s.db.Collection(collection).UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$inc": bson.M{"num_reports": 1}})
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return storage.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// content deleted by its author says nothing about spam
+var spamSampleFilter = bson.M{"removal_type": bson.M{"$ne": models.RemovalType_DELETED.String()}}
+
+func (s *moderation) ListSpamThreads(ctx context.Context, page storage.Page) ([]*models.Thread, error) {
+	cursor, err := s.db.Collection("threads").Find(ctx, spamSampleFilter, findOptions(page, "_id"))
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*threadDocument).thread)
+}
+
+func (s *moderation) ListSpamComments(ctx context.Context, page storage.Page) ([]*models.Comment, error) {
+	cursor, err := s.db.Collection("comments").Find(ctx, spamSampleFilter, findOptions(page, "_id"))
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*commentDocument).comment)
+}
+
+func (s *moderation) GetSpamModel(ctx context.Context) ([]byte, *time.Time, error) {
+	model, err := decodeOne[spamModelDocument](s.db.Collection("spam_models").FindOne(ctx, bson.M{"_id": spamModelId}))
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return model.Model, model.TrainedAt, nil
+}
+
+func (s *moderation) SetSpamModel(ctx context.Context, model []byte, trainedAt time.Time) error {
+	update := bson.M{"$set": bson.M{"model": model, "trained_at": trainedAt}}
+	_, err := s.db.Collection("spam_models").UpdateOne(ctx, bson.M{"_id": spamModelId}, update, options.Update().SetUpsert(true))
+	return err
+}
@@ -0,0 +1,290 @@
+// Package mongodb stores the documents of db-service in MongoDB.
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"math/rand/v2"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Storage keeps every kind of document in its own collection of a database
+type Storage struct {
+	db *mongo.Database
+}
+
+func New(db *mongo.Database) *Storage {
+	return &Storage{db: db}
+}
+
+func (s *Storage) Communities() storage.CommunityRepository {
+	return &communities{s}
+}
+
+func (s *Storage) Threads() storage.ThreadRepository {
+	return &threads{s}
+}
+
+func (s *Storage) Comments() storage.CommentRepository {
+	return &comments{s}
+}
+
+func (s *Storage) Revisions() storage.RevisionRepository {
+	return &revisions{s}
+}
+
+func (s *Storage) Attachments() storage.AttachmentRepository {
+	return &attachments{s}
+}
+
+func (s *Storage) Moderation() storage.ModerationRepository {
+	return &moderation{s}
+}
+
+func (s *Storage) Counters() storage.CounterRepository {
+	return &counters{s}
+}
+
+func (s *Storage) Events() storage.EventRepository {
+	return &events{s}
+}
+
+func (s *Storage) Jobs() storage.JobRepository {
+	return &jobs{s}
+}
+
+// replies migrated per bulk write
+const migrateBatchSize = 500
+
+// indexes are created by Migrate, creating an index that exists does nothing
+var indexes = map[string][]mongo.IndexModel{
+	"comments": {
+		// replies at any depth, which are deleted with their ancestor
+		{Keys: bson.D{{Key: "ancestor_ids", Value: 1}}},
+		// replies of a thread or comment, which are listed and counted
+		{Keys: bson.D{{Key: "parent_id", Value: 1}, {Key: "parent_type", Value: 1}}},
+	},
+	"threads": {
+		{Keys: bson.D{{Key: "community_id", Value: 1}}},
+	},
+}
+
+// Migrate creates the indexes and brings the documents written by earlier versions up to date, and does nothing
+// once they are. Replies written before comments had an ancestor path get the path of their parent, one level of
+// replies per pass, read through a cursor and written in batches
+func Migrate(ctx context.Context, db *mongo.Database) error {
+	for collection, collectionIndexes := range indexes {
+		if _, err := db.Collection(collection).Indexes().CreateMany(ctx, collectionIndexes); err != nil {
+			return err
+		}
+	}
+
+	comments := db.Collection("comments")
+	missing := bson.M{"parent_type": models.CommentParentType_COMMENT.String(), "ancestor_ids": bson.M{"$exists": false}}
+	opts := options.Find().SetProjection(bson.M{"parent_id": 1}).SetBatchSize(migrateBatchSize)
+	for {
+		cursor, err := comments.Find(ctx, missing, opts)
+		if err != nil {
+			return err
+		}
+		migrated := 0
+		var batch []*pathDocument
+		for cursor.Next(ctx) {
+			reply := &pathDocument{}
+			if err := cursor.Decode(reply); err != nil {
+				cursor.Close(ctx)
+				return malformed(cursor.Current, err)
+			}
+			batch = append(batch, reply)
+			if len(batch) < migrateBatchSize {
+				continue
+			}
+			n, err := migrateReplies(ctx, comments, batch)
+			if err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+			migrated += n
+			batch = batch[:0]
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return err
+		}
+		n, err := migrateReplies(ctx, comments, batch)
+		if err != nil {
+			return err
+		}
+		if migrated += n; migrated == 0 {
+			// replies whose parent is gone keep no path
+			return nil
+		}
+	}
+}
+
+// migrateReplies gives replies the ancestor path of their parent when the parent has one, and returns how many got
+// one
+func migrateReplies(ctx context.Context, comments *mongo.Collection, replies []*pathDocument) (int, error) {
+	if len(replies) == 0 {
+		return 0, nil
+	}
+	parentIds := make([]string, 0, len(replies))
+	for _, reply := range replies {
+		parentIds = append(parentIds, reply.ParentId)
+	}
+	projection := options.Find().SetProjection(bson.M{"parent_type": 1, "ancestor_ids": 1})
+	cursor, err := comments.Find(ctx, bson.M{"_id": bson.M{"$in": parentIds}}, projection)
+	if err != nil {
+		return 0, err
+	}
+	parents, err := decodeAll(ctx, cursor, func(doc *pathDocument) *pathDocument { return doc })
+	if err != nil {
+		return 0, err
+	}
+	byId := make(map[string]*pathDocument, len(parents))
+	for _, parent := range parents {
+		byId[parent.Id] = parent
+	}
+
+	var updates []mongo.WriteModel
+	for _, reply := range replies {
+		parent, ok := byId[reply.ParentId]
+		if !ok || parent.ParentType == models.CommentParentType_COMMENT.String() && len(parent.AncestorIds) == 0 {
+			// the parent is gone, or gets its path first
+			continue
+		}
+		path := append(slices.Clone(parent.AncestorIds), parent.Id)
+		updates = append(updates, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": reply.Id}).
+			SetUpdate(bson.M{"$set": bson.M{"ancestor_ids": path}}))
+	}
+	if len(updates) == 0 {
+		return 0, nil
+	}
+	if _, err := comments.BulkWrite(ctx, updates, options.BulkWrite().SetOrdered(false)); err != nil {
+		return 0, err
+	}
+	return len(updates), nil
+}
+
+// transactions retried on transient errors, such as write conflicts on the event sequence, wait a random delay of up
+// to the base times 2^attempts, capped, so conflicting writers spread out instead of conflicting again right away
+const (
+	transactionRetryBase    = 5 * time.Millisecond
+	transactionRetryCap     = time.Second
+	transactionRetryTimeout = 2 * time.Minute
+)
+
+// withTransaction runs fn in a multi-document transaction, which needs MongoDB to run as a replica set.
+// fn is retried on transient errors with a jittered backoff so it must only read and write through ctx
+func (s *Storage) withTransaction(ctx context.Context, fn func(ctx mongo.SessionContext) error) error {
+	session, err := s.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	deadline := time.Now().Add(transactionRetryTimeout)
+	for attempt := 0; ; attempt++ {
+		err := runTransaction(ctx, session, deadline, fn)
+		if !hasErrorLabel(err, "TransientTransactionError") || time.Now().After(deadline) {
+			return err
+		}
+		delay := min(transactionRetryBase<<attempt, transactionRetryCap)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(rand.N(delay) + 1):
+		}
+	}
+}
+
+// runTransaction runs fn in one transaction of a session and commits it. Commits with an unknown result are retried
+// until the deadline, they are safe to repeat
+func runTransaction(ctx context.Context, session mongo.Session, deadline time.Time, fn func(ctx mongo.SessionContext) error) error {
+	if err := session.StartTransaction(); err != nil {
+		return err
+	}
+	if err := fn(mongo.NewSessionContext(ctx, session)); err != nil {
+		// the error of fn matters, the abort only ends the transaction early
+		_ = session.AbortTransaction(context.WithoutCancel(ctx))
+		return err
+	}
+	for {
+		err := session.CommitTransaction(ctx)
+		if !hasErrorLabel(err, "UnknownTransactionCommitResult") || time.Now().After(deadline) {
+			return err
+		}
+	}
+}
+
+// hasErrorLabel reports whether the server or the driver labeled an error with label
+func hasErrorLabel(err error, label string) bool {
+	var labeled mongo.LabeledError
+	return errors.As(err, &labeled) && labeled.HasErrorLabel(label)
+}
+
+// findOptions selects a page of documents sorted by the first orderings and then by a field in descending order
+// and by _id, _id sorts newest first
+func findOptions(page storage.Page, sortBy string, first ...bson.E) *options.FindOptions {
+	findOptions := options.Find()
+	if page.Offset > 0 {
+		findOptions.SetSkip(int64(page.Offset))
+	}
+	if page.Limit > 0 {
+		findOptions.SetLimit(int64(page.Limit))
+	}
+	sort := bson.D(first)
+	switch sortBy {
+	case "_id":
+		sort = append(sort, bson.E{Key: "_id", Value: -1})
+	case "":
+		sort = append(sort, bson.E{Key: "_id", Value: 1})
+	default:
+		sort = append(sort, bson.E{Key: sortBy, Value: -1}, bson.E{Key: "_id", Value: 1})
+	}
+	return findOptions.SetSort(sort)
+}
+
+// continueAfter adds the documents listed after the cursor of a page in the order of findOptions to a query,
+// pinned threads are listed first when the cursor has a pinned state
+func continueAfter(query bson.M, page storage.Page, sortBy string) {
+	cursor := page.After
+	if cursor == nil {
+		return
+	}
+	var after bson.M
+	switch {
+	case sortBy == "_id":
+		after = bson.M{"_id": bson.M{"$lt": cursor.Id}}
+	case sortBy == "":
+		after = bson.M{"_id": bson.M{"$gt": cursor.Id}}
+	case cursor.Value == nil:
+		// documents missing the field are listed last
+		after = bson.M{sortBy: nil, "_id": bson.M{"$gt": cursor.Id}}
+	default:
+		after = bson.M{"$or": bson.A{
+			bson.M{sortBy: bson.M{"$lt": cursor.Value}},
+			bson.M{sortBy: cursor.Value, "_id": bson.M{"$gt": cursor.Id}},
+			bson.M{sortBy: nil},
+		}}
+	}
+
+	// threads that are not pinned miss the field
+	if cursor.Pinned != nil {
+		if *cursor.Pinned {
+			after = bson.M{"$or": bson.A{bson.M{"pinned": bson.M{"$ne": true}}, bson.M{"$and": bson.A{bson.M{"pinned": true}, after}}}}
+		} else {
+			after = bson.M{"$and": bson.A{bson.M{"pinned": bson.M{"$ne": true}}, after}}
+		}
+	}
+	query["$and"] = bson.A{after}
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type revisions struct {
+	*Storage
+}
+
+// revisionFilter matches the revisions of a thread or comment
+func revisionFilter(target storage.RevisionTarget) bson.M {
+	if target.ThreadId != "" {
+		return bson.M{"thread_id": target.ThreadId}
+	}
+	return bson.M{"comment_id": target.CommentId}
+}
+
+// createRevisions inserts the revisions of a thread or comment in the transaction of the session
+func (s *Storage) createRevisions(ctx mongo.SessionContext, target storage.RevisionTarget, revisions []*models.Revision) error {
+	if len(revisions) == 0 {
+		return nil
+	}
+	docs := bson.A{}
+	for _, revision := range revisions {
+		docs = append(docs, &revisionDocument{
+			Id:        primitive.NewObjectID().Hex(),
+			ThreadId:  target.ThreadId,
+			CommentId: target.CommentId,
+			Version:   revision.GetVersion(),
+			Title:     revision.GetTitle(),
+			Content:   revision.GetContent(),
+			EditorId:  revision.GetEditorId(),
+			CreatedAt: timeValue(revision.GetCreatedAt()),
+		})
+	}
+	_, err := s.db.Collection("revisions").InsertMany(ctx, docs)
+	return err
+}
+
+func (s *revisions) List(ctx context.Context, target storage.RevisionTarget) ([]*models.Revision, error) {
+	cursor, err := s.db.Collection("revisions").Find(ctx, revisionFilter(target), options.Find().SetSort(bson.M{"version": 1}))
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*revisionDocument).revision)
+}
+
+// deleteRevisions deletes the revisions matched by a filter in the transaction of the session
+func (s *Storage) deleteRevisions(ctx mongo.SessionContext, filter bson.M) error {
+	_, err := s.db.Collection("revisions").DeleteMany(ctx, filter)
+	return err
+}
@@ -0,0 +1,398 @@
+package mongodb
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type threads struct {
+	*Storage
+}
+
+func (s *threads) collection() *mongo.Collection {
+	return s.db.Collection("threads")
+}
+
+func (s *threads) List(ctx context.Context, filter storage.ThreadFilter) ([]*models.Thread, error) {
+	query := bson.M{}
+	if filter.CommunityId != "" {
+		query["community_id"] = filter.CommunityId
+	}
+	if filter.Title != "" {
+		query["title"] = bson.M{"$regex": filter.Title, "$options": "i"} // case-insensitive title match
+	}
+	if filter.Pinned != nil {
+		if *filter.Pinned {
+			query["pinned"] = true
+		} else {
+			query["pinned"] = bson.M{"$ne": true}
+		}
+	}
+	if filter.Flair != nil {
+		query["flair"] = *filter.Flair
+	}
+	if filter.Tag != nil {
+		query["tags"] = *filter.Tag
+	}
+	if filter.AuthorId != nil {
+		query["author_id"] = *filter.AuthorId
+	}
+	if filter.CrosspostParentId != nil {
+		query["crosspost_parent_id"] = *filter.CrosspostParentId
+	}
+	if filter.Drafts {
+		query["draft"] = true
+	} else {
+		query["draft"] = bson.M{"$ne": true}
+	}
+
+	continueAfter(query, filter.Page, filter.SortBy)
+
+	// pinned threads are listed first within a community
+	var first []bson.E
+	if filter.CommunityId != "" {
+		first = append(first, bson.E{Key: "pinned", Value: -1})
+	}
+	cursor, err := s.collection().Find(ctx, query, findOptions(filter.Page, filter.SortBy, first...))
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*threadDocument).thread)
+}
+
+func (s *threads) Create(ctx context.Context, thread *models.Thread) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		if _, err := s.collection().InsertOne(ctx, newThreadDocument(thread)); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadCreated(thread)...)
+	})
+}
+
+func (s *threads) Get(ctx context.Context, id string) (*models.Thread, error) {
+	doc, err := decodeOne[threadDocument](s.collection().FindOne(ctx, bson.M{"_id": id}))
+	if err != nil {
+		return nil, err
+	}
+	return doc.thread(), nil
+}
+
+func (s *threads) Update(ctx context.Context, id string, update storage.ThreadUpdate) error {
+	setValues, incValues, unsetValues := bson.M{}, bson.M{}, bson.M{}
+	if update.Title != nil {
+		setValues["title"] = *update.Title
+	}
+	if update.Content != nil {
+		setValues["content"] = *update.Content
+	}
+	if update.ContentHtml != nil {
+		setValues["content_html"] = *update.ContentHtml
+	}
+	addOffset(incValues, "ups", update.UpsOffset)
+	addOffset(incValues, "downs", update.DownsOffset)
+	addOffset(incValues, "num_comments", update.NumCommentsOffset)
+	addOffset(incValues, "num_reports", update.NumReportsOffset)
+	if update.Locked != nil {
+		setValues["locked"] = *update.Locked
+	}
+	if update.Flair != nil {
+		setValues["flair"] = *update.Flair
+	}
+	if update.SpamScore != nil {
+		setValues["spam_score"] = *update.SpamScore
+	}
+	// unpinned threads miss the field like new threads, so lists sort them together after the pinned ones
+	if update.Pinned != nil && *update.Pinned {
+		setValues["pinned"] = true
+	} else if update.Pinned != nil {
+		unsetValues["pinned"] = ""
+	}
+	if update.FlairColor != nil {
+		setValues["flair_color"] = *update.FlairColor
+	}
+	if update.Tags != nil {
+		setValues["tags"] = *update.Tags
+	}
+	if update.PublishAt != nil {
+		setValues["publish_at"] = *update.PublishAt
+	}
+	if update.Edit != nil {
+		setValues["edited_at"] = update.Edit.EditedAt
+		incValues["num_edits"] = 1
+	}
+
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		// concurrent edits conflict on num_edits and are retried, so they record consecutive versions
+		before, err := findAndUpdate[threadDocument](ctx, s.collection(), id, setValues, incValues, unsetValues)
+		if err != nil {
+			return err
+		}
+		if err := s.createRevisions(ctx, storage.RevisionTarget{ThreadId: id}, update.Revisions(before.thread(), before.NumEdits)); err != nil {
+			return err
+		}
+		return s.emit(ctx, update.Events(id)...)
+	})
+}
+
+func (s *threads) Delete(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		result, err := s.collection().DeleteOne(ctx, bson.M{"_id": id})
+		if err != nil {
+			return err
+		}
+		if result.DeletedCount == 0 {
+			return storage.ErrNotFound
+		}
+		if _, err := s.db.Collection("poll_votes").DeleteMany(ctx, bson.M{"thread_id": id}); err != nil {
+			return err
+		}
+		if err := s.deleteRevisions(ctx, bson.M{"thread_id": id}); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_THREAD_DELETED, id))
+	})
+}
+
+func (s *threads) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		if err := remove(ctx, s.collection(), id, removalType, reason, removedAt); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_THREAD_REMOVED, id))
+	})
+}
+
+func (s *threads) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		if err := restore(ctx, s.collection(), id, removedAfter); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_THREAD_RESTORED, id))
+	})
+}
+
+func (s *threads) Publish(ctx context.Context, id string, publishedAt time.Time) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		// a draft is published once even when several schedulers pick it up
+		filter := bson.M{"_id": id, "draft": true}
+		update := bson.M{"$set": bson.M{"created_at": publishedAt}, "$unset": bson.M{"draft": "", "publish_at": ""}}
+		result, err := s.collection().UpdateOne(ctx, filter, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return existsOr(ctx, s.collection(), id, storage.ErrFailedPrecondition)
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_THREAD_PUBLISHED, id))
+	})
+}
+
+func (s *threads) CreateAndIncrement(ctx context.Context, thread *models.Thread) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		offset := 0
+		if storage.Counted(thread) {
+			offset = 1
+		}
+		result, err := s.db.Collection("communities").UpdateOne(ctx, bson.M{"_id": thread.GetCommunityId()}, bson.M{"$inc": bson.M{"num_threads": offset}})
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return storage.ErrNotFound
+		}
+		// crossposts are counted, so concurrent ones write the same community document and conflict, and the one
+		// retried sees the other
+		if parentId := thread.GetCrosspostParentId(); parentId != "" {
+			count, err := s.collection().CountDocuments(ctx, bson.M{"community_id": thread.GetCommunityId(), "crosspost_parent_id": parentId}, options.Count().SetLimit(1))
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return storage.ErrAlreadyExists
+			}
+		}
+		if _, err := s.collection().InsertOne(ctx, newThreadDocument(thread)); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadCreated(thread)...)
+	})
+}
+
+func (s *threads) DeleteAndDecrement(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		doc, err := decodeOne[threadDocument](s.collection().FindOneAndDelete(ctx, bson.M{"_id": id}))
+		if err != nil {
+			return err
+		}
+		if _, err := s.db.Collection("poll_votes").DeleteMany(ctx, bson.M{"thread_id": id}); err != nil {
+			return err
+		}
+		if err := s.deleteRevisions(ctx, bson.M{"thread_id": id}); err != nil {
+			return err
+		}
+		if err := s.countThread(ctx, doc.thread(), -1); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_THREAD_DELETED, id))
+	})
+}
+
+func (s *threads) PublishAndIncrement(ctx context.Context, id string, publishedAt time.Time) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		filter := bson.M{"_id": id, "draft": true}
+		update := bson.M{"$set": bson.M{"created_at": publishedAt}, "$unset": bson.M{"draft": "", "publish_at": ""}}
+		doc, err := decodeOne[threadDocument](s.collection().FindOneAndUpdate(ctx, filter, update))
+		if errors.Is(err, storage.ErrNotFound) {
+			return existsOr(ctx, s.collection(), id, storage.ErrFailedPrecondition)
+		}
+		if err != nil {
+			return err
+		}
+		thread := doc.thread()
+		thread.Draft = false
+		if err := s.countThread(ctx, thread, 1); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_THREAD_PUBLISHED, id))
+	})
+}
+
+// countThread adds an offset to num_threads of the community of a counted thread, when the community still exists
+func (s *threads) countThread(ctx context.Context, thread *models.Thread, offset int32) error {
+	if !storage.Counted(thread) {
+		return nil
+	}
+	_, err := s.db.Collection("communities").UpdateOne(ctx, bson.M{"_id": thread.GetCommunityId()}, bson.M{"$inc": bson.M{"num_threads": offset}})
+	return err
+}
+
+func (s *threads) ListScheduled(ctx context.Context, before time.Time, limit int32) ([]*models.Thread, error) {
+	filter := bson.M{"draft": true, "publish_at": bson.M{"$lte": before}}
+	opts := options.Find().SetSort(bson.M{"publish_at": 1})
+	if limit > 0 {
+		opts.SetLimit(int64(limit))
+	}
+	cursor, err := s.collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	return decodeAll(ctx, cursor, (*threadDocument).thread)
+}
+
+func (s *threads) Pin(ctx context.Context, id string, max int32) error {
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		doc, err := decodeOne[threadDocument](s.collection().FindOne(ctx, bson.M{"_id": id}))
+		if err != nil {
+			return err
+		}
+		if doc.Pinned {
+			return nil
+		}
+
+		// pins of a community write the same counter document, so concurrent ones conflict and the one retried counts
+		// the pin of the other
+		_, err = s.db.Collection("sequences").UpdateOne(ctx, bson.M{"_id": "pins:" + doc.CommunityId}, bson.M{"$inc": bson.M{"value": int64(1)}}, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+		count, err := s.collection().CountDocuments(ctx, bson.M{"community_id": doc.CommunityId, "pinned": true})
+		if err != nil {
+			return err
+		}
+		if count >= int64(max) {
+			return storage.ErrFailedPrecondition
+		}
+		if _, err := s.collection().UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"pinned": true}}); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_THREAD_UPDATED, id))
+	})
+}
+
+func (s *threads) CastPollVote(ctx context.Context, threadId string, userId string, optionIds []string) error {
+	vote := &pollVoteDocument{
+		Id:        threadId + ":" + userId,
+		ThreadId:  threadId,
+		UserId:    userId,
+		OptionIds: optionIds,
+		CreatedAt: time.Now(),
+	}
+
+	// the vote and its count are written together, so a vote is never recorded without being counted
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		_, err := s.db.Collection("poll_votes").InsertOne(ctx, vote)
+		if mongo.IsDuplicateKeyError(err) {
+			return storage.ErrAlreadyExists
+		}
+		if err != nil {
+			return err
+		}
+
+		// count the vote on the chosen options
+		update := bson.M{"$inc": bson.M{"poll.num_voters": 1, "poll.options.$[option].num_votes": 1}}
+		updateOptions := options.Update().SetArrayFilters(options.ArrayFilters{
+			Filters: bson.A{bson.M{"option._id": bson.M{"$in": optionIds}}},
+		})
+		result, err := s.collection().UpdateOne(ctx, bson.M{"_id": threadId}, update, updateOptions)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return storage.ErrNotFound
+		}
+		return s.emit(ctx, storage.ThreadEvent(models.EventType_POLL_VOTE_CAST, threadId))
+	})
+}
+
+func (s *threads) Move(ctx context.Context, id string, from string, to string, redirect *models.Thread) error {
+	communities := s.db.Collection("communities")
+
+	// the thread, its redirect and both counters change together
+	return s.withTransaction(ctx, func(ctx mongo.SessionContext) error {
+		result, err := communities.UpdateOne(ctx, bson.M{"_id": to}, bson.M{"$inc": bson.M{"num_threads": 1}})
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return storage.ErrNotFound
+		}
+		if _, err := communities.UpdateOne(ctx, bson.M{"_id": from}, bson.M{"$inc": bson.M{"num_threads": -1}}); err != nil {
+			return err
+		}
+
+		// flairs and pins belong to the previous community
+		update := bson.M{
+			"$set":   bson.M{"community_id": to},
+			"$unset": bson.M{"pinned": "", "flair": "", "flair_color": ""},
+		}
+		result, err = s.collection().UpdateOne(ctx, bson.M{"_id": id, "community_id": from}, update)
+		if err != nil {
+			return err
+		}
+		if result.MatchedCount == 0 {
+			return storage.ErrNotFound
+		}
+		if _, err := s.db.Collection("mod_queue").UpdateMany(ctx, bson.M{"thread_id": id}, bson.M{"$set": bson.M{"community_id": to}}); err != nil {
+			return err
+		}
+
+		// redirects of earlier moves follow the thread, a redirect in the community it returns to is dropped
+		if _, err := s.collection().DeleteMany(ctx, bson.M{"redirect_thread_id": id, "community_id": to}); err != nil {
+			return err
+		}
+		if _, err := s.collection().UpdateMany(ctx, bson.M{"redirect_thread_id": id}, bson.M{"$set": bson.M{"redirect_community_id": to}}); err != nil {
+			return err
+		}
+		if _, err := s.collection().InsertOne(ctx, newThreadDocument(redirect)); err != nil {
+			return err
+		}
+		return s.emit(ctx, storage.ThreadMoved(id, to))
+	})
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type attachments struct {
+	*Storage
+}
+
+const attachmentColumns = `id, filename, content_type, size, sha256, author_id, created_at, width, height,
+	thumbnail_sha256, thumbnail_content_type, thumbnail_size`
+
+func (s *attachments) Create(ctx context.Context, attachment *models.Attachment) error {
+	query := "INSERT INTO attachments (" + attachmentColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)"
+	_, err := s.pool.Exec(ctx, query,
+		attachment.GetId(), attachment.GetFilename(), attachment.GetContentType(), attachment.GetSize(),
+		attachment.GetSha256(), attachment.GetAuthorId(), timeValue(attachment.GetCreatedAt()), attachment.GetWidth(),
+		attachment.GetHeight(), attachment.GetThumbnailSha256(), attachment.GetThumbnailContentType(), attachment.GetThumbnailSize(),
+	)
+	return err
+}
+
+func (s *attachments) Get(ctx context.Context, id string) (*models.Attachment, error) {
+	attachment := &models.Attachment{}
+	var createdAt *time.Time
+	err := s.pool.QueryRow(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE id = $1", id).Scan(
+		&attachment.Id, &attachment.Filename, &attachment.ContentType, &attachment.Size, &attachment.Sha256,
+		&attachment.AuthorId, &createdAt, &attachment.Width, &attachment.Height, &attachment.ThumbnailSha256,
+		&attachment.ThumbnailContentType, &attachment.ThumbnailSize,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	attachment.CreatedAt = timestamp(createdAt)
+	return attachment, nil
+}
@@ -0,0 +1,266 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+type comments struct {
+	*Storage
+}
+
+const commentColumns = `id, content, ups, downs, parent_id, parent_type, author_id, created_at, num_comments, num_reports,
+	spam_score, attachment_ids, edited_at, removal_type, removal_reason, removed_at, ancestor_ids, content_html`
+
+func (s *comments) List(ctx context.Context, filter storage.CommentFilter) ([]*models.Comment, error) {
+	where := clauses{}
+	if filter.ParentId != "" {
+		where.add("parent_id = ?", filter.ParentId)
+	}
+	if filter.Page.After != nil {
+		where.after(filter.Page.After, filter.SortBy)
+	}
+	query := "SELECT " + commentColumns + " FROM comments" + where.where() + orderBy(filter.SortBy) + pageClause(filter.Page)
+	return listComments(ctx, s.pool, query, where.args...)
+}
+
+func (s *comments) Create(ctx context.Context, comment *models.Comment) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		if err := insertComment(ctx, tx, comment); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	})
+}
+
+func (s *comments) Get(ctx context.Context, id string) (*models.Comment, error) {
+	comment, _, err := getComment(ctx, s.pool, id, "")
+	return comment, err
+}
+
+func (s *comments) Update(ctx context.Context, id string, update storage.CommentUpdate) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		// the row stays locked until the edit is counted, so concurrent edits record consecutive versions
+		before, numEdits, err := getComment(ctx, tx, id, " FOR UPDATE")
+		if err != nil {
+			return err
+		}
+		if err := createRevisions(ctx, tx, storage.RevisionTarget{CommentId: id}, update.Revisions(before, numEdits)); err != nil {
+			return err
+		}
+
+		set := clauses{}
+		if update.Content != nil {
+			set.add("content = ?", *update.Content)
+		}
+		if update.ContentHtml != nil {
+			set.add("content_html = ?", *update.ContentHtml)
+		}
+		addOffset(&set, "ups", update.UpsOffset)
+		addOffset(&set, "downs", update.DownsOffset)
+		addOffset(&set, "num_comments", update.NumCommentsOffset)
+		addOffset(&set, "num_reports", update.NumReportsOffset)
+		if update.SpamScore != nil {
+			set.add("spam_score = ?", *update.SpamScore)
+		}
+		if update.Edit != nil {
+			set.add("edited_at = ?", update.Edit.EditedAt)
+			set.add("num_edits = num_edits + 1")
+		}
+		if len(set.clauses) == 0 {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, "UPDATE comments SET "+set.set()+" WHERE id = "+set.arg(id), set.args...); err != nil {
+			return err
+		}
+		return emit(ctx, tx, update.Events(id)...)
+	})
+}
+
+func (s *comments) Delete(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "DELETE FROM comments WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		if err := deleteRevisions(ctx, tx, storage.RevisionTarget{CommentId: id}); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+	})
+}
+
+func (s *comments) CreateAndIncrement(ctx context.Context, comment *models.Comment) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		tag, err := countComment(ctx, tx, comment.GetParentId(), comment.GetParentType().String(), 1)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		if err := insertComment(ctx, tx, comment); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.CommentEvent(models.EventType_COMMENT_CREATED, comment.GetId()))
+	})
+}
+
+func (s *comments) DeleteAndDecrement(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		var parentId, parentType string
+		err := tx.QueryRow(ctx, "DELETE FROM comments WHERE id = $1 RETURNING parent_id, parent_type", id).Scan(&parentId, &parentType)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if _, err := countComment(ctx, tx, parentId, parentType, -1); err != nil {
+			return err
+		}
+		if err := deleteRevisions(ctx, tx, storage.RevisionTarget{CommentId: id}); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+	})
+}
+
+func (s *comments) DeleteTree(ctx context.Context, id string) (int32, error) {
+	var ids []string
+	err := s.withTransaction(ctx, func(tx pgx.Tx) error {
+		var parentId, parentType string
+		err := tx.QueryRow(ctx, "DELETE FROM comments WHERE id = $1 RETURNING parent_id, parent_type", id).Scan(&parentId, &parentType)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		rows, err := tx.Query(ctx, "DELETE FROM comments WHERE ancestor_ids @> ARRAY[$1::TEXT] RETURNING id", id)
+		if err != nil {
+			return err
+		}
+		replies, err := scanAll(rows, func(row pgx.Row) (string, error) {
+			var id string
+			err := row.Scan(&id)
+			return id, err
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := countComment(ctx, tx, parentId, parentType, -1); err != nil {
+			return err
+		}
+
+		ids = append([]string{id}, replies...)
+		if _, err := tx.Exec(ctx, "DELETE FROM revisions WHERE comment_id = ANY($1)", ids); err != nil {
+			return err
+		}
+		events := make([]*models.Event, 0, len(ids))
+		for _, id := range ids {
+			events = append(events, storage.CommentEvent(models.EventType_COMMENT_DELETED, id))
+		}
+		return emit(ctx, tx, events...)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int32(len(ids)), nil
+}
+
+func (s *comments) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		if err := remove(ctx, tx, "comments", id, removalType, reason, removedAt); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.CommentEvent(models.EventType_COMMENT_REMOVED, id))
+	})
+}
+
+func (s *comments) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		if err := restore(ctx, tx, "comments", id, removedAfter); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.CommentEvent(models.EventType_COMMENT_RESTORED, id))
+	})
+}
+
+// insertComment inserts a comment
+func insertComment(ctx context.Context, q querier, comment *models.Comment) error {
+	query := `INSERT INTO comments (` + commentColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`
+	_, err := q.Exec(ctx, query,
+		comment.GetId(), comment.GetContent(), comment.GetUps(), comment.GetDowns(), comment.GetParentId(),
+		comment.GetParentType().String(), comment.GetAuthorId(), timeValue(comment.GetCreatedAt()), comment.GetNumComments(),
+		comment.GetNumReports(), comment.GetSpamScore(), textArray(comment.GetAttachmentIds()), timeValue(comment.GetEditedAt()),
+		comment.GetRemovalType().String(), comment.GetRemovalReason(), timeValue(comment.GetRemovedAt()),
+		textArray(comment.GetAncestorIds()), comment.GetContentHtml(),
+	)
+	return err
+}
+
+// countComment adds an offset to num_comments of the parent thread or comment of a comment
+func countComment(ctx context.Context, tx pgx.Tx, parentId string, parentType string, offset int32) (pgconn.CommandTag, error) {
+	table := "threads"
+	if parentType == models.CommentParentType_COMMENT.String() {
+		table = "comments"
+	}
+	return tx.Exec(ctx, "UPDATE "+table+" SET num_comments = num_comments + $2 WHERE id = $1", parentId, offset)
+}
+
+// listComments reads the comments selected by a query
+func listComments(ctx context.Context, q querier, query string, args ...any) ([]*models.Comment, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, func(row pgx.Row) (*models.Comment, error) {
+		return scanComment(row)
+	})
+}
+
+// getComment reads a comment and the number of edits it had, lock is appended to the query
+func getComment(ctx context.Context, q querier, id string, lock string) (*models.Comment, int32, error) {
+	var numEdits int32
+	row := q.QueryRow(ctx, "SELECT "+commentColumns+", num_edits FROM comments WHERE id = $1"+lock, id)
+	comment, err := scanComment(row, &numEdits)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return comment, numEdits, nil
+}
+
+// scanComment scans the comment columns, followed by the extra columns of the query
+func scanComment(row pgx.Row, extra ...any) (*models.Comment, error) {
+	comment := &models.Comment{}
+	var createdAt, editedAt, removedAt *time.Time
+	var parentType, removalType string
+	dest := []any{
+		&comment.Id, &comment.Content, &comment.Ups, &comment.Downs, &comment.ParentId, &parentType, &comment.AuthorId,
+		&createdAt, &comment.NumComments, &comment.NumReports, &comment.SpamScore, &comment.AttachmentIds, &editedAt,
+		&removalType, &comment.RemovalReason, &removedAt, &comment.AncestorIds, &comment.ContentHtml,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	comment.ParentType = models.CommentParentType(models.CommentParentType_value[parentType])
+	comment.CreatedAt = timestamp(createdAt)
+	comment.EditedAt = timestamp(editedAt)
+	comment.RemovalType = models.RemovalType(models.RemovalType_value[removalType])
+	comment.RemovedAt = timestamp(removedAt)
+	return comment, nil
+}
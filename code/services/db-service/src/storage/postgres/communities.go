@@ -0,0 +1,198 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+type communities struct {
+	*Storage
+}
+
+const communityColumns = "id, name, num_threads, posting_rules"
+
+func (s *communities) List(ctx context.Context, filter storage.CommunityFilter) ([]*models.Community, error) {
+	where := clauses{}
+	if filter.Name != "" {
+		where.add("name ~* ?", filter.Name) // case-insensitive name match
+	}
+	if filter.Page.After != nil {
+		where.after(filter.Page.After, "")
+	}
+	query := "SELECT " + communityColumns + " FROM communities" + where.where() + orderBy("") + pageClause(filter.Page)
+	rows, err := s.pool.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	results, err := scanAll(rows, scanCommunity)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadFlairTemplates(ctx, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+func (s *communities) Create(ctx context.Context, community *models.Community) error {
+	postingRules, err := postingRulesValue(community.GetPostingRules())
+	if err != nil {
+		return err
+	}
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		query := `INSERT INTO communities (id, name, num_threads, posting_rules) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (name) DO NOTHING`
+		tag, err := tx.Exec(ctx, query, community.GetId(), community.GetName(), community.GetNumThreads(), postingRules)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrAlreadyExists
+		}
+		return emit(ctx, tx, storage.CommunityEvent(models.EventType_COMMUNITY_CREATED, community.GetId()))
+	})
+}
+
+func (s *communities) Get(ctx context.Context, id string) (*models.Community, error) {
+	row := s.pool.QueryRow(ctx, "SELECT "+communityColumns+" FROM communities WHERE id = $1", id)
+	community, err := scanCommunity(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.loadFlairTemplates(ctx, []*models.Community{community}); err != nil {
+		return nil, err
+	}
+	return community, nil
+}
+
+func (s *communities) Update(ctx context.Context, id string, update storage.CommunityUpdate) error {
+	set := clauses{}
+	if update.Name != nil {
+		set.add("name = ?", *update.Name)
+	}
+	if update.PostingRules != nil {
+		postingRules, err := postingRulesValue(update.PostingRules)
+		if err != nil {
+			return err
+		}
+		set.add("posting_rules = ?", postingRules)
+	}
+	if update.NumThreadsOffset != 0 {
+		set.add("num_threads = num_threads + ?", update.NumThreadsOffset)
+	}
+	if len(set.clauses) == 0 {
+		return existsOr(ctx, s.pool, "communities", id, nil)
+	}
+
+	query := "UPDATE communities SET " + set.set() + " WHERE id = " + set.arg(id)
+	tag, err := s.pool.Exec(ctx, query, set.args...)
+	if isUniqueViolation(err) {
+		return storage.ErrAlreadyExists
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (s *communities) Delete(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "DELETE FROM communities WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		return emit(ctx, tx, storage.CommunityEvent(models.EventType_COMMUNITY_DELETED, id))
+	})
+}
+
+func (s *communities) AddFlairTemplate(ctx context.Context, communityId string, template *models.FlairTemplate) error {
+	// flair texts are unique within a community
+	query := `INSERT INTO flair_templates (id, community_id, text, color)
+		SELECT $1, $2, $3, $4 WHERE EXISTS (SELECT 1 FROM communities WHERE id = $2)
+		ON CONFLICT (community_id, text) DO NOTHING`
+	tag, err := s.pool.Exec(ctx, query, template.GetId(), communityId, template.GetText(), template.GetColor())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return existsOr(ctx, s.pool, "communities", communityId, storage.ErrAlreadyExists)
+	}
+	return nil
+}
+
+func (s *communities) RemoveFlairTemplate(ctx context.Context, communityId string, id string) error {
+	tag, err := s.pool.Exec(ctx, "DELETE FROM flair_templates WHERE community_id = $1 AND id = $2", communityId, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+// loadFlairTemplates adds their flair templates to communities
+func (s *communities) loadFlairTemplates(ctx context.Context, communities []*models.Community) error {
+	if len(communities) == 0 {
+		return nil
+	}
+	byId := map[string]*models.Community{}
+	var ids []string
+	for _, community := range communities {
+		byId[community.Id] = community
+		ids = append(ids, community.Id)
+	}
+
+	query := "SELECT community_id, id, text, color FROM flair_templates WHERE community_id = ANY($1) ORDER BY seq"
+	rows, err := s.pool.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var communityId string
+		template := &models.FlairTemplate{}
+		if err := rows.Scan(&communityId, &template.Id, &template.Text, &template.Color); err != nil {
+			return err
+		}
+		byId[communityId].FlairTemplates = append(byId[communityId].FlairTemplates, template)
+	}
+	return rows.Err()
+}
+
+func scanCommunity(row pgx.Row) (*models.Community, error) {
+	community := &models.Community{}
+	var postingRules []byte
+	if err := row.Scan(&community.Id, &community.Name, &community.NumThreads, &postingRules); err != nil {
+		return nil, err
+	}
+	if postingRules != nil {
+		community.PostingRules = &models.PostingRules{}
+		if err := protojson.Unmarshal(postingRules, community.PostingRules); err != nil {
+			return nil, err
+		}
+	}
+	return community, nil
+}
+
+// postingRulesValue stores posting rules as json, communities without rules have none
+func postingRulesValue(rules *models.PostingRules) ([]byte, error) {
+	if rules == nil {
+		return nil, nil
+	}
+	return protojson.Marshal(rules)
+}
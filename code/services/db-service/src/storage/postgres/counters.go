@@ -0,0 +1,101 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type counters struct {
+	*Storage
+}
+
+func (s *counters) RecountThreads(ctx context.Context, communityId string, fix bool) (storage.Recount, error) {
+	query := "SELECT count(*) FROM threads WHERE community_id = $1 AND NOT draft AND kind <> 'REDIRECT'"
+	return s.recount(ctx, "communities", "num_threads", communityId, fix, query, communityId)
+}
+
+func (s *counters) RecountComments(ctx context.Context, parentId string, parentType models.CommentParentType, fix bool) (storage.Recount, error) {
+	table := "threads"
+	if parentType == models.CommentParentType_COMMENT {
+		table = "comments"
+	}
+	query := "SELECT count(*) FROM comments WHERE parent_id = $1 AND parent_type = $2"
+	return s.recount(ctx, table, "num_comments", parentId, fix, query, parentId, parentType.String())
+}
+
+// recount compares a counter column of a row with the count of a query, and stores the count when fix is set.
+// The row is locked first, so the increments of concurrent transactions either wait for the recount or are counted
+func (s *counters) recount(ctx context.Context, table string, column string, id string, fix bool, count string, args ...any) (storage.Recount, error) {
+	var recount storage.Recount
+	err := s.withTransaction(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, "SELECT "+column+" FROM "+table+" WHERE id = $1 FOR UPDATE", id).Scan(&recount.Stored)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := tx.QueryRow(ctx, count, args...).Scan(&recount.Counted); err != nil {
+			return err
+		}
+		if !fix || recount.Stored == recount.Counted {
+			return nil
+		}
+		_, err = tx.Exec(ctx, "UPDATE "+table+" SET "+column+" = $2 WHERE id = $1", id, recount.Counted)
+		return err
+	})
+	return recount, err
+}
+
+// Compare counts with one grouped query per counter, so the rows are never read out
+func (s *counters) Compare(ctx context.Context) (int32, []storage.Suspect, error) {
+	var numChecked int32
+	err := s.pool.QueryRow(ctx, "SELECT (SELECT count(*) FROM threads) + (SELECT count(*) FROM comments) + (SELECT count(*) FROM communities)").Scan(&numChecked)
+	if err != nil {
+		return 0, nil, err
+	}
+	queries := []struct {
+		query   string
+		suspect func(id string) storage.Suspect
+	}{
+		{
+			`SELECT t.id FROM threads t
+			LEFT JOIN (SELECT parent_id, count(*) AS n FROM comments WHERE parent_type = 'THREAD' GROUP BY parent_id) c ON c.parent_id = t.id
+			WHERE t.num_comments <> coalesce(c.n, 0) ORDER BY t.id`,
+			func(id string) storage.Suspect { return storage.Suspect{ThreadId: id} },
+		},
+		{
+			`SELECT p.id FROM comments p
+			LEFT JOIN (SELECT parent_id, count(*) AS n FROM comments WHERE parent_type = 'COMMENT' GROUP BY parent_id) c ON c.parent_id = p.id
+			WHERE p.num_comments <> coalesce(c.n, 0) ORDER BY p.id`,
+			func(id string) storage.Suspect { return storage.Suspect{CommentId: id} },
+		},
+		{
+			`SELECT m.id FROM communities m
+			LEFT JOIN (SELECT community_id, count(*) AS n FROM threads WHERE NOT draft AND kind <> 'REDIRECT' GROUP BY community_id) t ON t.community_id = m.id
+			WHERE m.num_threads <> coalesce(t.n, 0) ORDER BY m.id`,
+			func(id string) storage.Suspect { return storage.Suspect{CommunityId: id} },
+		},
+	}
+	var suspects []storage.Suspect
+	for _, q := range queries {
+		rows, err := s.pool.Query(ctx, q.query)
+		if err != nil {
+			return 0, nil, err
+		}
+		found, err := scanAll(rows, func(row pgx.Row) (storage.Suspect, error) {
+			var id string
+			err := row.Scan(&id)
+			return q.suspect(id), err
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+		suspects = append(suspects, found...)
+	}
+	return numChecked, suspects, nil
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// arbitrary key of the advisory lock held from appending events until commit
+const eventsLockId = 7_482_014
+
+type events struct {
+	*Storage
+}
+
+func (s *events) List(ctx context.Context, after int64, limit int32) ([]*models.Event, error) {
+	query := "SELECT id, type, community_id, thread_id, comment_id, vote, created_at FROM events WHERE id > $1 ORDER BY id" +
+		pageClause(storage.Page{Limit: limit})
+	rows, err := s.pool.Query(ctx, query, after)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, func(row pgx.Row) (*models.Event, error) {
+		event := &models.Event{}
+		var eventType string
+		var createdAt time.Time
+		if err := row.Scan(&event.Offset, &eventType, &event.CommunityId, &event.ThreadId, &event.CommentId, &event.Vote, &createdAt); err != nil {
+			return nil, err
+		}
+		event.Type = models.EventType(models.EventType_value[eventType])
+		event.CreatedAt = timestamp(&createdAt)
+		return event, nil
+	})
+}
+
+func (s *events) Prune(ctx context.Context, before time.Time) error {
+	_, err := s.pool.Exec(ctx, "DELETE FROM events WHERE created_at < $1", before)
+	return err
+}
+
+// emit appends events to the outbox in the transaction of the change they describe. Identities are drawn in the
+// order of the calls but transactions may commit in another order, which would let a reader skip an offset that is
+// not visible yet. The lock makes the appends of concurrent transactions wait for each other's commit, so it is
+// taken after every other statement of the transaction
+func emit(ctx context.Context, tx pgx.Tx, events ...*models.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", eventsLockId); err != nil {
+		return err
+	}
+	for _, event := range events {
+		query := `INSERT INTO events (type, community_id, thread_id, comment_id, vote, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+		err := tx.QueryRow(ctx, query, event.GetType().String(), event.GetCommunityId(), event.GetThreadId(),
+			event.GetCommentId(), event.GetVote(), timeValue(event.GetCreatedAt())).Scan(&event.Offset)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type jobs struct {
+	*Storage
+}
+
+const jobColumns = `id, type, target_id, state, num_threads_deleted, num_comments_deleted, attempts, error, created_at,
+	updated_at, lease_expires_at, lease_id`
+
+func (s *jobs) Create(ctx context.Context, job *models.Job) error {
+	query := "INSERT INTO jobs (" + jobColumns + ") VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)"
+	_, err := s.pool.Exec(ctx, query,
+		job.GetId(), job.GetType().String(), job.GetTargetId(), job.GetState().String(), job.GetNumThreadsDeleted(),
+		job.GetNumCommentsDeleted(), job.GetAttempts(), job.GetError(), timeValue(job.GetCreatedAt()),
+		timeValue(job.GetUpdatedAt()), timeValue(job.GetLeaseExpiresAt()), job.GetLeaseId(),
+	)
+	if isUniqueViolation(err) {
+		return storage.ErrAlreadyExists
+	}
+	return err
+}
+
+func (s *jobs) Get(ctx context.Context, id string) (*models.Job, error) {
+	job, err := scanJob(s.pool.QueryRow(ctx, "SELECT "+jobColumns+" FROM jobs WHERE id = $1", id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	return job, err
+}
+
+func (s *jobs) Claim(ctx context.Context, leaseId string, now time.Time, leaseExpiresAt time.Time) (*models.Job, error) {
+	// runners claiming together skip the job the other one locked instead of waiting for it
+	query := `UPDATE jobs SET state = 'JOB_RUNNING', lease_id = $3, lease_expires_at = $2, updated_at = $1 WHERE id = (
+			SELECT id FROM jobs
+			WHERE state IN ('JOB_PENDING', 'JOB_RUNNING') AND (lease_expires_at IS NULL OR lease_expires_at <= $1)
+			ORDER BY created_at LIMIT 1 FOR UPDATE SKIP LOCKED
+		) RETURNING ` + jobColumns
+	job, err := scanJob(s.pool.QueryRow(ctx, query, now, leaseExpiresAt, leaseId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, storage.ErrNotFound
+	}
+	return job, err
+}
+
+func (s *jobs) Update(ctx context.Context, job *models.Job) error {
+	query := `UPDATE jobs SET state = $2, num_threads_deleted = $3, num_comments_deleted = $4, attempts = $5, error = $6,
+		updated_at = $7, lease_expires_at = $8 WHERE id = $1 AND lease_id = $9`
+	tag, err := s.pool.Exec(ctx, query,
+		job.GetId(), job.GetState().String(), job.GetNumThreadsDeleted(), job.GetNumCommentsDeleted(), job.GetAttempts(),
+		job.GetError(), timeValue(job.GetUpdatedAt()), timeValue(job.GetLeaseExpiresAt()), job.GetLeaseId(),
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		// another runner claimed the job since, unless it is gone
+		return existsOr(ctx, s.pool, "jobs", job.GetId(), storage.ErrFailedPrecondition)
+	}
+	return nil
+}
+
+func scanJob(row pgx.Row) (*models.Job, error) {
+	job := &models.Job{}
+	var jobType, state string
+	var createdAt, updatedAt, leaseExpiresAt *time.Time
+	err := row.Scan(&job.Id, &jobType, &job.TargetId, &state, &job.NumThreadsDeleted, &job.NumCommentsDeleted,
+		&job.Attempts, &job.Error, &createdAt, &updatedAt, &leaseExpiresAt, &job.LeaseId)
+	if err != nil {
+		return nil, err
+	}
+	job.Type = models.JobType(models.JobType_value[jobType])
+	job.State = models.JobState(models.JobState_value[state])
+	job.CreatedAt = timestamp(createdAt)
+	job.UpdatedAt = timestamp(updatedAt)
+	job.LeaseExpiresAt = timestamp(leaseExpiresAt)
+	return job, nil
+}
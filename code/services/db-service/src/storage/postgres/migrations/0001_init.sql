@@ -0,0 +1,173 @@
+-- enums are stored by name and seq keeps the insertion order, which is the order of lists that are not sorted
+
+CREATE TABLE communities (
+    id            TEXT PRIMARY KEY,
+    seq           BIGSERIAL NOT NULL,
+    name          TEXT NOT NULL UNIQUE,
+    num_threads   INTEGER NOT NULL DEFAULT 0,
+    posting_rules JSONB
+);
+
+CREATE TABLE flair_templates (
+    id           TEXT PRIMARY KEY,
+    seq          BIGSERIAL NOT NULL,
+    community_id TEXT NOT NULL REFERENCES communities (id) ON DELETE CASCADE,
+    text         TEXT NOT NULL,
+    color        TEXT NOT NULL DEFAULT '',
+    UNIQUE (community_id, text)
+);
+
+CREATE TABLE threads (
+    id                    TEXT PRIMARY KEY,
+    seq                   BIGSERIAL NOT NULL,
+    community_id          TEXT NOT NULL,
+    title                 TEXT NOT NULL DEFAULT '',
+    content               TEXT NOT NULL DEFAULT '',
+    content_html          TEXT NOT NULL DEFAULT '',
+    author_id             TEXT NOT NULL DEFAULT '',
+    created_at            TIMESTAMPTZ,
+    ups                   INTEGER NOT NULL DEFAULT 0,
+    downs                 INTEGER NOT NULL DEFAULT 0,
+    num_comments          INTEGER NOT NULL DEFAULT 0,
+    num_reports           INTEGER NOT NULL DEFAULT 0,
+    locked                BOOLEAN NOT NULL DEFAULT FALSE,
+    flair                 TEXT NOT NULL DEFAULT '',
+    flair_color           TEXT NOT NULL DEFAULT '',
+    spam_score            DOUBLE PRECISION NOT NULL DEFAULT 0,
+    pinned                BOOLEAN NOT NULL DEFAULT FALSE,
+    tags                  TEXT[] NOT NULL DEFAULT '{}',
+    kind                  TEXT NOT NULL DEFAULT 'TEXT',
+    url                   TEXT NOT NULL DEFAULT '',
+    domain                TEXT NOT NULL DEFAULT '',
+    attachment_id         TEXT NOT NULL DEFAULT '',
+    thumbnail_url         TEXT NOT NULL DEFAULT '',
+    edited_at             TIMESTAMPTZ,
+    num_edits             INTEGER NOT NULL DEFAULT 0,
+    draft                 BOOLEAN NOT NULL DEFAULT FALSE,
+    publish_at            TIMESTAMPTZ,
+    crosspost_parent_id   TEXT NOT NULL DEFAULT '',
+    redirect_thread_id    TEXT NOT NULL DEFAULT '',
+    redirect_community_id TEXT NOT NULL DEFAULT '',
+    removal_type          TEXT NOT NULL DEFAULT 'NOT_REMOVED',
+    removal_reason        TEXT NOT NULL DEFAULT '',
+    removed_at            TIMESTAMPTZ
+);
+
+CREATE INDEX threads_community_id_idx ON threads (community_id, pinned DESC, seq);
+CREATE INDEX threads_author_id_idx ON threads (author_id);
+CREATE INDEX threads_ups_idx ON threads (ups DESC);
+CREATE INDEX threads_created_at_idx ON threads (created_at DESC);
+CREATE INDEX threads_tags_idx ON threads USING GIN (tags);
+CREATE INDEX threads_crosspost_parent_id_idx ON threads (crosspost_parent_id) WHERE crosspost_parent_id <> '';
+CREATE INDEX threads_redirect_thread_id_idx ON threads (redirect_thread_id) WHERE redirect_thread_id <> '';
+CREATE INDEX threads_publish_at_idx ON threads (publish_at) WHERE draft;
+
+CREATE TABLE polls (
+    thread_id       TEXT PRIMARY KEY REFERENCES threads (id) ON DELETE CASCADE,
+    ends_at         TIMESTAMPTZ,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    hide_results    BOOLEAN NOT NULL DEFAULT FALSE,
+    num_voters      INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE poll_options (
+    id        TEXT PRIMARY KEY,
+    thread_id TEXT NOT NULL REFERENCES polls (thread_id) ON DELETE CASCADE,
+    position  INTEGER NOT NULL,
+    text      TEXT NOT NULL,
+    num_votes INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX poll_options_thread_id_idx ON poll_options (thread_id, position);
+
+-- users vote once per poll
+CREATE TABLE poll_votes (
+    thread_id  TEXT NOT NULL REFERENCES threads (id) ON DELETE CASCADE,
+    user_id    TEXT NOT NULL,
+    option_ids TEXT[] NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (thread_id, user_id)
+);
+
+CREATE TABLE comments (
+    id             TEXT PRIMARY KEY,
+    seq            BIGSERIAL NOT NULL,
+    content        TEXT NOT NULL DEFAULT '',
+    content_html   TEXT NOT NULL DEFAULT '',
+    ups            INTEGER NOT NULL DEFAULT 0,
+    downs          INTEGER NOT NULL DEFAULT 0,
+    parent_id      TEXT NOT NULL,
+    parent_type    TEXT NOT NULL,
+    author_id      TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ,
+    num_comments   INTEGER NOT NULL DEFAULT 0,
+    num_reports    INTEGER NOT NULL DEFAULT 0,
+    spam_score     DOUBLE PRECISION NOT NULL DEFAULT 0,
+    attachment_ids TEXT[] NOT NULL DEFAULT '{}',
+    edited_at      TIMESTAMPTZ,
+    num_edits      INTEGER NOT NULL DEFAULT 0,
+    removal_type   TEXT NOT NULL DEFAULT 'NOT_REMOVED',
+    removal_reason TEXT NOT NULL DEFAULT '',
+    removed_at     TIMESTAMPTZ
+);
+
+CREATE INDEX comments_parent_id_idx ON comments (parent_id, seq);
+CREATE INDEX comments_author_id_idx ON comments (author_id);
+CREATE INDEX comments_ups_idx ON comments (ups DESC);
+
+-- revisions belong to either a thread or a comment, and are recorded in the transaction of the edit, so a version
+-- is recorded once
+CREATE TABLE revisions (
+    id         BIGSERIAL PRIMARY KEY,
+    thread_id  TEXT NOT NULL DEFAULT '',
+    comment_id TEXT NOT NULL DEFAULT '',
+    version    INTEGER NOT NULL,
+    title      TEXT NOT NULL DEFAULT '',
+    content    TEXT NOT NULL DEFAULT '',
+    editor_id  TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX revisions_thread_id_idx ON revisions (thread_id, version) WHERE thread_id <> '';
+CREATE UNIQUE INDEX revisions_comment_id_idx ON revisions (comment_id, version) WHERE comment_id <> '';
+
+CREATE TABLE attachments (
+    id                     TEXT PRIMARY KEY,
+    filename               TEXT NOT NULL,
+    content_type           TEXT NOT NULL,
+    size                   BIGINT NOT NULL,
+    sha256                 TEXT NOT NULL,
+    author_id              TEXT NOT NULL DEFAULT '',
+    created_at             TIMESTAMPTZ,
+    width                  INTEGER NOT NULL DEFAULT 0,
+    height                 INTEGER NOT NULL DEFAULT 0,
+    thumbnail_sha256       TEXT NOT NULL DEFAULT '',
+    thumbnail_content_type TEXT NOT NULL DEFAULT '',
+    thumbnail_size         BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE automod_configs (
+    community_id TEXT PRIMARY KEY,
+    config       TEXT NOT NULL
+);
+
+CREATE TABLE mod_queue (
+    id           TEXT PRIMARY KEY,
+    seq          BIGSERIAL NOT NULL,
+    community_id TEXT NOT NULL,
+    thread_id    TEXT NOT NULL DEFAULT '',
+    comment_id   TEXT NOT NULL DEFAULT '',
+    rule         TEXT NOT NULL DEFAULT '',
+    reason       TEXT NOT NULL DEFAULT '',
+    dry_run      BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at   TIMESTAMPTZ
+);
+
+CREATE INDEX mod_queue_community_id_idx ON mod_queue (community_id, created_at DESC);
+CREATE INDEX mod_queue_thread_id_idx ON mod_queue (thread_id);
+
+CREATE TABLE spam_models (
+    id         TEXT PRIMARY KEY,
+    model      BYTEA NOT NULL,
+    trained_at TIMESTAMPTZ NOT NULL
+);
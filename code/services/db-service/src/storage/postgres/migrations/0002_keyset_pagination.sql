@@ -0,0 +1,13 @@
+-- lists are ordered by id instead of seq after their sort column, so a page continues after the sort value and id
+-- of the last row of the previous page instead of skipping rows
+DROP INDEX threads_community_id_idx;
+CREATE INDEX threads_community_id_idx ON threads (community_id, pinned DESC, id);
+DROP INDEX threads_ups_idx;
+CREATE INDEX threads_ups_idx ON threads (ups DESC NULLS LAST, id);
+DROP INDEX threads_created_at_idx;
+CREATE INDEX threads_created_at_idx ON threads (created_at DESC NULLS LAST, id);
+
+DROP INDEX comments_parent_id_idx;
+CREATE INDEX comments_parent_id_idx ON comments (parent_id, id);
+DROP INDEX comments_ups_idx;
+CREATE INDEX comments_ups_idx ON comments (ups DESC NULLS LAST, id);
@@ -0,0 +1,13 @@
+-- the outbox of the events written with the changes they describe, id is the offset of an event. Writers append
+-- under an advisory lock held until they commit, so ids become visible in increasing order
+CREATE TABLE events (
+    id           BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    type         TEXT NOT NULL,
+    community_id TEXT NOT NULL DEFAULT '',
+    thread_id    TEXT NOT NULL DEFAULT '',
+    comment_id   TEXT NOT NULL DEFAULT '',
+    vote         INTEGER NOT NULL DEFAULT 0,
+    created_at   TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX events_created_at_idx ON events (created_at);
@@ -0,0 +1,19 @@
+-- the background jobs of db-service, runners claim the oldest unfinished job whose lease expired. A job is only
+-- updated by the runner holding its lease, so a runner whose lease expired no longer overwrites the progress of the
+-- runner that claimed the job after it
+CREATE TABLE jobs (
+    id                   TEXT PRIMARY KEY,
+    type                 TEXT NOT NULL,
+    target_id            TEXT NOT NULL,
+    state                TEXT NOT NULL,
+    num_threads_deleted  INTEGER NOT NULL DEFAULT 0,
+    num_comments_deleted INTEGER NOT NULL DEFAULT 0,
+    attempts             INTEGER NOT NULL DEFAULT 0,
+    error                TEXT NOT NULL DEFAULT '',
+    created_at           TIMESTAMPTZ NOT NULL,
+    updated_at           TIMESTAMPTZ NOT NULL,
+    lease_id             TEXT NOT NULL DEFAULT '',
+    lease_expires_at     TIMESTAMPTZ
+);
+
+CREATE INDEX jobs_unfinished_idx ON jobs (created_at) WHERE state IN ('JOB_PENDING', 'JOB_RUNNING');
@@ -0,0 +1,16 @@
+-- the comments a comment replies to, from the top-level comment down, so a comment is deleted with its replies in
+-- one statement
+ALTER TABLE comments ADD COLUMN ancestor_ids TEXT[] NOT NULL DEFAULT '{}';
+
+-- replies created before get the path of their parent
+WITH RECURSIVE paths (id, ancestor_ids) AS (
+    SELECT id, ARRAY[]::TEXT[] FROM comments WHERE parent_type = 'THREAD'
+    UNION ALL
+    SELECT comments.id, paths.ancestor_ids || paths.id
+    FROM comments JOIN paths ON comments.parent_id = paths.id
+    WHERE comments.parent_type = 'COMMENT'
+)
+UPDATE comments SET ancestor_ids = paths.ancestor_ids
+FROM paths WHERE comments.id = paths.id AND cardinality(paths.ancestor_ids) > 0;
+
+CREATE INDEX comments_ancestor_ids_idx ON comments USING GIN (ancestor_ids);
@@ -0,0 +1,9 @@
+-- the automod rules that acted on a thread or comment, so evaluating it again does not repeat their actions
+CREATE TABLE automod_actions (
+    thread_id  TEXT NOT NULL,
+    comment_id TEXT NOT NULL DEFAULT '',
+    rule       TEXT NOT NULL,
+    dry_run    BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (thread_id, comment_id, rule, dry_run)
+);
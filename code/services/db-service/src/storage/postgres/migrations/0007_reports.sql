@@ -0,0 +1,8 @@
+-- the users that reported a thread or comment, each user reports a target once
+CREATE TABLE reports (
+    thread_id   TEXT NOT NULL DEFAULT '',
+    comment_id  TEXT NOT NULL DEFAULT '',
+    reporter_id TEXT NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (thread_id, comment_id, reporter_id)
+);
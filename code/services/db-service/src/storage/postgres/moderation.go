@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// the spam classifier is shared by all communities
+const spamModelId = "default"
+
+type moderation struct {
+	*Storage
+}
+
+func (s *moderation) AuthorStats(ctx context.Context, authorId string) (int32, *time.Time, error) {
+	// karma and first activity are aggregated over both threads and comments
+	query := `SELECT COALESCE(SUM(ups - downs), 0)::INTEGER, MIN(created_at) FROM (
+			SELECT ups, downs, created_at FROM threads WHERE author_id = $1
+			UNION ALL
+			SELECT ups, downs, created_at FROM comments WHERE author_id = $1
+		) AS activity`
+	var karma int32
+	var firstSeen *time.Time
+	if err := s.pool.QueryRow(ctx, query, authorId).Scan(&karma, &firstSeen); err != nil {
+		return 0, nil, err
+	}
+	return karma, firstSeen, nil
+}
+
+func (s *moderation) GetAutomodConfig(ctx context.Context, communityId string) (string, error) {
+	var config string
+	err := s.pool.QueryRow(ctx, "SELECT config FROM automod_configs WHERE community_id = $1", communityId).Scan(&config)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+	return config, err
+}
+
+func (s *moderation) SetAutomodConfig(ctx context.Context, communityId string, config string) error {
+	query := `INSERT INTO automod_configs (community_id, config) VALUES ($1, $2)
+		ON CONFLICT (community_id) DO UPDATE SET config = EXCLUDED.config`
+	_, err := s.pool.Exec(ctx, query, communityId, config)
+	return err
+}
+
+func (s *moderation) ListModQueue(ctx context.Context, communityId string, page storage.Page) ([]*models.ModQueueItem, error) {
+	where := clauses{}
+	if communityId != "" {
+		where.add("community_id = ?", communityId)
+	}
+	query := `SELECT id, community_id, thread_id, comment_id, rule, reason, dry_run, created_at FROM mod_queue` +
+		where.where() + orderBy("created_at") + pageClause(page)
+	rows, err := s.pool.Query(ctx, query, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, func(row pgx.Row) (*models.ModQueueItem, error) {
+		item := &models.ModQueueItem{}
+		var createdAt *time.Time
+		err := row.Scan(&item.Id, &item.CommunityId, &item.ThreadId, &item.CommentId, &item.Rule, &item.Reason, &item.DryRun, &createdAt)
+		if err != nil {
+			return nil, err
+		}
+		item.CreatedAt = timestamp(createdAt)
+		return item, nil
+	})
+}
+
+func (s *moderation) CreateModQueueItem(ctx context.Context, item *models.ModQueueItem) error {
+	query := `INSERT INTO mod_queue (id, community_id, thread_id, comment_id, rule, reason, dry_run, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	_, err := s.pool.Exec(ctx, query, item.GetId(), item.GetCommunityId(), item.GetThreadId(), item.GetCommentId(),
+		item.GetRule(), item.GetReason(), item.GetDryRun(), timeValue(item.GetCreatedAt()))
+	return err
+}
+
+func (s *moderation) DeleteModQueueItem(ctx context.Context, id string) error {
+	tag, err := s.pool.Exec(ctx, "DELETE FROM mod_queue WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrNotFound
+	}
+	return nil
+}
+
+func (s *moderation) RecordAutomodAction(ctx context.Context, action storage.AutomodAction) error {
+	query := `INSERT INTO automod_actions (thread_id, comment_id, rule, dry_run, created_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (thread_id, comment_id, rule, dry_run) DO NOTHING`
+	tag, err := s.pool.Exec(ctx, query, action.ThreadId, action.CommentId, action.Rule, action.DryRun, time.Now())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return storage.ErrAlreadyExists
+	}
+	return nil
+}
+
+func (s *moderation) CreateReport(ctx context.Context, report storage.Report) error {
+	table, id := "threads", report.ThreadId
+	if report.CommentId != "" {
+		table, id = "comments", report.CommentId
+	}
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		// the report is unique per user and target, a second report fails to insert
+		query := `INSERT INTO reports (thread_id, comment_id, reporter_id, created_at) VALUES ($1, $2, $3, $4)
+			ON CONFLICT (thread_id, comment_id, reporter_id) DO NOTHING`
+		tag, err := tx.Exec(ctx, query, report.ThreadId, report.CommentId, report.ReporterId, time.Now())
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrAlreadyExists
+		}
+
+		tag, err = tx.Exec(ctx, "UPDATE "+table+" SET num_reports = num_reports + 1 WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// content deleted by its author says nothing about spam
+const spamSampleCondition = " WHERE removal_type <> 'DELETED'"
+
+func (s *moderation) ListSpamThreads(ctx context.Context, page storage.Page) ([]*models.Thread, error) {
+	query := "SELECT " + threadColumns + " FROM threads" + spamSampleCondition + orderBy("_id") + pageClause(page)
+	return listThreads(ctx, s.pool, query)
+}
+
+func (s *moderation) ListSpamComments(ctx context.Context, page storage.Page) ([]*models.Comment, error) {
+	query := "SELECT " + commentColumns + " FROM comments" + spamSampleCondition + orderBy("_id") + pageClause(page)
+	return listComments(ctx, s.pool, query)
+}
+
+func (s *moderation) GetSpamModel(ctx context.Context) ([]byte, *time.Time, error) {
+	var model []byte
+	var trainedAt time.Time
+	err := s.pool.QueryRow(ctx, "SELECT model, trained_at FROM spam_models WHERE id = $1", spamModelId).Scan(&model, &trainedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return model, &trainedAt, nil
+}
+
+func (s *moderation) SetSpamModel(ctx context.Context, model []byte, trainedAt time.Time) error {
+	query := `INSERT INTO spam_models (id, model, trained_at) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET model = EXCLUDED.model, trained_at = EXCLUDED.trained_at`
+	if model == nil {
+		model = []byte{}
+	}
+	_, err := s.pool.Exec(ctx, query, spamModelId, model, trainedAt)
+	return err
+}
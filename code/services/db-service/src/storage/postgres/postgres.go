@@ -0,0 +1,278 @@
+// Package postgres stores the documents of db-service in PostgreSQL.
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// arbitrary key of the advisory lock held while migrating
+const migrationLockId = 7_482_013
+
+// Storage keeps every kind of document in its own table of a database
+type Storage struct {
+	pool *pgxpool.Pool
+}
+
+func New(pool *pgxpool.Pool) *Storage {
+	return &Storage{pool: pool}
+}
+
+func (s *Storage) Communities() storage.CommunityRepository {
+	return &communities{s}
+}
+
+func (s *Storage) Threads() storage.ThreadRepository {
+	return &threads{s}
+}
+
+func (s *Storage) Comments() storage.CommentRepository {
+	return &comments{s}
+}
+
+func (s *Storage) Revisions() storage.RevisionRepository {
+	return &revisions{s}
+}
+
+func (s *Storage) Attachments() storage.AttachmentRepository {
+	return &attachments{s}
+}
+
+func (s *Storage) Moderation() storage.ModerationRepository {
+	return &moderation{s}
+}
+
+func (s *Storage) Counters() storage.CounterRepository {
+	return &counters{s}
+}
+
+func (s *Storage) Events() storage.EventRepository {
+	return &events{s}
+}
+
+func (s *Storage) Jobs() storage.JobRepository {
+	return &jobs{s}
+}
+
+// Migrate applies the embedded migrations that are not applied yet, in the order of their file names.
+// Replicas starting together wait for the first one to migrate
+func Migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1)", migrationLockId); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    TEXT PRIMARY KEY,
+			applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+		)`)
+		if err != nil {
+			return err
+		}
+
+		entries, err := fs.ReadDir(migrations, "migrations")
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			version := strings.TrimSuffix(entry.Name(), ".sql")
+			var applied bool
+			err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied)
+			if err != nil {
+				return err
+			}
+			if applied {
+				continue
+			}
+
+			migration, err := migrations.ReadFile("migrations/" + entry.Name())
+			if err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, string(migration)); err != nil {
+				return fmt.Errorf("migration %s: %w", version, err)
+			}
+			if _, err := tx.Exec(ctx, "INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// querier runs statements on the pool or in a transaction
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// withTransaction runs fn in a transaction, which is rolled back when fn fails
+func (s *Storage) withTransaction(ctx context.Context, fn func(tx pgx.Tx) error) error {
+	return pgx.BeginFunc(ctx, s.pool, fn)
+}
+
+// clauses builds the conditions or assignments of a statement, ? stands for the argument of a clause
+type clauses struct {
+	clauses []string
+	args    []any
+}
+
+func (c *clauses) add(clause string, args ...any) {
+	for _, arg := range args {
+		c.args = append(c.args, arg)
+		clause = strings.Replace(clause, "?", fmt.Sprintf("$%d", len(c.args)), 1)
+	}
+	c.clauses = append(c.clauses, clause)
+}
+
+// arg adds an argument that is not part of a clause and returns its placeholder
+func (c *clauses) arg(value any) string {
+	c.args = append(c.args, value)
+	return fmt.Sprintf("$%d", len(c.args))
+}
+
+func (c *clauses) where() string {
+	if len(c.clauses) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.clauses, " AND ")
+}
+
+func (c *clauses) set() string {
+	return strings.Join(c.clauses, ", ")
+}
+
+// columns of threads and comments lists can be sorted by, other fields sort by id like documents missing the
+// field in MongoDB
+var sortColumns = map[string]bool{
+	"ups":          true,
+	"downs":        true,
+	"created_at":   true,
+	"num_comments": true,
+	"num_reports":  true,
+	"spam_score":   true,
+	"edited_at":    true,
+	"title":        true,
+}
+
+// orderBy sorts by the first orderings and then by a column in descending order and by id, _id sorts newest first
+func orderBy(sortBy string, first ...string) string {
+	switch {
+	case sortBy == "_id":
+		first = append(first, "id DESC")
+	case sortColumns[sortBy]:
+		first = append(first, sortBy+" DESC NULLS LAST", "id")
+	default:
+		first = append(first, "id")
+	}
+	return " ORDER BY " + strings.Join(first, ", ")
+}
+
+// after selects the rows listed after a cursor in the order of orderBy, pinned threads are listed first when the
+// cursor has a pinned state
+func (c *clauses) after(cursor *storage.Cursor, sortBy string) {
+	var clause string
+	switch {
+	case sortBy == "_id":
+		clause = "id < " + c.arg(cursor.Id)
+	case !sortColumns[sortBy]:
+		clause = "id > " + c.arg(cursor.Id)
+	case cursor.Value == nil:
+		// rows without the column are listed last
+		clause = sortBy + " IS NULL AND id > " + c.arg(cursor.Id)
+	default:
+		value := c.arg(cursor.Value)
+		clause = fmt.Sprintf("(%s < %s OR %s = %s AND id > %s OR %s IS NULL)", sortBy, value, sortBy, value, c.arg(cursor.Id), sortBy)
+	}
+	if cursor.Pinned != nil {
+		pinned := c.arg(*cursor.Pinned)
+		clause = fmt.Sprintf("(pinned < %s OR pinned = %s AND %s)", pinned, pinned, clause)
+	}
+	c.clauses = append(c.clauses, clause)
+}
+
+// pageClause selects the rows of a page
+func pageClause(page storage.Page) string {
+	clause := ""
+	if page.Offset > 0 {
+		clause += fmt.Sprintf(" OFFSET %d", page.Offset)
+	}
+	if page.Limit > 0 {
+		clause += fmt.Sprintf(" LIMIT %d", page.Limit)
+	}
+	return clause
+}
+
+// scanAll scans every row with scan
+func scanAll[T any](rows pgx.Rows, scan func(row pgx.Row) (T, error)) ([]T, error) {
+	defer rows.Close()
+
+	var results []T
+	for rows.Next() {
+		result, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// existsOr tells why a statement matched no row of a table, ErrNotFound without the row and err otherwise
+func existsOr(ctx context.Context, q querier, table string, id string, err error) error {
+	var exists bool
+	if scanErr := q.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1)", id).Scan(&exists); scanErr != nil {
+		return scanErr
+	}
+	if !exists {
+		return storage.ErrNotFound
+	}
+	return err
+}
+
+// isUniqueViolation reports whether a statement failed on a unique constraint
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+// timeValue stores missing timestamps as null
+func timeValue(t *timestamppb.Timestamp) *time.Time {
+	if t == nil {
+		return nil
+	}
+	value := t.AsTime()
+	return &value
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+// textArray stores missing lists as empty arrays
+func textArray(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
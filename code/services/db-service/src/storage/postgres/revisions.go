@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type revisions struct {
+	*Storage
+}
+
+// revisionFilter matches the revisions of a thread or comment
+func revisionFilter(target storage.RevisionTarget) (string, string) {
+	if target.ThreadId != "" {
+		return "thread_id = $1", target.ThreadId
+	}
+	return "comment_id = $1", target.CommentId
+}
+
+func createRevisions(ctx context.Context, tx pgx.Tx, target storage.RevisionTarget, revisions []*models.Revision) error {
+	for _, revision := range revisions {
+		query := `INSERT INTO revisions (thread_id, comment_id, version, title, content, editor_id, created_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`
+		_, err := tx.Exec(ctx, query, target.ThreadId, target.CommentId, revision.GetVersion(), revision.GetTitle(),
+			revision.GetContent(), revision.GetEditorId(), timeValue(revision.GetCreatedAt()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *revisions) List(ctx context.Context, target storage.RevisionTarget) ([]*models.Revision, error) {
+	condition, id := revisionFilter(target)
+	query := "SELECT version, title, content, editor_id, created_at FROM revisions WHERE " + condition + " ORDER BY version"
+	rows, err := s.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	return scanAll(rows, func(row pgx.Row) (*models.Revision, error) {
+		revision := &models.Revision{}
+		var createdAt *time.Time
+		if err := row.Scan(&revision.Version, &revision.Title, &revision.Content, &revision.EditorId, &createdAt); err != nil {
+			return nil, err
+		}
+		revision.CreatedAt = timestamp(createdAt)
+		return revision, nil
+	})
+}
+
+func deleteRevisions(ctx context.Context, tx pgx.Tx, target storage.RevisionTarget) error {
+	condition, id := revisionFilter(target)
+	_, err := tx.Exec(ctx, "DELETE FROM revisions WHERE "+condition, id)
+	return err
+}
@@ -0,0 +1,556 @@
+package postgres
+
+import (
+	"context"
+	"db-service/src/storage"
+	"errors"
+	models "gen/models/pb"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type threads struct {
+	*Storage
+}
+
+const threadColumns = `id, community_id, title, content, author_id, created_at, ups, downs, num_comments, num_reports,
+	locked, flair, flair_color, spam_score, pinned, tags, kind, url, domain, attachment_id, thumbnail_url, edited_at,
+	draft, publish_at, crosspost_parent_id, redirect_thread_id, redirect_community_id,
+	removal_type, removal_reason, removed_at, content_html`
+
+// arbitrary class of the advisory locks held while pinning, keyed by community
+const pinsLockClass = 7_482_015
+
+func (s *threads) List(ctx context.Context, filter storage.ThreadFilter) ([]*models.Thread, error) {
+	where := clauses{}
+	if filter.CommunityId != "" {
+		where.add("community_id = ?", filter.CommunityId)
+	}
+	if filter.Title != "" {
+		where.add("title ~* ?", filter.Title) // case-insensitive title match
+	}
+	if filter.Pinned != nil {
+		where.add("pinned = ?", *filter.Pinned)
+	}
+	if filter.Flair != nil {
+		where.add("flair = ?", *filter.Flair)
+	}
+	if filter.Tag != nil {
+		where.add("? = ANY(tags)", *filter.Tag)
+	}
+	if filter.AuthorId != nil {
+		where.add("author_id = ?", *filter.AuthorId)
+	}
+	if filter.CrosspostParentId != nil {
+		where.add("crosspost_parent_id = ?", *filter.CrosspostParentId)
+	}
+	where.add("draft = ?", filter.Drafts)
+	if filter.Page.After != nil {
+		where.after(filter.Page.After, filter.SortBy)
+	}
+
+	// pinned threads are listed first within a community
+	var first []string
+	if filter.CommunityId != "" {
+		first = append(first, "pinned DESC")
+	}
+
+	query := "SELECT " + threadColumns + " FROM threads" + where.where() + orderBy(filter.SortBy, first...) + pageClause(filter.Page)
+	return listThreads(ctx, s.pool, query, where.args...)
+}
+
+func (s *threads) Create(ctx context.Context, thread *models.Thread) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		if err := insertThread(ctx, tx, thread); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadCreated(thread)...)
+	})
+}
+
+func (s *threads) Get(ctx context.Context, id string) (*models.Thread, error) {
+	thread, _, err := getThread(ctx, s.pool, id, "")
+	return thread, err
+}
+
+func (s *threads) Update(ctx context.Context, id string, update storage.ThreadUpdate) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		// the row stays locked until the edit is counted, so concurrent edits record consecutive versions
+		before, numEdits, err := getThread(ctx, tx, id, " FOR UPDATE")
+		if err != nil {
+			return err
+		}
+		if err := createRevisions(ctx, tx, storage.RevisionTarget{ThreadId: id}, update.Revisions(before, numEdits)); err != nil {
+			return err
+		}
+
+		set := clauses{}
+		if update.Title != nil {
+			set.add("title = ?", *update.Title)
+		}
+		if update.Content != nil {
+			set.add("content = ?", *update.Content)
+		}
+		if update.ContentHtml != nil {
+			set.add("content_html = ?", *update.ContentHtml)
+		}
+		addOffset(&set, "ups", update.UpsOffset)
+		addOffset(&set, "downs", update.DownsOffset)
+		addOffset(&set, "num_comments", update.NumCommentsOffset)
+		addOffset(&set, "num_reports", update.NumReportsOffset)
+		if update.Locked != nil {
+			set.add("locked = ?", *update.Locked)
+		}
+		if update.Flair != nil {
+			set.add("flair = ?", *update.Flair)
+		}
+		if update.SpamScore != nil {
+			set.add("spam_score = ?", *update.SpamScore)
+		}
+		if update.Pinned != nil {
+			set.add("pinned = ?", *update.Pinned)
+		}
+		if update.FlairColor != nil {
+			set.add("flair_color = ?", *update.FlairColor)
+		}
+		if update.Tags != nil {
+			set.add("tags = ?", textArray(*update.Tags))
+		}
+		if update.PublishAt != nil {
+			set.add("publish_at = ?", *update.PublishAt)
+		}
+		if update.Edit != nil {
+			set.add("edited_at = ?", update.Edit.EditedAt)
+			set.add("num_edits = num_edits + 1")
+		}
+		if len(set.clauses) == 0 {
+			return nil
+		}
+		if _, err := tx.Exec(ctx, "UPDATE threads SET "+set.set()+" WHERE id = "+set.arg(id), set.args...); err != nil {
+			return err
+		}
+		return emit(ctx, tx, update.Events(id)...)
+	})
+}
+
+func (s *threads) Delete(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		// the poll and its votes are deleted with the thread
+		tag, err := tx.Exec(ctx, "DELETE FROM threads WHERE id = $1", id)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		if err := deleteRevisions(ctx, tx, storage.RevisionTarget{ThreadId: id}); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_THREAD_DELETED, id))
+	})
+}
+
+func (s *threads) Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		if err := remove(ctx, tx, "threads", id, removalType, reason, removedAt); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_THREAD_REMOVED, id))
+	})
+}
+
+func (s *threads) Restore(ctx context.Context, id string, removedAfter time.Time) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		if err := restore(ctx, tx, "threads", id, removedAfter); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_THREAD_RESTORED, id))
+	})
+}
+
+func (s *threads) Publish(ctx context.Context, id string, publishedAt time.Time) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		// a draft is published once even when several schedulers pick it up
+		query := "UPDATE threads SET draft = FALSE, publish_at = NULL, created_at = $2 WHERE id = $1 AND draft"
+		tag, err := tx.Exec(ctx, query, id, publishedAt)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return existsOr(ctx, tx, "threads", id, storage.ErrFailedPrecondition)
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_THREAD_PUBLISHED, id))
+	})
+}
+
+func (s *threads) CreateAndIncrement(ctx context.Context, thread *models.Thread) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		offset := 0
+		if storage.Counted(thread) {
+			offset = 1
+		}
+		tag, err := tx.Exec(ctx, "UPDATE communities SET num_threads = num_threads + $2 WHERE id = $1", thread.GetCommunityId(), offset)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		// threads of a community are created one at a time while its row is locked, so the check sees every
+		// crosspost committed before
+		if parentId := thread.GetCrosspostParentId(); parentId != "" {
+			var crossposted bool
+			query := "SELECT EXISTS (SELECT 1 FROM threads WHERE community_id = $1 AND crosspost_parent_id = $2)"
+			if err := tx.QueryRow(ctx, query, thread.GetCommunityId(), parentId).Scan(&crossposted); err != nil {
+				return err
+			}
+			if crossposted {
+				return storage.ErrAlreadyExists
+			}
+		}
+		if err := insertThread(ctx, tx, thread); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadCreated(thread)...)
+	})
+}
+
+func (s *threads) DeleteAndDecrement(ctx context.Context, id string) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		thread := &models.Thread{}
+		var kind string
+		row := tx.QueryRow(ctx, "DELETE FROM threads WHERE id = $1 RETURNING community_id, draft, kind", id)
+		err := row.Scan(&thread.CommunityId, &thread.Draft, &kind)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		thread.Kind = models.ThreadKind(models.ThreadKind_value[kind])
+		if err := countThread(ctx, tx, thread, -1); err != nil {
+			return err
+		}
+		if err := deleteRevisions(ctx, tx, storage.RevisionTarget{ThreadId: id}); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_THREAD_DELETED, id))
+	})
+}
+
+func (s *threads) PublishAndIncrement(ctx context.Context, id string, publishedAt time.Time) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		thread := &models.Thread{}
+		var kind string
+		query := "UPDATE threads SET draft = FALSE, publish_at = NULL, created_at = $2 WHERE id = $1 AND draft RETURNING community_id, kind"
+		err := tx.QueryRow(ctx, query, id, publishedAt).Scan(&thread.CommunityId, &kind)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return existsOr(ctx, tx, "threads", id, storage.ErrFailedPrecondition)
+		}
+		if err != nil {
+			return err
+		}
+		thread.Kind = models.ThreadKind(models.ThreadKind_value[kind])
+		if err := countThread(ctx, tx, thread, 1); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_THREAD_PUBLISHED, id))
+	})
+}
+
+func (s *threads) ListScheduled(ctx context.Context, before time.Time, limit int32) ([]*models.Thread, error) {
+	query := "SELECT " + threadColumns + " FROM threads WHERE draft AND publish_at <= $1 ORDER BY publish_at, seq" +
+		pageClause(storage.Page{Limit: limit})
+	return listThreads(ctx, s.pool, query, before)
+}
+
+func (s *threads) Pin(ctx context.Context, id string, max int32) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		var communityId string
+		var pinned bool
+		err := tx.QueryRow(ctx, "SELECT community_id, pinned FROM threads WHERE id = $1", id).Scan(&communityId, &pinned)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+		if err != nil || pinned {
+			return err
+		}
+
+		// pins of a community wait for each other, so each counts the pins committed before it
+		if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1, hashtext($2))", pinsLockClass, communityId); err != nil {
+			return err
+		}
+		var count int32
+		if err := tx.QueryRow(ctx, "SELECT count(*) FROM threads WHERE community_id = $1 AND pinned", communityId).Scan(&count); err != nil {
+			return err
+		}
+		if count >= max {
+			return storage.ErrFailedPrecondition
+		}
+		if _, err := tx.Exec(ctx, "UPDATE threads SET pinned = TRUE WHERE id = $1", id); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_THREAD_UPDATED, id))
+	})
+}
+
+func (s *threads) CastPollVote(ctx context.Context, threadId string, userId string, optionIds []string) error {
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		// the vote is unique per user and poll, a second vote fails to insert
+		query := `INSERT INTO poll_votes (thread_id, user_id, option_ids, created_at)
+			SELECT $1, $2, $3, $4 WHERE EXISTS (SELECT 1 FROM threads WHERE id = $1)
+			ON CONFLICT (thread_id, user_id) DO NOTHING`
+		tag, err := tx.Exec(ctx, query, threadId, userId, textArray(optionIds), time.Now())
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return existsOr(ctx, tx, "threads", threadId, storage.ErrAlreadyExists)
+		}
+
+		// count the vote on the chosen options
+		if _, err := tx.Exec(ctx, "UPDATE polls SET num_voters = num_voters + 1 WHERE thread_id = $1", threadId); err != nil {
+			return err
+		}
+		query = "UPDATE poll_options SET num_votes = num_votes + 1 WHERE thread_id = $1 AND id = ANY($2)"
+		if _, err := tx.Exec(ctx, query, threadId, textArray(optionIds)); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadEvent(models.EventType_POLL_VOTE_CAST, threadId))
+	})
+}
+
+func (s *threads) Move(ctx context.Context, id string, from string, to string, redirect *models.Thread) error {
+	// the thread, its redirect and both counters change together
+	return s.withTransaction(ctx, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, "UPDATE communities SET num_threads = num_threads + 1 WHERE id = $1", to)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		if _, err := tx.Exec(ctx, "UPDATE communities SET num_threads = num_threads - 1 WHERE id = $1", from); err != nil {
+			return err
+		}
+
+		// flairs and pins belong to the previous community
+		query := `UPDATE threads SET community_id = $3, pinned = FALSE, flair = '', flair_color = ''
+			WHERE id = $1 AND community_id = $2`
+		tag, err = tx.Exec(ctx, query, id, from, to)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return storage.ErrNotFound
+		}
+		if _, err := tx.Exec(ctx, "UPDATE mod_queue SET community_id = $2 WHERE thread_id = $1", id, to); err != nil {
+			return err
+		}
+
+		// redirects of earlier moves follow the thread, a redirect in the community it returns to is dropped
+		if _, err := tx.Exec(ctx, "DELETE FROM threads WHERE redirect_thread_id = $1 AND community_id = $2", id, to); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, "UPDATE threads SET redirect_community_id = $2 WHERE redirect_thread_id = $1", id, to); err != nil {
+			return err
+		}
+		if err := insertThread(ctx, tx, redirect); err != nil {
+			return err
+		}
+		return emit(ctx, tx, storage.ThreadMoved(id, to))
+	})
+}
+
+// countThread adds an offset to num_threads of the community of a counted thread, when the community still exists
+func countThread(ctx context.Context, tx pgx.Tx, thread *models.Thread, offset int32) error {
+	if !storage.Counted(thread) {
+		return nil
+	}
+	_, err := tx.Exec(ctx, "UPDATE communities SET num_threads = num_threads + $2 WHERE id = $1", thread.GetCommunityId(), offset)
+	return err
+}
+
+// listThreads reads the threads selected by a query with their polls
+func listThreads(ctx context.Context, q querier, query string, args ...any) ([]*models.Thread, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	results, err := scanAll(rows, func(row pgx.Row) (*models.Thread, error) {
+		return scanThread(row)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := loadPolls(ctx, q, results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// getThread reads a thread and the number of edits it had, lock is appended to the query
+func getThread(ctx context.Context, q querier, id string, lock string) (*models.Thread, int32, error) {
+	var numEdits int32
+	row := q.QueryRow(ctx, "SELECT "+threadColumns+", num_edits FROM threads WHERE id = $1"+lock, id)
+	thread, err := scanThread(row, &numEdits)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, 0, storage.ErrNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := loadPolls(ctx, q, []*models.Thread{thread}); err != nil {
+		return nil, 0, err
+	}
+	return thread, numEdits, nil
+}
+
+// insertThread inserts a thread with its poll
+func insertThread(ctx context.Context, tx pgx.Tx, thread *models.Thread) error {
+	query := `INSERT INTO threads (` + threadColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20,
+			$21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31)`
+	_, err := tx.Exec(ctx, query,
+		thread.GetId(), thread.GetCommunityId(), thread.GetTitle(), thread.GetContent(), thread.GetAuthorId(),
+		timeValue(thread.GetCreatedAt()), thread.GetUps(), thread.GetDowns(), thread.GetNumComments(), thread.GetNumReports(),
+		thread.GetLocked(), thread.GetFlair(), thread.GetFlairColor(), thread.GetSpamScore(), thread.GetPinned(),
+		textArray(thread.GetTags()), thread.GetKind().String(), thread.GetUrl(), thread.GetDomain(), thread.GetAttachmentId(),
+		thread.GetThumbnailUrl(), timeValue(thread.GetEditedAt()), thread.GetDraft(), timeValue(thread.GetPublishAt()),
+		thread.GetCrosspostParentId(), thread.GetRedirectThreadId(), thread.GetRedirectCommunityId(),
+		thread.GetRemovalType().String(), thread.GetRemovalReason(), timeValue(thread.GetRemovedAt()), thread.GetContentHtml(),
+	)
+	if err != nil {
+		return err
+	}
+
+	poll := thread.GetPoll()
+	if poll == nil {
+		return nil
+	}
+	query = "INSERT INTO polls (thread_id, ends_at, multiple_choice, hide_results, num_voters) VALUES ($1, $2, $3, $4, $5)"
+	_, err = tx.Exec(ctx, query, thread.GetId(), timeValue(poll.GetEndsAt()), poll.GetMultipleChoice(), poll.GetHideResults(), poll.GetNumVoters())
+	if err != nil {
+		return err
+	}
+	for i, option := range poll.GetOptions() {
+		query := "INSERT INTO poll_options (id, thread_id, position, text, num_votes) VALUES ($1, $2, $3, $4, $5)"
+		if _, err := tx.Exec(ctx, query, option.GetId(), thread.GetId(), i, option.GetText(), option.GetNumVotes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadPolls adds their polls to threads
+func loadPolls(ctx context.Context, q querier, threads []*models.Thread) error {
+	byId := map[string]*models.Thread{}
+	var ids []string
+	for _, thread := range threads {
+		if thread.Kind == models.ThreadKind_POLL {
+			byId[thread.Id] = thread
+			ids = append(ids, thread.Id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := q.Query(ctx, "SELECT thread_id, ends_at, multiple_choice, hide_results, num_voters FROM polls WHERE thread_id = ANY($1)", ids)
+	if err != nil {
+		return err
+	}
+	_, err = scanAll(rows, func(row pgx.Row) (*models.Poll, error) {
+		var threadId string
+		var endsAt *time.Time
+		poll := &models.Poll{}
+		if err := row.Scan(&threadId, &endsAt, &poll.MultipleChoice, &poll.HideResults, &poll.NumVoters); err != nil {
+			return nil, err
+		}
+		poll.EndsAt = timestamp(endsAt)
+		byId[threadId].Poll = poll
+		return poll, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	query := "SELECT thread_id, id, text, num_votes FROM poll_options WHERE thread_id = ANY($1) ORDER BY thread_id, position"
+	rows, err = q.Query(ctx, query, ids)
+	if err != nil {
+		return err
+	}
+	_, err = scanAll(rows, func(row pgx.Row) (*models.PollOption, error) {
+		var threadId string
+		option := &models.PollOption{}
+		if err := row.Scan(&threadId, &option.Id, &option.Text, &option.NumVotes); err != nil {
+			return nil, err
+		}
+		if poll := byId[threadId].Poll; poll != nil {
+			poll.Options = append(poll.Options, option)
+		}
+		return option, nil
+	})
+	return err
+}
+
+// scanThread scans the thread columns, followed by the extra columns of the query
+func scanThread(row pgx.Row, extra ...any) (*models.Thread, error) {
+	thread := &models.Thread{}
+	var createdAt, editedAt, publishAt, removedAt *time.Time
+	var kind, removalType string
+	dest := []any{
+		&thread.Id, &thread.CommunityId, &thread.Title, &thread.Content, &thread.AuthorId, &createdAt,
+		&thread.Ups, &thread.Downs, &thread.NumComments, &thread.NumReports, &thread.Locked, &thread.Flair,
+		&thread.FlairColor, &thread.SpamScore, &thread.Pinned, &thread.Tags, &kind, &thread.Url, &thread.Domain,
+		&thread.AttachmentId, &thread.ThumbnailUrl, &editedAt, &thread.Draft, &publishAt, &thread.CrosspostParentId,
+		&thread.RedirectThreadId, &thread.RedirectCommunityId, &removalType, &thread.RemovalReason, &removedAt,
+		&thread.ContentHtml,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	thread.CreatedAt = timestamp(createdAt)
+	thread.EditedAt = timestamp(editedAt)
+	thread.PublishAt = timestamp(publishAt)
+	thread.RemovedAt = timestamp(removedAt)
+	thread.Kind = models.ThreadKind(models.ThreadKind_value[kind])
+	thread.RemovalType = models.RemovalType(models.RemovalType_value[removalType])
+	return thread, nil
+}
+
+// addOffset adds an offset to a counter column
+func addOffset(set *clauses, column string, offset int32) {
+	if offset != 0 {
+		set.add(column+" = "+column+" + ?", offset)
+	}
+}
+
+// removal of threads and comments, which share the removal columns
+
+func remove(ctx context.Context, q querier, table string, id string, removalType models.RemovalType, reason string, removedAt time.Time) error {
+	// only what is not already removed can be removed
+	query := "UPDATE " + table + " SET removal_type = $2, removal_reason = $3, removed_at = $4 WHERE id = $1 AND removal_type = 'NOT_REMOVED'"
+	tag, err := q.Exec(ctx, query, id, removalType.String(), reason, removedAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return existsOr(ctx, q, table, id, storage.ErrFailedPrecondition)
+	}
+	return nil
+}
+
+func restore(ctx context.Context, q querier, table string, id string, removedAfter time.Time) error {
+	// only what is removed has a removal time
+	query := "UPDATE " + table + " SET removal_type = 'NOT_REMOVED', removal_reason = '', removed_at = NULL WHERE id = $1 AND removed_at >= $2"
+	tag, err := q.Exec(ctx, query, id, removedAfter)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return existsOr(ctx, q, table, id, storage.ErrFailedPrecondition)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package storage
+
+import (
+	"context"
+	models "gen/models/pb"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RevisionTarget is the thread or comment revisions belong to, exactly one id is set
+type RevisionTarget struct {
+	ThreadId  string
+	CommentId string
+}
+
+// RevisionRepository reads the revisions the thread and comment repositories record when they are edited, and delete
+// when they are deleted
+type RevisionRepository interface {
+	// List lists the revisions by version
+	List(ctx context.Context, target RevisionTarget) ([]*models.Revision, error)
+}
+
+// Revisions returns the revisions an edit of a thread records: the text after the edit, and on the first edit the
+// original text it replaced. before is the thread before the update and numEdits the number of edits before this one
+func (u ThreadUpdate) Revisions(before *models.Thread, numEdits int32) []*models.Revision {
+	if u.Edit == nil {
+		return nil
+	}
+	after := &models.Revision{
+		Version:   numEdits + 2,
+		Title:     before.GetTitle(),
+		Content:   before.GetContent(),
+		EditorId:  u.Edit.EditorId,
+		CreatedAt: timestamppb.New(u.Edit.EditedAt),
+	}
+	if u.Title != nil {
+		after.Title = *u.Title
+	}
+	if u.Content != nil {
+		after.Content = *u.Content
+	}
+	original := &models.Revision{
+		Version:   1,
+		Title:     before.GetTitle(),
+		Content:   before.GetContent(),
+		EditorId:  before.GetAuthorId(),
+		CreatedAt: before.GetCreatedAt(),
+	}
+	return revisions(original, after, numEdits)
+}
+
+// Revisions returns the revisions an edit of a comment records, like ThreadUpdate.Revisions
+func (u CommentUpdate) Revisions(before *models.Comment, numEdits int32) []*models.Revision {
+	if u.Edit == nil {
+		return nil
+	}
+	after := &models.Revision{
+		Version:   numEdits + 2,
+		Content:   before.GetContent(),
+		EditorId:  u.Edit.EditorId,
+		CreatedAt: timestamppb.New(u.Edit.EditedAt),
+	}
+	if u.Content != nil {
+		after.Content = *u.Content
+	}
+	original := &models.Revision{
+		Version:   1,
+		Content:   before.GetContent(),
+		EditorId:  before.GetAuthorId(),
+		CreatedAt: before.GetCreatedAt(),
+	}
+	return revisions(original, after, numEdits)
+}
+
+func revisions(original *models.Revision, after *models.Revision, numEdits int32) []*models.Revision {
+	if numEdits == 0 {
+		return []*models.Revision{original, after}
+	}
+	return []*models.Revision{after}
+}
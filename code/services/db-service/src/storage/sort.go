@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Cursor is the position of a document in a sorted list, lists continue after it through Page.After
+type Cursor struct {
+	Pinned *bool // whether the thread is pinned, set when the list sorts pinned threads first
+	Value  any   // sort field of the document, an int64, uint64, float64, string, bool or time.Time, nil when unset
+	Id     string
+}
+
+// NewCursor returns the position of a document in a list sorted by field, pinnedFirst for the threads of a community
+func NewCursor(row proto.Message, field string, pinnedFirst bool) *Cursor {
+	cursor := &Cursor{Value: fieldValue(row, field)}
+	if pinnedFirst {
+		pinned, _ := fieldValue(row, "pinned").(bool)
+		cursor.Pinned = &pinned
+	}
+	if row, ok := row.(interface{ GetId() string }); ok {
+		cursor.Id = row.GetId()
+	}
+	return cursor
+}
+
+// Precedes reports whether a document is listed after the cursor in a list sorted by field
+func (c *Cursor) Precedes(row proto.Message, field string) bool {
+	return compareCursors(c, NewCursor(row, field, c.Pinned != nil), field) < 0
+}
+
+// Sort sorts documents like every backend: pinned threads first when pinnedFirst, then by a field of their message
+// in descending order and then by id. _id sorts newest first and unknown fields sort by id
+func Sort[T proto.Message](rows []T, field string, pinnedFirst bool) {
+	slices.SortStableFunc(rows, func(a T, b T) int {
+		return compareCursors(NewCursor(a, field, pinnedFirst), NewCursor(b, field, pinnedFirst), field)
+	})
+}
+
+// compareCursors compares the positions of two documents, it is negative when a is listed first
+func compareCursors(a *Cursor, b *Cursor, field string) int {
+	if a.Pinned != nil && b.Pinned != nil {
+		if c := CompareBool(*b.Pinned, *a.Pinned); c != 0 {
+			return c
+		}
+	}
+	if field == "_id" {
+		return cmp.Compare(b.Id, a.Id)
+	}
+	if c := compareValues(b.Value, a.Value); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Id, b.Id)
+}
+
+// fieldValue returns a scalar or timestamp field of a message, nil for unknown or unset fields
+func fieldValue(row proto.Message, field string) any {
+	fd := row.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(field))
+	if fd == nil || fd.IsList() || fd.IsMap() {
+		return nil
+	}
+	value := row.ProtoReflect().Get(fd)
+	switch fd.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Int64Kind, protoreflect.Sint32Kind, protoreflect.Sint64Kind,
+		protoreflect.Sfixed32Kind, protoreflect.Sfixed64Kind:
+		return value.Int()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		return value.Uint()
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return value.Float()
+	case protoreflect.StringKind:
+		return value.String()
+	case protoreflect.EnumKind:
+		return int64(value.Enum())
+	case protoreflect.BoolKind:
+		return value.Bool()
+	case protoreflect.MessageKind:
+		if timestamp, ok := value.Message().Interface().(*timestamppb.Timestamp); ok && row.ProtoReflect().Has(fd) {
+			return timestamp.AsTime()
+		}
+	}
+	return nil
+}
+
+// compareValues compares two values of fieldValue, nil is lower than anything else
+func compareValues(a any, b any) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch a := a.(type) {
+	case int64:
+		b, _ := b.(int64)
+		return cmp.Compare(a, b)
+	case uint64:
+		b, _ := b.(uint64)
+		return cmp.Compare(a, b)
+	case float64:
+		b, _ := b.(float64)
+		return cmp.Compare(a, b)
+	case string:
+		b, _ := b.(string)
+		return cmp.Compare(a, b)
+	case bool:
+		b, _ := b.(bool)
+		return CompareBool(a, b)
+	case time.Time:
+		b, _ := b.(time.Time)
+		return a.Compare(b)
+	}
+	return 0
+}
+
+func CompareBool(a bool, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
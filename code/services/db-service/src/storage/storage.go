@@ -0,0 +1,267 @@
+// Package storage defines the repositories db-service reads and writes through, so it can run on several backends.
+package storage
+
+import (
+	"context"
+	"errors"
+	models "gen/models/pb"
+	"time"
+)
+
+var (
+	// ErrNotFound is returned when the document an operation targets does not exist
+	ErrNotFound = errors.New("not found")
+	// ErrAlreadyExists is returned when a document would break a uniqueness rule
+	ErrAlreadyExists = errors.New("already exists")
+	// ErrFailedPrecondition is returned when a document is not in the state an operation requires
+	ErrFailedPrecondition = errors.New("failed precondition")
+	// ErrDataLoss is returned when a stored document is malformed and cannot be read
+	ErrDataLoss = errors.New("data loss")
+)
+
+// Storage gives access to the repositories of a backend
+type Storage interface {
+	Communities() CommunityRepository
+	Threads() ThreadRepository
+	Comments() CommentRepository
+	Revisions() RevisionRepository
+	Attachments() AttachmentRepository
+	Moderation() ModerationRepository
+	Counters() CounterRepository
+	Events() EventRepository
+	Jobs() JobRepository
+}
+
+// Page selects a range of a list, a zero limit returns everything after the offset. Lists of threads, comments and
+// communities start after the document of After before skipping the offset
+type Page struct {
+	Offset int32
+	Limit  int32
+	After  *Cursor
+}
+
+// Bounds returns the range of a list of n items selected by the page
+func (p Page) Bounds(n int) (int, int) {
+	start := min(int(p.Offset), n)
+	end := n
+	if p.Limit > 0 {
+		end = min(start+int(p.Limit), n)
+	}
+	return start, end
+}
+
+type CommunityFilter struct {
+	Name string // case-insensitive regular expression matched against the name
+	Page Page
+}
+
+type CommunityUpdate struct {
+	Name             *string
+	PostingRules     *models.PostingRules
+	NumThreadsOffset int32
+}
+
+type CommunityRepository interface {
+	List(ctx context.Context, filter CommunityFilter) ([]*models.Community, error)
+	// Create fails with ErrAlreadyExists when the name is in use
+	Create(ctx context.Context, community *models.Community) error
+	Get(ctx context.Context, id string) (*models.Community, error)
+	// Update fails with ErrAlreadyExists when the new name is in use by another community
+	Update(ctx context.Context, id string, update CommunityUpdate) error
+	Delete(ctx context.Context, id string) error
+	// AddFlairTemplate fails with ErrAlreadyExists when the community has a template with the same text
+	AddFlairTemplate(ctx context.Context, communityId string, template *models.FlairTemplate) error
+	RemoveFlairTemplate(ctx context.Context, communityId string, id string) error
+}
+
+// ThreadFilter selects threads, nil fields do not filter. Pinned threads are listed first when CommunityId is set
+type ThreadFilter struct {
+	CommunityId       string
+	Title             string // case-insensitive regular expression matched against the title
+	Pinned            *bool
+	Flair             *string
+	Tag               *string
+	AuthorId          *string
+	CrosspostParentId *string
+	Drafts            bool   // lists drafts instead of published threads
+	SortBy            string // field sorted in descending order, e.g. ups or created_at
+	Page              Page
+}
+
+// Edit marks an update of the title or content of a thread or comment, which counts as an edit
+type Edit struct {
+	EditorId string
+	EditedAt time.Time
+}
+
+// ThreadUpdate changes the fields that are set and adds the offsets to the counters
+type ThreadUpdate struct {
+	Title             *string
+	Content           *string
+	ContentHtml       *string // set with Content
+	UpsOffset         int32
+	DownsOffset       int32
+	NumCommentsOffset int32
+	NumReportsOffset  int32
+	Locked            *bool
+	Flair             *string
+	FlairColor        *string
+	SpamScore         *float64
+	Pinned            *bool
+	Tags              *[]string
+	PublishAt         *time.Time
+	Edit              *Edit
+}
+
+type ThreadRepository interface {
+	List(ctx context.Context, filter ThreadFilter) ([]*models.Thread, error)
+	Create(ctx context.Context, thread *models.Thread) error
+	Get(ctx context.Context, id string) (*models.Thread, error)
+	// Update records the revisions of an edit in the same transaction
+	Update(ctx context.Context, id string, update ThreadUpdate) error
+	// Delete also deletes the votes of the poll of the thread and its revisions
+	Delete(ctx context.Context, id string) error
+	// Remove fails with ErrFailedPrecondition when the thread is already removed
+	Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error
+	// Restore fails with ErrFailedPrecondition when the thread is not removed or was removed before removedAfter
+	Restore(ctx context.Context, id string, removedAfter time.Time) error
+	// Publish turns a draft into a thread created at publishedAt, it fails with ErrFailedPrecondition when the thread is not a draft
+	Publish(ctx context.Context, id string, publishedAt time.Time) error
+	// ListScheduled lists the drafts to publish before a time, oldest schedule first
+	ListScheduled(ctx context.Context, before time.Time, limit int32) ([]*models.Thread, error)
+	// Pin pins a thread unless its community already has max pinned threads, then it fails with
+	// ErrFailedPrecondition. Pins are counted in the same transaction, so concurrent pins never exceed max
+	Pin(ctx context.Context, id string, max int32) error
+	// CastPollVote counts a vote on the options of a poll, it fails with ErrAlreadyExists when the user already voted
+	CastPollVote(ctx context.Context, threadId string, userId string, optionIds []string) error
+	// CreateAndIncrement creates a thread and counts it in num_threads of its community when it is published, atomically.
+	// It fails with ErrNotFound when the community does not exist, and with ErrAlreadyExists when the thread is a
+	// crosspost of a thread already crossposted to the community
+	CreateAndIncrement(ctx context.Context, thread *models.Thread) error
+	// DeleteAndDecrement deletes a thread like Delete and stops counting it in num_threads of its community, atomically
+	DeleteAndDecrement(ctx context.Context, id string) error
+	// PublishAndIncrement publishes a draft like Publish and counts it in num_threads of its community, atomically
+	PublishAndIncrement(ctx context.Context, id string, publishedAt time.Time) error
+	// Move moves a thread out of the community from and leaves the redirect there, atomically with the
+	// num_threads counters of both communities, the mod queue items of the thread and the redirects of earlier moves.
+	// It fails with ErrNotFound when the thread is not in from or the community to does not exist
+	Move(ctx context.Context, id string, from string, to string, redirect *models.Thread) error
+}
+
+// Counted reports whether a thread counts in num_threads of its community, drafts and redirects do not
+func Counted(thread *models.Thread) bool {
+	return !thread.GetDraft() && thread.GetKind() != models.ThreadKind_REDIRECT
+}
+
+type CommentFilter struct {
+	ParentId string // empty lists the comments of every parent
+	SortBy   string // field sorted in descending order, e.g. ups or created_at
+	Page     Page
+}
+
+// CommentUpdate changes the fields that are set and adds the offsets to the counters
+type CommentUpdate struct {
+	Content           *string
+	ContentHtml       *string // set with Content
+	UpsOffset         int32
+	DownsOffset       int32
+	NumCommentsOffset int32
+	NumReportsOffset  int32
+	SpamScore         *float64
+	Edit              *Edit
+}
+
+type CommentRepository interface {
+	List(ctx context.Context, filter CommentFilter) ([]*models.Comment, error)
+	Create(ctx context.Context, comment *models.Comment) error
+	Get(ctx context.Context, id string) (*models.Comment, error)
+	// Update records the revisions of an edit in the same transaction
+	Update(ctx context.Context, id string, update CommentUpdate) error
+	// Delete also deletes the revisions of the comment
+	Delete(ctx context.Context, id string) error
+	// CreateAndIncrement creates a comment and counts it in num_comments of its parent thread or comment, atomically.
+	// It fails with ErrNotFound when the parent does not exist
+	CreateAndIncrement(ctx context.Context, comment *models.Comment) error
+	// DeleteAndDecrement deletes a comment like Delete and stops counting it in num_comments of its parent, atomically
+	DeleteAndDecrement(ctx context.Context, id string) error
+	// DeleteTree deletes a comment and every reply under it like DeleteAndDecrement and returns the number of deleted
+	// comments. Replies are found by the ancestor path stored when they are created. Backends may delete the replies
+	// of a large subtree in several transactions before the comment, a failed call is finished by calling it again
+	DeleteTree(ctx context.Context, id string) (int32, error)
+	// Remove fails with ErrFailedPrecondition when the comment is already removed
+	Remove(ctx context.Context, id string, removalType models.RemovalType, reason string, removedAt time.Time) error
+	// Restore fails with ErrFailedPrecondition when the comment is not removed or was removed before removedAfter
+	Restore(ctx context.Context, id string, removedAfter time.Time) error
+}
+
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.Attachment) error
+	Get(ctx context.Context, id string) (*models.Attachment, error)
+}
+
+type ModerationRepository interface {
+	// AuthorStats sums the score of the threads and comments of an author and finds the first of them, which is nil without any
+	AuthorStats(ctx context.Context, authorId string) (int32, *time.Time, error)
+	// GetAutomodConfig returns an empty config for communities without rules
+	GetAutomodConfig(ctx context.Context, communityId string) (string, error)
+	SetAutomodConfig(ctx context.Context, communityId string, config string) error
+	// ListModQueue lists the items of a community, or of every community when communityId is empty, newest first
+	ListModQueue(ctx context.Context, communityId string, page Page) ([]*models.ModQueueItem, error)
+	CreateModQueueItem(ctx context.Context, item *models.ModQueueItem) error
+	DeleteModQueueItem(ctx context.Context, id string) error
+	// RecordAutomodAction records that a rule acted on a thread or comment, it fails with ErrAlreadyExists when the rule
+	// already did
+	RecordAutomodAction(ctx context.Context, action AutomodAction) error
+	// CreateReport counts a report in num_reports of the reported thread or comment, atomically. It fails with
+	// ErrAlreadyExists when the user already reported it, and with ErrNotFound when it does not exist
+	CreateReport(ctx context.Context, report Report) error
+	// ListSpamThreads and ListSpamComments list the samples of the spam classifier, everything but what authors deleted, newest first
+	ListSpamThreads(ctx context.Context, page Page) ([]*models.Thread, error)
+	ListSpamComments(ctx context.Context, page Page) ([]*models.Comment, error)
+	// GetSpamModel returns no model and a nil time when none was trained yet
+	GetSpamModel(ctx context.Context) ([]byte, *time.Time, error)
+	SetSpamModel(ctx context.Context, model []byte, trainedAt time.Time) error
+}
+
+// AutomodAction is an automod rule acting on a thread or comment, rules act once per target
+type AutomodAction struct {
+	ThreadId  string
+	CommentId string // empty when the rule acted on the thread
+	Rule      string
+	DryRun    bool
+}
+
+// Report is a user reporting a thread or comment, exactly one id of the target is set. Users report each target once
+type Report struct {
+	ThreadId   string
+	CommentId  string
+	ReporterId string
+}
+
+// Recount is a counter as it was stored and as counted again from the documents it counts
+type Recount struct {
+	Stored  int32
+	Counted int32
+}
+
+// Suspect is a counter that differed from the documents it counts, exactly one id is set
+type Suspect struct {
+	CommunityId string
+	ThreadId    string
+	CommentId   string
+}
+
+// CounterRepository counts again the documents behind the counters kept on other documents. A recount is atomic with
+// the operations that change the counter, so a fixed counter does not lose a concurrent increment
+type CounterRepository interface {
+	// RecountThreads counts the threads of a community that count in its num_threads, and stores the count when fix
+	// is set. It fails with ErrNotFound when the community does not exist
+	RecountThreads(ctx context.Context, communityId string, fix bool) (Recount, error)
+	// RecountComments counts the replies of a thread or comment, and stores the count in its num_comments when fix
+	// is set. It fails with ErrNotFound when the parent does not exist
+	RecountComments(ctx context.Context, parentId string, parentType models.CommentParentType, fix bool) (Recount, error)
+	// Compare compares every counter with the documents it counts, aggregated by the storage instead of read out, and
+	// returns the number of counters compared and the threads, comments and communities whose counter differs. It
+	// runs without a transaction, so a concurrent write can make a counter look wrong until it is recounted
+	Compare(ctx context.Context) (int32, []Suspect, error)
+}
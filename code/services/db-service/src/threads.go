@@ -2,149 +2,360 @@ package server
 
 import (
 	"context"
+	"db-service/src/storage"
 	"errors"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/mongo"
+	"time"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func (s *DBServer) ListThreads(ctx context.Context, req *dbpb.ListThreadsRequest) (*dbpb.ListThreadsResponse, error) {
-	collection := s.Mongo.Collection("threads")
-	filter := bson.M{}
-	if id := req.GetCommunityId(); id != "" {
-		filter["community_id"] = id
+	// pinned threads are listed first within a community
+	list := listing{
+		name:        "threads",
+		sortBy:      req.GetSortBy(),
+		pinnedFirst: req.GetCommunityId() != "",
+		filters:     filterHash(req.CommunityId, req.Title, req.Pinned, req.Flair, req.Tag, req.AuthorId, req.CrosspostParentId, req.GetDrafts()),
 	}
-	if title := req.GetTitle(); title != "" {
-		filter["title"] = bson.M{"$regex": title, "$options": "i"} // case-insensitive title match
+	page, err := list.page(req.Offset, req.Limit, req.PageToken)
+	if err != nil {
+		return nil, err
 	}
 
-	cursor, err := collection.Find(ctx, filter, getFindOptions(req.Offset, req.Limit, req.GetSortBy()))
+	results, err := s.Storage.Threads().List(ctx, storage.ThreadFilter{
+		CommunityId:       req.GetCommunityId(),
+		Title:             req.GetTitle(),
+		Pinned:            req.Pinned,
+		Flair:             req.Flair,
+		Tag:               req.Tag,
+		AuthorId:          req.AuthorId,
+		CrosspostParentId: req.CrosspostParentId,
+		Drafts:            req.GetDrafts(),
+		SortBy:            req.GetSortBy(),
+		Page:              page,
+	})
+	if errors.Is(err, storage.ErrDataLoss) {
+		return nil, status.Errorf(codes.DataLoss, "Stored thread is malformed")
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to find threads")
+		return nil, status.Errorf(codes.Internal, "Failed to list threads")
 	}
-	defer cursor.Close(ctx)
-
-	var results []*models.Thread
-	for cursor.Next(ctx) {
-		thread := bson.M{}
-		if err := cursor.Decode(&thread); err != nil {
-			return nil, status.Errorf(codes.Internal, "Failed to list threads")
-		}
-		results = append(results, &models.Thread{
-			Id:          thread["_id"].(string),
-			CommunityId: thread["community_id"].(string),
-			Title:       thread["title"].(string),
-			Content:     thread["content"].(string),
-			Ups:         thread["ups"].(int32),
-			Downs:       thread["downs"].(int32),
-			NumComments: thread["num_comments"].(int32),
-		})
+	token, err := nextPageToken(list, page, results)
+	if err != nil {
+		return nil, err
 	}
-	if err := cursor.Err(); err != nil {
-		return nil, status.Errorf(codes.Internal, "Unexpected cursor error")
+	for _, thread := range results {
+		renderThread(thread)
 	}
 
 	return &dbpb.ListThreadsResponse{
-		Threads: results,
+		Threads:       results,
+		NextPageToken: token,
 	}, nil
 }
 
 func (s *DBServer) CreateThread(ctx context.Context, req *dbpb.CreateThreadRequest) (*dbpb.CreateThreadResponse, error) {
-	collection := s.Mongo.Collection("threads")
-	// create thread
-	thread := bson.M{
-		"_id":          generateUniqueId(),
-		"community_id": req.GetCommunityId(),
-		"title":        req.GetTitle(),
-		"content":      req.GetContent(),
-		"ups":          0,
-		"downs":        0,
-		"num_comments": 0,
-	}
-	_, err := collection.InsertOne(ctx, thread)
-	if err != nil {
+	thread := newThread(req)
+	if err := s.Storage.Threads().Create(ctx, thread); err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to create thread")
 	}
 	return &dbpb.CreateThreadResponse{
-		Id: thread["_id"].(string),
+		Id: thread.Id,
 	}, nil
 }
 
-func (s *DBServer) GetThread(ctx context.Context, req *dbpb.GetThreadRequest) (*models.Thread, error) {
-	collection := s.Mongo.Collection("threads")
-	filter := bson.M{"_id": req.Id}
-
-	var thread bson.M
-	err := collection.FindOne(ctx, filter).Decode(&thread)
+func (s *DBServer) CreateThreadAndIncrement(ctx context.Context, req *dbpb.CreateThreadRequest) (*dbpb.CreateThreadResponse, error) {
+	// the thread is counted in its community in the same transaction, drafts are counted once published
+	thread := newThread(req)
+	err := s.Storage.Threads().CreateAndIncrement(ctx, thread)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Community not found")
+	}
+	if errors.Is(err, storage.ErrAlreadyExists) {
+		return nil, status.Errorf(codes.AlreadyExists, "Thread is already crossposted to this community")
+	}
 	if err != nil {
-		if errors.Is(err, mongo.ErrNoDocuments) {
-			return nil, status.Errorf(codes.NotFound, "Thread not found")
-		}
-		return nil, status.Errorf(codes.Internal, "Failed to get thread")
+		return nil, status.Errorf(codes.Internal, "Failed to create thread")
 	}
-	return &models.Thread{
-		Id:          thread["_id"].(string),
-		CommunityId: thread["community_id"].(string),
-		Title:       thread["title"].(string),
-		Content:     thread["content"].(string),
-		Ups:         thread["ups"].(int32),
-		Downs:       thread["downs"].(int32),
-		NumComments: thread["num_comments"].(int32),
+	return &dbpb.CreateThreadResponse{
+		Id: thread.Id,
 	}, nil
 }
 
-func (s *DBServer) UpdateThread(ctx context.Context, req *dbpb.UpdateThreadRequest) (*emptypb.Empty, error) {
-	collection := s.Mongo.Collection("threads")
-	setValues, incValues := bson.M{}, bson.M{}
-
-	if req.Title != nil {
-		setValues["title"] = req.GetTitle()
+// newThread builds the thread of a create request
+func newThread(req *dbpb.CreateThreadRequest) *models.Thread {
+	thread := &models.Thread{
+		Id:          generateUniqueId(),
+		CommunityId: req.GetCommunityId(),
+		Title:       req.GetTitle(),
+		Content:     req.GetContent(),
+		ContentHtml: RenderMarkdown(req.GetContent()),
+		AuthorId:    req.GetAuthorId(),
+		CreatedAt:   timestamppb.Now(),
+		Flair:       req.GetFlair(),
+		FlairColor:  req.GetFlairColor(),
+		Tags:        req.GetTags(),
+		Kind:        req.GetKind(),
 	}
-	if req.Content != nil {
-		setValues["content"] = req.GetContent()
+	if req.GetDraft() {
+		thread.Draft = true
+		thread.PublishAt = req.GetPublishAt()
 	}
-	if req.NumCommentsOffset != nil {
-		if offset := req.GetNumCommentsOffset(); offset == 1 {
-			incValues["num_comments"] = 1
-		} else {
-			incValues["num_comments"] = -1
+	switch req.GetKind() {
+	case models.ThreadKind_LINK:
+		thread.Url = req.GetUrl()
+		thread.Domain = req.GetDomain()
+	case models.ThreadKind_IMAGE:
+		thread.AttachmentId = req.GetAttachmentId()
+		thread.ThumbnailUrl = req.GetThumbnailUrl()
+	case models.ThreadKind_POLL:
+		thread.Poll = &models.Poll{
+			EndsAt:         req.GetPoll().GetEndsAt(),
+			MultipleChoice: req.GetPoll().GetMultipleChoice(),
+			HideResults:    req.GetPoll().GetHideResults(),
+		}
+		for _, option := range req.GetPoll().GetOptions() {
+			thread.Poll.Options = append(thread.Poll.Options, &models.PollOption{Id: generateUniqueId(), Text: option.GetText()})
 		}
+	case models.ThreadKind_CROSSPOST:
+		thread.CrosspostParentId = req.GetCrosspostParentId()
+	}
+	return thread
+}
+
+func (s *DBServer) GetThread(ctx context.Context, req *dbpb.GetThreadRequest) (*models.Thread, error) {
+	thread, err := s.Storage.Threads().Get(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	}
+	if errors.Is(err, storage.ErrDataLoss) {
+		return nil, status.Errorf(codes.DataLoss, "Stored thread is malformed")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to get thread")
+	}
+	return renderThread(thread), nil
+}
+
+func (s *DBServer) UpdateThread(ctx context.Context, req *dbpb.UpdateThreadRequest) (*emptypb.Empty, error) {
+	update := storage.ThreadUpdate{
+		Title:             req.Title,
+		Content:           req.Content,
+		NumCommentsOffset: counterOffset(req.NumCommentsOffset),
+		NumReportsOffset:  counterOffset(req.NumReportsOffset),
+		Locked:            req.Locked,
+		Flair:             req.Flair,
+		SpamScore:         req.SpamScore,
+		Pinned:            req.Pinned,
+		FlairColor:        req.FlairColor,
 	}
 	if req.VoteOffset != nil {
-		if offset := req.GetVoteOffset(); offset == 1 {
-			incValues["ups"] = 1
+		if req.GetVoteOffset() == 1 {
+			update.UpsOffset = 1
 		} else {
-			incValues["downs"] = 1
+			update.DownsOffset = 1
 		}
 	}
-	if len(setValues) == 0 && len(incValues) == 0 {
+	if req.Tags != nil {
+		tags := req.Tags.GetTags()
+		update.Tags = &tags
+	}
+	if req.PublishAt != nil {
+		publishAt := req.GetPublishAt().AsTime()
+		update.PublishAt = &publishAt
+	}
+	if update == (storage.ThreadUpdate{}) {
 		return nil, status.Errorf(codes.InvalidArgument, "No update fields provided")
 	}
 
-	update := bson.M{"$set": setValues, "$inc": incValues}
-	result, err := collection.UpdateOne(ctx, bson.M{"_id": req.GetId()}, update)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "Failed to update thread")
+	// the html is rendered once with the content, and edits of the text are kept as revisions
+	if req.Content != nil {
+		html := RenderMarkdown(req.GetContent())
+		update.ContentHtml = &html
+	}
+	if req.Title != nil || req.Content != nil {
+		update.Edit = &storage.Edit{EditorId: req.GetEditorId(), EditedAt: time.Now()}
 	}
-	if result.MatchedCount == 0 {
+	err := s.Storage.Threads().Update(ctx, req.GetId(), update)
+	if errors.Is(err, storage.ErrNotFound) {
 		return nil, status.Errorf(codes.NotFound, "Thread not found")
 	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to update thread")
+	}
 	return &emptypb.Empty{}, nil
 }
 
 func (s *DBServer) DeleteThread(ctx context.Context, req *dbpb.DeleteThreadRequest) (*emptypb.Empty, error) {
-	collection := s.Mongo.Collection("threads")
+	return s.deleteThread(ctx, req.GetId(), s.Storage.Threads().Delete)
+}
 
-	result, err := collection.DeleteOne(ctx, bson.M{"_id": req.GetId()})
+func (s *DBServer) DeleteThreadAndDecrement(ctx context.Context, req *dbpb.DeleteThreadRequest) (*emptypb.Empty, error) {
+	return s.deleteThread(ctx, req.GetId(), s.Storage.Threads().DeleteAndDecrement)
+}
+
+// deleteThread deletes a thread with one of the delete operations of the storage
+func (s *DBServer) deleteThread(ctx context.Context, id string, delete func(ctx context.Context, id string) error) (*emptypb.Empty, error) {
+	err := delete(ctx, id)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	}
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "Failed to delete thread")
 	}
-	if result.DeletedCount == 0 {
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) RemoveThread(ctx context.Context, req *dbpb.RemoveThreadRequest) (*emptypb.Empty, error) {
+	if req.GetRemovalType() == models.RemovalType_NOT_REMOVED {
+		return nil, status.Errorf(codes.InvalidArgument, "Removal type is required")
+	}
+
+	// only threads that are not already removed can be removed
+	err := s.Storage.Threads().Remove(ctx, req.GetId(), req.GetRemovalType(), req.GetReason(), time.Now())
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	case errors.Is(err, storage.ErrFailedPrecondition):
+		return nil, status.Errorf(codes.FailedPrecondition, "Thread is already removed")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to remove thread")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) RestoreThread(ctx context.Context, req *dbpb.RestoreThreadRequest) (*emptypb.Empty, error) {
+	// only threads removed within the grace period can be restored
+	err := s.Storage.Threads().Restore(ctx, req.GetId(), time.Now().Add(-RestoreGracePeriod))
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	case errors.Is(err, storage.ErrFailedPrecondition):
+		thread, getErr := s.Storage.Threads().Get(ctx, req.GetId())
+		if getErr == nil && thread.GetRemovalType() == models.RemovalType_NOT_REMOVED {
+			return nil, status.Errorf(codes.FailedPrecondition, "Thread is not removed")
+		}
+		return nil, status.Errorf(codes.FailedPrecondition, "Thread can no longer be restored")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to restore thread")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) PublishThread(ctx context.Context, req *dbpb.PublishThreadRequest) (*emptypb.Empty, error) {
+	return s.publishThread(ctx, req.GetId(), s.Storage.Threads().Publish)
+}
+
+func (s *DBServer) PublishThreadAndIncrement(ctx context.Context, req *dbpb.PublishThreadRequest) (*emptypb.Empty, error) {
+	return s.publishThread(ctx, req.GetId(), s.Storage.Threads().PublishAndIncrement)
+}
+
+// publishThread publishes a draft with one of the publish operations of the storage
+func (s *DBServer) publishThread(ctx context.Context, id string, publish func(ctx context.Context, id string, publishedAt time.Time) error) (*emptypb.Empty, error) {
+	// a draft is published once even when several schedulers pick it up, it is dated from its publication
+	err := publish(ctx, id, time.Now())
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	case errors.Is(err, storage.ErrFailedPrecondition):
+		return nil, status.Errorf(codes.FailedPrecondition, "Thread is already published")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to publish thread")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) ListScheduledThreads(ctx context.Context, req *dbpb.ListScheduledThreadsRequest) (*dbpb.ListThreadsResponse, error) {
+	// drafts due for publication, oldest schedule first
+	results, err := s.Storage.Threads().ListScheduled(ctx, req.GetPublishBefore().AsTime(), req.GetLimit())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to list scheduled threads")
+	}
+	for _, thread := range results {
+		renderThread(thread)
+	}
+
+	return &dbpb.ListThreadsResponse{
+		Threads: results,
+	}, nil
+}
+
+func (s *DBServer) MoveThread(ctx context.Context, req *dbpb.MoveThreadRequest) (*dbpb.MoveThreadResponse, error) {
+	thread, err := s.Storage.Threads().Get(ctx, req.GetId())
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to move thread")
+	}
+	if thread.CommunityId == req.GetCommunityId() {
+		return nil, status.Errorf(codes.FailedPrecondition, "Thread already belongs to this community")
+	}
+	if _, err := s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: req.GetCommunityId()}); err != nil {
+		return nil, err
+	}
+
+	// the redirect keeps the place of the thread in the previous community, it is locked and not counted
+	redirect := &models.Thread{
+		Id:                  generateUniqueId(),
+		CommunityId:         thread.CommunityId,
+		Title:               thread.Title,
+		AuthorId:            thread.AuthorId,
+		CreatedAt:           proto.Clone(thread.CreatedAt).(*timestamppb.Timestamp),
+		Locked:              true,
+		Kind:                models.ThreadKind_REDIRECT,
+		RedirectThreadId:    thread.Id,
+		RedirectCommunityId: req.GetCommunityId(),
+	}
+	err = s.Storage.Threads().Move(ctx, thread.Id, thread.CommunityId, req.GetCommunityId(), redirect)
+	if errors.Is(err, storage.ErrNotFound) {
+		// moved or deleted in the meantime
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to move thread")
+	}
+
+	return &dbpb.MoveThreadResponse{
+		RedirectId: redirect.Id,
+	}, nil
+}
+
+func (s *DBServer) PinThread(ctx context.Context, req *dbpb.PinThreadRequest) (*emptypb.Empty, error) {
+	// the pins of the community are counted with the pin, so concurrent pins cannot both take the last place
+	err := s.Storage.Threads().Pin(ctx, req.GetId(), req.GetMaxPinned())
+	switch {
+	case errors.Is(err, storage.ErrNotFound):
+		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	case errors.Is(err, storage.ErrFailedPrecondition):
+		return nil, status.Errorf(codes.FailedPrecondition, "Community already has %d pinned threads", req.GetMaxPinned())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to pin thread")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *DBServer) CastPollVote(ctx context.Context, req *dbpb.CastPollVoteRequest) (*emptypb.Empty, error) {
+	// a user votes once per poll
+	err := s.Storage.Threads().CastPollVote(ctx, req.GetThreadId(), req.GetUserId(), req.GetOptionIds())
+	switch {
+	case errors.Is(err, storage.ErrAlreadyExists):
+		return nil, status.Errorf(codes.AlreadyExists, "User already voted in this poll")
+	case errors.Is(err, storage.ErrNotFound):
 		return nil, status.Errorf(codes.NotFound, "Thread not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "Failed to cast poll vote")
 	}
 
 	return &emptypb.Empty{}, nil
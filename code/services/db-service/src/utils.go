@@ -1,41 +1,66 @@
 package server
 
 import (
-	"go.mongodb.org/mongo-driver/bson"
+	"db-service/src/storage"
+	models "gen/models/pb"
+	"time"
+
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 const (
 	DefaultOffset int32 = 0
 	DefaultLimit  int32 = 10
 	MaxLimit      int32 = 100
+
+	// time during which a deleted or removed thread or comment can still be restored
+	RestoreGracePeriod = 7 * 24 * time.Hour
 )
 
-func getFindOptions(offsetPtr *int32, limitPtr *int32, sortBy string) *options.FindOptions {
-	findOptions := options.Find()
+// page selects the requested range of a list, limits up to MaxLimit are honoured and a non-positive limit lists everything
+func page(offsetPtr *int32, limitPtr *int32) storage.Page {
 	offset := DefaultOffset
-	if offsetPtr != nil {
+	if offsetPtr != nil && *offsetPtr > 0 {
 		offset = *offsetPtr
 	}
 	limit := DefaultLimit
 	if limitPtr != nil && *limitPtr < MaxLimit {
 		limit = *limitPtr
 	}
-	if offset >= 0 {
-		findOptions.SetSkip(int64(offset))
-	}
-	if limit > 0 {
-		findOptions.SetLimit(int64(limit))
-	}
-	if sortBy != "" {
-		findOptions.SetSort(bson.D{{Key: sortBy, Value: -1}})
+	if limit < 0 {
+		limit = 0
 	}
-
-	return findOptions
+	return storage.Page{Offset: offset, Limit: limit}
 }
 
 func generateUniqueId() string {
 	id := primitive.NewObjectID()
 	return id.Hex()
 }
+
+// counterOffset turns the offsets of update requests into increments, 1 increments and anything else decrements
+func counterOffset(offset *int32) int32 {
+	if offset == nil {
+		return 0
+	}
+	if *offset == 1 {
+		return 1
+	}
+	return -1
+}
+
+// renderThread and renderComment add the html of the markdown content to what was stored before the html was
+// rendered on write, everything written since is read as stored
+func renderThread(thread *models.Thread) *models.Thread {
+	if thread.ContentHtml == "" && thread.Content != "" {
+		thread.ContentHtml = RenderMarkdown(thread.Content)
+	}
+	return thread
+}
+
+func renderComment(comment *models.Comment) *models.Comment {
+	if comment.ContentHtml == "" && comment.Content != "" {
+		comment.ContentHtml = RenderMarkdown(comment.Content)
+	}
+	return comment
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	src "db-service/src"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "emphasis",
+			content: "*a* **b** ~~c~~",
+			want:    "<p><em>a</em> <strong>b</strong> <del>c</del></p>",
+		},
+		{
+			name:    "link",
+			content: "[docs](https://example.com)",
+			want:    `<p><a href="https://example.com" rel="nofollow noopener" target="_blank">docs</a></p>`,
+		},
+		{
+			name:    "bare url",
+			content: "see https://example.com",
+			want:    `<p>see <a href="https://example.com" rel="nofollow noopener" target="_blank">https://example.com</a></p>`,
+		},
+		{
+			name:    "javascript link",
+			content: "[click](javascript:alert(1))",
+			want:    "<p>click</p>",
+		},
+		{
+			name:    "image",
+			content: "![cat](https://example.com/cat.png)",
+			want:    `<p><a href="https://example.com/cat.png" rel="nofollow noopener" target="_blank">cat</a></p>`,
+		},
+		{
+			name:    "html",
+			content: `<script>alert(1)</script><img src=x onerror="alert(1)">`,
+			want:    "<p>&lt;script&gt;alert(1)&lt;/script&gt;&lt;img src=x onerror=&#34;alert(1)&#34;&gt;</p>",
+		},
+		{
+			name:    "heading",
+			content: "# title",
+			want:    "<p># title</p>",
+		},
+		{
+			name:    "code",
+			content: "`a<b`\n\n```go\nx := 1\n```",
+			want:    "<p><code>a&lt;b</code></p>\n<pre><code class=\"language-go\">x := 1\n</code></pre>",
+		},
+		{
+			name:    "quote and list",
+			content: "> quoted\n\n1. one\n2. two",
+			want:    "<blockquote>\n<p>quoted</p>\n</blockquote>\n<ol>\n<li>one</li>\n<li>two</li>\n</ol>",
+		},
+		{
+			name:    "spoiler",
+			content: ">!the end!< and >!more!<",
+			want:    `<p><span class="spoiler">the end</span> and <span class="spoiler">more</span></p>`,
+		},
+		{
+			name:    "references",
+			content: "ask c/golang or (u/gopher_1), not example.com/c/golang",
+			want:    `<p>ask <a href="/c/golang" rel="nofollow">c/golang</a> or (<a href="/u/gopher_1" rel="nofollow">u/gopher_1</a>), not example.com/c/golang</p>`,
+		},
+		{
+			name:    "reference in code",
+			content: "`c/golang`",
+			want:    "<p><code>c/golang</code></p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, strings.TrimSpace(src.RenderMarkdown(tt.content)))
+		})
+	}
+}
@@ -0,0 +1,1232 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	src "db-service/src"
+	"db-service/src/storage"
+	"db-service/src/storage/bolt"
+	"db-service/src/storage/memory"
+	"db-service/src/storage/mongodb"
+	"db-service/src/storage/postgres"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// every storage backend runs the same suite through the RPCs of the server
+
+func TestServer_Memory(t *testing.T) {
+	testServer(t, func(t *testing.T) storage.Storage {
+		return memory.New()
+	})
+}
+
+func TestServer_Bolt(t *testing.T) {
+	testServer(t, func(t *testing.T) storage.Storage {
+		db, err := bolt.Open(filepath.Join(t.TempDir(), "threadit.db"))
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+		return db
+	})
+}
+
+func TestServer_BoltReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "threadit.db")
+
+	db, err := bolt.Open(path)
+	require.NoError(t, err)
+	res, err := (&src.DBServer{Storage: db}).CreateCommunity(ctx, &dbpb.CreateCommunityRequest{Name: "golang"})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// the data file keeps the documents and the name index
+	db, err = bolt.Open(path)
+	require.NoError(t, err)
+	defer db.Close()
+	s := &src.DBServer{Storage: db}
+	community, err := s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: res.Id})
+	require.NoError(t, err)
+	assert.Equal(t, "golang", community.Name)
+	_, err = s.CreateCommunity(ctx, &dbpb.CreateCommunityRequest{Name: "golang"})
+	assertCode(t, err, codes.AlreadyExists, "Community name already in use")
+}
+
+// files written before comments had an ancestor path and an ancestor index get both when they are opened
+func TestServer_BoltAncestors(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "threadit.db")
+
+	db, err := bolt.Open(path)
+	require.NoError(t, err)
+	s := &src.DBServer{Storage: db}
+	parentId := "thread"
+	parentType := models.CommentParentType_THREAD
+	var ids []string
+	for _, content := range []string{"top", "reply", "nested"} {
+		res, err := s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: content, ParentId: parentId, ParentType: parentType})
+		require.NoError(t, err)
+		ids = append(ids, res.Id)
+		parentId, parentType = res.Id, models.CommentParentType_COMMENT
+	}
+	require.NoError(t, db.Close())
+
+	raw, err := bbolt.Open(path, 0o600, nil)
+	require.NoError(t, err)
+	err = raw.Update(func(tx *bbolt.Tx) error {
+		if err := tx.DeleteBucket([]byte("comments_by_ancestor")); err != nil {
+			return err
+		}
+		rows := tx.Bucket([]byte("comments"))
+		return rows.ForEach(func(key, data []byte) error {
+			comment := &models.Comment{}
+			if err := proto.Unmarshal(data, comment); err != nil {
+				return err
+			}
+			comment.AncestorIds = nil
+			data, err := proto.Marshal(comment)
+			if err != nil {
+				return err
+			}
+			return rows.Put(key, data)
+		})
+	})
+	require.NoError(t, err)
+	require.NoError(t, raw.Close())
+
+	db, err = bolt.Open(path)
+	require.NoError(t, err)
+	defer db.Close()
+	s = &src.DBServer{Storage: db}
+	nested, err := s.GetComment(ctx, &dbpb.GetCommentRequest{Id: ids[2]})
+	require.NoError(t, err)
+	assert.Equal(t, ids[:2], nested.AncestorIds)
+	res, err := s.DeleteCommentTree(ctx, &dbpb.DeleteCommentRequest{Id: ids[0]})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), res.NumDeleted)
+}
+
+// runs against a MongoDB replica set, e.g. MONGO_TEST_URI=mongodb://localhost:27017/?directConnection=true
+func TestServer_Mongo(t *testing.T) {
+	client := mongoTestClient(t)
+	testServer(t, func(t *testing.T) storage.Storage {
+		return mongodb.New(mongoTestDatabase(t, client))
+	})
+}
+
+// documents written by other tools than db-service, with int64 counters or fields of the wrong type
+func TestServer_MongoDocuments(t *testing.T) {
+	ctx := context.Background()
+	db := mongoTestDatabase(t, mongoTestClient(t))
+	s := &src.DBServer{Storage: mongodb.New(db)}
+
+	_, err := db.Collection("threads").InsertMany(ctx, []any{
+		bson.M{"_id": "imported", "community_id": "golang", "title": "Imported", "content": "From the dataset",
+			"ups": int64(12), "downs": int64(2), "num_comments": int64(1)},
+		bson.M{"_id": "malformed", "community_id": "broken", "title": "Malformed", "content": "", "ups": "many"},
+	})
+	require.NoError(t, err)
+	_, err = db.Collection("comments").InsertOne(ctx, bson.M{"_id": "comment", "content": "Imported", "parent_id": "imported",
+		"parent_type": "THREAD", "ups": int64(3), "downs": int64(0), "num_comments": int64(0)})
+	require.NoError(t, err)
+
+	thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: "imported"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(12), thread.Ups)
+	assert.Equal(t, int32(2), thread.Downs)
+	threads, err := s.ListThreads(ctx, &dbpb.ListThreadsRequest{CommunityId: ptr("golang")})
+	require.NoError(t, err)
+	assert.Len(t, threads.Threads, 1)
+	comments, err := s.ListComments(ctx, &dbpb.ListCommentsRequest{ThreadId: ptr("imported")})
+	require.NoError(t, err)
+	require.Len(t, comments.Comments, 1)
+	assert.Equal(t, int32(3), comments.Comments[0].Ups)
+
+	_, err = s.GetThread(ctx, &dbpb.GetThreadRequest{Id: "malformed"})
+	assertCode(t, err, codes.DataLoss, "Stored thread is malformed")
+	_, err = s.ListThreads(ctx, &dbpb.ListThreadsRequest{CommunityId: ptr("broken")})
+	assertCode(t, err, codes.DataLoss, "Stored thread is malformed")
+}
+
+func mongoTestClient(t *testing.T) *mongo.Client {
+	uri := os.Getenv("MONGO_TEST_URI")
+	if uri == "" {
+		requireInCI(t, "MONGO_TEST_URI")
+		t.Skip("MONGO_TEST_URI is not set")
+	}
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	t.Cleanup(func() { client.Disconnect(context.Background()) })
+	return client
+}
+
+// mongoTestDatabase creates a database with its indexes that is dropped after the test
+func mongoTestDatabase(t *testing.T, client *mongo.Client) *mongo.Database {
+	db := client.Database(fmt.Sprintf("threadit_test_%d", time.Now().UnixNano()))
+	t.Cleanup(func() { db.Drop(context.Background()) })
+	require.NoError(t, mongodb.Migrate(context.Background(), db))
+	return db
+}
+
+// requireInCI fails instead of skipping a backend in CI, where the workflow starts its database
+func requireInCI(t *testing.T, name string) {
+	if os.Getenv("CI") != "" {
+		t.Fatalf("%s is required in CI", name)
+	}
+}
+
+// runs against a PostgreSQL database, e.g. POSTGRES_TEST_URI=postgres://postgres@localhost:5432/postgres?sslmode=disable
+func TestServer_Postgres(t *testing.T) {
+	uri := os.Getenv("POSTGRES_TEST_URI")
+	if uri == "" {
+		requireInCI(t, "POSTGRES_TEST_URI")
+		t.Skip("POSTGRES_TEST_URI is not set")
+	}
+	admin, err := pgxpool.New(context.Background(), uri)
+	require.NoError(t, err)
+	t.Cleanup(admin.Close)
+
+	testServer(t, func(t *testing.T) storage.Storage {
+		// every test gets its own schema
+		schema := fmt.Sprintf("threadit_test_%d", time.Now().UnixNano())
+		_, err := admin.Exec(context.Background(), "CREATE SCHEMA "+schema)
+		require.NoError(t, err)
+		t.Cleanup(func() { admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE") })
+
+		config, err := pgxpool.ParseConfig(uri)
+		require.NoError(t, err)
+		config.ConnConfig.RuntimeParams["search_path"] = schema
+		pool, err := pgxpool.NewWithConfig(context.Background(), config)
+		require.NoError(t, err)
+		t.Cleanup(pool.Close)
+
+		require.NoError(t, postgres.Migrate(context.Background(), pool))
+		return postgres.New(pool)
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func assertCode(t *testing.T, err error, code codes.Code, message string) {
+	t.Helper()
+	st, ok := status.FromError(err)
+	if assert.True(t, ok, "expected a status error, got %v", err) {
+		assert.Equal(t, code, st.Code())
+		assert.Equal(t, message, st.Message())
+	}
+}
+
+// eventStream passes the events sent to a subscriber on, the subscriber cancels once it received n of them
+type eventStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	n      int
+	events chan *models.Event
+}
+
+func (s *eventStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *eventStream) Send(event *models.Event) error {
+	s.events <- event
+	if s.n--; s.n == 0 {
+		s.cancel()
+	}
+	return nil
+}
+
+// subscribe subscribes after an offset in the background, the returned channel receives the events and is closed
+// once n of them were received
+func subscribe(t *testing.T, s *src.DBServer, after int64, n int) <-chan *models.Event {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	stream := &eventStream{ctx: ctx, cancel: cancel, n: n, events: make(chan *models.Event, n)}
+	go func() {
+		defer close(stream.events)
+		defer cancel()
+		err := s.SubscribeEvents(&dbpb.SubscribeEventsRequest{AfterOffset: after}, stream)
+		assertCode(t, err, codes.Canceled, "context canceled")
+	}()
+	return stream.events
+}
+
+func testServer(t *testing.T, newStorage func(t *testing.T) storage.Storage) {
+	ctx := context.Background()
+	newServer := func(t *testing.T) *src.DBServer {
+		return &src.DBServer{Storage: newStorage(t)}
+	}
+	createCommunity := func(t *testing.T, s *src.DBServer, name string) string {
+		res, err := s.CreateCommunity(ctx, &dbpb.CreateCommunityRequest{Name: name})
+		require.NoError(t, err)
+		return res.Id
+	}
+	createThread := func(t *testing.T, s *src.DBServer, req *dbpb.CreateThreadRequest) string {
+		res, err := s.CreateThread(ctx, req)
+		require.NoError(t, err)
+		return res.Id
+	}
+	threadTitles := func(t *testing.T, s *src.DBServer, req *dbpb.ListThreadsRequest) []string {
+		res, err := s.ListThreads(ctx, req)
+		require.NoError(t, err)
+		var titles []string
+		for _, thread := range res.Threads {
+			titles = append(titles, thread.Title)
+		}
+		return titles
+	}
+
+	t.Run("communities", func(t *testing.T) {
+		s := newServer(t)
+		id := createCommunity(t, s, "golang")
+		createCommunity(t, s, "rustlang")
+		createCommunity(t, s, "cooking")
+
+		_, err := s.CreateCommunity(ctx, &dbpb.CreateCommunityRequest{Name: "golang"})
+		assertCode(t, err, codes.AlreadyExists, "Community name already in use")
+
+		res, err := s.ListCommunities(ctx, &dbpb.ListCommunitiesRequest{Name: ptr("LANG")})
+		require.NoError(t, err)
+		assert.Len(t, res.Communities, 2)
+
+		res, err = s.ListCommunities(ctx, &dbpb.ListCommunitiesRequest{Offset: ptr(int32(1)), Limit: ptr(int32(1))})
+		require.NoError(t, err)
+		if assert.Len(t, res.Communities, 1) {
+			assert.Equal(t, "rustlang", res.Communities[0].Name)
+		}
+
+		_, err = s.UpdateCommunity(ctx, &dbpb.UpdateCommunityRequest{Id: id, Name: ptr("rustlang")})
+		assertCode(t, err, codes.AlreadyExists, "Community name already in use")
+		_, err = s.UpdateCommunity(ctx, &dbpb.UpdateCommunityRequest{Id: id})
+		assertCode(t, err, codes.InvalidArgument, "No update fields provided")
+		_, err = s.UpdateCommunity(ctx, &dbpb.UpdateCommunityRequest{Id: id, Name: ptr("go"), NumThreadsOffset: ptr(int32(1))})
+		require.NoError(t, err)
+		_, err = s.UpdateCommunity(ctx, &dbpb.UpdateCommunityRequest{Id: id, PostingRules: &models.PostingRules{MaxTitleLength: 80}})
+		require.NoError(t, err)
+
+		flair, err := s.AddFlairTemplate(ctx, &dbpb.AddFlairTemplateRequest{CommunityId: id, Text: "news", Color: "#ff0000"})
+		require.NoError(t, err)
+		_, err = s.AddFlairTemplate(ctx, &dbpb.AddFlairTemplateRequest{CommunityId: id, Text: "news"})
+		assertCode(t, err, codes.AlreadyExists, "Flair text already in use")
+
+		community, err := s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, "go", community.Name)
+		assert.Equal(t, int32(1), community.NumThreads)
+		assert.Equal(t, int32(80), community.GetPostingRules().GetMaxTitleLength())
+		assert.Len(t, community.FlairTemplates, 1)
+
+		_, err = s.RemoveFlairTemplate(ctx, &dbpb.RemoveFlairTemplateRequest{CommunityId: id, Id: flair.Id})
+		require.NoError(t, err)
+		_, err = s.RemoveFlairTemplate(ctx, &dbpb.RemoveFlairTemplateRequest{CommunityId: id, Id: flair.Id})
+		assertCode(t, err, codes.NotFound, "Flair template not found")
+
+		_, err = s.DeleteCommunity(ctx, &dbpb.DeleteCommunityRequest{Id: id})
+		require.NoError(t, err)
+		_, err = s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: id})
+		assertCode(t, err, codes.NotFound, "Community not found")
+	})
+
+	t.Run("thread filters and sort", func(t *testing.T) {
+		s := newServer(t)
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "First thread", Content: "one", AuthorId: "alice", Tags: []string{"go"}})
+		second := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Second thread", Content: "two", AuthorId: "bob", Flair: "news"})
+		third := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "b", Title: "Third post", Content: "three", AuthorId: "alice"})
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Draft thread", Content: "four", AuthorId: "alice", Draft: true})
+
+		for i := 0; i < 2; i++ {
+			_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: third, VoteOffset: ptr(int32(1))})
+			require.NoError(t, err)
+		}
+		_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: second, VoteOffset: ptr(int32(1))})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"First thread", "Second thread", "Third post"}, threadTitles(t, s, &dbpb.ListThreadsRequest{}))
+		assert.Equal(t, []string{"Third post", "Second thread", "First thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{SortBy: ptr("ups")}))
+		assert.Equal(t, []string{"Second thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{SortBy: ptr("ups"), Offset: ptr(int32(1)), Limit: ptr(int32(1))}))
+		assert.Equal(t, []string{"First thread", "Second thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{CommunityId: ptr("a")}))
+		assert.Equal(t, []string{"First thread", "Second thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{Title: ptr("THREAD")}))
+		assert.Equal(t, []string{"First thread", "Third post"}, threadTitles(t, s, &dbpb.ListThreadsRequest{AuthorId: ptr("alice")}))
+		assert.Equal(t, []string{"Second thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{Flair: ptr("news")}))
+		assert.Equal(t, []string{"First thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{Tag: ptr("go")}))
+		assert.Equal(t, []string{"Draft thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{Drafts: true}))
+
+		// pinned threads come first within a community
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: second, Pinned: ptr(true)})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Second thread", "First thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{CommunityId: ptr("a")}))
+		assert.Equal(t, []string{"Second thread"}, threadTitles(t, s, &dbpb.ListThreadsRequest{Pinned: ptr(true)}))
+		assert.Equal(t, []string{"First thread", "Third post"}, threadTitles(t, s, &dbpb.ListThreadsRequest{Pinned: ptr(false)}))
+	})
+
+	t.Run("pins", func(t *testing.T) {
+		s := newServer(t)
+		var ids []string
+		for i := 0; i < 5; i++ {
+			ids = append(ids, createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: fmt.Sprintf("Thread %d", i)}))
+		}
+		other := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "b", Title: "Other"})
+
+		// concurrent pins never take more places than the community has
+		var wg sync.WaitGroup
+		for _, id := range ids {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.PinThread(ctx, &dbpb.PinThreadRequest{Id: id, MaxPinned: 2})
+				if err != nil {
+					assertCode(t, err, codes.FailedPrecondition, "Community already has 2 pinned threads")
+				}
+			}()
+		}
+		wg.Wait()
+		pinned := threadTitles(t, s, &dbpb.ListThreadsRequest{CommunityId: ptr("a"), Pinned: ptr(true)})
+		assert.Len(t, pinned, 2)
+
+		_, err := s.PinThread(ctx, &dbpb.PinThreadRequest{Id: other, MaxPinned: 2})
+		require.NoError(t, err)
+		_, err = s.PinThread(ctx, &dbpb.PinThreadRequest{Id: "missing", MaxPinned: 2})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+	})
+
+	t.Run("page tokens", func(t *testing.T) {
+		s := newServer(t)
+		ids := map[string]string{}
+		for _, title := range []string{"one", "two", "three", "four", "five"} {
+			ids[title] = createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: title, Content: title, AuthorId: "alice"})
+		}
+		for title, votes := range map[string]int{"two": 2, "three": 1, "four": 2} {
+			for i := 0; i < votes; i++ {
+				_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: ids[title], VoteOffset: ptr(int32(1))})
+				require.NoError(t, err)
+			}
+		}
+		_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: ids["five"], Pinned: ptr(true)})
+		require.NoError(t, err)
+
+		// pages continue after the last thread of the previous page, ties are listed in creation order
+		pages := func(req *dbpb.ListThreadsRequest) [][]string {
+			var pages [][]string
+			for {
+				res, err := s.ListThreads(ctx, req)
+				require.NoError(t, err)
+				var titles []string
+				for _, thread := range res.Threads {
+					titles = append(titles, thread.Title)
+				}
+				pages = append(pages, titles)
+				if res.NextPageToken == "" || len(pages) > 5 {
+					return pages
+				}
+				req.PageToken = ptr(res.NextPageToken)
+			}
+		}
+		assert.Equal(t, [][]string{{"two", "four"}, {"three", "one"}, {"five"}},
+			pages(&dbpb.ListThreadsRequest{SortBy: ptr("ups"), Limit: ptr(int32(2))}))
+		assert.Equal(t, [][]string{{"five", "two"}, {"four", "three"}, {"one"}},
+			pages(&dbpb.ListThreadsRequest{CommunityId: ptr("a"), SortBy: ptr("ups"), Limit: ptr(int32(2))}))
+		assert.Equal(t, [][]string{{"one", "two", "three"}, {"four", "five"}},
+			pages(&dbpb.ListThreadsRequest{Limit: ptr(int32(3))}))
+		assert.Equal(t, [][]string{{"five", "four", "three"}, {"two"}},
+			pages(&dbpb.ListThreadsRequest{Title: ptr("^t|^f"), SortBy: ptr("_id"), Limit: ptr(int32(3))}))
+
+		// threads created between pages do not push threads of the previous page into the next one
+		first, err := s.ListThreads(ctx, &dbpb.ListThreadsRequest{SortBy: ptr("_id"), Limit: ptr(int32(2))})
+		require.NoError(t, err)
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "six", Content: "six", AuthorId: "alice"})
+		assert.Equal(t, []string{"three", "two", "one"}, threadTitles(t, s, &dbpb.ListThreadsRequest{SortBy: ptr("_id"), PageToken: ptr(first.NextPageToken)}))
+		assert.Equal(t, []string{"four", "three"}, threadTitles(t, s, &dbpb.ListThreadsRequest{SortBy: ptr("_id"), Offset: ptr(int32(2)), Limit: ptr(int32(2))}))
+
+		_, err = s.ListThreads(ctx, &dbpb.ListThreadsRequest{PageToken: ptr("not a token")})
+		assertCode(t, err, codes.InvalidArgument, "Invalid page token")
+		_, err = s.ListThreads(ctx, &dbpb.ListThreadsRequest{SortBy: ptr("ups"), PageToken: ptr(first.NextPageToken)})
+		assertCode(t, err, codes.InvalidArgument, "Invalid page token")
+		_, err = s.ListComments(ctx, &dbpb.ListCommentsRequest{SortBy: ptr("_id"), PageToken: ptr(first.NextPageToken)})
+		assertCode(t, err, codes.InvalidArgument, "Invalid page token")
+		// a token only continues the list with the filters it was created for, unset filters differ from empty ones
+		for _, req := range []*dbpb.ListThreadsRequest{
+			{Title: ptr("^t")},
+			{AuthorId: ptr("alice")},
+			{Flair: ptr("")},
+			{Drafts: true},
+		} {
+			req.SortBy, req.PageToken = ptr("_id"), ptr(first.NextPageToken)
+			_, err = s.ListThreads(ctx, req)
+			assertCode(t, err, codes.InvalidArgument, "Invalid page token")
+		}
+
+		for _, content := range []string{"a", "b", "c"} {
+			_, err := s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: content, ParentId: ids["one"], ParentType: models.CommentParentType_THREAD, AuthorId: "bob"})
+			require.NoError(t, err)
+		}
+		comments, err := s.ListComments(ctx, &dbpb.ListCommentsRequest{ThreadId: ptr(ids["one"]), SortBy: ptr("created_at"), Limit: ptr(int32(2))})
+		require.NoError(t, err)
+		require.Len(t, comments.Comments, 2)
+		_, err = s.ListComments(ctx, &dbpb.ListCommentsRequest{ThreadId: ptr(ids["two"]), SortBy: ptr("created_at"), PageToken: ptr(comments.NextPageToken)})
+		assertCode(t, err, codes.InvalidArgument, "Invalid page token")
+		comments, err = s.ListComments(ctx, &dbpb.ListCommentsRequest{ThreadId: ptr(ids["one"]), SortBy: ptr("created_at"), Limit: ptr(int32(2)), PageToken: ptr(comments.NextPageToken)})
+		require.NoError(t, err)
+		if assert.Len(t, comments.Comments, 1) {
+			assert.Equal(t, "a", comments.Comments[0].Content)
+		}
+		assert.Empty(t, comments.NextPageToken)
+
+		for _, name := range []string{"golang", "rustlang", "cooking"} {
+			createCommunity(t, s, name)
+		}
+		communities, err := s.ListCommunities(ctx, &dbpb.ListCommunitiesRequest{Limit: ptr(int32(1))})
+		require.NoError(t, err)
+		communities, err = s.ListCommunities(ctx, &dbpb.ListCommunitiesRequest{Offset: ptr(int32(5)), Limit: ptr(int32(1)), PageToken: ptr(communities.NextPageToken)})
+		require.NoError(t, err)
+		if assert.Len(t, communities.Communities, 1) {
+			assert.Equal(t, "rustlang", communities.Communities[0].Name)
+		}
+		_, err = s.ListCommunities(ctx, &dbpb.ListCommunitiesRequest{Name: ptr("lang"), PageToken: ptr(communities.NextPageToken)})
+		assertCode(t, err, codes.InvalidArgument, "Invalid page token")
+	})
+
+	t.Run("thread updates", func(t *testing.T) {
+		s := newServer(t)
+		id := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Title", Content: "*content*", AuthorId: "alice"})
+
+		_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: id})
+		assertCode(t, err, codes.InvalidArgument, "No update fields provided")
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: "missing", Locked: ptr(true)})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+
+		updates := []*dbpb.UpdateThreadRequest{
+			{Id: id, VoteOffset: ptr(int32(1))},
+			{Id: id, VoteOffset: ptr(int32(-1))},
+			{Id: id, NumCommentsOffset: ptr(int32(1))},
+			{Id: id, NumCommentsOffset: ptr(int32(1))},
+			{Id: id, NumCommentsOffset: ptr(int32(-1))},
+			{Id: id, NumReportsOffset: ptr(int32(1))},
+			{Id: id, Locked: ptr(true), SpamScore: ptr(0.5), Tags: &models.TagList{Tags: []string{"x"}}},
+		}
+		for _, update := range updates {
+			_, err := s.UpdateThread(ctx, update)
+			require.NoError(t, err)
+		}
+
+		thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), thread.Ups)
+		assert.Equal(t, int32(1), thread.Downs)
+		assert.Equal(t, int32(1), thread.NumComments)
+		assert.Equal(t, int32(1), thread.NumReports)
+		assert.True(t, thread.Locked)
+		assert.Equal(t, 0.5, thread.SpamScore)
+		assert.Equal(t, []string{"x"}, thread.Tags)
+		assert.Equal(t, "<p><em>content</em></p>\n", thread.ContentHtml)
+		assert.Nil(t, thread.EditedAt)
+
+		// the html is stored with the content, what was stored without it is rendered when read
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: id, Content: ptr("**edited**")})
+		require.NoError(t, err)
+		stored, err := s.Storage.Threads().Get(ctx, id)
+		require.NoError(t, err)
+		assert.Equal(t, "<p><strong>edited</strong></p>\n", stored.ContentHtml)
+		legacy := &models.Thread{Id: "legacy", CommunityId: "a", Title: "Legacy", Content: "*old*", CreatedAt: timestamppb.Now()}
+		require.NoError(t, s.Storage.Threads().Create(ctx, legacy))
+		thread, err = s.GetThread(ctx, &dbpb.GetThreadRequest{Id: "legacy"})
+		require.NoError(t, err)
+		assert.Equal(t, "<p><em>old</em></p>\n", thread.ContentHtml)
+	})
+
+	t.Run("revisions", func(t *testing.T) {
+		s := newServer(t)
+		id := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Title", Content: "one", AuthorId: "alice"})
+		_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: id, Content: ptr("two"), EditorId: "bob"})
+		require.NoError(t, err)
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: id, Title: ptr("New title"), EditorId: "alice"})
+		require.NoError(t, err)
+
+		res, err := s.ListRevisions(ctx, &dbpb.ListRevisionsRequest{ThreadId: id})
+		require.NoError(t, err)
+		if assert.Len(t, res.Revisions, 3) {
+			assert.Equal(t, int32(1), res.Revisions[0].Version)
+			assert.Equal(t, "alice", res.Revisions[0].EditorId)
+			assert.Equal(t, "one", res.Revisions[0].Content)
+			assert.Equal(t, "bob", res.Revisions[1].EditorId)
+			assert.Equal(t, "two", res.Revisions[1].Content)
+			assert.Contains(t, res.Revisions[1].Diff, "+two")
+			assert.Equal(t, int32(3), res.Revisions[2].Version)
+			assert.Equal(t, "New title", res.Revisions[2].Title)
+			assert.Contains(t, res.Revisions[2].Diff, "-Title\n+New title")
+		}
+
+		// concurrent edits record consecutive versions
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: id, Content: ptr(fmt.Sprint(i)), EditorId: "bob"})
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		res, err = s.ListRevisions(ctx, &dbpb.ListRevisionsRequest{ThreadId: id})
+		require.NoError(t, err)
+		if assert.Len(t, res.Revisions, 11) {
+			for i, revision := range res.Revisions {
+				assert.Equal(t, int32(i+1), revision.Version)
+			}
+		}
+
+		thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: id})
+		require.NoError(t, err)
+		assert.NotNil(t, thread.EditedAt)
+
+		_, err = s.ListRevisions(ctx, &dbpb.ListRevisionsRequest{})
+		assertCode(t, err, codes.InvalidArgument, "Exactly one of thread id and comment id is required")
+
+		_, err = s.DeleteThread(ctx, &dbpb.DeleteThreadRequest{Id: id})
+		require.NoError(t, err)
+		res, err = s.ListRevisions(ctx, &dbpb.ListRevisionsRequest{ThreadId: id})
+		require.NoError(t, err)
+		assert.Empty(t, res.Revisions)
+		_, err = s.DeleteThread(ctx, &dbpb.DeleteThreadRequest{Id: id})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+	})
+
+	t.Run("removal", func(t *testing.T) {
+		s := newServer(t)
+		id := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Title", Content: "content"})
+
+		_, err := s.RemoveThread(ctx, &dbpb.RemoveThreadRequest{Id: id})
+		assertCode(t, err, codes.InvalidArgument, "Removal type is required")
+		_, err = s.RemoveThread(ctx, &dbpb.RemoveThreadRequest{Id: id, RemovalType: models.RemovalType_REMOVED, Reason: "spam"})
+		require.NoError(t, err)
+		_, err = s.RemoveThread(ctx, &dbpb.RemoveThreadRequest{Id: id, RemovalType: models.RemovalType_REMOVED})
+		assertCode(t, err, codes.FailedPrecondition, "Thread is already removed")
+		_, err = s.RemoveThread(ctx, &dbpb.RemoveThreadRequest{Id: "missing", RemovalType: models.RemovalType_REMOVED})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+
+		thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, models.RemovalType_REMOVED, thread.RemovalType)
+		assert.Equal(t, "spam", thread.RemovalReason)
+		assert.NotNil(t, thread.RemovedAt)
+
+		_, err = s.RestoreThread(ctx, &dbpb.RestoreThreadRequest{Id: id})
+		require.NoError(t, err)
+		thread, err = s.GetThread(ctx, &dbpb.GetThreadRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, models.RemovalType_NOT_REMOVED, thread.RemovalType)
+		assert.Nil(t, thread.RemovedAt)
+		_, err = s.RestoreThread(ctx, &dbpb.RestoreThreadRequest{Id: id})
+		assertCode(t, err, codes.FailedPrecondition, "Thread is not removed")
+
+		// removals older than the grace period are final
+		removedAt := time.Now().Add(-src.RestoreGracePeriod - time.Hour)
+		require.NoError(t, s.Storage.Threads().Remove(ctx, id, models.RemovalType_DELETED, "", removedAt))
+		_, err = s.RestoreThread(ctx, &dbpb.RestoreThreadRequest{Id: id})
+		assertCode(t, err, codes.FailedPrecondition, "Thread can no longer be restored")
+		_, err = s.RestoreThread(ctx, &dbpb.RestoreThreadRequest{Id: "missing"})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+	})
+
+	t.Run("drafts", func(t *testing.T) {
+		s := newServer(t)
+		due := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Due", Draft: true, PublishAt: timestamppb.New(time.Now().Add(-time.Hour))})
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Later", Draft: true, PublishAt: timestamppb.New(time.Now().Add(time.Hour))})
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Unscheduled", Draft: true})
+
+		res, err := s.ListScheduledThreads(ctx, &dbpb.ListScheduledThreadsRequest{PublishBefore: timestamppb.Now()})
+		require.NoError(t, err)
+		if assert.Len(t, res.Threads, 1) {
+			assert.Equal(t, due, res.Threads[0].Id)
+		}
+
+		_, err = s.PublishThread(ctx, &dbpb.PublishThreadRequest{Id: due})
+		require.NoError(t, err)
+		_, err = s.PublishThread(ctx, &dbpb.PublishThreadRequest{Id: due})
+		assertCode(t, err, codes.FailedPrecondition, "Thread is already published")
+
+		thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: due})
+		require.NoError(t, err)
+		assert.False(t, thread.Draft)
+		assert.Nil(t, thread.PublishAt)
+		assert.Equal(t, []string{"Due"}, threadTitles(t, s, &dbpb.ListThreadsRequest{}))
+	})
+
+	t.Run("polls", func(t *testing.T) {
+		s := newServer(t)
+		id := createThread(t, s, &dbpb.CreateThreadRequest{
+			CommunityId: "a",
+			Title:       "Poll",
+			Kind:        models.ThreadKind_POLL,
+			Poll: &models.Poll{
+				Options:        []*models.PollOption{{Text: "yes"}, {Text: "no"}},
+				EndsAt:         timestamppb.New(time.Now().Add(time.Hour)),
+				MultipleChoice: true,
+			},
+		})
+		thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: id})
+		require.NoError(t, err)
+		require.Len(t, thread.GetPoll().GetOptions(), 2)
+		yes, no := thread.Poll.Options[0].Id, thread.Poll.Options[1].Id
+
+		_, err = s.CastPollVote(ctx, &dbpb.CastPollVoteRequest{ThreadId: id, UserId: "alice", OptionIds: []string{yes, no}})
+		require.NoError(t, err)
+		_, err = s.CastPollVote(ctx, &dbpb.CastPollVoteRequest{ThreadId: id, UserId: "bob", OptionIds: []string{yes}})
+		require.NoError(t, err)
+		_, err = s.CastPollVote(ctx, &dbpb.CastPollVoteRequest{ThreadId: id, UserId: "bob", OptionIds: []string{no}})
+		assertCode(t, err, codes.AlreadyExists, "User already voted in this poll")
+
+		thread, err = s.GetThread(ctx, &dbpb.GetThreadRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), thread.Poll.NumVoters)
+		assert.Equal(t, int32(2), thread.Poll.Options[0].NumVotes)
+		assert.Equal(t, int32(1), thread.Poll.Options[1].NumVotes)
+	})
+
+	t.Run("move", func(t *testing.T) {
+		s := newServer(t)
+		from := createCommunity(t, s, "from")
+		to := createCommunity(t, s, "to")
+		_, err := s.UpdateCommunity(ctx, &dbpb.UpdateCommunityRequest{Id: from, NumThreadsOffset: ptr(int32(1))})
+		require.NoError(t, err)
+		id := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: from, Title: "Moving", AuthorId: "alice", Flair: "news"})
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: id, Pinned: ptr(true)})
+		require.NoError(t, err)
+		item, err := s.CreateModQueueItem(ctx, &dbpb.CreateModQueueItemRequest{CommunityId: from, ThreadId: id, Rule: "rule"})
+		require.NoError(t, err)
+
+		_, err = s.MoveThread(ctx, &dbpb.MoveThreadRequest{Id: id, CommunityId: from})
+		assertCode(t, err, codes.FailedPrecondition, "Thread already belongs to this community")
+		_, err = s.MoveThread(ctx, &dbpb.MoveThreadRequest{Id: id, CommunityId: "missing"})
+		assertCode(t, err, codes.NotFound, "Community not found")
+
+		res, err := s.MoveThread(ctx, &dbpb.MoveThreadRequest{Id: id, CommunityId: to})
+		require.NoError(t, err)
+
+		thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: id})
+		require.NoError(t, err)
+		assert.Equal(t, to, thread.CommunityId)
+		assert.False(t, thread.Pinned)
+		assert.Empty(t, thread.Flair)
+
+		redirect, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: res.RedirectId})
+		require.NoError(t, err)
+		assert.Equal(t, models.ThreadKind_REDIRECT, redirect.Kind)
+		assert.Equal(t, from, redirect.CommunityId)
+		assert.Equal(t, id, redirect.RedirectThreadId)
+		assert.Equal(t, to, redirect.RedirectCommunityId)
+		assert.True(t, redirect.Locked)
+
+		for community, numThreads := range map[string]int32{from: 0, to: 1} {
+			res, err := s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: community})
+			require.NoError(t, err)
+			assert.Equal(t, numThreads, res.NumThreads)
+		}
+		items, err := s.ListModQueue(ctx, &dbpb.ListModQueueRequest{CommunityId: ptr(to)})
+		require.NoError(t, err)
+		if assert.Len(t, items.Items, 1) {
+			assert.Equal(t, item.Id, items.Items[0].Id)
+		}
+
+		// moving back drops the redirect left in the community
+		_, err = s.MoveThread(ctx, &dbpb.MoveThreadRequest{Id: id, CommunityId: from})
+		require.NoError(t, err)
+		_, err = s.GetThread(ctx, &dbpb.GetThreadRequest{Id: res.RedirectId})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+	})
+
+	t.Run("counters", func(t *testing.T) {
+		s := newServer(t)
+		community := createCommunity(t, s, "golang")
+		numThreads := func() int32 {
+			res, err := s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: community})
+			require.NoError(t, err)
+			return res.NumThreads
+		}
+		numComments := func(thread string) int32 {
+			res, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: thread})
+			require.NoError(t, err)
+			return res.NumComments
+		}
+
+		_, err := s.CreateThreadAndIncrement(ctx, &dbpb.CreateThreadRequest{CommunityId: "missing", Title: "Lost"})
+		assertCode(t, err, codes.NotFound, "Community not found")
+		assert.Empty(t, threadTitles(t, s, &dbpb.ListThreadsRequest{}))
+
+		thread, err := s.CreateThreadAndIncrement(ctx, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Counted"})
+		require.NoError(t, err)
+		draft, err := s.CreateThreadAndIncrement(ctx, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Draft", Draft: true})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), numThreads())
+		_, err = s.PublishThreadAndIncrement(ctx, &dbpb.PublishThreadRequest{Id: draft.Id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), numThreads())
+		_, err = s.PublishThreadAndIncrement(ctx, &dbpb.PublishThreadRequest{Id: draft.Id})
+		assertCode(t, err, codes.FailedPrecondition, "Thread is already published")
+		assert.Equal(t, int32(2), numThreads())
+
+		// concurrent crossposts of a thread to the same community create one
+		crossposts := make(chan string, 4)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				res, err := s.CreateThreadAndIncrement(ctx, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Crosspost", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"})
+				if err != nil {
+					assertCode(t, err, codes.AlreadyExists, "Thread is already crossposted to this community")
+					return
+				}
+				crossposts <- res.Id
+			}()
+		}
+		wg.Wait()
+		close(crossposts)
+		require.Len(t, crossposts, 1)
+		assert.Equal(t, int32(3), numThreads())
+		_, err = s.DeleteThreadAndDecrement(ctx, &dbpb.DeleteThreadRequest{Id: <-crossposts})
+		require.NoError(t, err)
+
+		_, err = s.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{Content: "lost", ParentId: "missing", ParentType: models.CommentParentType_THREAD})
+		assertCode(t, err, codes.NotFound, "Parent not found")
+		comment, err := s.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{Content: "top", ParentId: thread.Id, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+		reply, err := s.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{Content: "reply", ParentId: comment.Id, ParentType: models.CommentParentType_COMMENT})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), numComments(thread.Id))
+		parent, err := s.GetComment(ctx, &dbpb.GetCommentRequest{Id: comment.Id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), parent.NumComments)
+		comments, err := s.ListComments(ctx, &dbpb.ListCommentsRequest{})
+		require.NoError(t, err)
+		assert.Len(t, comments.Comments, 2)
+
+		_, err = s.DeleteCommentAndDecrement(ctx, &dbpb.DeleteCommentRequest{Id: reply.Id})
+		require.NoError(t, err)
+		_, err = s.DeleteCommentAndDecrement(ctx, &dbpb.DeleteCommentRequest{Id: comment.Id})
+		require.NoError(t, err)
+		_, err = s.DeleteCommentAndDecrement(ctx, &dbpb.DeleteCommentRequest{Id: comment.Id})
+		assertCode(t, err, codes.NotFound, "Comment not found")
+		assert.Equal(t, int32(0), numComments(thread.Id))
+
+		_, err = s.DeleteThreadAndDecrement(ctx, &dbpb.DeleteThreadRequest{Id: thread.Id})
+		require.NoError(t, err)
+		_, err = s.DeleteThreadAndDecrement(ctx, &dbpb.DeleteThreadRequest{Id: thread.Id})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+		assert.Equal(t, int32(1), numThreads())
+	})
+
+	t.Run("counter reconciliation", func(t *testing.T) {
+		s := newServer(t)
+		community := createCommunity(t, s, "golang")
+		thread := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Uncounted"})
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Draft", Draft: true})
+		comment, err := s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "top", ParentId: thread, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+		_, err = s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "reply", ParentId: comment.Id, ParentType: models.CommentParentType_COMMENT})
+		require.NoError(t, err)
+
+		want := []*dbpb.CounterDiscrepancy{
+			{ThreadId: thread, Stored: 0, Counted: 1},
+			{CommentId: comment.Id, Stored: 0, Counted: 1},
+			{CommunityId: community, Stored: 0, Counted: 1},
+		}
+		for _, fix := range []bool{false, true} {
+			res, err := s.ReconcileCounters(ctx, &dbpb.ReconcileCountersRequest{Fix: fix})
+			require.NoError(t, err)
+			assert.Equal(t, int32(5), res.NumChecked)
+			if assert.Len(t, res.Discrepancies, len(want)) {
+				for i := range want {
+					assert.Equal(t, want[i].String(), res.Discrepancies[i].String())
+				}
+			}
+		}
+
+		res, err := s.ReconcileCounters(ctx, &dbpb.ReconcileCountersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, res.Discrepancies)
+		got, err := s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: community})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), got.NumThreads)
+	})
+
+	t.Run("counter reconciliation job", func(t *testing.T) {
+		s := newServer(t)
+		s.ReconcileFix = true
+		community := createCommunity(t, s, "golang")
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Uncounted"})
+
+		// replicas scheduling the same interval create one job
+		job := &models.Job{Id: "reconcile-counters-1", Type: models.JobType_RECONCILE_COUNTERS, CreatedAt: timestamppb.Now(), UpdatedAt: timestamppb.Now()}
+		require.NoError(t, s.Storage.Jobs().Create(ctx, job))
+		assert.ErrorIs(t, s.Storage.Jobs().Create(ctx, job), storage.ErrAlreadyExists)
+
+		ran, err := s.RunNextJob(ctx)
+		require.NoError(t, err)
+		assert.True(t, ran)
+		ran, err = s.RunNextJob(ctx)
+		require.NoError(t, err)
+		assert.False(t, ran)
+		got, err := s.GetJobStatus(ctx, &dbpb.GetJobStatusRequest{Id: job.Id})
+		require.NoError(t, err)
+		assert.Equal(t, models.JobState_JOB_SUCCEEDED, got.State)
+		res, err := s.ReconcileCounters(ctx, &dbpb.ReconcileCountersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, res.Discrepancies)
+	})
+
+	t.Run("events", func(t *testing.T) {
+		s := newServer(t)
+		community := createCommunity(t, s, "golang")
+		thread := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Published"})
+		draft := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Draft", Draft: true})
+		_, err := s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: thread, VoteOffset: ptr(int32(-1))})
+		require.NoError(t, err)
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: thread, NumReportsOffset: ptr(int32(1))})
+		require.NoError(t, err)
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: thread, Locked: ptr(true)})
+		require.NoError(t, err)
+		_, err = s.PublishThread(ctx, &dbpb.PublishThreadRequest{Id: draft})
+		require.NoError(t, err)
+		_, err = s.CastPollVote(ctx, &dbpb.CastPollVoteRequest{ThreadId: draft, UserId: "alice"})
+		require.NoError(t, err)
+		comment, err := s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "top", ParentId: thread, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+		_, err = s.DeleteComment(ctx, &dbpb.DeleteCommentRequest{Id: comment.Id})
+		require.NoError(t, err)
+
+		want := []*models.Event{
+			{Type: models.EventType_COMMUNITY_CREATED, CommunityId: community},
+			{Type: models.EventType_THREAD_CREATED, ThreadId: thread},
+			{Type: models.EventType_VOTE_CAST, ThreadId: thread, Vote: -1},
+			{Type: models.EventType_THREAD_UPDATED, ThreadId: thread},
+			{Type: models.EventType_THREAD_PUBLISHED, ThreadId: draft},
+			{Type: models.EventType_POLL_VOTE_CAST, ThreadId: draft},
+			{Type: models.EventType_COMMENT_CREATED, CommentId: comment.Id},
+			{Type: models.EventType_COMMENT_DELETED, CommentId: comment.Id},
+		}
+		var events []*models.Event
+		for event := range subscribe(t, s, 0, len(want)) {
+			events = append(events, event)
+		}
+		if !assert.Len(t, events, len(want)) {
+			return
+		}
+		for i := range want {
+			if i > 0 {
+				assert.Greater(t, events[i].Offset, events[i-1].Offset)
+			}
+			assert.NotNil(t, events[i].CreatedAt)
+			got := &models.Event{Type: events[i].Type, CommunityId: events[i].CommunityId, ThreadId: events[i].ThreadId, CommentId: events[i].CommentId, Vote: events[i].Vote}
+			assert.Equal(t, want[i].String(), got.String())
+		}
+
+		// a subscriber resumes after the last event it handled, and receives the events written while subscribed
+		resumed := subscribe(t, s, events[len(want)-2].Offset, 2)
+		assert.Equal(t, events[len(want)-1].String(), (<-resumed).String())
+		_, err = s.DeleteThread(ctx, &dbpb.DeleteThreadRequest{Id: thread})
+		require.NoError(t, err)
+		if deleted := <-resumed; assert.NotNil(t, deleted) {
+			assert.Equal(t, models.EventType_THREAD_DELETED, deleted.Type)
+			assert.Equal(t, thread, deleted.ThreadId)
+		}
+
+		err = s.SubscribeEvents(&dbpb.SubscribeEventsRequest{AfterOffset: -1}, &eventStream{ctx: ctx})
+		assertCode(t, err, codes.InvalidArgument, "Offset cannot be negative")
+	})
+
+	t.Run("delete jobs", func(t *testing.T) {
+		s := newServer(t)
+		community := createCommunity(t, s, "golang")
+		other := createCommunity(t, s, "rustlang")
+		thread := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Thread"})
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: community, Title: "Draft", Draft: true})
+		comment, err := s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "top", ParentId: thread, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+		_, err = s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "reply", ParentId: comment.Id, ParentType: models.CommentParentType_COMMENT})
+		require.NoError(t, err)
+		_, err = s.CreateModQueueItem(ctx, &dbpb.CreateModQueueItemRequest{CommunityId: community, ThreadId: thread, Rule: "rule"})
+		require.NoError(t, err)
+		kept, err := s.CreateThreadAndIncrement(ctx, &dbpb.CreateThreadRequest{CommunityId: other, Title: "Kept"})
+		require.NoError(t, err)
+		_, err = s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "kept", ParentId: kept.Id, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+
+		_, err = s.CreateJob(ctx, &dbpb.CreateJobRequest{Type: models.JobType_DELETE_COMMUNITY})
+		assertCode(t, err, codes.InvalidArgument, "Target id is required")
+		_, err = s.CreateJob(ctx, &dbpb.CreateJobRequest{Type: models.JobType_DELETE_COMMUNITY, TargetId: "missing"})
+		assertCode(t, err, codes.NotFound, "Community not found")
+		_, err = s.CreateJob(ctx, &dbpb.CreateJobRequest{Type: models.JobType_DELETE_THREAD, TargetId: "missing"})
+		assertCode(t, err, codes.NotFound, "Thread not found")
+		_, err = s.CreateJob(ctx, &dbpb.CreateJobRequest{Type: models.JobType(42), TargetId: community})
+		assertCode(t, err, codes.InvalidArgument, "Invalid job type")
+		_, err = s.GetJobStatus(ctx, &dbpb.GetJobStatusRequest{Id: "missing"})
+		assertCode(t, err, codes.NotFound, "Job not found")
+
+		res, err := s.CreateJob(ctx, &dbpb.CreateJobRequest{Type: models.JobType_DELETE_COMMUNITY, TargetId: community})
+		require.NoError(t, err)
+		job, err := s.GetJobStatus(ctx, &dbpb.GetJobStatusRequest{Id: res.Id})
+		require.NoError(t, err)
+		assert.Equal(t, models.JobState_JOB_PENDING, job.State)
+		assert.Equal(t, community, job.TargetId)
+
+		// a runner that crashed keeps the job until its lease expires, then the job resumes
+		now := time.Now()
+		claimed, err := s.Storage.Jobs().Claim(ctx, "crashed", now, now.Add(time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, res.Id, claimed.Id)
+		assert.Equal(t, models.JobState_JOB_RUNNING, claimed.State)
+		ran, err := s.RunNextJob(ctx)
+		require.NoError(t, err)
+		assert.False(t, ran)
+		claimed.LeaseExpiresAt = timestamppb.New(now)
+		require.NoError(t, s.Storage.Jobs().Update(ctx, claimed))
+		ran, err = s.RunNextJob(ctx)
+		require.NoError(t, err)
+		assert.True(t, ran)
+
+		// the crashed runner no longer owns the job once it was claimed again
+		claimed.State = models.JobState_JOB_FAILED
+		assert.ErrorIs(t, s.Storage.Jobs().Update(ctx, claimed), storage.ErrFailedPrecondition)
+		job, err = s.GetJobStatus(ctx, &dbpb.GetJobStatusRequest{Id: res.Id})
+		require.NoError(t, err)
+		assert.Equal(t, models.JobState_JOB_SUCCEEDED, job.State)
+		assert.Equal(t, int32(2), job.NumThreadsDeleted)
+		assert.Equal(t, int32(2), job.NumCommentsDeleted)
+		assert.Nil(t, job.LeaseExpiresAt)
+		_, err = s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: community})
+		assertCode(t, err, codes.NotFound, "Community not found")
+		assert.Equal(t, []string{"Kept"}, threadTitles(t, s, &dbpb.ListThreadsRequest{}))
+		assert.Empty(t, threadTitles(t, s, &dbpb.ListThreadsRequest{CommunityId: ptr(community), Drafts: true}))
+		comments, err := s.ListComments(ctx, &dbpb.ListCommentsRequest{})
+		require.NoError(t, err)
+		if assert.Len(t, comments.Comments, 1) {
+			assert.Equal(t, "kept", comments.Comments[0].Content)
+		}
+		items, err := s.ListModQueue(ctx, &dbpb.ListModQueueRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, items.Items)
+
+		// deleting a thread stops counting it in its community
+		res, err = s.CreateJob(ctx, &dbpb.CreateJobRequest{Type: models.JobType_DELETE_THREAD, TargetId: kept.Id})
+		require.NoError(t, err)
+		ran, err = s.RunNextJob(ctx)
+		require.NoError(t, err)
+		assert.True(t, ran)
+		ran, err = s.RunNextJob(ctx)
+		require.NoError(t, err)
+		assert.False(t, ran)
+		job, err = s.GetJobStatus(ctx, &dbpb.GetJobStatusRequest{Id: res.Id})
+		require.NoError(t, err)
+		assert.Equal(t, models.JobState_JOB_SUCCEEDED, job.State)
+		assert.Equal(t, int32(1), job.NumThreadsDeleted)
+		assert.Equal(t, int32(1), job.NumCommentsDeleted)
+		assert.Empty(t, threadTitles(t, s, &dbpb.ListThreadsRequest{}))
+		got, err := s.GetCommunity(ctx, &dbpb.GetCommunityRequest{Id: other})
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), got.NumThreads)
+	})
+
+	t.Run("comments", func(t *testing.T) {
+		s := newServer(t)
+		var ids []string
+		for i, content := range []string{"first", "second", "third"} {
+			parentId := "thread"
+			if i == 2 {
+				parentId = "other"
+			}
+			res, err := s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: content, ParentId: parentId, ParentType: models.CommentParentType_THREAD, AuthorId: "alice"})
+			require.NoError(t, err)
+			ids = append(ids, res.Id)
+		}
+		_, err := s.UpdateComment(ctx, &dbpb.UpdateCommentRequest{Id: ids[1], VoteOffset: ptr(int32(1))})
+		require.NoError(t, err)
+		_, err = s.UpdateComment(ctx, &dbpb.UpdateCommentRequest{Id: ids[1]})
+		assertCode(t, err, codes.InvalidArgument, "No update fields provided")
+
+		res, err := s.ListComments(ctx, &dbpb.ListCommentsRequest{ThreadId: ptr("thread"), SortBy: ptr("ups")})
+		require.NoError(t, err)
+		if assert.Len(t, res.Comments, 2) {
+			assert.Equal(t, "second", res.Comments[0].Content)
+			assert.Equal(t, "<p>second</p>\n", res.Comments[0].ContentHtml)
+		}
+
+		_, err = s.UpdateComment(ctx, &dbpb.UpdateCommentRequest{Id: ids[0], Content: ptr("edited"), EditorId: "alice"})
+		require.NoError(t, err)
+		revisions, err := s.ListRevisions(ctx, &dbpb.ListRevisionsRequest{CommentId: ids[0]})
+		require.NoError(t, err)
+		if assert.Len(t, revisions.Revisions, 2) {
+			assert.Empty(t, revisions.Revisions[1].Title)
+			assert.Equal(t, "edited", revisions.Revisions[1].Content)
+		}
+
+		_, err = s.RemoveComment(ctx, &dbpb.RemoveCommentRequest{Id: ids[0], RemovalType: models.RemovalType_DELETED})
+		require.NoError(t, err)
+		_, err = s.RemoveComment(ctx, &dbpb.RemoveCommentRequest{Id: ids[0], RemovalType: models.RemovalType_REMOVED})
+		assertCode(t, err, codes.FailedPrecondition, "Comment is already removed")
+
+		_, err = s.DeleteComment(ctx, &dbpb.DeleteCommentRequest{Id: ids[0]})
+		require.NoError(t, err)
+		_, err = s.GetComment(ctx, &dbpb.GetCommentRequest{Id: ids[0]})
+		assertCode(t, err, codes.NotFound, "Comment not found")
+	})
+
+	t.Run("comment trees", func(t *testing.T) {
+		s := newServer(t)
+		thread := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "golang", Title: "Thread"})
+		top, err := s.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{Content: "top", ParentId: thread, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+		reply, err := s.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{Content: "reply", ParentId: top.Id, ParentType: models.CommentParentType_COMMENT})
+		require.NoError(t, err)
+		nested, err := s.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{Content: "nested", ParentId: reply.Id, ParentType: models.CommentParentType_COMMENT})
+		require.NoError(t, err)
+		_, err = s.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{Content: "sibling", ParentId: thread, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+		_, err = s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "orphan", ParentId: "missing", ParentType: models.CommentParentType_COMMENT})
+		assertCode(t, err, codes.NotFound, "Parent not found")
+
+		got, err := s.GetComment(ctx, &dbpb.GetCommentRequest{Id: nested.Id})
+		require.NoError(t, err)
+		assert.Equal(t, []string{top.Id, reply.Id}, got.AncestorIds)
+		got, err = s.GetComment(ctx, &dbpb.GetCommentRequest{Id: top.Id})
+		require.NoError(t, err)
+		assert.Empty(t, got.AncestorIds)
+
+		res, err := s.DeleteCommentTree(ctx, &dbpb.DeleteCommentRequest{Id: top.Id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), res.NumDeleted)
+		for _, id := range []string{top.Id, reply.Id, nested.Id} {
+			_, err = s.GetComment(ctx, &dbpb.GetCommentRequest{Id: id})
+			assertCode(t, err, codes.NotFound, "Comment not found")
+		}
+		comments, err := s.ListComments(ctx, &dbpb.ListCommentsRequest{})
+		require.NoError(t, err)
+		if assert.Len(t, comments.Comments, 1) {
+			assert.Equal(t, "sibling", comments.Comments[0].Content)
+		}
+		// only the top-level comment was counted on the thread
+		parent, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: thread})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), parent.NumComments)
+
+		_, err = s.DeleteCommentTree(ctx, &dbpb.DeleteCommentRequest{Id: top.Id})
+		assertCode(t, err, codes.NotFound, "Comment not found")
+	})
+
+	t.Run("moderation", func(t *testing.T) {
+		s := newServer(t)
+		createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Buy now", Content: "cheap", AuthorId: "spammer", Kind: models.ThreadKind_LINK, Url: "https://spam.example"})
+		spam := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Hello", Content: "world", AuthorId: "alice"})
+		deleted := createThread(t, s, &dbpb.CreateThreadRequest{CommunityId: "a", Title: "Gone", Content: "bye", AuthorId: "alice"})
+		_, err := s.RemoveThread(ctx, &dbpb.RemoveThreadRequest{Id: spam, RemovalType: models.RemovalType_REMOVED})
+		require.NoError(t, err)
+		_, err = s.RemoveThread(ctx, &dbpb.RemoveThreadRequest{Id: deleted, RemovalType: models.RemovalType_DELETED})
+		require.NoError(t, err)
+		_, err = s.UpdateThread(ctx, &dbpb.UpdateThreadRequest{Id: spam, VoteOffset: ptr(int32(1))})
+		require.NoError(t, err)
+
+		samples, err := s.ListSpamSamples(ctx, &dbpb.ListSpamSamplesRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, []*dbpb.SpamSample{
+			{Text: "Hello\nworld", Spam: true},
+			{Text: "Buy now\ncheap\nhttps://spam.example"},
+		}, samples.Samples)
+
+		stats, err := s.GetAuthorStats(ctx, &dbpb.GetAuthorStatsRequest{AuthorId: "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), stats.Karma)
+		assert.NotNil(t, stats.FirstSeenAt)
+		stats, err = s.GetAuthorStats(ctx, &dbpb.GetAuthorStatsRequest{AuthorId: "nobody"})
+		require.NoError(t, err)
+		assert.Nil(t, stats.FirstSeenAt)
+
+		config, err := s.GetAutomodConfig(ctx, &dbpb.GetAutomodConfigRequest{CommunityId: "a"})
+		require.NoError(t, err)
+		assert.Empty(t, config.Config)
+		_, err = s.SetAutomodConfig(ctx, &dbpb.SetAutomodConfigRequest{CommunityId: "a", Config: "rules: []"})
+		require.NoError(t, err)
+		config, err = s.GetAutomodConfig(ctx, &dbpb.GetAutomodConfigRequest{CommunityId: "a"})
+		require.NoError(t, err)
+		assert.Equal(t, "rules: []", config.Config)
+
+		model, err := s.GetSpamModel(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Nil(t, model.TrainedAt)
+		_, err = s.SetSpamModel(ctx, &dbpb.SpamModel{Model: []byte("model")})
+		require.NoError(t, err)
+		model, err = s.GetSpamModel(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("model"), model.Model)
+		assert.NotNil(t, model.TrainedAt)
+
+		_, err = s.DeleteModQueueItem(ctx, &dbpb.DeleteModQueueItemRequest{Id: "missing"})
+		assertCode(t, err, codes.NotFound, "Mod queue item not found")
+
+		// a rule acts once per target, dry runs are recorded apart
+		_, err = s.RecordAutomodAction(ctx, &dbpb.RecordAutomodActionRequest{ThreadId: spam, Rule: "links"})
+		require.NoError(t, err)
+		_, err = s.RecordAutomodAction(ctx, &dbpb.RecordAutomodActionRequest{ThreadId: spam, Rule: "links"})
+		assertCode(t, err, codes.AlreadyExists, "Rule already acted on this target")
+		_, err = s.RecordAutomodAction(ctx, &dbpb.RecordAutomodActionRequest{ThreadId: spam, Rule: "links", DryRun: true})
+		require.NoError(t, err)
+		_, err = s.RecordAutomodAction(ctx, &dbpb.RecordAutomodActionRequest{ThreadId: spam, CommentId: "comment", Rule: "links"})
+		require.NoError(t, err)
+		_, err = s.RecordAutomodAction(ctx, &dbpb.RecordAutomodActionRequest{ThreadId: spam, Rule: "reports"})
+		require.NoError(t, err)
+
+		// a user reports a target once, every reporter counts
+		_, err = s.CreateReport(ctx, &dbpb.CreateReportRequest{ThreadId: spam, ReporterId: "bob"})
+		require.NoError(t, err)
+		_, err = s.CreateReport(ctx, &dbpb.CreateReportRequest{ThreadId: spam, ReporterId: "bob"})
+		assertCode(t, err, codes.AlreadyExists, "User already reported this target")
+		_, err = s.CreateReport(ctx, &dbpb.CreateReportRequest{ThreadId: spam, ReporterId: "carol"})
+		require.NoError(t, err)
+		thread, err := s.GetThread(ctx, &dbpb.GetThreadRequest{Id: spam})
+		require.NoError(t, err)
+		assert.Equal(t, int32(2), thread.NumReports)
+		comment, err := s.CreateComment(ctx, &dbpb.CreateCommentRequest{Content: "spam", ParentId: deleted, ParentType: models.CommentParentType_THREAD})
+		require.NoError(t, err)
+		_, err = s.CreateReport(ctx, &dbpb.CreateReportRequest{CommentId: comment.Id, ReporterId: "bob"})
+		require.NoError(t, err)
+		reported, err := s.GetComment(ctx, &dbpb.GetCommentRequest{Id: comment.Id})
+		require.NoError(t, err)
+		assert.Equal(t, int32(1), reported.NumReports)
+		_, err = s.CreateReport(ctx, &dbpb.CreateReportRequest{ThreadId: "missing", ReporterId: "bob"})
+		assertCode(t, err, codes.NotFound, "Report target not found")
+		_, err = s.CreateReport(ctx, &dbpb.CreateReportRequest{ThreadId: spam})
+		assertCode(t, err, codes.InvalidArgument, "Exactly one of thread id and comment id, and reporter id are required")
+	})
+
+	t.Run("attachments", func(t *testing.T) {
+		s := newServer(t)
+		res, err := s.CreateAttachment(ctx, &dbpb.CreateAttachmentRequest{Filename: "a.png", ContentType: "image/png", Size: 10, Sha256: "abc", AuthorId: "alice"})
+		require.NoError(t, err)
+		attachment, err := s.GetAttachment(ctx, &dbpb.GetAttachmentRequest{Id: res.Id})
+		require.NoError(t, err)
+		assert.Equal(t, "a.png", attachment.Filename)
+		assert.Equal(t, int64(10), attachment.Size)
+		_, err = s.GetAttachment(ctx, &dbpb.GetAttachmentRequest{Id: "missing"})
+		assertCode(t, err, codes.NotFound, "Attachment not found")
+	})
+}
@@ -0,0 +1,250 @@
+package server
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// AutomodConfig is the set of rules a community defines in yaml, e.g.
+//
+//	dry_run: false
+//	rules:
+//	  - name: new-account-links
+//	    type: thread
+//	    domains: [example.com]
+//	    author:
+//	      account_age_below: 24h
+//	    action: remove
+//	    action_reason: Links from new accounts are not allowed
+type AutomodConfig struct {
+	// when set, matching rules are recorded in the mod queue but no action is taken
+	DryRun bool          `yaml:"dry_run"`
+	Rules  []AutomodRule `yaml:"rules"`
+}
+
+// AutomodRule matches when all of its conditions match
+type AutomodRule struct {
+	Name string `yaml:"name"`
+	Type string `yaml:"type"` // thread, comment or any
+
+	// conditions
+	Title   string            `yaml:"title"`   // regex matched against the thread title
+	Content string            `yaml:"content"` // regex matched against the content
+	Domains []string          `yaml:"domains"` // domains of links found in the content
+	Author  *AuthorConditions `yaml:"author"`
+	Reports int32             `yaml:"reports"` // minimum number of reports
+
+	// actions
+	Action       string `yaml:"action"` // remove or flag
+	ActionReason string `yaml:"action_reason"`
+	Lock         bool   `yaml:"lock"`
+	SetFlair     string `yaml:"set_flair"`
+	Reply        string `yaml:"reply"`
+
+	titleRegex   *regexp.Regexp
+	contentRegex *regexp.Regexp
+	accountAge   time.Duration
+}
+
+// AuthorConditions match authors by account age (time since their first thread or
+// comment) and karma (upvotes minus downvotes over all their threads and comments)
+type AuthorConditions struct {
+	AccountAgeBelow string `yaml:"account_age_below"`
+	KarmaBelow      *int32 `yaml:"karma_below"`
+}
+
+// AuthorStats are fetched lazily, only when a rule has author conditions
+type AuthorStats struct {
+	Karma      int32
+	AccountAge time.Duration
+}
+
+const (
+	RuleTypeThread  = "thread"
+	RuleTypeComment = "comment"
+	RuleTypeAny     = "any"
+
+	ActionRemove = "remove"
+	ActionFlag   = "flag"
+	ActionLock   = "lock"
+	ActionFlair  = "set_flair"
+	ActionReply  = "reply"
+)
+
+// AutomodSubject is the thread or comment rules are evaluated against
+type AutomodSubject struct {
+	Type       string
+	Title      string
+	Content    string
+	NumReports int32
+	SpamScore  float64 // 0 when the subject has not been scored yet
+}
+
+var linkRegex = regexp.MustCompile(`(?i)https?://([^/\s?#:]+)`)
+
+// ParseAutomodConfig parses and validates a yaml automod config
+func ParseAutomodConfig(text string) (*AutomodConfig, error) {
+	config := &AutomodConfig{}
+	if strings.TrimSpace(text) == "" {
+		return config, nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewBufferString(text))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil {
+		return nil, fmt.Errorf("invalid yaml: %w", err)
+	}
+
+	names := map[string]bool{}
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d: name is required", i+1)
+		}
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rule %s: duplicate name", rule.Name)
+		}
+		names[rule.Name] = true
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("rule %s: %w", rule.Name, err)
+		}
+	}
+	return config, nil
+}
+
+func (r *AutomodRule) compile() error {
+	switch r.Type {
+	case "":
+		r.Type = RuleTypeAny
+	case RuleTypeThread, RuleTypeComment, RuleTypeAny:
+	default:
+		return fmt.Errorf("unknown type %q", r.Type)
+	}
+
+	var err error
+	if r.Title != "" {
+		if r.Type == RuleTypeComment {
+			return errors.New("comments have no title")
+		}
+		if r.titleRegex, err = regexp.Compile(r.Title); err != nil {
+			return fmt.Errorf("invalid title regex: %w", err)
+		}
+	}
+	if r.Content != "" {
+		if r.contentRegex, err = regexp.Compile(r.Content); err != nil {
+			return fmt.Errorf("invalid content regex: %w", err)
+		}
+	}
+	for i, domain := range r.Domains {
+		r.Domains[i] = normalizeDomain(domain)
+	}
+	if r.Author != nil {
+		if r.Author.AccountAgeBelow == "" && r.Author.KarmaBelow == nil {
+			return errors.New("author conditions are empty")
+		}
+		if r.Author.AccountAgeBelow != "" {
+			if r.accountAge, err = time.ParseDuration(r.Author.AccountAgeBelow); err != nil {
+				return fmt.Errorf("invalid account age: %w", err)
+			}
+		}
+	}
+	if r.Reports < 0 {
+		return errors.New("reports must be a positive number")
+	}
+	if r.titleRegex == nil && r.contentRegex == nil && len(r.Domains) == 0 && r.Author == nil && r.Reports == 0 {
+		return errors.New("at least one condition is required")
+	}
+
+	switch r.Action {
+	case "", ActionRemove, ActionFlag:
+	default:
+		return fmt.Errorf("unknown action %q", r.Action)
+	}
+	if (r.Lock || r.SetFlair != "") && r.Type != RuleTypeThread {
+		return errors.New("lock and set_flair are only available for thread rules")
+	}
+	if len(r.Actions()) == 0 {
+		return errors.New("at least one action is required")
+	}
+	return nil
+}
+
+// Actions lists the actions a rule takes when it matches
+func (r *AutomodRule) Actions() []string {
+	var actions []string
+	if r.Action != "" {
+		actions = append(actions, r.Action)
+	}
+	if r.Lock {
+		actions = append(actions, ActionLock)
+	}
+	if r.SetFlair != "" {
+		actions = append(actions, ActionFlair)
+	}
+	if r.Reply != "" {
+		actions = append(actions, ActionReply)
+	}
+	return actions
+}
+
+// Match returns the rules matching the subject, author stats are only fetched if needed
+func (c *AutomodConfig) Match(subject *AutomodSubject, authorStats func() (*AuthorStats, error)) ([]*AutomodRule, error) {
+	var stats *AuthorStats
+	var matches []*AutomodRule
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Type != RuleTypeAny && rule.Type != subject.Type {
+			continue
+		}
+		if rule.titleRegex != nil && !rule.titleRegex.MatchString(subject.Title) {
+			continue
+		}
+		if rule.contentRegex != nil && !rule.contentRegex.MatchString(subject.Content) {
+			continue
+		}
+		if len(rule.Domains) > 0 && !containsDomain(subject.Content, rule.Domains) {
+			continue
+		}
+		if rule.Reports > 0 && subject.NumReports < rule.Reports {
+			continue
+		}
+		if rule.Author != nil {
+			if stats == nil {
+				var err error
+				if stats, err = authorStats(); err != nil {
+					return nil, err
+				}
+			}
+			if rule.accountAge > 0 && stats.AccountAge >= rule.accountAge {
+				continue
+			}
+			if rule.Author.KarmaBelow != nil && stats.Karma >= *rule.Author.KarmaBelow {
+				continue
+			}
+		}
+		matches = append(matches, rule)
+	}
+	return matches, nil
+}
+
+func containsDomain(content string, domains []string) bool {
+	for _, link := range linkRegex.FindAllStringSubmatch(content, -1) {
+		host := normalizeDomain(link[1])
+		for _, domain := range domains {
+			if host == domain || strings.HasSuffix(host, "."+domain) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizeDomain(domain string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(domain)), "www.")
+}
@@ -0,0 +1,636 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"gen/auth"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	moderationpb "gen/moderation-service/pb"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type ModerationServer struct {
+	moderationpb.UnimplementedModerationServiceServer
+	DBClient dbpb.DBServiceClient
+
+	// spam classifier, reloaded periodically to pick up models trained by other replicas
+	spamMu       sync.Mutex
+	spam         *SpamClassifier
+	spamLoadedAt time.Time
+}
+
+const (
+	// author id used for replies posted by automod
+	AutomodAuthorId = "automod"
+
+	// time allowed for evaluations triggered by reports, which run in the background
+	ReportEvaluationTimeout = 30 * time.Second
+
+	// content scoring at least this spam probability goes to the mod queue
+	SpamThreshold = 0.9
+	// rule name of mod queue items created by the spam classifier
+	SpamRuleName = "spam"
+	// time after which the spam model is reloaded from the database
+	SpamModelRefreshInterval = 10 * time.Minute
+	// samples fetched per request while training, below the largest page the db-service returns
+	SpamTrainingBatchSize int32 = 50
+)
+
+func (s *ModerationServer) CheckHealth(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ModerationServer) GetAutomodConfig(ctx context.Context, req *moderationpb.GetAutomodConfigRequest) (*moderationpb.AutomodConfig, error) {
+	// validate input
+	if req.GetCommunityId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id is required")
+	}
+
+	// fetch config
+	res, err := s.DBClient.GetAutomodConfig(ctx, &dbpb.GetAutomodConfigRequest{
+		CommunityId: req.CommunityId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &moderationpb.AutomodConfig{
+		CommunityId: req.CommunityId,
+		Config:      res.Config,
+	}, nil
+}
+
+func (s *ModerationServer) UpdateAutomodConfig(ctx context.Context, req *moderationpb.UpdateAutomodConfigRequest) (*emptypb.Empty, error) {
+	// validate inputs
+	if req.GetCommunityId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id is required")
+	}
+	if _, err := ParseAutomodConfig(req.GetConfig()); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid automod config: %v", err)
+	}
+	if !auth.RequesterHasRole(ctx, "moderator", "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can update automod configs")
+	}
+
+	// store config
+	_, err := s.DBClient.SetAutomodConfig(ctx, &dbpb.SetAutomodConfigRequest{
+		CommunityId: req.CommunityId,
+		Config:      req.Config,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ModerationServer) EvaluateThread(ctx context.Context, req *moderationpb.EvaluateThreadRequest) (*moderationpb.EvaluateResponse, error) {
+	// validate input
+	if req.GetThreadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
+	}
+	if !auth.RequesterIsInternal(ctx) && !auth.RequesterHasRole(ctx, "moderator", "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can evaluate automod rules")
+	}
+	return s.evaluateThread(ctx, req.ThreadId, req.DryRun)
+}
+
+func (s *ModerationServer) EvaluateComment(ctx context.Context, req *moderationpb.EvaluateCommentRequest) (*moderationpb.EvaluateResponse, error) {
+	// validate input
+	if req.GetCommentId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Comment id is required")
+	}
+	if !auth.RequesterIsInternal(ctx) && !auth.RequesterHasRole(ctx, "moderator", "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can evaluate automod rules")
+	}
+	return s.evaluateComment(ctx, req.CommentId, req.DryRun)
+}
+
+func (s *ModerationServer) ReportThread(ctx context.Context, req *moderationpb.ReportThreadRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetThreadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
+	}
+
+	reporterId := auth.RequesterId(ctx)
+	if reporterId == "" {
+		return nil, status.Error(codes.Unauthenticated, "Reporting requires an authenticated user")
+	}
+
+	// a user counts once in the thread num_reports, a repeated report fails with AlreadyExists and is not evaluated again
+	_, err := s.DBClient.CreateReport(ctx, &dbpb.CreateReportRequest{
+		ThreadId:   req.ThreadId,
+		ReporterId: reporterId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// report thresholds are evaluated in the background
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ReportEvaluationTimeout)
+		defer cancel()
+		if _, err := s.evaluateThread(ctx, req.ThreadId, false); err != nil {
+			log.Printf("failed to evaluate reported thread %s: %v", req.ThreadId, err)
+		}
+	}()
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ModerationServer) ReportComment(ctx context.Context, req *moderationpb.ReportCommentRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetCommentId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Comment id is required")
+	}
+
+	reporterId := auth.RequesterId(ctx)
+	if reporterId == "" {
+		return nil, status.Error(codes.Unauthenticated, "Reporting requires an authenticated user")
+	}
+
+	// a user counts once in the comment num_reports, a repeated report fails with AlreadyExists and is not evaluated again
+	_, err := s.DBClient.CreateReport(ctx, &dbpb.CreateReportRequest{
+		CommentId:  req.CommentId,
+		ReporterId: reporterId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// report thresholds are evaluated in the background
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), ReportEvaluationTimeout)
+		defer cancel()
+		if _, err := s.evaluateComment(ctx, req.CommentId, false); err != nil {
+			log.Printf("failed to evaluate reported comment %s: %v", req.CommentId, err)
+		}
+	}()
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ModerationServer) TrainSpam(ctx context.Context, req *moderationpb.TrainSpamRequest) (*moderationpb.TrainSpamResponse, error) {
+	if !auth.RequesterHasRole(ctx, "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only admins can train the spam classifier")
+	}
+
+	// train a new model from every thread and comment
+	classifier := NewSpamClassifier()
+	limit := SpamTrainingBatchSize
+	for _, source := range []dbpb.SpamSampleSource{dbpb.SpamSampleSource_THREADS, dbpb.SpamSampleSource_COMMENTS} {
+		// page until an empty page, the db-service may return fewer samples than requested
+		for offset := int32(0); ; {
+			res, err := s.DBClient.ListSpamSamples(ctx, &dbpb.ListSpamSamplesRequest{
+				Source: source,
+				Offset: &offset,
+				Limit:  &limit,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if len(res.Samples) == 0 {
+				break
+			}
+			for _, sample := range res.Samples {
+				classifier.Add(sample.Text, sample.Spam)
+			}
+			offset += int32(len(res.Samples))
+		}
+	}
+	classifier.Prune(MaxSpamVocabulary)
+
+	// store model
+	data, err := classifier.Marshal()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Failed to encode spam model")
+	}
+	_, err = s.DBClient.SetSpamModel(ctx, &dbpb.SpamModel{
+		Model: data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.spamMu.Lock()
+	s.spam, s.spamLoadedAt = classifier, time.Now()
+	s.spamMu.Unlock()
+
+	return &moderationpb.TrainSpamResponse{
+		NumSpam:        classifier.SpamDocs,
+		NumHam:         classifier.HamDocs,
+		VocabularySize: int32(classifier.VocabularySize()),
+	}, nil
+}
+
+func (s *ModerationServer) ScoreContent(ctx context.Context, req *moderationpb.ScoreContentRequest) (*moderationpb.ScoreContentResponse, error) {
+	// validate input
+	if req.GetContent() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Content is required")
+	}
+	if !auth.RequesterHasRole(ctx, "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only admins can score content")
+	}
+
+	// score content
+	classifier, err := s.spamClassifier(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &moderationpb.ScoreContentResponse{
+		Score: classifier.Score(spamText(req.Title, req.Content)),
+	}, nil
+}
+
+func (s *ModerationServer) ListModQueue(ctx context.Context, req *moderationpb.ListModQueueRequest) (*moderationpb.ListModQueueResponse, error) {
+	// validate inputs
+	if req.CommunityId != nil && req.GetCommunityId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id cannot be empty")
+	}
+	if req.Offset != nil && req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Offset must be a positive integer")
+	}
+	if req.Limit != nil && req.GetLimit() <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "Limit must be a positive integer")
+	}
+	if !auth.RequesterHasRole(ctx, "moderator", "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can list the mod queue")
+	}
+
+	// fetch queue
+	res, err := s.DBClient.ListModQueue(ctx, &dbpb.ListModQueueRequest{
+		CommunityId: req.CommunityId,
+		Offset:      req.Offset,
+		Limit:       req.Limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &moderationpb.ListModQueueResponse{
+		Items: res.Items,
+	}, nil
+}
+
+func (s *ModerationServer) ResolveModQueueItem(ctx context.Context, req *moderationpb.ResolveModQueueItemRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Mod queue item id is required")
+	}
+	if !auth.RequesterHasRole(ctx, "moderator", "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can resolve mod queue items")
+	}
+
+	// delete queue item
+	_, err := s.DBClient.DeleteModQueueItem(ctx, &dbpb.DeleteModQueueItemRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ModerationServer) evaluateThread(ctx context.Context, threadId string, dryRun bool) (*moderationpb.EvaluateResponse, error) {
+	thread, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
+		Id: threadId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if thread.GetRemovalType() != models.RemovalType_NOT_REMOVED {
+		return &moderationpb.EvaluateResponse{}, nil
+	}
+
+	// the url of link threads is checked like links in the content
+	content := thread.Content
+	if thread.Url != "" {
+		content += "\n" + thread.Url
+	}
+	subject := &AutomodSubject{
+		Type:       RuleTypeThread,
+		Title:      thread.Title,
+		Content:    content,
+		NumReports: thread.NumReports,
+		SpamScore:  thread.SpamScore,
+	}
+	target := &moderationTarget{
+		communityId: thread.CommunityId,
+		threadId:    thread.Id,
+	}
+	return s.evaluate(ctx, subject, target, thread.AuthorId, dryRun)
+}
+
+func (s *ModerationServer) evaluateComment(ctx context.Context, commentId string, dryRun bool) (*moderationpb.EvaluateResponse, error) {
+	comment, err := s.DBClient.GetComment(ctx, &dbpb.GetCommentRequest{
+		Id: commentId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if comment.GetRemovalType() != models.RemovalType_NOT_REMOVED {
+		return &moderationpb.EvaluateResponse{}, nil
+	}
+
+	// walk up the reply chain to find the thread, and with it the community
+	parent := comment
+	for parent.ParentType == models.CommentParentType_COMMENT {
+		parent, err = s.DBClient.GetComment(ctx, &dbpb.GetCommentRequest{
+			Id: parent.ParentId,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	thread, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
+		Id: parent.ParentId,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	subject := &AutomodSubject{
+		Type:       RuleTypeComment,
+		Content:    comment.Content,
+		NumReports: comment.NumReports,
+		SpamScore:  comment.SpamScore,
+	}
+	target := &moderationTarget{
+		communityId: thread.CommunityId,
+		threadId:    thread.Id,
+		commentId:   comment.Id,
+	}
+	return s.evaluate(ctx, subject, target, comment.AuthorId, dryRun)
+}
+
+// moderationTarget identifies the thread or comment actions are applied to
+type moderationTarget struct {
+	communityId string
+	threadId    string
+	commentId   string // empty when the target is a thread
+}
+
+func (s *ModerationServer) evaluate(ctx context.Context, subject *AutomodSubject, target *moderationTarget, authorId string, dryRun bool) (*moderationpb.EvaluateResponse, error) {
+	res, err := s.DBClient.GetAutomodConfig(ctx, &dbpb.GetAutomodConfigRequest{
+		CommunityId: target.communityId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	config, err := ParseAutomodConfig(res.GetConfig())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid stored automod config: %v", err)
+	}
+
+	rules, err := config.Match(subject, func() (*AuthorStats, error) {
+		return s.getAuthorStats(ctx, authorId)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]*moderationpb.RuleMatch, 0, len(rules))
+	for _, rule := range rules {
+		match := &moderationpb.RuleMatch{
+			Rule:    rule.Name,
+			Actions: rule.Actions(),
+		}
+		matches = append(matches, match)
+		if dryRun {
+			continue
+		}
+
+		// rules act once per target, evaluations triggered again by reports skip the rules that already acted
+		_, err = s.DBClient.RecordAutomodAction(ctx, &dbpb.RecordAutomodActionRequest{
+			ThreadId:  target.threadId,
+			CommentId: target.commentId,
+			Rule:      rule.Name,
+			DryRun:    config.DryRun,
+		})
+		if status.Code(err) == codes.AlreadyExists {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// community wide dry run only records what would have fired
+		if config.DryRun {
+			err = s.flag(ctx, target, rule, true)
+		} else {
+			err = s.applyActions(ctx, target, rule)
+			match.Applied = err == nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// new content is scored once, later evaluations report the recorded score
+	spamScore := subject.SpamScore
+	if spamScore == 0 {
+		spamScore = s.scoreSpam(ctx, subject, target, dryRun)
+	}
+	return &moderationpb.EvaluateResponse{
+		Matches:   matches,
+		SpamScore: spamScore,
+	}, nil
+}
+
+// scoreSpam scores the subject, records the score and flags spam unless this is a dry run,
+// failures are only logged since they should not prevent the rules from running
+func (s *ModerationServer) scoreSpam(ctx context.Context, subject *AutomodSubject, target *moderationTarget, dryRun bool) float64 {
+	classifier, err := s.spamClassifier(ctx)
+	if err != nil {
+		log.Printf("failed to load spam model: %v", err)
+		return 0
+	}
+	score := classifier.Score(spamText(subject.Title, subject.Content))
+	if dryRun || score == 0 {
+		return score
+	}
+
+	if target.commentId != "" {
+		_, err = s.DBClient.UpdateComment(ctx, &dbpb.UpdateCommentRequest{
+			Id:        target.commentId,
+			SpamScore: &score,
+		})
+	} else {
+		_, err = s.DBClient.UpdateThread(ctx, &dbpb.UpdateThreadRequest{
+			Id:        target.threadId,
+			SpamScore: &score,
+		})
+	}
+	if err != nil {
+		log.Printf("failed to record spam score: %v", err)
+	}
+	if score >= SpamThreshold {
+		_, err = s.DBClient.CreateModQueueItem(ctx, &dbpb.CreateModQueueItemRequest{
+			CommunityId: target.communityId,
+			ThreadId:    target.threadId,
+			CommentId:   target.commentId,
+			Rule:        SpamRuleName,
+			Reason:      fmt.Sprintf("Spam score %.2f", score),
+		})
+		if err != nil {
+			log.Printf("failed to flag spam: %v", err)
+		}
+	}
+	return score
+}
+
+func (s *ModerationServer) spamClassifier(ctx context.Context) (*SpamClassifier, error) {
+	s.spamMu.Lock()
+	defer s.spamMu.Unlock()
+	if s.spam != nil && time.Since(s.spamLoadedAt) < SpamModelRefreshInterval {
+		return s.spam, nil
+	}
+
+	res, err := s.DBClient.GetSpamModel(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+	classifier, err := ParseSpamClassifier(res.Model)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "Invalid stored spam model: %v", err)
+	}
+	s.spam, s.spamLoadedAt = classifier, time.Now()
+	return classifier, nil
+}
+
+func spamText(title string, content string) string {
+	if title == "" {
+		return content
+	}
+	return title + "\n" + content
+}
+
+func (s *ModerationServer) getAuthorStats(ctx context.Context, authorId string) (*AuthorStats, error) {
+	// anonymous authors are treated as new accounts without karma
+	if authorId == "" {
+		return &AuthorStats{}, nil
+	}
+	res, err := s.DBClient.GetAuthorStats(ctx, &dbpb.GetAuthorStatsRequest{
+		AuthorId: authorId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	stats := &AuthorStats{
+		Karma: res.Karma,
+	}
+	if res.FirstSeenAt != nil {
+		stats.AccountAge = time.Since(res.FirstSeenAt.AsTime())
+	}
+	return stats, nil
+}
+
+func (s *ModerationServer) applyActions(ctx context.Context, target *moderationTarget, rule *AutomodRule) error {
+	reason := rule.ActionReason
+	if reason == "" {
+		reason = fmt.Sprintf("automod: %s", rule.Name)
+	}
+
+	switch rule.Action {
+	case ActionRemove:
+		if err := s.remove(ctx, target, reason); err != nil {
+			return err
+		}
+	case ActionFlag:
+		if err := s.flag(ctx, target, rule, false); err != nil {
+			return err
+		}
+	}
+	if rule.Lock || rule.SetFlair != "" {
+		update := &dbpb.UpdateThreadRequest{
+			Id: target.threadId,
+		}
+		if rule.Lock {
+			update.Locked = &rule.Lock
+		}
+		if rule.SetFlair != "" {
+			color, err := s.flairColor(ctx, target.communityId, rule.SetFlair)
+			if err != nil {
+				return err
+			}
+			update.Flair, update.FlairColor = &rule.SetFlair, &color
+		}
+		if _, err := s.DBClient.UpdateThread(ctx, update); err != nil {
+			return err
+		}
+	}
+	if rule.Reply != "" {
+		if err := s.reply(ctx, target, rule.Reply); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// returns the colour of the community flair template with the given text, rules may set flair without a template
+func (s *ModerationServer) flairColor(ctx context.Context, communityId string, text string) (string, error) {
+	community, err := s.DBClient.GetCommunity(ctx, &dbpb.GetCommunityRequest{
+		Id: communityId,
+	})
+	if err != nil {
+		return "", err
+	}
+	for _, template := range community.FlairTemplates {
+		if template.Text == text {
+			return template.Color, nil
+		}
+	}
+	return "", nil
+}
+
+func (s *ModerationServer) remove(ctx context.Context, target *moderationTarget, reason string) error {
+	var err error
+	if target.commentId != "" {
+		_, err = s.DBClient.RemoveComment(ctx, &dbpb.RemoveCommentRequest{
+			Id:          target.commentId,
+			RemovalType: models.RemovalType_REMOVED,
+			Reason:      reason,
+		})
+	} else {
+		_, err = s.DBClient.RemoveThread(ctx, &dbpb.RemoveThreadRequest{
+			Id:          target.threadId,
+			RemovalType: models.RemovalType_REMOVED,
+			Reason:      reason,
+		})
+	}
+	// already removed by a moderator or another rule
+	if status.Code(err) == codes.FailedPrecondition {
+		return nil
+	}
+	return err
+}
+
+func (s *ModerationServer) flag(ctx context.Context, target *moderationTarget, rule *AutomodRule, dryRun bool) error {
+	_, err := s.DBClient.CreateModQueueItem(ctx, &dbpb.CreateModQueueItemRequest{
+		CommunityId: target.communityId,
+		ThreadId:    target.threadId,
+		CommentId:   target.commentId,
+		Rule:        rule.Name,
+		Reason:      rule.ActionReason,
+		DryRun:      dryRun,
+	})
+	return err
+}
+
+func (s *ModerationServer) reply(ctx context.Context, target *moderationTarget, content string) error {
+	parentId, parentType := target.threadId, models.CommentParentType_THREAD
+	if target.commentId != "" {
+		parentId, parentType = target.commentId, models.CommentParentType_COMMENT
+	}
+
+	// create reply, with the parent num_comments in the same transaction
+	_, err := s.DBClient.CreateCommentAndIncrement(ctx, &dbpb.CreateCommentRequest{
+		Content:    content,
+		ParentId:   parentId,
+		ParentType: parentType,
+		AuthorId:   AutomodAuthorId,
+	})
+	return err
+}
@@ -0,0 +1,149 @@
+package server
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// tokens kept in a trained model, the least frequent are dropped
+	MaxSpamVocabulary = 50000
+
+	// minimum samples of each class before the classifier scores anything
+	MinSpamSamples = 10
+)
+
+// SpamClassifier is a multinomial naive Bayes classifier over content tokens,
+// trained from threads and comments removed by moderators (spam) or left up (ham)
+type SpamClassifier struct {
+	SpamDocs   int32            `json:"spam_docs"`
+	HamDocs    int32            `json:"ham_docs"`
+	SpamTokens map[string]int32 `json:"spam_tokens"`
+	HamTokens  map[string]int32 `json:"ham_tokens"`
+	SpamTotal  int64            `json:"spam_total"`
+	HamTotal   int64            `json:"ham_total"`
+}
+
+func NewSpamClassifier() *SpamClassifier {
+	return &SpamClassifier{
+		SpamTokens: map[string]int32{},
+		HamTokens:  map[string]int32{},
+	}
+}
+
+// ParseSpamClassifier decodes a model stored with Marshal, an empty model is untrained
+func ParseSpamClassifier(data []byte) (*SpamClassifier, error) {
+	c := NewSpamClassifier()
+	if len(data) == 0 {
+		return c, nil
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *SpamClassifier) Marshal() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+// Add trains the classifier with a single sample
+func (c *SpamClassifier) Add(text string, spam bool) {
+	tokens, total, docs := c.HamTokens, &c.HamTotal, &c.HamDocs
+	if spam {
+		tokens, total, docs = c.SpamTokens, &c.SpamTotal, &c.SpamDocs
+	}
+	*docs++
+	for _, token := range tokenize(text) {
+		tokens[token]++
+		*total++
+	}
+}
+
+// Prune keeps the max most frequent tokens
+func (c *SpamClassifier) Prune(max int) {
+	if c.VocabularySize() <= max {
+		return
+	}
+	counts := make(map[string]int32, c.VocabularySize())
+	for token, count := range c.SpamTokens {
+		counts[token] += count
+	}
+	for token, count := range c.HamTokens {
+		counts[token] += count
+	}
+	tokens := make([]string, 0, len(counts))
+	for token := range counts {
+		tokens = append(tokens, token)
+	}
+	sort.Slice(tokens, func(i, j int) bool {
+		if counts[tokens[i]] != counts[tokens[j]] {
+			return counts[tokens[i]] > counts[tokens[j]]
+		}
+		return tokens[i] < tokens[j]
+	})
+	for _, token := range tokens[max:] {
+		c.SpamTotal -= int64(c.SpamTokens[token])
+		c.HamTotal -= int64(c.HamTokens[token])
+		delete(c.SpamTokens, token)
+		delete(c.HamTokens, token)
+	}
+}
+
+func (c *SpamClassifier) VocabularySize() int {
+	size := len(c.SpamTokens)
+	for token := range c.HamTokens {
+		if _, ok := c.SpamTokens[token]; !ok {
+			size++
+		}
+	}
+	return size
+}
+
+// Trained reports whether enough samples of both classes have been seen to score content
+func (c *SpamClassifier) Trained() bool {
+	return c.SpamDocs >= MinSpamSamples && c.HamDocs >= MinSpamSamples
+}
+
+// Score returns the probability that text is spam, 0 while the classifier is untrained
+func (c *SpamClassifier) Score(text string) float64 {
+	if !c.Trained() {
+		return 0
+	}
+
+	// log probabilities with laplace smoothing, tokens never seen in training are ignored
+	vocabulary := float64(c.VocabularySize())
+	spamLog := math.Log(float64(c.SpamDocs) / float64(c.SpamDocs+c.HamDocs))
+	hamLog := math.Log(float64(c.HamDocs) / float64(c.SpamDocs+c.HamDocs))
+	for _, token := range tokenize(text) {
+		spamCount, inSpam := c.SpamTokens[token]
+		hamCount, inHam := c.HamTokens[token]
+		if !inSpam && !inHam {
+			continue
+		}
+		spamLog += math.Log((float64(spamCount) + 1) / (float64(c.SpamTotal) + vocabulary))
+		hamLog += math.Log((float64(hamCount) + 1) / (float64(c.HamTotal) + vocabulary))
+	}
+	return 1 / (1 + math.Exp(hamLog-spamLog))
+}
+
+// tokenize splits text into lowercase words, links also yield a token for their domain
+func tokenize(text string) []string {
+	var tokens []string
+	for _, link := range linkRegex.FindAllStringSubmatch(text, -1) {
+		tokens = append(tokens, "domain:"+normalizeDomain(link[1]))
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		if len([]rune(word)) < 2 {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
@@ -0,0 +1,836 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"gen/auth"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	moderationpb "gen/moderation-service/pb"
+	src "moderation-service/src"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type MockDBClient struct {
+	dbpb.DBServiceClient
+	GetThreadFunc                 func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error)
+	UpdateThreadFunc              func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveThreadFunc              func(ctx context.Context, req *dbpb.RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAuthorStatsFunc            func(ctx context.Context, req *dbpb.GetAuthorStatsRequest, opts ...grpc.CallOption) (*dbpb.GetAuthorStatsResponse, error)
+	GetAutomodConfigFunc          func(ctx context.Context, req *dbpb.GetAutomodConfigRequest, opts ...grpc.CallOption) (*dbpb.GetAutomodConfigResponse, error)
+	SetAutomodConfigFunc          func(ctx context.Context, req *dbpb.SetAutomodConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateModQueueItemFunc        func(ctx context.Context, req *dbpb.CreateModQueueItemRequest, opts ...grpc.CallOption) (*dbpb.CreateModQueueItemResponse, error)
+	ListModQueueFunc              func(ctx context.Context, req *dbpb.ListModQueueRequest, opts ...grpc.CallOption) (*dbpb.ListModQueueResponse, error)
+	DeleteModQueueItemFunc        func(ctx context.Context, req *dbpb.DeleteModQueueItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RecordAutomodActionFunc       func(ctx context.Context, req *dbpb.RecordAutomodActionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateCommentAndIncrementFunc func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error)
+	GetSpamModelFunc              func(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*dbpb.SpamModel, error)
+	SetSpamModelFunc              func(ctx context.Context, req *dbpb.SpamModel, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListSpamSamplesFunc           func(ctx context.Context, req *dbpb.ListSpamSamplesRequest, opts ...grpc.CallOption) (*dbpb.ListSpamSamplesResponse, error)
+	GetCommunityFunc              func(ctx context.Context, req *dbpb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error)
+	CreateReportFunc              func(ctx context.Context, req *dbpb.CreateReportRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+func (m *MockDBClient) GetThread(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+	return m.GetThreadFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) UpdateThread(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.UpdateThreadFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) RemoveThread(ctx context.Context, req *dbpb.RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.RemoveThreadFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetAuthorStats(ctx context.Context, req *dbpb.GetAuthorStatsRequest, opts ...grpc.CallOption) (*dbpb.GetAuthorStatsResponse, error) {
+	return m.GetAuthorStatsFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetAutomodConfig(ctx context.Context, req *dbpb.GetAutomodConfigRequest, opts ...grpc.CallOption) (*dbpb.GetAutomodConfigResponse, error) {
+	return m.GetAutomodConfigFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) SetAutomodConfig(ctx context.Context, req *dbpb.SetAutomodConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.SetAutomodConfigFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) CreateModQueueItem(ctx context.Context, req *dbpb.CreateModQueueItemRequest, opts ...grpc.CallOption) (*dbpb.CreateModQueueItemResponse, error) {
+	return m.CreateModQueueItemFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) ListModQueue(ctx context.Context, req *dbpb.ListModQueueRequest, opts ...grpc.CallOption) (*dbpb.ListModQueueResponse, error) {
+	return m.ListModQueueFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) DeleteModQueueItem(ctx context.Context, req *dbpb.DeleteModQueueItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.DeleteModQueueItemFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) RecordAutomodAction(ctx context.Context, req *dbpb.RecordAutomodActionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.RecordAutomodActionFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) CreateCommentAndIncrement(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
+	return m.CreateCommentAndIncrementFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetSpamModel(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*dbpb.SpamModel, error) {
+	return m.GetSpamModelFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) SetSpamModel(ctx context.Context, req *dbpb.SpamModel, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.SetSpamModelFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) ListSpamSamples(ctx context.Context, req *dbpb.ListSpamSamplesRequest, opts ...grpc.CallOption) (*dbpb.ListSpamSamplesResponse, error) {
+	return m.ListSpamSamplesFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetCommunity(ctx context.Context, req *dbpb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
+	return m.GetCommunityFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) CreateReport(ctx context.Context, req *dbpb.CreateReportRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.CreateReportFunc(ctx, req, opts...)
+}
+
+const testConfig = `
+rules:
+  - name: no-spam-links
+    type: thread
+    domains: [spam.com]
+    action: remove
+    action_reason: Spam links are not allowed
+  - name: new-accounts
+    author:
+      account_age_below: 24h
+      karma_below: 5
+    action: flag
+  - name: questions
+    type: thread
+    title: "(?i)^question"
+    set_flair: Question
+`
+
+func TestParseAutomodConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr bool
+	}{
+		{
+			name:    "empty config",
+			config:  "",
+			wantErr: false,
+		},
+		{
+			name:    "valid config",
+			config:  testConfig,
+			wantErr: false,
+		},
+		{
+			name:    "unknown field",
+			config:  "rules:\n  - name: a\n    titel: b\n    action: flag\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing condition",
+			config:  "rules:\n  - name: a\n    action: flag\n",
+			wantErr: true,
+		},
+		{
+			name:    "missing action",
+			config:  "rules:\n  - name: a\n    content: b\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid regex",
+			config:  "rules:\n  - name: a\n    content: \"(\"\n    action: flag\n",
+			wantErr: true,
+		},
+		{
+			name:    "lock on comments",
+			config:  "rules:\n  - name: a\n    type: comment\n    content: b\n    lock: true\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := src.ParseAutomodConfig(tt.config)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAutomodConfig_Match(t *testing.T) {
+	config, err := src.ParseAutomodConfig(testConfig)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		subject   *src.AutomodSubject
+		stats     *src.AuthorStats
+		wantRules []string
+	}{
+		{
+			name:      "spam domain",
+			subject:   &src.AutomodSubject{Type: src.RuleTypeThread, Title: "hello", Content: "visit https://www.SPAM.com/offer"},
+			stats:     &src.AuthorStats{Karma: 100, AccountAge: 365 * 24 * time.Hour},
+			wantRules: []string{"no-spam-links"},
+		},
+		{
+			name:      "subdomain of spam domain",
+			subject:   &src.AutomodSubject{Type: src.RuleTypeThread, Title: "hello", Content: "http://cheap.spam.com"},
+			stats:     &src.AuthorStats{Karma: 100, AccountAge: 365 * 24 * time.Hour},
+			wantRules: []string{"no-spam-links"},
+		},
+		{
+			name:      "new account",
+			subject:   &src.AutomodSubject{Type: src.RuleTypeComment, Content: "hello"},
+			stats:     &src.AuthorStats{Karma: 0},
+			wantRules: []string{"new-accounts"},
+		},
+		{
+			name:      "thread rules do not apply to comments",
+			subject:   &src.AutomodSubject{Type: src.RuleTypeComment, Content: "https://spam.com"},
+			stats:     &src.AuthorStats{Karma: 100, AccountAge: 365 * 24 * time.Hour},
+			wantRules: nil,
+		},
+		{
+			name:      "title regex",
+			subject:   &src.AutomodSubject{Type: src.RuleTypeThread, Title: "Question about go", Content: "hello"},
+			stats:     &src.AuthorStats{Karma: 100, AccountAge: 365 * 24 * time.Hour},
+			wantRules: []string{"questions"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := config.Match(tt.subject, func() (*src.AuthorStats, error) {
+				return tt.stats, nil
+			})
+			assert.NoError(t, err)
+			var names []string
+			for _, rule := range rules {
+				names = append(names, rule.Name)
+			}
+			assert.Equal(t, tt.wantRules, names)
+		})
+	}
+}
+
+func TestUpdateAutomodConfig_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *moderationpb.UpdateAutomodConfigRequest
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "missing community id",
+			req:     &moderationpb.UpdateAutomodConfigRequest{},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Community id is required"),
+		},
+		{
+			name: "invalid config",
+			req: &moderationpb.UpdateAutomodConfigRequest{
+				CommunityId: "123",
+				Config:      "rules: [",
+			},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Invalid automod config: invalid yaml: yaml: line 1: did not find expected node content"),
+		},
+		{
+			name: "not a moderator",
+			req: &moderationpb.UpdateAutomodConfigRequest{
+				CommunityId: "123",
+				Config:      testConfig,
+			},
+			roles:   "member",
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can update automod configs"),
+		},
+		{
+			name: "valid request",
+			req: &moderationpb.UpdateAutomodConfigRequest{
+				CommunityId: "123",
+				Config:      testConfig,
+			},
+			roles:   "member, moderator",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					SetAutomodConfigFunc: func(ctx context.Context, req *dbpb.SetAutomodConfigRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			_, err := server.UpdateAutomodConfig(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListModQueue_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *moderationpb.ListModQueueRequest
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "empty community id",
+			req:     &moderationpb.ListModQueueRequest{CommunityId: strPtr("")},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Community id cannot be empty"),
+		},
+		{
+			name:    "anonymous",
+			req:     &moderationpb.ListModQueueRequest{CommunityId: strPtr("123")},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can list the mod queue"),
+		},
+		{
+			name:    "valid request",
+			req:     &moderationpb.ListModQueueRequest{CommunityId: strPtr("123")},
+			roles:   "admin",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					ListModQueueFunc: func(ctx context.Context, req *dbpb.ListModQueueRequest, opts ...grpc.CallOption) (*dbpb.ListModQueueResponse, error) {
+						return &dbpb.ListModQueueResponse{Items: []*models.ModQueueItem{{Id: "1"}}}, nil
+					},
+				},
+			}
+
+			res, err := server.ListModQueue(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.GetItems(), 1)
+			}
+		})
+	}
+}
+
+func TestResolveModQueueItem_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *moderationpb.ResolveModQueueItemRequest
+		roles        string
+		wantResolved bool
+		wantErr      error
+	}{
+		{
+			name:    "missing id",
+			req:     &moderationpb.ResolveModQueueItemRequest{},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Mod queue item id is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &moderationpb.ResolveModQueueItemRequest{Id: "1"},
+			roles:   "member",
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can resolve mod queue items"),
+		},
+		{
+			name:         "valid request",
+			req:          &moderationpb.ResolveModQueueItemRequest{Id: "1"},
+			roles:        "moderator",
+			wantResolved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolved := false
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					DeleteModQueueItemFunc: func(ctx context.Context, req *dbpb.DeleteModQueueItemRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, "1", req.GetId())
+						resolved = true
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			_, err := server.ResolveModQueueItem(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantResolved, resolved)
+		})
+	}
+}
+
+// recordAutomodActions records actions like the db service, failing when a rule already acted on a target
+func recordAutomodActions() func(ctx context.Context, req *dbpb.RecordAutomodActionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	recorded := map[string]bool{}
+	return func(ctx context.Context, req *dbpb.RecordAutomodActionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+		key := fmt.Sprintf("%s:%s:%s:%t", req.GetThreadId(), req.GetCommentId(), req.GetRule(), req.GetDryRun())
+		if recorded[key] {
+			return nil, status.Error(codes.AlreadyExists, "Rule already acted on this target")
+		}
+		recorded[key] = true
+		return &emptypb.Empty{}, nil
+	}
+}
+
+func TestEvaluateThread(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		dryRun      bool
+		wantRemoved bool
+		wantQueued  bool
+	}{
+		{
+			name:        "rule applied",
+			config:      testConfig,
+			wantRemoved: true,
+		},
+		{
+			name:   "dry run request",
+			config: testConfig,
+			dryRun: true,
+		},
+		{
+			name:       "dry run config",
+			config:     "dry_run: true\n" + testConfig,
+			wantQueued: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removed, queued := false, false
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return &models.Thread{
+							Id:          "123",
+							CommunityId: "456",
+							Title:       "cheap offer",
+							Content:     "https://spam.com",
+							AuthorId:    "789",
+						}, nil
+					},
+					GetAutomodConfigFunc: func(ctx context.Context, req *dbpb.GetAutomodConfigRequest, opts ...grpc.CallOption) (*dbpb.GetAutomodConfigResponse, error) {
+						return &dbpb.GetAutomodConfigResponse{Config: tt.config}, nil
+					},
+					GetAuthorStatsFunc: func(ctx context.Context, req *dbpb.GetAuthorStatsRequest, opts ...grpc.CallOption) (*dbpb.GetAuthorStatsResponse, error) {
+						return &dbpb.GetAuthorStatsResponse{Karma: 50, FirstSeenAt: timestamppb.New(time.Now().AddDate(-1, 0, 0))}, nil
+					},
+					RemoveThreadFunc: func(ctx context.Context, req *dbpb.RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, "Spam links are not allowed", req.GetReason())
+						removed = true
+						return &emptypb.Empty{}, nil
+					},
+					CreateModQueueItemFunc: func(ctx context.Context, req *dbpb.CreateModQueueItemRequest, opts ...grpc.CallOption) (*dbpb.CreateModQueueItemResponse, error) {
+						assert.True(t, req.GetDryRun())
+						queued = true
+						return &dbpb.CreateModQueueItemResponse{Id: "1"}, nil
+					},
+					RecordAutomodActionFunc: recordAutomodActions(),
+					GetSpamModelFunc: func(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*dbpb.SpamModel, error) {
+						return &dbpb.SpamModel{}, nil
+					},
+				},
+			}
+
+			res, err := server.EvaluateThread(context.Background(), &moderationpb.EvaluateThreadRequest{ThreadId: "123", DryRun: tt.dryRun})
+			assert.NoError(t, err)
+			assert.Len(t, res.Matches, 1)
+			assert.Equal(t, "no-spam-links", res.Matches[0].Rule)
+			assert.Equal(t, tt.wantRemoved, res.Matches[0].Applied)
+			assert.Equal(t, tt.wantRemoved, removed)
+			assert.Equal(t, tt.wantQueued, queued)
+		})
+	}
+}
+
+func TestEvaluateThread_SetFlair(t *testing.T) {
+	updated := false
+	server := &src.ModerationServer{
+		DBClient: &MockDBClient{
+			GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				return &models.Thread{
+					Id:          "123",
+					CommunityId: "456",
+					Title:       "Question about go",
+					Content:     "hello",
+					AuthorId:    "789",
+				}, nil
+			},
+			GetAutomodConfigFunc: func(ctx context.Context, req *dbpb.GetAutomodConfigRequest, opts ...grpc.CallOption) (*dbpb.GetAutomodConfigResponse, error) {
+				return &dbpb.GetAutomodConfigResponse{Config: testConfig}, nil
+			},
+			GetAuthorStatsFunc: func(ctx context.Context, req *dbpb.GetAuthorStatsRequest, opts ...grpc.CallOption) (*dbpb.GetAuthorStatsResponse, error) {
+				return &dbpb.GetAuthorStatsResponse{Karma: 50, FirstSeenAt: timestamppb.New(time.Now().AddDate(-1, 0, 0))}, nil
+			},
+			GetCommunityFunc: func(ctx context.Context, req *dbpb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
+				assert.Equal(t, "456", req.GetId())
+				return &models.Community{
+					Id:             "456",
+					FlairTemplates: []*models.FlairTemplate{{Id: "1", Text: "Question", Color: "#0079d3"}},
+				}, nil
+			},
+			UpdateThreadFunc: func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+				if req.Flair != nil {
+					assert.Equal(t, "Question", req.GetFlair())
+					assert.Equal(t, "#0079d3", req.GetFlairColor())
+					updated = true
+				}
+				return &emptypb.Empty{}, nil
+			},
+			GetSpamModelFunc: func(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*dbpb.SpamModel, error) {
+				return &dbpb.SpamModel{}, nil
+			},
+			RecordAutomodActionFunc: recordAutomodActions(),
+		},
+	}
+
+	_, err := server.EvaluateThread(context.Background(), &moderationpb.EvaluateThreadRequest{ThreadId: "123"})
+	assert.NoError(t, err)
+	assert.True(t, updated)
+}
+
+func TestEvaluateThread_Permissions(t *testing.T) {
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		wantErr error
+	}{
+		{
+			name:    "user through the gateway",
+			md:      metadata.Pairs(auth.GatewayMetadataKey, "true"),
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can evaluate automod rules"),
+		},
+		{
+			name: "moderator through the gateway",
+			md:   metadata.Pairs(auth.GatewayMetadataKey, "true", auth.UserRolesMetadataKey, "moderator"),
+		},
+		{
+			name: "internal caller",
+			md:   metadata.MD{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return &models.Thread{Id: "123", CommunityId: "456", Title: "hello", Content: "world"}, nil
+					},
+					GetAutomodConfigFunc: func(ctx context.Context, req *dbpb.GetAutomodConfigRequest, opts ...grpc.CallOption) (*dbpb.GetAutomodConfigResponse, error) {
+						return &dbpb.GetAutomodConfigResponse{}, nil
+					},
+					GetSpamModelFunc: func(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*dbpb.SpamModel, error) {
+						return &dbpb.SpamModel{}, nil
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := server.EvaluateThread(ctx, &moderationpb.EvaluateThreadRequest{ThreadId: "123"})
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestEvaluateThread_RulesActOnce(t *testing.T) {
+	config := `
+rules:
+  - name: reported
+    type: thread
+    reports: 1
+    action: flag
+    reply: Thanks for the reports, a moderator will take a look
+`
+	replies, queued := 0, 0
+	server := &src.ModerationServer{
+		DBClient: &MockDBClient{
+			GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				return &models.Thread{Id: "123", CommunityId: "456", Title: "hello", Content: "world", NumReports: 2}, nil
+			},
+			GetAutomodConfigFunc: func(ctx context.Context, req *dbpb.GetAutomodConfigRequest, opts ...grpc.CallOption) (*dbpb.GetAutomodConfigResponse, error) {
+				return &dbpb.GetAutomodConfigResponse{Config: config}, nil
+			},
+			CreateModQueueItemFunc: func(ctx context.Context, req *dbpb.CreateModQueueItemRequest, opts ...grpc.CallOption) (*dbpb.CreateModQueueItemResponse, error) {
+				queued++
+				return &dbpb.CreateModQueueItemResponse{Id: "1"}, nil
+			},
+			CreateCommentAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateCommentRequest, opts ...grpc.CallOption) (*dbpb.CreateCommentResponse, error) {
+				assert.Equal(t, src.AutomodAuthorId, req.GetAuthorId())
+				replies++
+				return &dbpb.CreateCommentResponse{Id: "789"}, nil
+			},
+			GetSpamModelFunc: func(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*dbpb.SpamModel, error) {
+				return &dbpb.SpamModel{}, nil
+			},
+			RecordAutomodActionFunc: recordAutomodActions(),
+		},
+	}
+
+	// every further report evaluates the thread again
+	for i := 0; i < 3; i++ {
+		res, err := server.EvaluateThread(context.Background(), &moderationpb.EvaluateThreadRequest{ThreadId: "123"})
+		assert.NoError(t, err)
+		assert.Len(t, res.Matches, 1)
+	}
+	assert.Equal(t, 1, replies)
+	assert.Equal(t, 1, queued)
+}
+
+func TestReportThread(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		reported     bool
+		wantErr      error
+		wantEvaluate bool
+	}{
+		{
+			name:    "anonymous",
+			ctx:     context.Background(),
+			wantErr: status.Error(codes.Unauthenticated, "Reporting requires an authenticated user"),
+		},
+		{
+			name:         "first report",
+			ctx:          asUser("bob"),
+			wantEvaluate: true,
+		},
+		{
+			name:     "repeated report",
+			ctx:      asUser("bob"),
+			reported: true,
+			wantErr:  status.Error(codes.AlreadyExists, "User already reported this target"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluated := make(chan struct{}, 1)
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					CreateReportFunc: func(ctx context.Context, req *dbpb.CreateReportRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, "123", req.GetThreadId())
+						assert.Equal(t, "bob", req.GetReporterId())
+						if tt.reported {
+							return nil, status.Error(codes.AlreadyExists, "User already reported this target")
+						}
+						return &emptypb.Empty{}, nil
+					},
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						evaluated <- struct{}{}
+						return nil, status.Error(codes.NotFound, "Thread not found")
+					},
+				},
+			}
+
+			_, err := server.ReportThread(tt.ctx, &moderationpb.ReportThreadRequest{ThreadId: "123"})
+			assert.Equal(t, tt.wantErr, err)
+			if tt.wantEvaluate {
+				select {
+				case <-evaluated:
+				case <-time.After(time.Second):
+					t.Fatal("reported thread was not evaluated")
+				}
+			} else {
+				assert.Empty(t, evaluated)
+			}
+		})
+	}
+}
+
+func trainedSpamClassifier() *src.SpamClassifier {
+	classifier := src.NewSpamClassifier()
+	for i := 0; i < src.MinSpamSamples; i++ {
+		classifier.Add("Buy cheap watches now at https://spam.com, limited offer", true)
+		classifier.Add("Cheap pills, click https://pills.example now", true)
+		classifier.Add("Has anyone tried the new release of the compiler?", false)
+		classifier.Add("I think the second chapter of the book was better", false)
+	}
+	return classifier
+}
+
+func TestSpamClassifier_Score(t *testing.T) {
+	classifier := trainedSpamClassifier()
+
+	assert.Greater(t, classifier.Score("cheap watches at https://www.spam.com"), src.SpamThreshold)
+	assert.Less(t, classifier.Score("which release of the book is better?"), 0.5)
+	assert.Equal(t, 0.0, src.NewSpamClassifier().Score("cheap watches"))
+
+	// models survive a round trip through the database
+	data, err := classifier.Marshal()
+	assert.NoError(t, err)
+	parsed, err := src.ParseSpamClassifier(data)
+	assert.NoError(t, err)
+	assert.Equal(t, classifier.Score("cheap watches"), parsed.Score("cheap watches"))
+}
+
+func TestSpamClassifier_Prune(t *testing.T) {
+	classifier := trainedSpamClassifier()
+	classifier.Prune(5)
+	assert.Equal(t, 5, classifier.VocabularySize())
+	assert.True(t, classifier.Trained())
+}
+
+func TestTrainSpam_NotAdmin(t *testing.T) {
+	server := &src.ModerationServer{
+		DBClient: &MockDBClient{},
+	}
+
+	_, err := server.TrainSpam(asRoles("moderator"), &moderationpb.TrainSpamRequest{})
+	assert.Equal(t, status.Error(codes.PermissionDenied, "Only admins can train the spam classifier").Error(), err.Error())
+}
+
+func TestTrainSpam_AllPages(t *testing.T) {
+	// more samples than fit in one page, also when the db-service returns fewer samples than requested
+	samples := map[dbpb.SpamSampleSource][]*dbpb.SpamSample{}
+	for i := 0; i < 120; i++ {
+		samples[dbpb.SpamSampleSource_THREADS] = append(samples[dbpb.SpamSampleSource_THREADS], &dbpb.SpamSample{
+			Text: fmt.Sprintf("thread %d", i),
+			Spam: i%2 == 0,
+		})
+	}
+	for i := 0; i < 25; i++ {
+		samples[dbpb.SpamSampleSource_COMMENTS] = append(samples[dbpb.SpamSampleSource_COMMENTS], &dbpb.SpamSample{
+			Text: fmt.Sprintf("comment %d", i),
+		})
+	}
+
+	for _, pageSize := range []int32{src.SpamTrainingBatchSize, 10} {
+		t.Run(fmt.Sprintf("page size %d", pageSize), func(t *testing.T) {
+			var stored *dbpb.SpamModel
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					ListSpamSamplesFunc: func(ctx context.Context, req *dbpb.ListSpamSamplesRequest, opts ...grpc.CallOption) (*dbpb.ListSpamSamplesResponse, error) {
+						assert.Less(t, req.GetLimit(), int32(100), "the db-service falls back to its default limit from 100")
+						list := samples[req.GetSource()]
+						start := min(int(req.GetOffset()), len(list))
+						end := min(start+int(min(req.GetLimit(), pageSize)), len(list))
+						return &dbpb.ListSpamSamplesResponse{Samples: list[start:end]}, nil
+					},
+					SetSpamModelFunc: func(ctx context.Context, req *dbpb.SpamModel, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						stored = req
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			res, err := server.TrainSpam(asRoles("admin"), &moderationpb.TrainSpamRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, int32(60), res.GetNumSpam())
+			assert.Equal(t, int32(85), res.GetNumHam())
+			assert.NotEmpty(t, stored.GetModel())
+		})
+	}
+}
+
+func TestScoreContent_Validation(t *testing.T) {
+	data, err := trainedSpamClassifier().Marshal()
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		req     *moderationpb.ScoreContentRequest
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "missing content",
+			req:     &moderationpb.ScoreContentRequest{},
+			roles:   "admin",
+			wantErr: status.Error(codes.InvalidArgument, "Content is required"),
+		},
+		{
+			name: "moderator",
+			req: &moderationpb.ScoreContentRequest{
+				Title:   "Cheap watches",
+				Content: "https://spam.com",
+			},
+			roles:   "moderator",
+			wantErr: status.Error(codes.PermissionDenied, "Only admins can score content"),
+		},
+		{
+			name: "valid request",
+			req: &moderationpb.ScoreContentRequest{
+				Title:   "Cheap watches",
+				Content: "https://spam.com",
+			},
+			roles:   "moderator,admin",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ModerationServer{
+				DBClient: &MockDBClient{
+					GetSpamModelFunc: func(ctx context.Context, req *emptypb.Empty, opts ...grpc.CallOption) (*dbpb.SpamModel, error) {
+						return &dbpb.SpamModel{Model: data}, nil
+					},
+				},
+			}
+
+			res, err := server.ScoreContent(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Greater(t, res.Score, src.SpamThreshold)
+			}
+		})
+	}
+}
+
+func strPtr(s string) *string { return &s }
+
+// asUser returns a context of a request the gateway authenticated as the user
+func asUser(userId string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, userId))
+}
+
+// asRoles returns a context of a request the gateway authenticated with the comma separated roles
+func asRoles(roles string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, roles))
+}
@@ -1,8 +1,10 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	commentpb "gen/comment-service/pb"
+	dbpb "gen/db-service/pb"
 	popularpb "gen/popular-service/pb"
 	threadpb "gen/thread-service/pb"
 	"log"
@@ -43,6 +45,9 @@ func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
 
 	// Connect to other services
+	dbConn := connectGrpcClient("DB_SERVICE_HOST", "DB_SERVICE_PORT")
+	defer dbConn.Close()
+
 	threadConn := connectGrpcClient("THREAD_SERVICE_HOST", "THREAD_SERVICE_PORT")
 	defer threadConn.Close()
 
@@ -51,10 +56,14 @@ func main() {
 
 	// Create popular service
 	popularService := &server.PopularServer{
+		DBClient:      dbpb.NewDBServiceClient(dbConn),
 		ThreadClient:  threadpb.NewThreadServiceClient(threadConn),
 		CommentClient: commentpb.NewCommentServiceClient(commentConn),
 	}
 
+	// first pages are cached until the events of db-service report a change
+	go popularService.RunEventSubscriber(context.Background())
+
 	// get env port
 	port := os.Getenv("SERVICE_PORT")
 	if port == "" {
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"context"
+	dbpb "gen/db-service/pb"
+	popularpb "gen/popular-service/pb"
+	"log"
+	"sync"
+	"time"
+)
+
+// time waited before subscribing again after the event stream of db-service broke
+const eventRetryInterval = 5 * time.Second
+
+// RunEventSubscriber drops the cached pages whenever db-service reports a change of a community, thread, comment or
+// vote, until ctx is done. The stream resumes after the last handled event when it breaks, and nothing is cached
+// while it is down
+func (s *PopularServer) RunEventSubscriber(ctx context.Context) {
+	var after int64
+	for {
+		after = s.subscribe(ctx, after)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventRetryInterval):
+		}
+	}
+}
+
+// subscribe handles the events after an offset until the stream breaks and returns the offset of the last one
+func (s *PopularServer) subscribe(ctx context.Context, after int64) int64 {
+	stream, err := s.DBClient.SubscribeEvents(ctx, &dbpb.SubscribeEventsRequest{AfterOffset: after})
+	if err != nil {
+		log.Printf("failed to subscribe to events: %v", err)
+		return after
+	}
+	s.cache.enable(true)
+	defer s.cache.enable(false)
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("event subscription ended: %v", err)
+			}
+			return after
+		}
+		// every event may change the ranking or the content of the listed threads and comments
+		s.cache.clear()
+		after = event.Offset
+	}
+}
+
+// pageCache holds the first pages of popular threads and comments by limit. Each change bumps a generation, so a
+// page fetched while an event arrived is not cached
+type pageCache struct {
+	mu           sync.Mutex
+	enabled      bool
+	gen          uint64
+	threadPages  map[int32]*popularpb.GetPopularThreadsResponse
+	commentPages map[int32]*popularpb.GetPopularCommentsResponse
+}
+
+func (c *pageCache) enable(enabled bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enabled = enabled
+	c.reset()
+}
+
+func (c *pageCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.reset()
+}
+
+func (c *pageCache) reset() {
+	c.gen++
+	c.threadPages, c.commentPages = nil, nil
+}
+
+func (c *pageCache) generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+func (c *pageCache) threads(limit int32) (*popularpb.GetPopularThreadsResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	page, ok := c.threadPages[limit]
+	return page, ok
+}
+
+func (c *pageCache) comments(limit int32) (*popularpb.GetPopularCommentsResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	page, ok := c.commentPages[limit]
+	return page, ok
+}
+
+func (c *pageCache) putThreads(limit int32, page *popularpb.GetPopularThreadsResponse, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.enabled || c.gen != gen {
+		return
+	}
+	if c.threadPages == nil {
+		c.threadPages = map[int32]*popularpb.GetPopularThreadsResponse{}
+	}
+	c.threadPages[limit] = page
+}
+
+func (c *pageCache) putComments(limit int32, page *popularpb.GetPopularCommentsResponse, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.enabled || c.gen != gen {
+		return
+	}
+	if c.commentPages == nil {
+		c.commentPages = map[int32]*popularpb.GetPopularCommentsResponse{}
+	}
+	c.commentPages[limit] = page
+}
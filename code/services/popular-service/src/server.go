@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	commentpb "gen/comment-service/pb"
+	dbpb "gen/db-service/pb"
 	popularpb "gen/popular-service/pb"
 	threadpb "gen/thread-service/pb"
 
@@ -13,8 +14,12 @@ import (
 
 type PopularServer struct {
 	popularpb.UnimplementedPopularServiceServer
+	DBClient      dbpb.DBServiceClient
 	ThreadClient  threadpb.ThreadServiceClient
 	CommentClient commentpb.CommentServiceClient
+
+	// first pages by limit, kept while subscribed to the events of db-service until an event reports a change
+	cache pageCache
 }
 
 func (s *PopularServer) CheckHealth(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
@@ -29,20 +34,38 @@ func (s *PopularServer) GetPopularThreads(ctx context.Context, req *popularpb.Ge
 	if req.Limit != nil && req.GetLimit() <= 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "Limit must be a positive integer")
 	}
+	if req.PageToken != nil && req.GetPageToken() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Page token cannot be empty")
+	}
+
+	// first pages are served from the cache
+	firstPage := req.GetOffset() == 0 && req.PageToken == nil
+	if firstPage {
+		if cached, ok := s.cache.threads(req.GetLimit()); ok {
+			return cached, nil
+		}
+	}
+	generation := s.cache.generation()
 
 	// fetch threads
 	sortBy := "ups" // upvotes
 	res, err := s.ThreadClient.ListThreads(ctx, &threadpb.ListThreadsRequest{
-		Offset: req.Offset,
-		Limit:  req.Limit,
-		SortBy: &sortBy,
+		Offset:    req.Offset,
+		Limit:     req.Limit,
+		SortBy:    &sortBy,
+		PageToken: req.PageToken,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &popularpb.GetPopularThreadsResponse{
-		Threads: res.Threads,
-	}, nil
+	page := &popularpb.GetPopularThreadsResponse{
+		Threads:       res.Threads,
+		NextPageToken: res.NextPageToken,
+	}
+	if firstPage {
+		s.cache.putThreads(req.GetLimit(), page, generation)
+	}
+	return page, nil
 }
 
 func (s *PopularServer) GetPopularComments(ctx context.Context, req *popularpb.GetPopularCommentsRequest) (*popularpb.GetPopularCommentsResponse, error) {
@@ -53,18 +76,36 @@ func (s *PopularServer) GetPopularComments(ctx context.Context, req *popularpb.G
 	if req.Limit != nil && req.GetLimit() <= 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "Limit must be a positive integer")
 	}
+	if req.PageToken != nil && req.GetPageToken() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Page token cannot be empty")
+	}
+
+	// first pages are served from the cache
+	firstPage := req.GetOffset() == 0 && req.PageToken == nil
+	if firstPage {
+		if cached, ok := s.cache.comments(req.GetLimit()); ok {
+			return cached, nil
+		}
+	}
+	generation := s.cache.generation()
 
 	// fetch comments
 	sortBy := "ups" // upvotes
 	res, err := s.CommentClient.ListComments(ctx, &commentpb.ListCommentsRequest{
-		Offset: req.Offset,
-		Limit:  req.Limit,
-		SortBy: &sortBy,
+		Offset:    req.Offset,
+		Limit:     req.Limit,
+		SortBy:    &sortBy,
+		PageToken: req.PageToken,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return &popularpb.GetPopularCommentsResponse{
-		Comments: res.Comments,
-	}, nil
+	page := &popularpb.GetPopularCommentsResponse{
+		Comments:      res.Comments,
+		NextPageToken: res.NextPageToken,
+	}
+	if firstPage {
+		s.cache.putComments(req.GetLimit(), page, generation)
+	}
+	return page, nil
 }
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	commentpb "gen/comment-service/pb"
+	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
 	popularpb "gen/popular-service/pb"
 	threadpb "gen/thread-service/pb"
@@ -34,6 +35,36 @@ func (m *MockCommentClient) ListComments(ctx context.Context, req *commentpb.Lis
 	return m.ListCommentsFunc(ctx, req, opts...)
 }
 
+type MockDBClient struct {
+	dbpb.DBServiceClient
+	SubscribeEventsFunc func(ctx context.Context, req *dbpb.SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[models.Event], error)
+}
+
+func (m *MockDBClient) SubscribeEvents(ctx context.Context, req *dbpb.SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[models.Event], error) {
+	return m.SubscribeEventsFunc(ctx, req, opts...)
+}
+
+// eventStream receives the events sent to a channel until its context is done. Nil events are skipped, sending one
+// returns once the subscriber handled the previous event
+type eventStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events chan *models.Event
+}
+
+func (s *eventStream) Recv() (*models.Event, error) {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return nil, status.FromContextError(s.ctx.Err()).Err()
+		case event := <-s.events:
+			if event != nil {
+				return event, nil
+			}
+		}
+	}
+}
+
 func TestGetPopularThreads_Validation(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -154,6 +185,82 @@ func TestGetPopularComments_Validation(t *testing.T) {
 	}
 }
 
+func TestGetPopularThreads_PageToken(t *testing.T) {
+	server := &src.PopularServer{
+		ThreadClient: &MockThreadClient{
+			ListThreadsFunc: func(ctx context.Context, req *threadpb.ListThreadsRequest, opts ...grpc.CallOption) (*threadpb.ListThreadsResponse, error) {
+				assert.Equal(t, "ups", req.GetSortBy())
+				assert.Equal(t, "first", req.GetPageToken())
+				return &threadpb.ListThreadsResponse{
+					Threads:       []*models.Thread{{Id: "1"}},
+					NextPageToken: "second",
+				}, nil
+			},
+		},
+		CommentClient: &MockCommentClient{},
+	}
+
+	token := "first"
+	res, err := server.GetPopularThreads(context.Background(), &popularpb.GetPopularThreadsRequest{PageToken: &token})
+	assert.NoError(t, err)
+	assert.Equal(t, "second", res.NextPageToken)
+
+	empty := ""
+	_, err = server.GetPopularThreads(context.Background(), &popularpb.GetPopularThreadsRequest{PageToken: &empty})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Page token cannot be empty").Error(), err.Error())
+}
+
+func TestGetPopularThreads_Cache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	subscribed := make(chan *dbpb.SubscribeEventsRequest)
+	events := make(chan *models.Event)
+	numListed := 0
+	server := &src.PopularServer{
+		DBClient: &MockDBClient{
+			SubscribeEventsFunc: func(ctx context.Context, req *dbpb.SubscribeEventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[models.Event], error) {
+				subscribed <- req
+				return &eventStream{ctx: ctx, events: events}, nil
+			},
+		},
+		ThreadClient: &MockThreadClient{
+			ListThreadsFunc: func(ctx context.Context, req *threadpb.ListThreadsRequest, opts ...grpc.CallOption) (*threadpb.ListThreadsResponse, error) {
+				numListed++
+				return &threadpb.ListThreadsResponse{Threads: []*models.Thread{{Id: "1"}}}, nil
+			},
+		},
+	}
+
+	// nothing is cached before the subscription, since changes would go unnoticed
+	_, err := server.GetPopularThreads(ctx, &popularpb.GetPopularThreadsRequest{})
+	assert.NoError(t, err)
+	_, err = server.GetPopularThreads(ctx, &popularpb.GetPopularThreadsRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, numListed)
+
+	go server.RunEventSubscriber(ctx)
+	assert.Equal(t, int64(0), (<-subscribed).AfterOffset)
+	events <- &models.Event{Offset: 1, Type: models.EventType_THREAD_CREATED, ThreadId: "1"}
+	events <- nil
+
+	// the first page is cached until the next event, other pages are not
+	for range 2 {
+		res, err := server.GetPopularThreads(ctx, &popularpb.GetPopularThreadsRequest{Limit: int32Ptr(10)})
+		assert.NoError(t, err)
+		assert.Len(t, res.Threads, 1)
+	}
+	_, err = server.GetPopularThreads(ctx, &popularpb.GetPopularThreadsRequest{Offset: int32Ptr(10), Limit: int32Ptr(10)})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, numListed)
+
+	events <- &models.Event{Offset: 2, Type: models.EventType_VOTE_CAST, ThreadId: "1", Vote: 1}
+	events <- nil
+	_, err = server.GetPopularThreads(ctx, &popularpb.GetPopularThreadsRequest{Limit: int32Ptr(10)})
+	assert.NoError(t, err)
+	assert.Equal(t, 5, numListed)
+}
+
 func int32Ptr(i int32) *int32 {
 	return &i
 }
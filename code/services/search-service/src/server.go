@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	communitypb "gen/community-service/pb"
-	models "gen/models/pb"
 	searchpb "gen/search-service/pb"
 	threadpb "gen/thread-service/pb"
 
@@ -28,19 +27,22 @@ func (s *SearchServer) GlobalSearch(ctx context.Context, req *searchpb.SearchReq
 	if reqErr != nil {
 		return nil, reqErr
 	}
+	if req.PageToken != nil {
+		return nil, status.Error(codes.InvalidArgument, "Page tokens are only supported by thread and community search")
+	}
 
 	// search communities and threads
-	communityResults, err := s.searchCommunities(ctx, &req.Query)
+	communityResults, err := s.searchCommunities(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-	threadResults, err := s.searchThreads(ctx, &req.Query)
+	threadResults, err := s.searchThreads(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	return &searchpb.GlobalSearchResponse{
-		CommunityResults: communityResults,
-		ThreadResults:    threadResults,
+		CommunityResults: communityResults.Communities,
+		ThreadResults:    threadResults.Threads,
 	}, nil
 }
 
@@ -52,12 +54,13 @@ func (s *SearchServer) CommunitySearch(ctx context.Context, req *searchpb.Search
 	}
 
 	// search communities
-	results, err := s.searchCommunities(ctx, &req.Query)
+	results, err := s.searchCommunities(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	return &searchpb.CommunitySearchResponse{
-		Results: results,
+		Results:       results.Communities,
+		NextPageToken: results.NextPageToken,
 	}, nil
 }
 
@@ -68,33 +71,42 @@ func (s *SearchServer) ThreadSearch(ctx context.Context, req *searchpb.SearchReq
 		return nil, reqErr
 	}
 	// search threads
-	results, err := s.searchThreads(ctx, &req.Query)
+	results, err := s.searchThreads(ctx, req)
 	if err != nil {
 		return nil, err
 	}
 	return &searchpb.ThreadSearchResponse{
-		Results: results,
+		Results:       results.Threads,
+		NextPageToken: results.NextPageToken,
 	}, nil
 }
 
-func (s *SearchServer) searchCommunities(ctx context.Context, query *string) ([]*models.Community, error) {
-	res, err := s.CommunityClient.ListCommunities(ctx, &communitypb.ListCommunitiesRequest{
-		Name: query,
+func (s *SearchServer) searchCommunities(ctx context.Context, req *searchpb.SearchRequest) (*communitypb.ListCommunitiesResponse, error) {
+	return s.CommunityClient.ListCommunities(ctx, &communitypb.ListCommunitiesRequest{
+		Name:      &req.Query,
+		Offset:    req.Offset,
+		Limit:     searchLimit(req),
+		PageToken: req.PageToken,
 	})
-	if err != nil {
-		return nil, err
-	}
-	return res.Communities, nil
 }
 
-func (s *SearchServer) searchThreads(ctx context.Context, query *string) ([]*models.Thread, error) {
-	res, err := s.ThreadClient.ListThreads(ctx, &threadpb.ListThreadsRequest{
-		Title: query,
+func (s *SearchServer) searchThreads(ctx context.Context, req *searchpb.SearchRequest) (*threadpb.ListThreadsResponse, error) {
+	return s.ThreadClient.ListThreads(ctx, &threadpb.ListThreadsRequest{
+		Title:     &req.Query,
+		Offset:    req.Offset,
+		Limit:     searchLimit(req),
+		Flair:     req.Flair,
+		Tag:       req.Tag,
+		PageToken: req.PageToken,
 	})
-	if err != nil {
-		return nil, err
+}
+
+// searchLimit leaves a zero limit to the default of the searched service
+func searchLimit(req *searchpb.SearchRequest) *int32 {
+	if req.GetLimit() == 0 {
+		return nil
 	}
-	return res.Threads, nil
+	return req.Limit
 }
 
 func validateSearchRequest(req *searchpb.SearchRequest) error {
@@ -107,5 +119,8 @@ func validateSearchRequest(req *searchpb.SearchRequest) error {
 	if req.GetLimit() < 0 {
 		return status.Error(codes.InvalidArgument, "Limit cannot be negative")
 	}
+	if req.PageToken != nil && req.GetPageToken() == "" {
+		return status.Error(codes.InvalidArgument, "Page token cannot be empty")
+	}
 	return nil
 }
@@ -61,6 +61,14 @@ func TestGlobalSearch_Validation(t *testing.T) {
 			},
 			wantErr: status.Error(codes.InvalidArgument, "Limit cannot be negative"),
 		},
+		{
+			name: "page token",
+			req: &searchpb.SearchRequest{
+				Query:     "test",
+				PageToken: stringPtr("token"),
+			},
+			wantErr: status.Error(codes.InvalidArgument, "Page tokens are only supported by thread and community search"),
+		},
 		{
 			name: "valid request",
 			req: &searchpb.SearchRequest{
@@ -221,4 +229,29 @@ func TestThreadSearch_Validation(t *testing.T) {
 	}
 }
 
+func TestThreadSearch_PageToken(t *testing.T) {
+	server := &src.SearchServer{
+		ThreadClient: &MockThreadClient{
+			ListThreadsFunc: func(ctx context.Context, req *threadpb.ListThreadsRequest, opts ...grpc.CallOption) (*threadpb.ListThreadsResponse, error) {
+				assert.Equal(t, "test", req.GetTitle())
+				assert.Equal(t, "first", req.GetPageToken())
+				assert.Equal(t, int32(2), req.GetLimit())
+				return &threadpb.ListThreadsResponse{
+					Threads:       []*models.Thread{{Id: "1"}, {Id: "2"}},
+					NextPageToken: "second",
+				}, nil
+			},
+		},
+	}
+
+	res, err := server.ThreadSearch(context.Background(), &searchpb.SearchRequest{Query: "test", Limit: int32Ptr(2), PageToken: stringPtr("first")})
+	assert.NoError(t, err)
+	assert.Len(t, res.Results, 2)
+	assert.Equal(t, "second", res.NextPageToken)
+
+	_, err = server.ThreadSearch(context.Background(), &searchpb.SearchRequest{Query: "test", PageToken: stringPtr("")})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Page token cannot be empty").Error(), err.Error())
+}
+
 func int32Ptr(i int32) *int32 { return &i }
+func stringPtr(s string) *string { return &s }
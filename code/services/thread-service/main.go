@@ -1,16 +1,20 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	commentpb "gen/comment-service/pb"
 	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
+	moderationpb "gen/moderation-service/pb"
 	threadpb "gen/thread-service/pb"
 	"log"
 	"net"
 	"os"
 	"runtime"
+	"strconv"
 	server "thread-service/src"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -55,12 +59,48 @@ func main() {
 	commentConn := connectGrpcClient("COMMENT_SERVICE_HOST", "COMMENT_SERVICE_PORT")
 	defer commentConn.Close()
 
+	// connect to moderation service
+	moderationConn := connectGrpcClient("MODERATION_SERVICE_HOST", "MODERATION_SERVICE_PORT")
+	defer moderationConn.Close()
+
 	// create thread service with database service
 	threadService := &server.ThreadServer{
-		DBClient:        dbpb.NewDBServiceClient(dbConn),
-		CommunityClient: communitypb.NewCommunityServiceClient(communityConn),
-		CommentClient:   commentpb.NewCommentServiceClient(commentConn),
+		DBClient:         dbpb.NewDBServiceClient(dbConn),
+		CommunityClient:  communitypb.NewCommunityServiceClient(communityConn),
+		CommentClient:    commentpb.NewCommentServiceClient(commentConn),
+		ModerationClient: moderationpb.NewModerationServiceClient(moderationConn),
+	}
+
+	// threads older than the archive age become read-only
+	threadService.ArchiveAge = server.DefaultArchiveAge
+	if archiveAge := os.Getenv("THREAD_ARCHIVE_AGE"); archiveAge != "" {
+		age, err := time.ParseDuration(archiveAge)
+		if err != nil {
+			log.Fatalf("invalid THREAD_ARCHIVE_AGE env var: %v", err)
+		}
+		threadService.ArchiveAge = age
+	}
+
+	// communities can pin up to this many threads
+	threadService.MaxPinned = server.DefaultMaxPinned
+	if maxPinned := os.Getenv("THREAD_MAX_PINNED"); maxPinned != "" {
+		parsed, err := strconv.Atoi(maxPinned)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("invalid THREAD_MAX_PINNED env var: must be a positive integer")
+		}
+		threadService.MaxPinned = int32(parsed)
+	}
+
+	// scheduled drafts are published in the background
+	schedulerInterval := server.DefaultSchedulerInterval
+	if interval := os.Getenv("THREAD_SCHEDULER_INTERVAL"); interval != "" {
+		parsed, err := time.ParseDuration(interval)
+		if err != nil || parsed <= 0 {
+			log.Fatalf("invalid THREAD_SCHEDULER_INTERVAL env var: must be a positive duration")
+		}
+		schedulerInterval = parsed
 	}
+	go threadService.RunScheduler(context.Background(), schedulerInterval)
 
 	// get env port
 	port := os.Getenv("SERVICE_PORT")
@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	dbpb "gen/db-service/pb"
+	models "gen/models/pb"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	DefaultSchedulerInterval = time.Minute
+
+	// maximum number of drafts published per scheduler run
+	SchedulerBatchSize = 100
+)
+
+// RunScheduler publishes scheduled drafts once their publish time has passed until ctx is done.
+// the queue is the drafts stored in the db so it survives restarts, and publishing is atomic so replicas can all run it
+func (s *ThreadServer) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := s.publishScheduled(ctx); err != nil {
+			log.Printf("failed to publish scheduled threads: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// publishes the drafts whose publish time has passed, oldest schedule first
+func (s *ThreadServer) publishScheduled(ctx context.Context) error {
+	res, err := s.DBClient.ListScheduledThreads(ctx, &dbpb.ListScheduledThreadsRequest{
+		PublishBefore: timestamppb.Now(),
+		Limit:         SchedulerBatchSize,
+	})
+	if err != nil {
+		return err
+	}
+	for _, thread := range res.Threads {
+		err := s.publish(ctx, thread)
+		if status.Code(err) == codes.FailedPrecondition {
+			// published by another replica or by its author in the meantime
+			continue
+		}
+		if err != nil {
+			log.Printf("failed to publish scheduled thread %s: %v", thread.Id, err)
+		}
+	}
+	return nil
+}
+
+// publishes a draft and counts it in its community, then runs automod rules on it
+func (s *ThreadServer) publish(ctx context.Context, thread *models.Thread) error {
+	_, err := s.DBClient.PublishThreadAndIncrement(ctx, &dbpb.PublishThreadRequest{
+		Id: thread.Id,
+	})
+	if err != nil {
+		return err
+	}
+	s.onPublished(ctx, thread.Id)
+	return nil
+}
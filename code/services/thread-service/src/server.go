@@ -2,32 +2,60 @@ package server
 
 import (
 	"context"
+	"fmt"
+	"gen/auth"
 	commentpb "gen/comment-service/pb"
 	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
+	moderationpb "gen/moderation-service/pb"
 	threadpb "gen/thread-service/pb"
+	"log"
 	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type ThreadServer struct {
 	threadpb.UnimplementedThreadServiceServer
-	CommunityClient communitypb.CommunityServiceClient
-	DBClient        dbpb.DBServiceClient
-	CommentClient   commentpb.CommentServiceClient
+	CommunityClient  communitypb.CommunityServiceClient
+	DBClient         dbpb.DBServiceClient
+	CommentClient    commentpb.CommentServiceClient
+	ModerationClient moderationpb.ModerationServiceClient
+
+	// threads older than this are archived and become read-only, 0 disables archiving
+	ArchiveAge time.Duration
+
+	// maximum number of pinned threads per community
+	MaxPinned int32
 }
 
 const (
-	MinTitleLength   = 3
-	MaxTitleLength   = 50
-	MaxContentLength = 500
-	MinContentLength = 3
+	DefaultMaxPinned  = 2
+	DefaultArchiveAge = 180 * 24 * time.Hour
+
+	MaxTags      = 5
+	MaxTagLength = 20
+
+	MaxUrlLength        = 2000
+	MinPollOptions      = 2
+	MaxPollOptions      = 10
+	MaxPollOptionLength = 80
+	DefaultPollDuration = 7 * 24 * time.Hour
+	MaxPollDuration     = 31 * 24 * time.Hour
 )
 
+// tags are lowercase words separated by dashes, e.g. "help-wanted"
+var tagRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
 func (s *ThreadServer) CheckHealth(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
 	return &emptypb.Empty{}, nil
 }
@@ -49,20 +77,59 @@ func (s *ThreadServer) ListThreads(ctx context.Context, req *threadpb.ListThread
 	if req.SortBy != nil && req.GetSortBy() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Sort cannot be empty")
 	}
+	if req.Flair != nil && req.GetFlair() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Flair cannot be empty")
+	}
+	var tag *string
+	if req.Tag != nil {
+		normalized := strings.ToLower(strings.TrimSpace(req.GetTag()))
+		if normalized == "" {
+			return nil, status.Error(codes.InvalidArgument, "Tag cannot be empty")
+		}
+		tag = &normalized
+	}
+	if req.AuthorId != nil && req.GetAuthorId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Author id cannot be empty")
+	}
+	if req.GetDrafts() && req.GetAuthorId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Author id is required to list drafts")
+	}
+	if req.GetDrafts() && req.GetAuthorId() != auth.RequesterId(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Drafts are only listed to their author")
+	}
+	if req.CrosspostParentId != nil && req.GetCrosspostParentId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Crosspost parent id cannot be empty")
+	}
+	if req.PageToken != nil && req.GetPageToken() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Page token cannot be empty")
+	}
 
 	// fetch threads
 	res, err := s.DBClient.ListThreads(ctx, &dbpb.ListThreadsRequest{
-		CommunityId: req.CommunityId,
-		Title:       req.Title,
-		Offset:      req.Offset,
-		Limit:       req.Limit,
-		SortBy:      req.SortBy,
+		CommunityId:       req.CommunityId,
+		Title:             req.Title,
+		Offset:            req.Offset,
+		Limit:             req.Limit,
+		SortBy:            req.SortBy,
+		Flair:             req.Flair,
+		Tag:               tag,
+		AuthorId:          req.AuthorId,
+		Drafts:            req.GetDrafts(),
+		CrosspostParentId: req.CrosspostParentId,
+		PageToken:         req.PageToken,
 	})
 	if err != nil {
 		return nil, err
 	}
+	for _, thread := range res.Threads {
+		closePoll(s.markArchived(maskRemovedThread(thread)))
+	}
+	if err := s.resolveCrosspostParents(ctx, res.Threads); err != nil {
+		return nil, err
+	}
 	return &threadpb.ListThreadsResponse{
-		Threads: res.Threads,
+		Threads:       res.Threads,
+		NextPageToken: res.NextPageToken,
 	}, nil
 }
 
@@ -74,41 +141,188 @@ func (s *ThreadServer) CreateThread(ctx context.Context, req *threadpb.CreateThr
 	if req.GetTitle() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Title is required")
 	}
-	if req.GetContent() == "" {
+	// only text threads require content, other kinds can add it as a description
+	if req.GetContent() == "" && req.GetKind() == models.ThreadKind_TEXT {
 		return nil, status.Error(codes.InvalidArgument, "Content is required")
 	}
-	if len(req.GetTitle()) < MinTitleLength || len(req.GetTitle()) > MaxTitleLength {
-		return nil, status.Errorf(codes.InvalidArgument, "Title must be between %d and %d characters long", MinTitleLength, MaxTitleLength)
+	// a publish time makes the thread a scheduled draft
+	draft := req.GetDraft() || req.PublishAt != nil
+	if draft && auth.RequesterId(ctx) == "" {
+		return nil, status.Error(codes.Unauthenticated, "Drafts require an authenticated user")
+	}
+	if req.PublishAt != nil && !req.GetPublishAt().AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "Publish time must be in the future")
+	}
+	tags, err := normalizeTags(req.GetTags())
+	if err != nil {
+		return nil, err
+	}
+	kind, err := newThreadKind(req)
+	if err != nil {
+		return nil, err
+	}
+	if kind.poll != nil && req.PublishAt != nil && !kind.poll.EndsAt.AsTime().After(req.GetPublishAt().AsTime()) {
+		return nil, status.Error(codes.InvalidArgument, "Poll must end after the thread is published")
+	}
+
+	// length limits are set by the community
+	rules, err := s.CommunityClient.GetCommunityPostingRules(ctx, &communitypb.GetCommunityPostingRulesRequest{
+		Id: req.CommunityId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := validateTitle(req.GetTitle(), rules); err != nil {
+		return nil, err
+	}
+	if req.GetContent() != "" || req.GetKind() == models.ThreadKind_TEXT {
+		if err := validateContent(req.GetContent(), rules); err != nil {
+			return nil, err
+		}
 	}
-	if len(req.GetContent()) < MinContentLength || len(req.GetContent()) > MaxContentLength {
-		return nil, status.Errorf(codes.InvalidArgument, "Content must be between %d and %d characters long", MinContentLength, MaxContentLength)
+	if kind.attachmentId != "" {
+		attachment, err := s.DBClient.GetAttachment(ctx, &dbpb.GetAttachmentRequest{
+			Id: kind.attachmentId,
+		})
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.InvalidArgument, "Attachment not found")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(attachment.GetContentType(), "image/") {
+			return nil, status.Error(codes.InvalidArgument, "Attachment is not an image")
+		}
+		if attachment.GetThumbnailSha256() != "" {
+			kind.thumbnailUrl = fmt.Sprintf("/attachments/%s/thumbnail", attachment.Id)
+		}
 	}
 
-	// create thread
-	res, err := s.DBClient.CreateThread(ctx, &dbpb.CreateThreadRequest{
-		CommunityId: req.CommunityId,
-		Title:       req.Title,
-		Content:     req.Content,
+	// flair is copied onto the thread so listings don't need the community
+	flair := &models.FlairTemplate{}
+	if req.GetFlairId() != "" {
+		flair, err = s.getFlairTemplate(ctx, req.GetCommunityId(), req.GetFlairId())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// create thread, counted in its community once it is published
+	res, err := s.DBClient.CreateThreadAndIncrement(ctx, &dbpb.CreateThreadRequest{
+		CommunityId:  req.CommunityId,
+		Title:        req.Title,
+		Content:      req.Content,
+		AuthorId:     auth.RequesterId(ctx),
+		Flair:        flair.Text,
+		FlairColor:   flair.Color,
+		Tags:         tags,
+		Kind:         req.GetKind(),
+		Url:          kind.url,
+		Domain:       kind.domain,
+		AttachmentId: kind.attachmentId,
+		Poll:         kind.poll,
+		ThumbnailUrl: kind.thumbnailUrl,
+		Draft:        draft,
+		PublishAt:    req.PublishAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// drafts are moderated once they are published
+	if !draft {
+		s.onPublished(ctx, res.Id)
+	}
+
+	return &threadpb.CreateThreadResponse{
+		Id: res.Id,
+	}, nil
+}
+
+func (s *ThreadServer) CrosspostThread(ctx context.Context, req *threadpb.CrosspostThreadRequest) (*threadpb.CreateThreadResponse, error) {
+	// validate inputs
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
+	}
+	if req.GetCommunityId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id is required")
+	}
+	if req.Title != nil && req.GetTitle() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Title cannot be empty")
+	}
+	authorId := auth.RequesterId(ctx)
+	if authorId == "" {
+		return nil, status.Error(codes.Unauthenticated, "Crossposting requires an authenticated user")
+	}
+
+	// crossposts of a crosspost share its original thread
+	original, err := s.getThread(ctx, req.Id, "")
+	if err != nil {
+		return nil, err
+	}
+	if original.CrosspostParentId != "" {
+		original, err = s.getThread(ctx, original.CrosspostParentId, "")
+		if status.Code(err) == codes.NotFound {
+			return nil, status.Error(codes.FailedPrecondition, "Thread is removed")
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if original.RemovalType != models.RemovalType_NOT_REMOVED {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is removed")
+	}
+	if original.Kind == models.ThreadKind_REDIRECT {
+		return nil, status.Error(codes.FailedPrecondition, "Thread was moved")
+	}
+	if original.CommunityId == req.CommunityId {
+		return nil, status.Error(codes.InvalidArgument, "Thread already belongs to this community")
+	}
+
+	// the title follows the posting rules of the target community
+	title := original.Title
+	if req.Title != nil {
+		title = req.GetTitle()
+	}
+	rules, err := s.CommunityClient.GetCommunityPostingRules(ctx, &communitypb.GetCommunityPostingRulesRequest{
+		Id: req.CommunityId,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if err := validateTitle(title, rules); err != nil {
+		return nil, err
+	}
 
-	// update community num_threads
-	numThreadsOffset := int32(1)
-	_, err = s.CommunityClient.UpdateCommunity(ctx, &communitypb.UpdateCommunityRequest{
-		Id:               req.CommunityId,
-		NumThreadsOffset: &numThreadsOffset,
+	// create crosspost, a thread is crossposted once per community
+	res, err := s.DBClient.CreateThreadAndIncrement(ctx, &dbpb.CreateThreadRequest{
+		CommunityId:       req.CommunityId,
+		Title:             title,
+		AuthorId:          authorId,
+		Kind:              models.ThreadKind_CROSSPOST,
+		CrosspostParentId: original.Id,
 	})
 	if err != nil {
 		return nil, err
 	}
+	// the automod rules of the target community apply as to any new thread, author account age and karma included
+	s.onPublished(ctx, res.Id)
 
 	return &threadpb.CreateThreadResponse{
 		Id: res.Id,
 	}, nil
 }
 
+// runs automod rules on a newly published thread, it is already published so failures are only logged
+func (s *ThreadServer) onPublished(ctx context.Context, threadId string) {
+	_, err := s.ModerationClient.EvaluateThread(ctx, &moderationpb.EvaluateThreadRequest{
+		ThreadId: threadId,
+	})
+	if err != nil {
+		log.Printf("failed to evaluate automod rules for thread %s: %v", threadId, err)
+	}
+}
+
 func (s *ThreadServer) GetThread(ctx context.Context, req *threadpb.GetThreadRequest) (*models.Thread, error) {
 	// validate inputs
 	if req.GetId() == "" {
@@ -116,13 +330,28 @@ func (s *ThreadServer) GetThread(ctx context.Context, req *threadpb.GetThreadReq
 	}
 
 	// fetch thread
+	res, err := s.getThread(ctx, req.Id, auth.RequesterId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if err := s.resolveCrosspostParents(ctx, []*models.Thread{res}); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// fetches a thread as seen by viewerId, drafts do not exist for anyone but their author
+func (s *ThreadServer) getThread(ctx context.Context, id string, viewerId string) (*models.Thread, error) {
 	res, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
-		Id: req.Id,
+		Id: id,
 	})
 	if err != nil {
 		return nil, err
 	}
-	return res, nil
+	if res.Draft && (viewerId == "" || viewerId != res.AuthorId) {
+		return nil, status.Error(codes.NotFound, "Thread not found")
+	}
+	return closePoll(s.markArchived(maskRemovedThread(res))), nil
 }
 
 func (s *ThreadServer) UpdateThread(ctx context.Context, req *threadpb.UpdateThreadRequest) (*emptypb.Empty, error) {
@@ -130,20 +359,77 @@ func (s *ThreadServer) UpdateThread(ctx context.Context, req *threadpb.UpdateThr
 	if req.GetId() == "" {
 		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
 	}
-	titleLen := len(req.GetTitle())
-	if req.Title != nil && (titleLen < MinTitleLength || titleLen > MaxTitleLength) {
-		return nil, status.Errorf(codes.InvalidArgument, "Title must be between %d and %d characters long", MinTitleLength, MaxTitleLength)
-	}
-	contentLen := len(req.GetContent())
-	if req.Content != nil && (contentLen < MinContentLength || contentLen > MaxContentLength) {
-		return nil, status.Errorf(codes.InvalidArgument, "Content must be between %d and %d characters long", MinContentLength, MaxContentLength)
-	}
 	if req.VoteOffset != nil && math.Abs(float64(req.GetVoteOffset())) != 1 {
 		return nil, status.Error(codes.InvalidArgument, "Vote offset must be either -1 or 1")
 	}
 	if req.NumCommentsOffset != nil && math.Abs(float64(req.GetNumCommentsOffset())) != 1 {
 		return nil, status.Error(codes.InvalidArgument, "Number comments offset must be either -1 or 1")
 	}
+	var tags *models.TagList
+	if req.Tags != nil {
+		normalized, err := normalizeTags(req.Tags.GetTags())
+		if err != nil {
+			return nil, err
+		}
+		tags = &models.TagList{Tags: normalized}
+	}
+
+	// archived threads are read-only and locked threads cannot be voted on
+	var flair, flairColor *string
+	if req.Title != nil || req.Content != nil || req.VoteOffset != nil || req.FlairId != nil || req.Tags != nil {
+		thread, err := s.getThread(ctx, req.Id, auth.RequesterId(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if thread.Archived {
+			return nil, status.Error(codes.FailedPrecondition, "Thread is archived")
+		}
+		if thread.Kind == models.ThreadKind_REDIRECT {
+			return nil, status.Error(codes.FailedPrecondition, "Thread was moved")
+		}
+		if thread.Locked && req.VoteOffset != nil {
+			return nil, status.Error(codes.FailedPrecondition, "Thread is locked")
+		}
+
+		// only the author or a moderator can edit a thread, threads created before they were attributed have no
+		// author to check against and are only edited by moderators
+		edit := req.Title != nil || req.Content != nil || req.FlairId != nil || req.Tags != nil
+		if edit && (thread.GetAuthorId() == "" || thread.GetAuthorId() != auth.RequesterId(ctx)) && !auth.RequesterIsModerator(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a thread")
+		}
+
+		// length limits are set by the community
+		if req.Title != nil || req.Content != nil {
+			rules, err := s.CommunityClient.GetCommunityPostingRules(ctx, &communitypb.GetCommunityPostingRulesRequest{
+				Id: thread.CommunityId,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if req.Title != nil {
+				if err := validateTitle(req.GetTitle(), rules); err != nil {
+					return nil, err
+				}
+			}
+			if req.Content != nil {
+				if err := validateContent(req.GetContent(), rules); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		// an empty flair id clears the flair
+		if req.FlairId != nil {
+			template := &models.FlairTemplate{}
+			if req.GetFlairId() != "" {
+				template, err = s.getFlairTemplate(ctx, thread.CommunityId, req.GetFlairId())
+				if err != nil {
+					return nil, err
+				}
+			}
+			flair, flairColor = &template.Text, &template.Color
+		}
+	}
 
 	// update thread
 	_, err := s.DBClient.UpdateThread(ctx, &dbpb.UpdateThreadRequest{
@@ -152,6 +438,10 @@ func (s *ThreadServer) UpdateThread(ctx context.Context, req *threadpb.UpdateThr
 		Content:           req.Content,
 		VoteOffset:        req.VoteOffset,
 		NumCommentsOffset: req.NumCommentsOffset,
+		Flair:             flair,
+		FlairColor:        flairColor,
+		Tags:              tags,
+		EditorId:          auth.RequesterId(ctx),
 	})
 	if err != nil {
 		return nil, err
@@ -159,12 +449,195 @@ func (s *ThreadServer) UpdateThread(ctx context.Context, req *threadpb.UpdateThr
 	return &emptypb.Empty{}, nil
 }
 
+func (s *ThreadServer) ListRevisions(ctx context.Context, req *threadpb.ListRevisionsRequest) (*threadpb.ListRevisionsResponse, error) {
+	// validate inputs
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
+	}
+
+	// the history of a thread goes away with it when its author deletes it
+	thread, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if thread.Draft {
+		return nil, status.Error(codes.NotFound, "Thread not found")
+	}
+	if thread.GetRemovalType() == models.RemovalType_DELETED {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is deleted")
+	}
+	// removed threads are masked, their history would reveal the removed content to anyone but moderators
+	if thread.GetRemovalType() != models.RemovalType_NOT_REMOVED && !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can list the revisions of removed threads")
+	}
+
+	// fetch revisions
+	res, err := s.DBClient.ListRevisions(ctx, &dbpb.ListRevisionsRequest{
+		ThreadId: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &threadpb.ListRevisionsResponse{
+		Revisions: res.Revisions,
+	}, nil
+}
+
 func (s *ThreadServer) DeleteThread(ctx context.Context, req *threadpb.DeleteThreadRequest) (*emptypb.Empty, error) {
 	// validate input
 	if req.GetId() == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "Thread id is required")
 	}
 
+	// only the author or a moderator can delete a thread, drafts of other users are not found. Threads of other users
+	// and threads created before they were attributed, which have no author to check against, are removed as a moderator
+	thread, err := s.getThread(ctx, req.Id, auth.RequesterId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	removalType := models.RemovalType_DELETED
+	if thread.GetAuthorId() == "" || thread.GetAuthorId() != auth.RequesterId(ctx) {
+		if !auth.RequesterIsModerator(ctx) {
+			return nil, status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a thread")
+		}
+		removalType = models.RemovalType_REMOVED
+	}
+
+	// soft delete thread, comments are kept so the tree stays intact
+	_, err = s.DBClient.RemoveThread(ctx, &dbpb.RemoveThreadRequest{
+		Id:          req.Id,
+		RemovalType: removalType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ThreadServer) RemoveThread(ctx context.Context, req *threadpb.RemoveThreadRequest) (*emptypb.Empty, error) {
+	// validate inputs
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Thread id is required")
+	}
+	if req.GetReason() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Removal reason is required")
+	}
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can remove threads")
+	}
+
+	// soft delete thread as a moderator
+	_, err := s.DBClient.RemoveThread(ctx, &dbpb.RemoveThreadRequest{
+		Id:          req.Id,
+		RemovalType: models.RemovalType_REMOVED,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ThreadServer) RestoreThread(ctx context.Context, req *threadpb.RestoreThreadRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Thread id is required")
+	}
+
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can restore threads")
+	}
+
+	// restore thread, the db service rejects threads past their grace period
+	_, err := s.DBClient.RestoreThread(ctx, &dbpb.RestoreThreadRequest{
+		Id: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ThreadServer) PinThread(ctx context.Context, req *threadpb.PinThreadRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Thread id is required")
+	}
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can pin threads")
+	}
+
+	if req.Pinned {
+		// get thread
+		thread, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
+			Id: req.Id,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if thread.Pinned {
+			return &emptypb.Empty{}, nil
+		}
+		if thread.Draft {
+			return nil, status.Error(codes.FailedPrecondition, "Drafts cannot be pinned")
+		}
+
+		// pin thread, the community pin limit is checked in the same transaction
+		_, err = s.DBClient.PinThread(ctx, &dbpb.PinThreadRequest{
+			Id:        req.Id,
+			MaxPinned: s.MaxPinned,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	// update thread
+	_, err := s.DBClient.UpdateThread(ctx, &dbpb.UpdateThreadRequest{
+		Id:     req.Id,
+		Pinned: &req.Pinned,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ThreadServer) LockThread(ctx context.Context, req *threadpb.LockThreadRequest) (*emptypb.Empty, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Thread id is required")
+	}
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can lock threads")
+	}
+
+	// update thread
+	_, err := s.DBClient.UpdateThread(ctx, &dbpb.UpdateThreadRequest{
+		Id:     req.Id,
+		Locked: &req.Locked,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ThreadServer) MoveThread(ctx context.Context, req *threadpb.MoveThreadRequest) (*threadpb.MoveThreadResponse, error) {
+	// validate inputs
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
+	}
+	if req.GetCommunityId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Community id is required")
+	}
+	if !auth.RequesterIsModerator(ctx) {
+		return nil, status.Error(codes.PermissionDenied, "Only moderators can move threads")
+	}
+
 	// get thread
 	thread, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
 		Id: req.Id,
@@ -172,42 +645,437 @@ func (s *ThreadServer) DeleteThread(ctx context.Context, req *threadpb.DeleteThr
 	if err != nil {
 		return nil, err
 	}
+	if thread.Draft {
+		return nil, status.Error(codes.FailedPrecondition, "Drafts cannot be moved")
+	}
+	if thread.Kind == models.ThreadKind_REDIRECT {
+		return nil, status.Error(codes.FailedPrecondition, "Redirects cannot be moved")
+	}
+	if thread.CommunityId == req.CommunityId {
+		return nil, status.Error(codes.InvalidArgument, "Thread already belongs to this community")
+	}
+
+	// a crosspost cannot join its original thread
+	if thread.CrosspostParentId != "" {
+		original, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
+			Id: thread.CrosspostParentId,
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		if original.GetCommunityId() == req.CommunityId {
+			return nil, status.Error(codes.InvalidArgument, "Crossposts cannot be moved to the community of their original thread")
+		}
+	}
 
-	// find all comments from thread
-	comments, err := s.CommentClient.ListComments(ctx, &commentpb.ListCommentsRequest{
-		ThreadId: &req.Id,
+	// move thread, the counters of both communities and the redirect are updated in the same transaction
+	res, err := s.DBClient.MoveThread(ctx, &dbpb.MoveThreadRequest{
+		Id:          req.Id,
+		CommunityId: req.CommunityId,
 	})
 	if err != nil {
 		return nil, err
 	}
+	return &threadpb.MoveThreadResponse{
+		RedirectId: res.RedirectId,
+	}, nil
+}
+
+func (s *ThreadServer) PublishThread(ctx context.Context, req *threadpb.PublishThreadRequest) (*emptypb.Empty, error) {
+	// validate inputs
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
+	}
+	if req.PublishAt != nil && !req.GetPublishAt().AsTime().After(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "Publish time must be in the future")
+	}
+
+	// only the author of a draft can see and publish it
+	thread, err := s.getThread(ctx, req.Id, auth.RequesterId(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if !thread.Draft {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is already published")
+	}
 
-	// delete comments
-	for _, comment := range comments.Comments {
-		_, err = s.CommentClient.DeleteComment(ctx, &commentpb.DeleteCommentRequest{
-			Id: comment.Id,
+	// a publish time reschedules the draft, the scheduler publishes it
+	if req.PublishAt != nil {
+		if thread.Poll != nil && !thread.Poll.EndsAt.AsTime().After(req.GetPublishAt().AsTime()) {
+			return nil, status.Error(codes.InvalidArgument, "Poll must end after the thread is published")
+		}
+		_, err := s.DBClient.UpdateThread(ctx, &dbpb.UpdateThreadRequest{
+			Id:        req.Id,
+			PublishAt: req.PublishAt,
 		})
 		if err != nil {
 			return nil, err
 		}
+		return &emptypb.Empty{}, nil
+	}
+
+	// publish thread
+	if err := s.publish(ctx, thread); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ThreadServer) CastPollVote(ctx context.Context, req *threadpb.CastPollVoteRequest) (*emptypb.Empty, error) {
+	// validate inputs
+	if req.GetThreadId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Thread id is required")
+	}
+	if len(req.GetOptionIds()) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "At least one option is required")
+	}
+	userId := auth.RequesterId(ctx)
+	if userId == "" {
+		return nil, status.Error(codes.Unauthenticated, "Voting requires an authenticated user")
+	}
+
+	// only open polls can be voted on
+	thread, err := s.getThread(ctx, req.ThreadId, "")
+	if err != nil {
+		return nil, err
+	}
+	if thread.Kind != models.ThreadKind_POLL {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is not a poll")
+	}
+	if thread.RemovalType != models.RemovalType_NOT_REMOVED {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is removed")
+	}
+	if thread.Locked {
+		return nil, status.Error(codes.FailedPrecondition, "Thread is locked")
+	}
+	if thread.GetPoll().GetClosed() {
+		return nil, status.Error(codes.FailedPrecondition, "Poll is closed")
+	}
+
+	// chosen options must belong to the poll
+	optionIds := make([]string, 0, len(req.GetOptionIds()))
+	seen := make(map[string]bool, len(req.GetOptionIds()))
+	for _, id := range req.GetOptionIds() {
+		if !seen[id] {
+			seen[id] = true
+			optionIds = append(optionIds, id)
+		}
+	}
+	if len(optionIds) > 1 && !thread.GetPoll().GetMultipleChoice() {
+		return nil, status.Error(codes.InvalidArgument, "Poll only allows a single choice")
+	}
+	for _, id := range optionIds {
+		found := false
+		for _, option := range thread.GetPoll().GetOptions() {
+			if option.Id == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, status.Error(codes.InvalidArgument, "Poll option not found")
+		}
+	}
+
+	// record vote, users can only vote once
+	_, err = s.DBClient.CastPollVote(ctx, &dbpb.CastPollVoteRequest{
+		ThreadId:  req.ThreadId,
+		UserId:    userId,
+		OptionIds: optionIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *ThreadServer) PurgeThread(ctx context.Context, req *threadpb.PurgeThreadRequest) (*threadpb.PurgeThreadResponse, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Thread id is required")
+	}
+	if !auth.RequesterHasRole(ctx, "admin") {
+		return nil, status.Error(codes.PermissionDenied, "Only admins can purge threads")
+	}
+
+	// the thread and its replies are deleted page by page in the background
+	res, err := s.DBClient.CreateJob(ctx, &dbpb.CreateJobRequest{
+		Type:     models.JobType_DELETE_THREAD,
+		TargetId: req.Id,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &threadpb.PurgeThreadResponse{
+		JobId: res.Id,
+	}, nil
+}
+
+func (s *ThreadServer) GetJobStatus(ctx context.Context, req *threadpb.GetJobStatusRequest) (*models.Job, error) {
+	// validate input
+	if req.GetId() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "Job id is required")
 	}
 
-	// delete thread
-	_, err = s.DBClient.DeleteThread(ctx, &dbpb.DeleteThreadRequest{
+	job, err := s.DBClient.GetJobStatus(ctx, &dbpb.GetJobStatusRequest{
 		Id: req.Id,
 	})
 	if err != nil {
 		return nil, err
 	}
+	// the jobs of other services are not reported here
+	if job.GetType() != models.JobType_DELETE_THREAD {
+		return nil, status.Errorf(codes.NotFound, "Job not found")
+	}
+	return job, nil
+}
+
+// replaces the content of deleted and removed threads with a marker
+func maskRemovedThread(thread *models.Thread) *models.Thread {
+	switch thread.GetRemovalType() {
+	case models.RemovalType_DELETED:
+		thread.Content = "[deleted]"
+	case models.RemovalType_REMOVED:
+		thread.Content = "[removed]"
+	default:
+		return thread
+	}
+	thread.ContentHtml = "<p>" + thread.Content + "</p>"
+	thread.Url, thread.Domain, thread.AttachmentId, thread.ThumbnailUrl = "", "", "", ""
+	return thread
+}
+
+// fills the original thread of crossposts, each original and community is only fetched once
+func (s *ThreadServer) resolveCrosspostParents(ctx context.Context, threads []*models.Thread) error {
+	parents := map[string]*models.CrosspostParent{}
+	communityNames := map[string]string{}
+	for _, thread := range threads {
+		if thread.CrosspostParentId == "" || thread.RemovalType != models.RemovalType_NOT_REMOVED {
+			continue
+		}
+		parent, ok := parents[thread.CrosspostParentId]
+		if !ok {
+			var err error
+			parent, err = s.crosspostParent(ctx, thread.CrosspostParentId, communityNames)
+			if err != nil {
+				return err
+			}
+			parents[thread.CrosspostParentId] = parent
+		}
+		thread.CrosspostParent = parent
+	}
+	return nil
+}
 
-	// update community num_threads
-	numThreadsOffset := int32(-1)
-	_, err = s.CommunityClient.UpdateCommunity(ctx, &communitypb.UpdateCommunityRequest{
-		Id:               thread.CommunityId,
-		NumThreadsOffset: &numThreadsOffset,
+// reads the community and score of the original of a crosspost, communityNames caches community lookups
+func (s *ThreadServer) crosspostParent(ctx context.Context, id string, communityNames map[string]string) (*models.CrosspostParent, error) {
+	original, err := s.DBClient.GetThread(ctx, &dbpb.GetThreadRequest{
+		Id: id,
 	})
+	if status.Code(err) == codes.NotFound {
+		return &models.CrosspostParent{ThreadId: id, Removed: true}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
+	if original.RemovalType != models.RemovalType_NOT_REMOVED {
+		return &models.CrosspostParent{ThreadId: id, Removed: true}, nil
+	}
 
-	return &emptypb.Empty{}, nil
+	name, ok := communityNames[original.CommunityId]
+	if !ok {
+		community, err := s.CommunityClient.GetCommunity(ctx, &communitypb.GetCommunityRequest{
+			Id: original.CommunityId,
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return nil, err
+		}
+		name = community.GetName()
+		communityNames[original.CommunityId] = name
+	}
+	return &models.CrosspostParent{
+		ThreadId:      original.Id,
+		CommunityId:   original.CommunityId,
+		CommunityName: name,
+		Ups:           original.Ups,
+		Downs:         original.Downs,
+	}, nil
+}
+
+// flags threads older than the archive age, threads created before creation times were stored are never archived
+func (s *ThreadServer) markArchived(thread *models.Thread) *models.Thread {
+	if s.ArchiveAge > 0 && thread.CreatedAt != nil && time.Since(thread.CreatedAt.AsTime()) > s.ArchiveAge {
+		thread.Archived = true
+	}
+	return thread
+}
+
+// finds a flair template of the community the thread is posted in
+func (s *ThreadServer) getFlairTemplate(ctx context.Context, communityId string, flairId string) (*models.FlairTemplate, error) {
+	community, err := s.CommunityClient.GetCommunity(ctx, &communitypb.GetCommunityRequest{
+		Id: communityId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, template := range community.FlairTemplates {
+		if template.Id == flairId {
+			return template, nil
+		}
+	}
+	return nil, status.Error(codes.InvalidArgument, "Flair template not found")
+}
+
+// checks the length of a title in characters against the posting rules of its community
+func validateTitle(title string, rules *models.PostingRules) error {
+	titleLen := int32(utf8.RuneCountInString(title))
+	if titleLen < rules.GetMinTitleLength() || titleLen > rules.GetMaxTitleLength() {
+		return status.Errorf(codes.InvalidArgument, "Title must be between %d and %d characters long", rules.GetMinTitleLength(), rules.GetMaxTitleLength())
+	}
+	return nil
+}
+
+// checks the length of a content in characters against the posting rules of its community
+func validateContent(content string, rules *models.PostingRules) error {
+	contentLen := int32(utf8.RuneCountInString(content))
+	if contentLen < rules.GetMinContentLength() || contentLen > rules.GetMaxContentLength() {
+		return status.Errorf(codes.InvalidArgument, "Content must be between %d and %d characters long", rules.GetMinContentLength(), rules.GetMaxContentLength())
+	}
+	return nil
+}
+
+// trims, lowercases and dedupes tags, keeping their order
+func normalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, status.Error(codes.InvalidArgument, "Tag cannot be empty")
+		}
+		if len(tag) > MaxTagLength {
+			return nil, status.Errorf(codes.InvalidArgument, "Tag exceeds maximum length of %d characters", MaxTagLength)
+		}
+		if !tagRegex.MatchString(tag) {
+			return nil, status.Error(codes.InvalidArgument, "Tags can only contain letters, digits and dashes")
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxTags {
+		return nil, status.Errorf(codes.InvalidArgument, "Thread cannot have more than %d tags", MaxTags)
+	}
+	return normalized, nil
+}
+
+// kind specific fields of a new thread
+type threadKind struct {
+	url          string
+	domain       string
+	attachmentId string
+	thumbnailUrl string
+	poll         *models.Poll
+}
+
+// validates the fields of the thread kind, fields of other kinds are rejected
+func newThreadKind(req *threadpb.CreateThreadRequest) (*threadKind, error) {
+	kind := req.GetKind()
+	if req.GetUrl() != "" && kind != models.ThreadKind_LINK {
+		return nil, status.Error(codes.InvalidArgument, "Url is only allowed for link threads")
+	}
+	if req.GetAttachmentId() != "" && kind != models.ThreadKind_IMAGE {
+		return nil, status.Error(codes.InvalidArgument, "Attachment id is only allowed for image threads")
+	}
+	hasPoll := len(req.GetPollOptions()) > 0 || req.PollEndsAt != nil || req.GetPollMultipleChoice() || req.GetPollHideResults()
+	if hasPoll && kind != models.ThreadKind_POLL {
+		return nil, status.Error(codes.InvalidArgument, "Poll fields are only allowed for poll threads")
+	}
+
+	switch kind {
+	case models.ThreadKind_TEXT:
+		return &threadKind{}, nil
+	case models.ThreadKind_CROSSPOST:
+		return nil, status.Error(codes.InvalidArgument, "Crossposts are created by crossposting a thread")
+	case models.ThreadKind_REDIRECT:
+		return nil, status.Error(codes.InvalidArgument, "Redirects are created by moving a thread")
+	case models.ThreadKind_LINK:
+		link := strings.TrimSpace(req.GetUrl())
+		if link == "" {
+			return nil, status.Error(codes.InvalidArgument, "Url is required for link threads")
+		}
+		if len(link) > MaxUrlLength {
+			return nil, status.Errorf(codes.InvalidArgument, "Url exceeds maximum length of %d characters", MaxUrlLength)
+		}
+		u, err := url.Parse(link)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+			return nil, status.Error(codes.InvalidArgument, "Url must be an absolute http or https url")
+		}
+		return &threadKind{url: link, domain: normalizeDomain(u.Hostname())}, nil
+	case models.ThreadKind_IMAGE:
+		if req.GetAttachmentId() == "" {
+			return nil, status.Error(codes.InvalidArgument, "Attachment id is required for image threads")
+		}
+		return &threadKind{attachmentId: req.GetAttachmentId()}, nil
+	case models.ThreadKind_POLL:
+		options := req.GetPollOptions()
+		if len(options) < MinPollOptions || len(options) > MaxPollOptions {
+			return nil, status.Errorf(codes.InvalidArgument, "Poll must have between %d and %d options", MinPollOptions, MaxPollOptions)
+		}
+		endsAt := time.Now().Add(DefaultPollDuration)
+		if req.PollEndsAt != nil {
+			endsAt = req.GetPollEndsAt().AsTime()
+		}
+		if !endsAt.After(time.Now()) || time.Until(endsAt) > MaxPollDuration {
+			return nil, status.Errorf(codes.InvalidArgument, "Poll must end within %d days", int(MaxPollDuration.Hours()/24))
+		}
+		poll := &models.Poll{
+			EndsAt:         timestamppb.New(endsAt),
+			MultipleChoice: req.GetPollMultipleChoice(),
+			HideResults:    req.GetPollHideResults(),
+		}
+		seen := make(map[string]bool, len(options))
+		for _, option := range options {
+			option = strings.TrimSpace(option)
+			if option == "" {
+				return nil, status.Error(codes.InvalidArgument, "Poll option cannot be empty")
+			}
+			if utf8.RuneCountInString(option) > MaxPollOptionLength {
+				return nil, status.Errorf(codes.InvalidArgument, "Poll option exceeds maximum length of %d characters", MaxPollOptionLength)
+			}
+			if seen[strings.ToLower(option)] {
+				return nil, status.Error(codes.InvalidArgument, "Poll options must be unique")
+			}
+			seen[strings.ToLower(option)] = true
+			poll.Options = append(poll.Options, &models.PollOption{Text: option})
+		}
+		return &threadKind{poll: poll}, nil
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Unknown thread kind")
+	}
+}
+
+// lowercases a host name and drops the www. prefix, e.g. WWW.Example.com becomes example.com
+func normalizeDomain(host string) string {
+	return strings.TrimPrefix(strings.TrimSuffix(strings.ToLower(host), "."), "www.")
+}
+
+// closes polls that ended or whose thread is archived, vote counts of open polls are hidden if the author chose so
+func closePoll(thread *models.Thread) *models.Thread {
+	poll := thread.GetPoll()
+	if poll == nil {
+		return thread
+	}
+	if thread.Archived || (poll.EndsAt != nil && !time.Now().Before(poll.EndsAt.AsTime())) {
+		poll.Closed = true
+	}
+	if poll.HideResults && !poll.Closed {
+		for _, option := range poll.Options {
+			option.NumVotes = 0
+		}
+	}
+	return thread
 }
@@ -2,36 +2,54 @@ package test
 
 import (
 	"context"
+	"strings"
 	"testing"
+	"time"
 
+	"gen/auth"
 	communitypb "gen/community-service/pb"
 	dbpb "gen/db-service/pb"
 	models "gen/models/pb"
+	moderationpb "gen/moderation-service/pb"
 	threadpb "gen/thread-service/pb"
 	src "thread-service/src"
 
 	"github.com/stretchr/testify/assert"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 	"google.golang.org/grpc"
 )
 
 type MockDBClient struct {
 	dbpb.DBServiceClient
 	ListThreadsFunc  func(ctx context.Context, req *dbpb.ListThreadsRequest, opts ...grpc.CallOption) (*dbpb.ListThreadsResponse, error)
-	CreateThreadFunc func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error)
+	CreateThreadAndIncrementFunc func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error)
 	GetThreadFunc    func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error)
 	UpdateThreadFunc func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	DeleteThreadFunc func(ctx context.Context, req *dbpb.DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteThreadAndDecrementFunc func(ctx context.Context, req *dbpb.DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveThreadFunc func(ctx context.Context, req *dbpb.RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RestoreThreadFunc func(ctx context.Context, req *dbpb.RestoreThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CastPollVoteFunc  func(ctx context.Context, req *dbpb.CastPollVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetAttachmentFunc func(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error)
+	ListRevisionsFunc func(ctx context.Context, req *dbpb.ListRevisionsRequest, opts ...grpc.CallOption) (*dbpb.ListRevisionsResponse, error)
+
+	PublishThreadAndIncrementFunc        func(ctx context.Context, req *dbpb.PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListScheduledThreadsFunc func(ctx context.Context, req *dbpb.ListScheduledThreadsRequest, opts ...grpc.CallOption) (*dbpb.ListThreadsResponse, error)
+	MoveThreadFunc           func(ctx context.Context, req *dbpb.MoveThreadRequest, opts ...grpc.CallOption) (*dbpb.MoveThreadResponse, error)
+	CreateJobFunc            func(ctx context.Context, req *dbpb.CreateJobRequest, opts ...grpc.CallOption) (*dbpb.CreateJobResponse, error)
+	GetJobStatusFunc         func(ctx context.Context, req *dbpb.GetJobStatusRequest, opts ...grpc.CallOption) (*models.Job, error)
+	PinThreadFunc            func(ctx context.Context, req *dbpb.PinThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 func (m *MockDBClient) ListThreads(ctx context.Context, req *dbpb.ListThreadsRequest, opts ...grpc.CallOption) (*dbpb.ListThreadsResponse, error) {
 	return m.ListThreadsFunc(ctx, req, opts...)
 }
 
-func (m *MockDBClient) CreateThread(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
-	return m.CreateThreadFunc(ctx, req, opts...)
+func (m *MockDBClient) CreateThreadAndIncrement(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+	return m.CreateThreadAndIncrementFunc(ctx, req, opts...)
 }
 
 func (m *MockDBClient) GetThread(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
@@ -42,22 +60,81 @@ func (m *MockDBClient) UpdateThread(ctx context.Context, req *dbpb.UpdateThreadR
 	return m.UpdateThreadFunc(ctx, req, opts...)
 }
 
-func (m *MockDBClient) DeleteThread(ctx context.Context, req *dbpb.DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	return m.DeleteThreadFunc(ctx, req, opts...)
+func (m *MockDBClient) DeleteThreadAndDecrement(ctx context.Context, req *dbpb.DeleteThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.DeleteThreadAndDecrementFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) RemoveThread(ctx context.Context, req *dbpb.RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.RemoveThreadFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) RestoreThread(ctx context.Context, req *dbpb.RestoreThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.RestoreThreadFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) CastPollVote(ctx context.Context, req *dbpb.CastPollVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.CastPollVoteFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetAttachment(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error) {
+	return m.GetAttachmentFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) ListRevisions(ctx context.Context, req *dbpb.ListRevisionsRequest, opts ...grpc.CallOption) (*dbpb.ListRevisionsResponse, error) {
+	return m.ListRevisionsFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) PublishThreadAndIncrement(ctx context.Context, req *dbpb.PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.PublishThreadAndIncrementFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) ListScheduledThreads(ctx context.Context, req *dbpb.ListScheduledThreadsRequest, opts ...grpc.CallOption) (*dbpb.ListThreadsResponse, error) {
+	return m.ListScheduledThreadsFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) MoveThread(ctx context.Context, req *dbpb.MoveThreadRequest, opts ...grpc.CallOption) (*dbpb.MoveThreadResponse, error) {
+	return m.MoveThreadFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) PinThread(ctx context.Context, req *dbpb.PinThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	return m.PinThreadFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) CreateJob(ctx context.Context, req *dbpb.CreateJobRequest, opts ...grpc.CallOption) (*dbpb.CreateJobResponse, error) {
+	return m.CreateJobFunc(ctx, req, opts...)
+}
+
+func (m *MockDBClient) GetJobStatus(ctx context.Context, req *dbpb.GetJobStatusRequest, opts ...grpc.CallOption) (*models.Job, error) {
+	return m.GetJobStatusFunc(ctx, req, opts...)
 }
 
 type MockCommunityClient struct {
 	communitypb.CommunityServiceClient
 	GetCommunityFunc    func(ctx context.Context, req *communitypb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error)
-	UpdateCommunityFunc func(ctx context.Context, req *communitypb.UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+
+	GetCommunityPostingRulesFunc func(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*models.PostingRules, error)
 }
 
 func (m *MockCommunityClient) GetCommunity(ctx context.Context, req *communitypb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
 	return m.GetCommunityFunc(ctx, req, opts...)
 }
 
-func (m *MockCommunityClient) UpdateCommunity(ctx context.Context, req *communitypb.UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-	return m.UpdateCommunityFunc(ctx, req, opts...)
+func (m *MockCommunityClient) GetCommunityPostingRules(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*models.PostingRules, error) {
+	return m.GetCommunityPostingRulesFunc(ctx, req, opts...)
+}
+
+// posting rules of a community without overrides
+func getDefaultPostingRules(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*models.PostingRules, error) {
+	return &models.PostingRules{MinTitleLength: 3, MaxTitleLength: 50, MinContentLength: 3, MaxContentLength: 500, MaxCommentLength: 500}, nil
+}
+
+type MockModerationClient struct {
+	moderationpb.ModerationServiceClient
+	EvaluateThreadFunc func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error)
+}
+
+func (m *MockModerationClient) EvaluateThread(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+	return m.EvaluateThreadFunc(ctx, req, opts...)
 }
 
 func TestCreateThread_Validation(t *testing.T) {
@@ -86,6 +163,36 @@ func TestCreateThread_Validation(t *testing.T) {
 			},
 			wantErr: status.Error(codes.InvalidArgument, "Content is required"),
 		},
+		{
+			name: "too many tags",
+			req: &threadpb.CreateThreadRequest{
+				CommunityId: "123",
+				Title:       "test thread",
+				Content:     "test content",
+				Tags:        []string{"a", "b", "c", "d", "e", "f"},
+			},
+			wantErr: status.Errorf(codes.InvalidArgument, "Thread cannot have more than %d tags", src.MaxTags),
+		},
+		{
+			name: "invalid tag",
+			req: &threadpb.CreateThreadRequest{
+				CommunityId: "123",
+				Title:       "test thread",
+				Content:     "test content",
+				Tags:        []string{"help wanted"},
+			},
+			wantErr: status.Error(codes.InvalidArgument, "Tags can only contain letters, digits and dashes"),
+		},
+		{
+			name: "unknown flair",
+			req: &threadpb.CreateThreadRequest{
+				CommunityId: "123",
+				Title:       "test thread",
+				Content:     "test content",
+				FlairId:     "999",
+			},
+			wantErr: status.Error(codes.InvalidArgument, "Flair template not found"),
+		},
 		{
 			name: "valid request",
 			req: &threadpb.CreateThreadRequest{
@@ -95,25 +202,40 @@ func TestCreateThread_Validation(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "valid request with flair and tags",
+			req: &threadpb.CreateThreadRequest{
+				CommunityId: "123",
+				Title:       "test thread",
+				Content:     "test content",
+				FlairId:     "1",
+				Tags:        []string{"Help-Wanted", "go", "go"},
+			},
+			wantErr: nil,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			server := &src.ThreadServer{
 				DBClient: &MockDBClient{
-					CreateThreadFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+					CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
 						return &dbpb.CreateThreadResponse{Id: "123"}, nil
 					},
 				},
 				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
 					GetCommunityFunc: func(ctx context.Context, req *communitypb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
 						return &models.Community{
-							Id:   "123",
-							Name: "test-community",
+							Id:             "123",
+							Name:           "test-community",
+							FlairTemplates: []*models.FlairTemplate{{Id: "1", Text: "Question", Color: "#ff4500"}},
 						}, nil
 					},
-					UpdateCommunityFunc: func(ctx context.Context, req *communitypb.UpdateCommunityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
-						return &emptypb.Empty{}, nil
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						return &moderationpb.EvaluateResponse{}, nil
 					},
 				},
 			}
@@ -246,5 +368,1761 @@ func TestListThreads_Validation(t *testing.T) {
 	}
 }
 
-func strPtr(s string) *string { return &s }
-func int32Ptr(i int32) *int32 { return &i }
+func TestDeleteThread_Validation(t *testing.T) {
+	threads := map[string]*models.Thread{
+		"thread": {Id: "thread", AuthorId: "author"},
+		"draft":  {Id: "draft", AuthorId: "author", Draft: true},
+		"legacy": {Id: "legacy"},
+	}
+
+	tests := []struct {
+		name        string
+		req         *threadpb.DeleteThreadRequest
+		user        string
+		roles       string
+		wantRemoval models.RemovalType
+		wantErr     error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.DeleteThreadRequest{},
+			user:    "author",
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "another user",
+			req:     &threadpb.DeleteThreadRequest{Id: "thread"},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a thread"),
+		},
+		{
+			name:    "anonymous",
+			req:     &threadpb.DeleteThreadRequest{Id: "thread"},
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a thread"),
+		},
+		{
+			name:    "draft of another user",
+			req:     &threadpb.DeleteThreadRequest{Id: "draft"},
+			user:    "other",
+			wantErr: status.Error(codes.NotFound, "Thread not found"),
+		},
+		{
+			name:        "author",
+			req:         &threadpb.DeleteThreadRequest{Id: "thread"},
+			user:        "author",
+			wantRemoval: models.RemovalType_DELETED,
+		},
+		{
+			name:        "draft of the author",
+			req:         &threadpb.DeleteThreadRequest{Id: "draft"},
+			user:        "author",
+			wantRemoval: models.RemovalType_DELETED,
+		},
+		{
+			name:        "moderator",
+			req:         &threadpb.DeleteThreadRequest{Id: "thread"},
+			user:        "other",
+			roles:       "moderator",
+			wantRemoval: models.RemovalType_REMOVED,
+		},
+		{
+			name:        "moderator deleting their own thread",
+			req:         &threadpb.DeleteThreadRequest{Id: "thread"},
+			user:        "author",
+			roles:       "moderator",
+			wantRemoval: models.RemovalType_DELETED,
+		},
+		{
+			name:    "thread without author",
+			req:     &threadpb.DeleteThreadRequest{Id: "legacy"},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a thread"),
+		},
+		{
+			name:    "anonymous thread without author",
+			req:     &threadpb.DeleteThreadRequest{Id: "legacy"},
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can delete a thread"),
+		},
+		{
+			name:        "moderator thread without author",
+			req:         &threadpb.DeleteThreadRequest{Id: "legacy"},
+			user:        "other",
+			roles:       "moderator",
+			wantRemoval: models.RemovalType_REMOVED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			removal := models.RemovalType_NOT_REMOVED
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return threads[req.GetId()], nil
+					},
+					RemoveThreadFunc: func(ctx context.Context, req *dbpb.RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						removal = req.GetRemovalType()
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, tt.user, auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.DeleteThread(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantRemoval, removal)
+		})
+	}
+}
+
+func TestRemoveThread_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *threadpb.RemoveThreadRequest
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.RemoveThreadRequest{},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "missing reason",
+			req:     &threadpb.RemoveThreadRequest{Id: "123"},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Removal reason is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &threadpb.RemoveThreadRequest{Id: "123", Reason: "spam"},
+			roles:   "member",
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can remove threads"),
+		},
+		{
+			name:    "valid request",
+			req:     &threadpb.RemoveThreadRequest{Id: "123", Reason: "spam"},
+			roles:   "moderator",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					RemoveThreadFunc: func(ctx context.Context, req *dbpb.RemoveThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, models.RemovalType_REMOVED, req.GetRemovalType())
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			_, err := server.RemoveThread(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestRestoreThread_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *threadpb.RestoreThreadRequest
+		roles      string
+		restoreErr error
+		wantErr    error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.RestoreThreadRequest{},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &threadpb.RestoreThreadRequest{Id: "123"},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can restore threads"),
+		},
+		{
+			name:       "grace period expired",
+			req:        &threadpb.RestoreThreadRequest{Id: "123"},
+			roles:      "moderator",
+			restoreErr: status.Error(codes.FailedPrecondition, "Thread can no longer be restored"),
+			wantErr:    status.Error(codes.FailedPrecondition, "Thread can no longer be restored"),
+		},
+		{
+			name:    "valid request",
+			req:     &threadpb.RestoreThreadRequest{Id: "123"},
+			roles:   "admin",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					RestoreThreadFunc: func(ctx context.Context, req *dbpb.RestoreThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						return &emptypb.Empty{}, tt.restoreErr
+					},
+				},
+			}
+
+			_, err := server.RestoreThread(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCrosspostThread_Validation(t *testing.T) {
+	threads := map[string]*models.Thread{
+		"original":  {Id: "original", CommunityId: "source", Title: "original title"},
+		"crosspost": {Id: "crosspost", CommunityId: "other", Title: "original title", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"},
+		"removed":   {Id: "removed", CommunityId: "source", Title: "removed title", RemovalType: models.RemovalType_REMOVED},
+	}
+
+	tests := []struct {
+		name        string
+		req         *threadpb.CrosspostThreadRequest
+		user        string
+		createErr   error
+		wantCreated *dbpb.CreateThreadRequest
+		wantErr     error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.CrosspostThreadRequest{CommunityId: "target"},
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "missing community id",
+			req:     &threadpb.CrosspostThreadRequest{Id: "original"},
+			wantErr: status.Error(codes.InvalidArgument, "Community id is required"),
+		},
+		{
+			name:    "anonymous",
+			req:     &threadpb.CrosspostThreadRequest{Id: "original", CommunityId: "target", AuthorId: "author"},
+			wantErr: status.Error(codes.Unauthenticated, "Crossposting requires an authenticated user"),
+		},
+		{
+			name:    "empty title",
+			req:     &threadpb.CrosspostThreadRequest{Id: "original", CommunityId: "target", Title: strPtr("")},
+			user:    "author",
+			wantErr: status.Error(codes.InvalidArgument, "Title cannot be empty"),
+		},
+		{
+			name:    "removed thread",
+			req:     &threadpb.CrosspostThreadRequest{Id: "removed", CommunityId: "target"},
+			user:    "author",
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is removed"),
+		},
+		{
+			name:    "same community",
+			req:     &threadpb.CrosspostThreadRequest{Id: "original", CommunityId: "source"},
+			user:    "author",
+			wantErr: status.Error(codes.InvalidArgument, "Thread already belongs to this community"),
+		},
+		{
+			name:      "already crossposted",
+			req:       &threadpb.CrosspostThreadRequest{Id: "original", CommunityId: "target"},
+			user:      "author",
+			createErr: status.Error(codes.AlreadyExists, "Thread is already crossposted to this community"),
+			wantErr:   status.Error(codes.AlreadyExists, "Thread is already crossposted to this community"),
+		},
+		{
+			name:    "title too short for the target community",
+			req:     &threadpb.CrosspostThreadRequest{Id: "original", CommunityId: "target", Title: strPtr("ab")},
+			user:    "author",
+			wantErr: status.Error(codes.InvalidArgument, "Title must be between 3 and 50 characters long"),
+		},
+		{
+			name:        "valid crosspost",
+			req:         &threadpb.CrosspostThreadRequest{Id: "original", CommunityId: "target"},
+			user:        "author",
+			wantCreated: &dbpb.CreateThreadRequest{CommunityId: "target", Title: "original title", AuthorId: "author", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"},
+		},
+		{
+			name:        "author in body is ignored",
+			req:         &threadpb.CrosspostThreadRequest{Id: "original", CommunityId: "target", AuthorId: "someone-else"},
+			user:        "author",
+			wantCreated: &dbpb.CreateThreadRequest{CommunityId: "target", Title: "original title", AuthorId: "author", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"},
+		},
+		{
+			name:        "crosspost of a crosspost references the original",
+			req:         &threadpb.CrosspostThreadRequest{Id: "crosspost", CommunityId: "target", Title: strPtr("new title")},
+			user:        "author",
+			wantCreated: &dbpb.CreateThreadRequest{CommunityId: "target", Title: "new title", AuthorId: "author", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *dbpb.CreateThreadRequest
+			var evaluated string
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return threads[req.GetId()], nil
+					},
+					CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+						if tt.createErr != nil {
+							return nil, tt.createErr
+						}
+						created = req
+						return &dbpb.CreateThreadResponse{Id: "new"}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						evaluated = req.GetThreadId()
+						return &moderationpb.EvaluateResponse{}, nil
+					},
+				},
+			}
+
+			res, err := server.CrosspostThread(asUser(tt.user), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+				assert.Nil(t, created)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "new", res.GetId())
+			// only the target community gains a thread
+			assert.Equal(t, tt.wantCreated.String(), created.String())
+			// the crosspost goes through automod like any new thread
+			assert.Equal(t, "new", evaluated)
+		})
+	}
+}
+
+func TestListThreads_CrosspostParents(t *testing.T) {
+	parents := map[string]*models.Thread{
+		"original": {Id: "original", CommunityId: "source", Ups: 10, Downs: 2},
+		"removed":  {Id: "removed", CommunityId: "source", RemovalType: models.RemovalType_REMOVED},
+	}
+	threadLookups, communityLookups := 0, 0
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			ListThreadsFunc: func(ctx context.Context, req *dbpb.ListThreadsRequest, opts ...grpc.CallOption) (*dbpb.ListThreadsResponse, error) {
+				return &dbpb.ListThreadsResponse{Threads: []*models.Thread{
+					{Id: "1", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"},
+					{Id: "2", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"},
+					{Id: "3", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "removed"},
+					{Id: "4", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "deleted"},
+					{Id: "5"},
+				}}, nil
+			},
+			GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				threadLookups++
+				if parent, ok := parents[req.GetId()]; ok {
+					return parent, nil
+				}
+				return nil, status.Error(codes.NotFound, "Thread not found")
+			},
+		},
+		CommunityClient: &MockCommunityClient{
+			GetCommunityFunc: func(ctx context.Context, req *communitypb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
+				communityLookups++
+				return &models.Community{Id: req.GetId(), Name: "source community"}, nil
+			},
+		},
+	}
+
+	res, err := server.ListThreads(context.Background(), &threadpb.ListThreadsRequest{})
+	assert.NoError(t, err)
+	want := &models.CrosspostParent{ThreadId: "original", CommunityId: "source", CommunityName: "source community", Ups: 10, Downs: 2}
+	assert.Equal(t, want.String(), res.Threads[0].GetCrosspostParent().String())
+	assert.Equal(t, want.String(), res.Threads[1].GetCrosspostParent().String())
+	assert.Equal(t, (&models.CrosspostParent{ThreadId: "removed", Removed: true}).String(), res.Threads[2].GetCrosspostParent().String())
+	assert.Equal(t, (&models.CrosspostParent{ThreadId: "deleted", Removed: true}).String(), res.Threads[3].GetCrosspostParent().String())
+	assert.Nil(t, res.Threads[4].GetCrosspostParent())
+	// each original and community is fetched once per request
+	assert.Equal(t, 3, threadLookups)
+	assert.Equal(t, 1, communityLookups)
+}
+
+func TestMoveThread_Validation(t *testing.T) {
+	threads := map[string]*models.Thread{
+		"thread":    {Id: "thread", CommunityId: "source"},
+		"draft":     {Id: "draft", CommunityId: "source", Draft: true},
+		"redirect":  {Id: "redirect", CommunityId: "source", Kind: models.ThreadKind_REDIRECT, RedirectThreadId: "thread"},
+		"crosspost": {Id: "crosspost", CommunityId: "source", Kind: models.ThreadKind_CROSSPOST, CrosspostParentId: "original"},
+		"original":  {Id: "original", CommunityId: "target"},
+	}
+
+	tests := []struct {
+		name      string
+		req       *threadpb.MoveThreadRequest
+		roles     string
+		wantMoved bool
+		wantErr   error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.MoveThreadRequest{CommunityId: "target"},
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "missing community id",
+			req:     &threadpb.MoveThreadRequest{Id: "thread"},
+			wantErr: status.Error(codes.InvalidArgument, "Community id is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &threadpb.MoveThreadRequest{Id: "thread", CommunityId: "target"},
+			roles:   "member",
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can move threads"),
+		},
+		{
+			name:      "admin",
+			req:       &threadpb.MoveThreadRequest{Id: "thread", CommunityId: "target"},
+			roles:     "member, admin",
+			wantMoved: true,
+		},
+		{
+			name:    "draft",
+			req:     &threadpb.MoveThreadRequest{Id: "draft", CommunityId: "target"},
+			wantErr: status.Error(codes.FailedPrecondition, "Drafts cannot be moved"),
+		},
+		{
+			name:    "redirect",
+			req:     &threadpb.MoveThreadRequest{Id: "redirect", CommunityId: "target"},
+			wantErr: status.Error(codes.FailedPrecondition, "Redirects cannot be moved"),
+		},
+		{
+			name:    "same community",
+			req:     &threadpb.MoveThreadRequest{Id: "thread", CommunityId: "source"},
+			wantErr: status.Error(codes.InvalidArgument, "Thread already belongs to this community"),
+		},
+		{
+			name:    "crosspost to the community of its original",
+			req:     &threadpb.MoveThreadRequest{Id: "crosspost", CommunityId: "target"},
+			wantErr: status.Error(codes.InvalidArgument, "Crossposts cannot be moved to the community of their original thread"),
+		},
+		{
+			name:      "valid move",
+			req:       &threadpb.MoveThreadRequest{Id: "thread", CommunityId: "target"},
+			wantMoved: true,
+		},
+		{
+			name:      "crosspost to another community",
+			req:       &threadpb.MoveThreadRequest{Id: "crosspost", CommunityId: "other"},
+			wantMoved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			moved := false
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return threads[req.GetId()], nil
+					},
+					MoveThreadFunc: func(ctx context.Context, req *dbpb.MoveThreadRequest, opts ...grpc.CallOption) (*dbpb.MoveThreadResponse, error) {
+						assert.Equal(t, tt.req.GetId(), req.GetId())
+						assert.Equal(t, tt.req.GetCommunityId(), req.GetCommunityId())
+						moved = true
+						return &dbpb.MoveThreadResponse{RedirectId: "redirect-id"}, nil
+					},
+				},
+			}
+
+			roles := tt.roles
+			if roles == "" {
+				roles = "moderator"
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, roles))
+			res, err := server.MoveThread(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "redirect-id", res.GetRedirectId())
+			}
+			assert.Equal(t, tt.wantMoved, moved)
+		})
+	}
+}
+
+func TestUpdateThread_Redirect(t *testing.T) {
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				return &models.Thread{Id: "123", Kind: models.ThreadKind_REDIRECT, Locked: true, CreatedAt: timestamppb.Now()}, nil
+			},
+		},
+	}
+
+	_, err := server.UpdateThread(context.Background(), &threadpb.UpdateThreadRequest{Id: "123", Title: strPtr("new title")})
+	assert.Equal(t, status.Error(codes.FailedPrecondition, "Thread was moved").Error(), err.Error())
+}
+
+func TestUpdateThread_Drafts(t *testing.T) {
+	updated := false
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				return &models.Thread{Id: "123", AuthorId: "author", Draft: true, CreatedAt: timestamppb.Now()}, nil
+			},
+			UpdateThreadFunc: func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+				updated = true
+				return &emptypb.Empty{}, nil
+			},
+		},
+		CommunityClient: &MockCommunityClient{
+			GetCommunityPostingRulesFunc: getDefaultPostingRules,
+		},
+	}
+
+	// drafts of other users are not found
+	req := &threadpb.UpdateThreadRequest{Id: "123", Content: strPtr("new content")}
+	_, err := server.UpdateThread(asUser("someone-else"), req)
+	assert.Equal(t, status.Error(codes.NotFound, "Thread not found").Error(), err.Error())
+	assert.False(t, updated)
+
+	_, err = server.UpdateThread(asUser("author"), req)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+}
+
+func TestUpdateThread_Ownership(t *testing.T) {
+	threads := map[string]*models.Thread{
+		"thread": {Id: "thread", AuthorId: "author", CreatedAt: timestamppb.Now()},
+		"legacy": {Id: "legacy", CreatedAt: timestamppb.Now()},
+	}
+
+	tests := []struct {
+		name       string
+		req        *threadpb.UpdateThreadRequest
+		user       string
+		roles      string
+		wantEditor string
+		wantErr    error
+	}{
+		{
+			name:    "edit by another user",
+			req:     &threadpb.UpdateThreadRequest{Id: "thread", Content: strPtr("new content")},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a thread"),
+		},
+		{
+			name:    "anonymous retag",
+			req:     &threadpb.UpdateThreadRequest{Id: "thread", Tags: &models.TagList{Tags: []string{"solved"}}},
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a thread"),
+		},
+		{
+			name:    "flair by another user",
+			req:     &threadpb.UpdateThreadRequest{Id: "thread", FlairId: strPtr("")},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a thread"),
+		},
+		{
+			name:    "edit of a thread without author",
+			req:     &threadpb.UpdateThreadRequest{Id: "legacy", Title: strPtr("new title")},
+			user:    "other",
+			wantErr: status.Error(codes.PermissionDenied, "Only the author or a moderator can edit a thread"),
+		},
+		{
+			name:       "edit by the author",
+			req:        &threadpb.UpdateThreadRequest{Id: "thread", Content: strPtr("new content")},
+			user:       "author",
+			wantEditor: "author",
+		},
+		{
+			name:       "edit by a moderator",
+			req:        &threadpb.UpdateThreadRequest{Id: "thread", Title: strPtr("new title")},
+			user:       "mod",
+			roles:      "moderator",
+			wantEditor: "mod",
+		},
+		{
+			name:       "moderator edit of a thread without author",
+			req:        &threadpb.UpdateThreadRequest{Id: "legacy", Content: strPtr("new content")},
+			user:       "mod",
+			roles:      "moderator",
+			wantEditor: "mod",
+		},
+		{
+			name: "vote by another user",
+			req:  &threadpb.UpdateThreadRequest{Id: "thread", VoteOffset: int32Ptr(1)},
+			user: "other",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return threads[req.GetId()], nil
+					},
+					UpdateThreadFunc: func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						if tt.wantEditor != "" {
+							assert.Equal(t, tt.wantEditor, req.GetEditorId())
+						}
+						return &emptypb.Empty{}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+			}
+
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, tt.user, auth.UserRolesMetadataKey, tt.roles))
+			_, err := server.UpdateThread(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetThread_MasksRemovedContent(t *testing.T) {
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				return &models.Thread{
+					Id:          "123",
+					Title:       "test thread",
+					Content:     "test content",
+					ContentHtml: "<p>test content</p>",
+					Kind:        models.ThreadKind_LINK,
+					Url:         "https://spam.com/offer",
+					Domain:      "spam.com",
+					RemovalType: models.RemovalType_REMOVED,
+				}, nil
+			},
+		},
+	}
+
+	res, err := server.GetThread(context.Background(), &threadpb.GetThreadRequest{Id: "123"})
+	assert.NoError(t, err)
+	assert.Equal(t, "[removed]", res.GetContent())
+	assert.Equal(t, "<p>[removed]</p>", res.GetContentHtml())
+	assert.Empty(t, res.GetUrl())
+	assert.Empty(t, res.GetDomain())
+}
+
+func TestGetThread_Drafts(t *testing.T) {
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+				return &models.Thread{Id: "123", AuthorId: "author", Draft: true, CreatedAt: timestamppb.Now()}, nil
+			},
+		},
+	}
+
+	for _, ctx := range []context.Context{context.Background(), asUser("someone-else")} {
+		_, err := server.GetThread(ctx, &threadpb.GetThreadRequest{Id: "123"})
+		assert.Equal(t, status.Error(codes.NotFound, "Thread not found").Error(), err.Error())
+	}
+
+	// the viewer id of the request is not trusted
+	_, err := server.GetThread(context.Background(), &threadpb.GetThreadRequest{Id: "123", ViewerId: "author"})
+	assert.Equal(t, status.Error(codes.NotFound, "Thread not found").Error(), err.Error())
+
+	res, err := server.GetThread(asUser("author"), &threadpb.GetThreadRequest{Id: "123"})
+	assert.NoError(t, err)
+	assert.True(t, res.GetDraft())
+}
+
+func TestListThreads_Drafts(t *testing.T) {
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			ListThreadsFunc: func(ctx context.Context, req *dbpb.ListThreadsRequest, opts ...grpc.CallOption) (*dbpb.ListThreadsResponse, error) {
+				return &dbpb.ListThreadsResponse{}, nil
+			},
+		},
+	}
+
+	req := &threadpb.ListThreadsRequest{AuthorId: strPtr("author"), Drafts: true}
+	for _, ctx := range []context.Context{context.Background(), asUser("someone-else")} {
+		_, err := server.ListThreads(ctx, req)
+		assert.Equal(t, status.Error(codes.PermissionDenied, "Drafts are only listed to their author").Error(), err.Error())
+	}
+
+	_, err := server.ListThreads(asUser("author"), req)
+	assert.NoError(t, err)
+}
+
+func TestCreateThread_Drafts(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *threadpb.CreateThreadRequest
+		user    string
+		wantErr error
+	}{
+		{
+			name:    "anonymous draft",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: "test content", Draft: true},
+			wantErr: status.Error(codes.Unauthenticated, "Drafts require an authenticated user"),
+		},
+		{
+			name:    "anonymous draft with author in body",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: "test content", AuthorId: "author", Draft: true},
+			wantErr: status.Error(codes.Unauthenticated, "Drafts require an authenticated user"),
+		},
+		{
+			name:    "publish time in the past",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: "test content", PublishAt: timestamppb.New(time.Now().Add(-time.Hour))},
+			user:    "author",
+			wantErr: status.Error(codes.InvalidArgument, "Publish time must be in the future"),
+		},
+		{
+			name: "poll ending before publication",
+			req: &threadpb.CreateThreadRequest{
+				CommunityId: "123",
+				Title:       "test poll",
+				Kind:        models.ThreadKind_POLL,
+				PollOptions: []string{"yes", "no"},
+				PollEndsAt:  timestamppb.New(time.Now().Add(time.Hour)),
+				PublishAt:   timestamppb.New(time.Now().Add(2 * time.Hour)),
+			},
+			user:    "author",
+			wantErr: status.Error(codes.InvalidArgument, "Poll must end after the thread is published"),
+		},
+		{
+			name:    "draft",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: "test content", Draft: true},
+			user:    "author",
+			wantErr: nil,
+		},
+		{
+			name:    "scheduled thread",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: "test content", PublishAt: timestamppb.New(time.Now().Add(time.Hour))},
+			user:    "author",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+						assert.True(t, req.GetDraft())
+						assert.Equal(t, "author", req.GetAuthorId())
+						assert.Equal(t, tt.req.GetPublishAt(), req.GetPublishAt())
+						return &dbpb.CreateThreadResponse{Id: "456"}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+			}
+
+			_, err := server.CreateThread(asUser(tt.user), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPublishThread_Validation(t *testing.T) {
+	future := timestamppb.New(time.Now().Add(time.Hour))
+
+	tests := []struct {
+		name          string
+		thread        *models.Thread
+		req           *threadpb.PublishThreadRequest
+		user          string
+		wantPublished bool
+		wantErr       error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.PublishThreadRequest{},
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "publish time in the past",
+			req:     &threadpb.PublishThreadRequest{Id: "123", PublishAt: timestamppb.New(time.Now().Add(-time.Hour))},
+			wantErr: status.Error(codes.InvalidArgument, "Publish time must be in the future"),
+		},
+		{
+			name:    "draft of another user",
+			thread:  &models.Thread{Id: "123", AuthorId: "author", Draft: true},
+			req:     &threadpb.PublishThreadRequest{Id: "123"},
+			user:    "someone-else",
+			wantErr: status.Error(codes.NotFound, "Thread not found"),
+		},
+		{
+			name:    "draft of another user claiming to be its author",
+			thread:  &models.Thread{Id: "123", AuthorId: "author", Draft: true},
+			req:     &threadpb.PublishThreadRequest{Id: "123", AuthorId: "author"},
+			user:    "someone-else",
+			wantErr: status.Error(codes.NotFound, "Thread not found"),
+		},
+		{
+			name:    "anonymous",
+			thread:  &models.Thread{Id: "123", AuthorId: "author", Draft: true},
+			req:     &threadpb.PublishThreadRequest{Id: "123", AuthorId: "author"},
+			user:    "",
+			wantErr: status.Error(codes.NotFound, "Thread not found"),
+		},
+		{
+			name:    "published thread",
+			thread:  &models.Thread{Id: "123", AuthorId: "author"},
+			req:     &threadpb.PublishThreadRequest{Id: "123"},
+			user:    "author",
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is already published"),
+		},
+		{
+			name:          "publish now",
+			thread:        &models.Thread{Id: "123", AuthorId: "author", Draft: true},
+			req:           &threadpb.PublishThreadRequest{Id: "123"},
+			user:          "author",
+			wantPublished: true,
+			wantErr:       nil,
+		},
+		{
+			name:    "reschedule",
+			thread:  &models.Thread{Id: "123", AuthorId: "author", Draft: true},
+			req:     &threadpb.PublishThreadRequest{Id: "123", PublishAt: future},
+			user:    "author",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			published := false
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return tt.thread, nil
+					},
+					PublishThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						published = true
+						return &emptypb.Empty{}, nil
+					},
+					UpdateThreadFunc: func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, future, req.GetPublishAt())
+						return &emptypb.Empty{}, nil
+					},
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						return &moderationpb.EvaluateResponse{}, nil
+					},
+				},
+			}
+
+			ctx := context.Background()
+			if tt.user != "" {
+				ctx = asUser(tt.user)
+			}
+			_, err := server.PublishThread(ctx, tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPublished, published)
+		})
+	}
+}
+
+func TestRunScheduler(t *testing.T) {
+	var published []string
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			ListScheduledThreadsFunc: func(ctx context.Context, req *dbpb.ListScheduledThreadsRequest, opts ...grpc.CallOption) (*dbpb.ListThreadsResponse, error) {
+				return &dbpb.ListThreadsResponse{Threads: []*models.Thread{
+					{Id: "1", CommunityId: "123", Draft: true},
+					{Id: "2", CommunityId: "123", Draft: true},
+				}}, nil
+			},
+			PublishThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.PublishThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+				// the second thread was published by another replica
+				if req.GetId() == "2" {
+					return nil, status.Error(codes.FailedPrecondition, "Thread is already published")
+				}
+				published = append(published, req.GetId())
+				return &emptypb.Empty{}, nil
+			},
+		},
+		ModerationClient: &MockModerationClient{
+			EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+				return &moderationpb.EvaluateResponse{}, nil
+			},
+		},
+	}
+
+	// a done context stops the scheduler after its first run
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	server.RunScheduler(ctx, time.Hour)
+
+	assert.Equal(t, []string{"1"}, published)
+}
+
+func TestPinThread_Validation(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *threadpb.PinThreadRequest
+		roles     string
+		numPinned int
+		wantErr   error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.PinThreadRequest{},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &threadpb.PinThreadRequest{Id: "123", Pinned: true},
+			roles:   "member",
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can pin threads"),
+		},
+		{
+			name:      "too many pinned threads",
+			req:       &threadpb.PinThreadRequest{Id: "123", Pinned: true},
+			roles:     "moderator",
+			numPinned: 3,
+			wantErr:   status.Errorf(codes.FailedPrecondition, "Community already has %d pinned threads", 3),
+		},
+		{
+			name:      "valid request",
+			req:       &threadpb.PinThreadRequest{Id: "123", Pinned: true},
+			roles:     "moderator",
+			numPinned: 2,
+			wantErr:   nil,
+		},
+		{
+			name:      "unpin",
+			req:       &threadpb.PinThreadRequest{Id: "123", Pinned: false},
+			roles:     "admin",
+			numPinned: 3,
+			wantErr:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return &models.Thread{Id: "123", CommunityId: "456"}, nil
+					},
+					PinThreadFunc: func(ctx context.Context, req *dbpb.PinThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, "123", req.GetId())
+						if tt.numPinned >= int(req.GetMaxPinned()) {
+							return nil, status.Errorf(codes.FailedPrecondition, "Community already has %d pinned threads", req.GetMaxPinned())
+						}
+						return &emptypb.Empty{}, nil
+					},
+					UpdateThreadFunc: func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.False(t, req.GetPinned())
+						return &emptypb.Empty{}, nil
+					},
+				},
+				MaxPinned: 3,
+			}
+
+			_, err := server.PinThread(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestLockThread_Validation(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *threadpb.LockThreadRequest
+		roles      string
+		wantLocked bool
+		wantErr    error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.LockThreadRequest{Locked: true},
+			roles:   "moderator",
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "not a moderator",
+			req:     &threadpb.LockThreadRequest{Id: "123", Locked: true},
+			roles:   "member",
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can lock threads"),
+		},
+		{
+			name:       "valid request",
+			req:        &threadpb.LockThreadRequest{Id: "123", Locked: true},
+			roles:      "moderator",
+			wantLocked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locked := false
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					UpdateThreadFunc: func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						locked = req.GetLocked()
+						return &emptypb.Empty{}, nil
+					},
+				},
+			}
+
+			_, err := server.LockThread(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantLocked, locked)
+		})
+	}
+}
+
+func TestUpdateThread_ReadOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		thread  *models.Thread
+		req     *threadpb.UpdateThreadRequest
+		wantErr error
+	}{
+		{
+			name:    "vote on locked thread",
+			thread:  &models.Thread{Id: "123", Locked: true, CreatedAt: timestamppb.Now()},
+			req:     &threadpb.UpdateThreadRequest{Id: "123", VoteOffset: int32Ptr(1)},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is locked"),
+		},
+		{
+			name:    "edit locked thread",
+			thread:  &models.Thread{Id: "123", AuthorId: "author", Locked: true, CreatedAt: timestamppb.Now()},
+			req:     &threadpb.UpdateThreadRequest{Id: "123", Content: strPtr("new content")},
+			wantErr: nil,
+		},
+		{
+			name:    "vote on archived thread",
+			thread:  &models.Thread{Id: "123", CreatedAt: timestamppb.New(time.Now().Add(-src.DefaultArchiveAge - time.Hour))},
+			req:     &threadpb.UpdateThreadRequest{Id: "123", VoteOffset: int32Ptr(1)},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is archived"),
+		},
+		{
+			name:    "edit archived thread",
+			thread:  &models.Thread{Id: "123", CreatedAt: timestamppb.New(time.Now().Add(-src.DefaultArchiveAge - time.Hour))},
+			req:     &threadpb.UpdateThreadRequest{Id: "123", Title: strPtr("new title")},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is archived"),
+		},
+		{
+			name:    "retag archived thread",
+			thread:  &models.Thread{Id: "123", CreatedAt: timestamppb.New(time.Now().Add(-src.DefaultArchiveAge - time.Hour))},
+			req:     &threadpb.UpdateThreadRequest{Id: "123", Tags: &models.TagList{Tags: []string{"solved"}}},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is archived"),
+		},
+		{
+			name:    "clear flair of locked thread",
+			thread:  &models.Thread{Id: "123", AuthorId: "author", Locked: true, Flair: "Question", CreatedAt: timestamppb.Now()},
+			req:     &threadpb.UpdateThreadRequest{Id: "123", FlairId: strPtr("")},
+			wantErr: nil,
+		},
+		{
+			name:    "vote on open thread",
+			thread:  &models.Thread{Id: "123", CreatedAt: timestamppb.Now()},
+			req:     &threadpb.UpdateThreadRequest{Id: "123", VoteOffset: int32Ptr(-1)},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return tt.thread, nil
+					},
+					UpdateThreadFunc: func(ctx context.Context, req *dbpb.UpdateThreadRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						return &emptypb.Empty{}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+				ArchiveAge: src.DefaultArchiveAge,
+			}
+
+			_, err := server.UpdateThread(asUser("author"), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestListRevisions_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		thread  *models.Thread
+		req     *threadpb.ListRevisionsRequest
+		roles   string
+		wantErr error
+	}{
+		{
+			name:    "missing id",
+			req:     &threadpb.ListRevisionsRequest{},
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "deleted thread",
+			thread:  &models.Thread{Id: "123", RemovalType: models.RemovalType_DELETED},
+			req:     &threadpb.ListRevisionsRequest{Id: "123"},
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is deleted"),
+		},
+		{
+			name:    "removed thread",
+			thread:  &models.Thread{Id: "123", RemovalType: models.RemovalType_REMOVED},
+			req:     &threadpb.ListRevisionsRequest{Id: "123"},
+			wantErr: status.Error(codes.PermissionDenied, "Only moderators can list the revisions of removed threads"),
+		},
+		{
+			name:    "removed thread as moderator",
+			thread:  &models.Thread{Id: "123", RemovalType: models.RemovalType_REMOVED},
+			req:     &threadpb.ListRevisionsRequest{Id: "123"},
+			roles:   "moderator",
+			wantErr: nil,
+		},
+		{
+			name:    "valid request",
+			thread:  &models.Thread{Id: "123"},
+			req:     &threadpb.ListRevisionsRequest{Id: "123"},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return tt.thread, nil
+					},
+					ListRevisionsFunc: func(ctx context.Context, req *dbpb.ListRevisionsRequest, opts ...grpc.CallOption) (*dbpb.ListRevisionsResponse, error) {
+						assert.Equal(t, "123", req.GetThreadId())
+						assert.Empty(t, req.GetCommentId())
+						return &dbpb.ListRevisionsResponse{Revisions: []*models.Revision{{Version: 1}, {Version: 2}}}, nil
+					},
+				},
+			}
+
+			res, err := server.ListRevisions(asRoles(tt.roles), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.GetRevisions(), 2)
+			}
+		})
+	}
+}
+
+func TestCreateThread_FlairAndTags(t *testing.T) {
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+				assert.Equal(t, "Question", req.GetFlair())
+				assert.Equal(t, "#ff4500", req.GetFlairColor())
+				assert.Equal(t, []string{"help-wanted", "go"}, req.GetTags())
+				return &dbpb.CreateThreadResponse{Id: "123"}, nil
+			},
+		},
+		CommunityClient: &MockCommunityClient{
+			GetCommunityPostingRulesFunc: getDefaultPostingRules,
+			GetCommunityFunc: func(ctx context.Context, req *communitypb.GetCommunityRequest, opts ...grpc.CallOption) (*models.Community, error) {
+				return &models.Community{
+					Id:             "456",
+					FlairTemplates: []*models.FlairTemplate{{Id: "1", Text: "Question", Color: "#ff4500"}},
+				}, nil
+			},
+		},
+		ModerationClient: &MockModerationClient{
+			EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+				return &moderationpb.EvaluateResponse{}, nil
+			},
+		},
+	}
+
+	_, err := server.CreateThread(context.Background(), &threadpb.CreateThreadRequest{
+		CommunityId: "456",
+		Title:       "test thread",
+		Content:     "test content",
+		FlairId:     "1",
+		Tags:        []string{" Help-Wanted ", "go", "GO"},
+	})
+	assert.NoError(t, err)
+}
+
+func TestCreateThread_AuthorIsRequester(t *testing.T) {
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+				assert.Equal(t, "author", req.GetAuthorId())
+				return &dbpb.CreateThreadResponse{Id: "123"}, nil
+			},
+		},
+		CommunityClient: &MockCommunityClient{
+			GetCommunityPostingRulesFunc: getDefaultPostingRules,
+		},
+		ModerationClient: &MockModerationClient{
+			EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+				return &moderationpb.EvaluateResponse{}, nil
+			},
+		},
+	}
+
+	// the author id of the body cannot attribute the thread to another user
+	_, err := server.CreateThread(asUser("author"), &threadpb.CreateThreadRequest{
+		CommunityId: "456",
+		Title:       "test thread",
+		Content:     "test content",
+		AuthorId:    "someone-else",
+	})
+	assert.NoError(t, err)
+}
+
+func TestCreateThread_PostingRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *threadpb.CreateThreadRequest
+		wantErr error
+	}{
+		{
+			name:    "title too short",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "ab", Content: "test content"},
+			wantErr: status.Error(codes.InvalidArgument, "Title must be between 3 and 80 characters long"),
+		},
+		{
+			name:    "title too long",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: strings.Repeat("a", 81), Content: "test content"},
+			wantErr: status.Error(codes.InvalidArgument, "Title must be between 3 and 80 characters long"),
+		},
+		{
+			name:    "content too long",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: strings.Repeat("a", 501)},
+			wantErr: status.Error(codes.InvalidArgument, "Content must be between 3 and 500 characters long"),
+		},
+		{
+			name:    "multibyte title counted in characters",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: strings.Repeat("日本語", 25), Content: "test content"},
+			wantErr: nil,
+		},
+		{
+			name:    "multibyte content counted in characters",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: strings.Repeat("é", 500)},
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+						return &dbpb.CreateThreadResponse{Id: "456"}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: func(ctx context.Context, req *communitypb.GetCommunityPostingRulesRequest, opts ...grpc.CallOption) (*models.PostingRules, error) {
+						return &models.PostingRules{MinTitleLength: 3, MaxTitleLength: 80, MinContentLength: 3, MaxContentLength: 500}, nil
+					},
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						return &moderationpb.EvaluateResponse{}, nil
+					},
+				},
+			}
+
+			_, err := server.CreateThread(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateThread_Kinds(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *threadpb.CreateThreadRequest
+		wantErr error
+		wantDB  *dbpb.CreateThreadRequest
+	}{
+		{
+			name:    "text thread without content",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread"},
+			wantErr: status.Error(codes.InvalidArgument, "Content is required"),
+		},
+		{
+			name:    "link thread without url",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_LINK},
+			wantErr: status.Error(codes.InvalidArgument, "Url is required for link threads"),
+		},
+		{
+			name:    "link thread with relative url",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_LINK, Url: "example.com/page"},
+			wantErr: status.Error(codes.InvalidArgument, "Url must be an absolute http or https url"),
+		},
+		{
+			name:    "link thread with javascript url",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_LINK, Url: "javascript:alert(1)"},
+			wantErr: status.Error(codes.InvalidArgument, "Url must be an absolute http or https url"),
+		},
+		{
+			name:    "url on text thread",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Content: "test content", Url: "https://example.com"},
+			wantErr: status.Error(codes.InvalidArgument, "Url is only allowed for link threads"),
+		},
+		{
+			name: "link thread",
+			req:  &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_LINK, Url: "https://WWW.Example.com:8080/page"},
+			wantDB: &dbpb.CreateThreadRequest{
+				Kind:   models.ThreadKind_LINK,
+				Url:    "https://WWW.Example.com:8080/page",
+				Domain: "example.com",
+			},
+		},
+		{
+			name:    "image thread without attachment",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_IMAGE},
+			wantErr: status.Error(codes.InvalidArgument, "Attachment id is required for image threads"),
+		},
+		{
+			name: "image thread",
+			req:  &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_IMAGE, AttachmentId: "abc"},
+			wantDB: &dbpb.CreateThreadRequest{
+				Kind:         models.ThreadKind_IMAGE,
+				AttachmentId: "abc",
+				ThumbnailUrl: "/attachments/abc/thumbnail",
+			},
+		},
+		{
+			name:    "image thread with unknown attachment",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_IMAGE, AttachmentId: "404"},
+			wantErr: status.Error(codes.InvalidArgument, "Attachment not found"),
+		},
+		{
+			name:    "image thread with non-image attachment",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_IMAGE, AttachmentId: "pdf"},
+			wantErr: status.Error(codes.InvalidArgument, "Attachment is not an image"),
+		},
+		{
+			name:    "poll with one option",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_POLL, PollOptions: []string{"yes"}},
+			wantErr: status.Errorf(codes.InvalidArgument, "Poll must have between %d and %d options", src.MinPollOptions, src.MaxPollOptions),
+		},
+		{
+			name:    "poll with duplicate options",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_POLL, PollOptions: []string{"yes", "Yes "}},
+			wantErr: status.Error(codes.InvalidArgument, "Poll options must be unique"),
+		},
+		{
+			name: "poll thread",
+			req:  &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind_POLL, PollOptions: []string{" yes", "no "}},
+			wantDB: &dbpb.CreateThreadRequest{
+				Kind: models.ThreadKind_POLL,
+				Poll: &models.Poll{Options: []*models.PollOption{{Text: "yes"}, {Text: "no"}}},
+			},
+		},
+		{
+			name:    "unknown kind",
+			req:     &threadpb.CreateThreadRequest{CommunityId: "123", Title: "test thread", Kind: models.ThreadKind(42)},
+			wantErr: status.Error(codes.InvalidArgument, "Unknown thread kind"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+						assert.Equal(t, tt.wantDB.GetKind(), req.GetKind())
+						assert.Equal(t, tt.wantDB.GetUrl(), req.GetUrl())
+						assert.Equal(t, tt.wantDB.GetDomain(), req.GetDomain())
+						assert.Equal(t, tt.wantDB.GetAttachmentId(), req.GetAttachmentId())
+						assert.Equal(t, tt.wantDB.GetThumbnailUrl(), req.GetThumbnailUrl())
+						assert.Equal(t, pollTexts(tt.wantDB.GetPoll()), pollTexts(req.GetPoll()))
+						return &dbpb.CreateThreadResponse{Id: "123"}, nil
+					},
+					GetAttachmentFunc: func(ctx context.Context, req *dbpb.GetAttachmentRequest, opts ...grpc.CallOption) (*models.Attachment, error) {
+						switch req.GetId() {
+						case "404":
+							return nil, status.Error(codes.NotFound, "Attachment not found")
+						case "pdf":
+							return &models.Attachment{Id: req.GetId(), ContentType: "application/pdf"}, nil
+						}
+						return &models.Attachment{Id: req.GetId(), ContentType: "image/png", ThumbnailSha256: "abc"}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						return &moderationpb.EvaluateResponse{}, nil
+					},
+				},
+			}
+
+			_, err := server.CreateThread(context.Background(), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCreateThread_PollSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		endsAt  *timestamppb.Timestamp
+		wantErr error
+	}{
+		{
+			name:    "poll ending in the past",
+			endsAt:  timestamppb.New(time.Now().Add(-time.Hour)),
+			wantErr: status.Errorf(codes.InvalidArgument, "Poll must end within %d days", 31),
+		},
+		{
+			name:    "poll ending too late",
+			endsAt:  timestamppb.New(time.Now().Add(src.MaxPollDuration + time.Hour)),
+			wantErr: status.Errorf(codes.InvalidArgument, "Poll must end within %d days", 31),
+		},
+		{
+			name:   "default end time",
+			endsAt: nil,
+		},
+		{
+			name:   "valid end time",
+			endsAt: timestamppb.New(time.Now().Add(24 * time.Hour)),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					CreateThreadAndIncrementFunc: func(ctx context.Context, req *dbpb.CreateThreadRequest, opts ...grpc.CallOption) (*dbpb.CreateThreadResponse, error) {
+						assert.True(t, req.GetPoll().GetMultipleChoice())
+						assert.True(t, req.GetPoll().GetHideResults())
+						assert.True(t, req.GetPoll().GetEndsAt().AsTime().After(time.Now()))
+						return &dbpb.CreateThreadResponse{Id: "123"}, nil
+					},
+				},
+				CommunityClient: &MockCommunityClient{
+					GetCommunityPostingRulesFunc: getDefaultPostingRules,
+				},
+				ModerationClient: &MockModerationClient{
+					EvaluateThreadFunc: func(ctx context.Context, req *moderationpb.EvaluateThreadRequest, opts ...grpc.CallOption) (*moderationpb.EvaluateResponse, error) {
+						return &moderationpb.EvaluateResponse{}, nil
+					},
+				},
+			}
+
+			_, err := server.CreateThread(context.Background(), &threadpb.CreateThreadRequest{
+				CommunityId:        "123",
+				Title:              "test poll",
+				Kind:               models.ThreadKind_POLL,
+				PollOptions:        []string{"yes", "no"},
+				PollEndsAt:         tt.endsAt,
+				PollMultipleChoice: true,
+				PollHideResults:    true,
+			})
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestCastPollVote_Validation(t *testing.T) {
+	openPoll := func() *models.Thread {
+		return &models.Thread{
+			Id:        "123",
+			Kind:      models.ThreadKind_POLL,
+			CreatedAt: timestamppb.Now(),
+			Poll: &models.Poll{
+				EndsAt:  timestamppb.New(time.Now().Add(time.Hour)),
+				Options: []*models.PollOption{{Id: "a", Text: "yes"}, {Id: "b", Text: "no"}},
+			},
+		}
+	}
+	closedPoll := openPoll()
+	closedPoll.Poll.EndsAt = timestamppb.New(time.Now().Add(-time.Hour))
+	lockedPoll := openPoll()
+	lockedPoll.Locked = true
+	multiplePoll := openPoll()
+	multiplePoll.Poll.MultipleChoice = true
+
+	tests := []struct {
+		name    string
+		thread  *models.Thread
+		req     *threadpb.CastPollVoteRequest
+		user    string
+		dbErr   error
+		wantErr error
+	}{
+		{
+			name:    "missing thread id",
+			req:     &threadpb.CastPollVoteRequest{},
+			wantErr: status.Error(codes.InvalidArgument, "Thread id is required"),
+		},
+		{
+			name:    "missing options",
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123"},
+			user:    "456",
+			wantErr: status.Error(codes.InvalidArgument, "At least one option is required"),
+		},
+		{
+			name:    "anonymous",
+			thread:  openPoll(),
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a"}},
+			wantErr: status.Error(codes.Unauthenticated, "Voting requires an authenticated user"),
+		},
+		{
+			name:    "not a poll",
+			thread:  &models.Thread{Id: "123", CreatedAt: timestamppb.Now()},
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a"}},
+			user:    "456",
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is not a poll"),
+		},
+		{
+			name:    "closed poll",
+			thread:  closedPoll,
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a"}},
+			user:    "456",
+			wantErr: status.Error(codes.FailedPrecondition, "Poll is closed"),
+		},
+		{
+			name:    "locked thread",
+			thread:  lockedPoll,
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a"}},
+			user:    "456",
+			wantErr: status.Error(codes.FailedPrecondition, "Thread is locked"),
+		},
+		{
+			name:    "multiple options on single choice poll",
+			thread:  openPoll(),
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a", "b"}},
+			user:    "456",
+			wantErr: status.Error(codes.InvalidArgument, "Poll only allows a single choice"),
+		},
+		{
+			name:    "unknown option",
+			thread:  openPoll(),
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"c"}},
+			user:    "456",
+			wantErr: status.Error(codes.InvalidArgument, "Poll option not found"),
+		},
+		{
+			name:    "second vote",
+			thread:  openPoll(),
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a"}},
+			user:    "456",
+			dbErr:   status.Error(codes.AlreadyExists, "User already voted in this poll"),
+			wantErr: status.Error(codes.AlreadyExists, "User already voted in this poll"),
+		},
+		{
+			name:    "valid request",
+			thread:  openPoll(),
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a", "a"}},
+			user:    "456",
+			wantErr: nil,
+		},
+		{
+			name:    "multiple choice",
+			thread:  multiplePoll,
+			req:     &threadpb.CastPollVoteRequest{ThreadId: "123", OptionIds: []string{"a", "b"}},
+			user:    "456",
+			wantErr: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return tt.thread, nil
+					},
+					CastPollVoteFunc: func(ctx context.Context, req *dbpb.CastPollVoteRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+						assert.Equal(t, "456", req.GetUserId())
+						return &emptypb.Empty{}, tt.dbErr
+					},
+				},
+				ArchiveAge: src.DefaultArchiveAge,
+			}
+
+			_, err := server.CastPollVote(asUser(tt.user), tt.req)
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr.Error(), err.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestGetThread_HidesPollResults(t *testing.T) {
+	tests := []struct {
+		name      string
+		endsAt    time.Time
+		wantVotes int32
+	}{
+		{
+			name:      "open poll",
+			endsAt:    time.Now().Add(time.Hour),
+			wantVotes: 0,
+		},
+		{
+			name:      "closed poll",
+			endsAt:    time.Now().Add(-time.Hour),
+			wantVotes: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &src.ThreadServer{
+				DBClient: &MockDBClient{
+					GetThreadFunc: func(ctx context.Context, req *dbpb.GetThreadRequest, opts ...grpc.CallOption) (*models.Thread, error) {
+						return &models.Thread{
+							Id:   "123",
+							Kind: models.ThreadKind_POLL,
+							Poll: &models.Poll{
+								EndsAt:      timestamppb.New(tt.endsAt),
+								HideResults: true,
+								NumVoters:   3,
+								Options:     []*models.PollOption{{Id: "a", Text: "yes", NumVotes: 3}},
+							},
+						}, nil
+					},
+				},
+			}
+
+			res, err := server.GetThread(context.Background(), &threadpb.GetThreadRequest{Id: "123"})
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVotes, res.GetPoll().GetOptions()[0].GetNumVotes())
+			assert.Equal(t, int32(3), res.GetPoll().GetNumVoters())
+		})
+	}
+}
+
+func pollTexts(poll *models.Poll) []string {
+	var texts []string
+	for _, option := range poll.GetOptions() {
+		texts = append(texts, option.GetText())
+	}
+	return texts
+}
+
+func strPtr(s string) *string { return &s }
+func int32Ptr(i int32) *int32 { return &i }
+
+// asUser returns a context of a request the gateway authenticated as a user
+func asUser(userId string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserIdMetadataKey, userId))
+}
+
+// asRoles returns a context of a request the gateway authenticated with the comma separated roles
+func asRoles(roles string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(auth.UserRolesMetadataKey, roles))
+}
+
+func TestPurgeThread(t *testing.T) {
+	var created *dbpb.CreateJobRequest
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			CreateJobFunc: func(ctx context.Context, req *dbpb.CreateJobRequest, opts ...grpc.CallOption) (*dbpb.CreateJobResponse, error) {
+				created = req
+				return &dbpb.CreateJobResponse{Id: "job"}, nil
+			},
+		},
+	}
+
+	_, err := server.PurgeThread(context.Background(), &threadpb.PurgeThreadRequest{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Thread id is required").Error(), err.Error())
+
+	_, err = server.PurgeThread(asRoles("moderator"), &threadpb.PurgeThreadRequest{Id: "123"})
+	assert.Equal(t, status.Error(codes.PermissionDenied, "Only admins can purge threads").Error(), err.Error())
+	assert.Nil(t, created)
+
+	// the replies are deleted by the job, not listed here
+	res, err := server.PurgeThread(asRoles("admin"), &threadpb.PurgeThreadRequest{Id: "123"})
+	assert.NoError(t, err)
+	assert.Equal(t, "job", res.JobId)
+	assert.Equal(t, models.JobType_DELETE_THREAD, created.Type)
+	assert.Equal(t, "123", created.TargetId)
+}
+
+func TestGetJobStatus(t *testing.T) {
+	jobs := map[string]*models.Job{
+		"purge":  {Id: "purge", Type: models.JobType_DELETE_THREAD, State: models.JobState_JOB_RUNNING, NumCommentsDeleted: 3},
+		"delete": {Id: "delete", Type: models.JobType_DELETE_COMMUNITY},
+	}
+	server := &src.ThreadServer{
+		DBClient: &MockDBClient{
+			GetJobStatusFunc: func(ctx context.Context, req *dbpb.GetJobStatusRequest, opts ...grpc.CallOption) (*models.Job, error) {
+				job, ok := jobs[req.Id]
+				if !ok {
+					return nil, status.Error(codes.NotFound, "Job not found")
+				}
+				return job, nil
+			},
+		},
+	}
+
+	_, err := server.GetJobStatus(context.Background(), &threadpb.GetJobStatusRequest{})
+	assert.Equal(t, status.Error(codes.InvalidArgument, "Job id is required").Error(), err.Error())
+
+	job, err := server.GetJobStatus(context.Background(), &threadpb.GetJobStatusRequest{Id: "purge"})
+	assert.NoError(t, err)
+	assert.Equal(t, models.JobState_JOB_RUNNING, job.State)
+	assert.Equal(t, int32(3), job.NumCommentsDeleted)
+
+	// community deletions are reported by community-service
+	for _, id := range []string{"delete", "missing"} {
+		_, err = server.GetJobStatus(context.Background(), &threadpb.GetJobStatusRequest{Id: id})
+		assert.Equal(t, status.Error(codes.NotFound, "Job not found").Error(), err.Error())
+	}
+}